@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/micro-community/auth/config"
+	"github.com/micro-community/auth/db"
+	"github.com/micro-community/auth/repository/backup"
+	"github.com/urfave/cli/v2"
+)
+
+//backupCommand write a backup of a database to a file:
+//	auth backup --db sqlite --path ./data --out auth.backup
+func backupCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "backup",
+		Usage: "back up users, roles, resources, links and logs of the database",
+		Flags: append(dbFlags(""),
+			&cli.StringFlag{Name: "db", Usage: "type of the database: mysql, sqlite, mongo, dgraph or file", EnvVars: []string{"MICRO_STARTER_DB_TYPE"}, Value: config.Default.DBType},
+			&cli.StringFlag{Name: "out", Usage: "file of the backup", Required: true},
+		),
+		Action: func(c *cli.Context) error {
+			dbType, err := buildBackupConfig(c)
+			if err != nil {
+				return err
+			}
+			if dbType == "file" {
+				//backing up a missing file would create it
+				if _, err = os.Stat(config.Default.File.Path); err != nil {
+					return err
+				}
+			}
+			out, err := os.OpenFile(c.String("out"), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+			if err != nil {
+				return err
+			}
+			db.BuildDBContext(dbType)
+			counts, err := backup.Backup(context.Background(), openRepositories(dbType), dbType, out)
+			if closeErr := out.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				os.Remove(c.String("out"))
+				return err
+			}
+			fmt.Printf("backed up %+v to %s\n", counts, c.String("out"))
+			return nil
+		},
+	}
+}
+
+//restoreCommand restore a backup to an empty database, or verify it by a restore to memory:
+//	auth restore --db mongo --host 127.0.0.1 --in auth.backup
+//	auth restore --verify --in auth.backup
+func restoreCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "restore",
+		Usage: "restore a backup to the empty database",
+		Flags: append(dbFlags(""),
+			&cli.StringFlag{Name: "db", Usage: "type of the database: mysql, sqlite, mongo, dgraph or file", EnvVars: []string{"MICRO_STARTER_DB_TYPE"}, Value: config.Default.DBType},
+			&cli.StringFlag{Name: "in", Usage: "file of the backup", Required: true},
+			&cli.BoolFlag{Name: "verify", Usage: "restore to memory and compare with the backup, the database is not touched"},
+		),
+		Action: func(c *cli.Context) error {
+			in, err := os.Open(c.String("in"))
+			if err != nil {
+				return err
+			}
+			defer in.Close()
+			ctx := context.Background()
+
+			if c.Bool("verify") {
+				r, err := backup.NewReader(in)
+				if err != nil {
+					return err
+				}
+				diffs, counts, err := backup.Verify(ctx, r)
+				if err != nil {
+					return err
+				}
+				for _, d := range diffs {
+					fmt.Println("difference:", d)
+				}
+				if len(diffs) > 0 {
+					return fmt.Errorf("%d differences in the backup of %s at %s", len(diffs), r.Header.Source, r.Header.CreatedAt)
+				}
+				fmt.Printf("verified %+v of the backup of %s at %s\n", counts, r.Header.Source, r.Header.CreatedAt)
+				return nil
+			}
+
+			dbType, err := buildBackupConfig(c)
+			if err != nil {
+				return err
+			}
+			//check the whole backup before writing any of it
+			if _, _, err = backup.Check(in); err != nil {
+				return err
+			}
+			if _, err = in.Seek(0, 0); err != nil {
+				return err
+			}
+			r, err := backup.NewReader(in)
+			if err != nil {
+				return err
+			}
+			db.BuildDBContext(dbType)
+			if err = db.Migrate(ctx); err != nil {
+				return fmt.Errorf("migrate %s: %v", dbType, err)
+			}
+			counts, err := backup.Restore(ctx, r, openRepositories(dbType))
+			if err != nil {
+				return err
+			}
+			fmt.Printf("restored %+v of the backup of %s at %s\n", counts, r.Header.Source, r.Header.CreatedAt)
+			return nil
+		},
+	}
+}
+
+//buildBackupConfig apply the flags of the database to the config, return its type
+func buildBackupConfig(c *cli.Context) (string, error) {
+	dbType := c.String("db")
+	switch dbType {
+	case "mysql", "sqlite", "mongo", "dgraph", "file":
+		applyDBFlags(c, "", dbType, config.Default)
+		return dbType, nil
+	}
+	return "", fmt.Errorf("%q is only backed up by the Rbac.Backup endpoint of the service, use mysql, sqlite, mongo, dgraph or file", dbType)
+}
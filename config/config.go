@@ -35,9 +35,15 @@ type Options struct {
 	SQLite  *sql.SQLiteOptions
 	Mongodb *nosql.MongoOptions
 	Dgraph  *nosql.DgraphOptions
+	File    *nosql.BoltOptions
 	Pubsub  *pubsub.Options
 
+	//metadata key of the tenant of requests, tenancy is off while it is empty
 	TenantKey string
+
+	//deleted users, roles and resources are purged after the retention, checked every interval
+	SoftDeleteRetention time.Duration
+	PurgeInterval       time.Duration
 }
 
 //Default of config
@@ -48,6 +54,9 @@ var Default = &Options{
 	MaxIdleConns:    10,
 	ConnMaxLifetime: time.Duration(time.Hour),
 
+	SoftDeleteRetention: 30 * 24 * time.Hour,
+	PurgeInterval:       time.Hour,
+
 	Redis: cache.Options{
 		MasterName:     "",
 		SentinelAddrs:  nil,
@@ -57,11 +66,18 @@ var Default = &Options{
 		MaxIdle:        1,
 		MaxIdleTimeout: 1,
 	},
+	MySQL: &sql.MySqlOptions{
+		User:     "root",
+		Password: "",
+		Host:     "localhost",
+		Port:     3306,
+		DBName:   "auth",
+	},
 	SQLite: &sql.SQLiteOptions{
 		User:     "",
 		Password: "",
 		Host:     "localhost",
-		DBName:   "",
+		DBName:   "auth.db",
 		Path:     "",
 	},
 	Mongodb: &nosql.MongoOptions{
@@ -77,6 +93,11 @@ var Default = &Options{
 		Host:     "localhost",
 		Port:     0,
 		DBName:   "",
+		Url:      "localhost:9080",
+	},
+	File: &nosql.BoltOptions{
+		Path:    "auth.bolt",
+		Timeout: time.Second,
 	},
 	Pubsub: &pubsub.Options{
 		PubTopics: nil,
@@ -106,7 +127,7 @@ func LoadConfigWithDefault(fn func(defaultConfig *Options) *Options) {
 	//  get config
 	dbTypeValue, err := config.Get("DBType")
 	dbType := dbTypeValue.String("")
-	if err != nil && dbType != "" {
+	if err == nil && dbType != "" {
 		Default.DBType = dbType
 	}
 	logger.Infof("DBType %+v", dbType)
@@ -114,7 +135,7 @@ func LoadConfigWithDefault(fn func(defaultConfig *Options) *Options) {
 	redisHostValue, err := config.Get("RedisHost")
 	redisHost := redisHostValue.String("")
 
-	if err != nil && redisHost != "" {
+	if err == nil && redisHost != "" {
 		Default.Redis.Host = redisHost
 	}
 
@@ -132,5 +153,35 @@ func LoadConfigWithDefault(fn func(defaultConfig *Options) *Options) {
 		Default.Pubsub.SubTopics = subtopic
 	}
 
+	retentionValue, err := config.Get("SoftDeleteRetention")
+	retention := retentionValue.Duration(0)
+	if err == nil && retention != 0 {
+		Default.SoftDeleteRetention = retention
+	}
+
+	intervalValue, err := config.Get("PurgeInterval")
+	interval := intervalValue.Duration(0)
+	if err == nil && interval != 0 {
+		Default.PurgeInterval = interval
+	}
+
+	filePathValue, err := config.Get("FilePath")
+	filePath := filePathValue.String("")
+	if err == nil && filePath != "" {
+		Default.File.Path = filePath
+	}
+
+	compactValue, err := config.Get("CompactInterval")
+	compact := compactValue.Duration(0)
+	if err == nil && compact != 0 {
+		Default.File.CompactInterval = compact
+	}
+
+	tenantKeyValue, err := config.Get("TenantKey")
+	tenantKey := tenantKeyValue.String("")
+	if err == nil && tenantKey != "" {
+		Default.TenantKey = tenantKey
+	}
+
 	logger.Infof("Redis Host %+v", redisHost)
 }
@@ -1,8 +1,6 @@
 package db
 
 import (
-	"sync"
-
 	"github.com/micro-community/auth/cache"
 	"github.com/micro-community/auth/config"
 	"github.com/micro-community/auth/db/nosql"
@@ -17,7 +15,7 @@ var (
 	db            *gorm.DB      // for mysql/sqlite
 	dg            *nosql.DormDB //for dgraph
 	mdb           *mongo.Database
-	once          sync.Once
+	fdb           *nosql.BoltDB //for file
 	dbContextType string
 )
 
@@ -39,6 +37,10 @@ func BuildDBContext(dbCase string) {
 		// connect to mongo
 	case "dgraph":
 		//connect to dgraph
+	case "store":
+		//use the store of the runtime
+	case "file":
+		//open the embedded database file
 	default:
 		//use memory to mock
 
@@ -53,25 +55,58 @@ func DDB() *nosql.DormDB {
 	}
 
 	dg = nosql.NewDGraphClient(config.Default.Dgraph)
-	once.Do(func() {
-		migrate()
-	})
 
 	return dg
 
 }
 
+//MDB connect to mongodb at the first call
+func MDB() *mongo.Database {
+
+	if mdb != nil {
+		return mdb
+	}
+
+	nosql.Init(config.Default.Mongodb)
+	mdb = nosql.GetDB()
+
+	return mdb
+}
+
+//FDB open the embedded database file at the first call
+func FDB() *nosql.BoltDB {
+
+	if fdb != nil {
+		return fdb
+	}
+
+	var err error
+	fdb, err = nosql.OpenBolt(config.Default.File, nosql.RbacBuckets...)
+	if err != nil {
+		logger.Fatalf("open %s error: %v", config.Default.File.Path, err)
+	}
+
+	return fdb
+}
+
+//DB connect to mysql or sqlite by the db context type at the first call
 func DB() *gorm.DB {
 
 	if db != nil {
 		return db
 	}
-	db = sql.NewSQLite(config.Default.SQLite)
-	once.Do(func() {
-		migrate()
-	})
 
-	if sqlDB, err := db.DB(); err != nil {
+	var err error
+	if dbContextType == "mysql" {
+		db, err = sql.NewMySQL(config.Default.MySQL)
+	} else {
+		db, err = sql.NewSQLite(config.Default.SQLite)
+	}
+	if err != nil {
+		logger.Fatalf("connect to %s error: %v", dbContextType, err)
+	}
+
+	if sqlDB, err := db.DB(); err == nil {
 		// SetMaxIdleConns 设置空闲连接池中连接的最大数量
 		sqlDB.SetMaxIdleConns(config.Default.MaxIdleConns)
 
@@ -9,29 +9,48 @@
 package db
 
 import (
-	"github.com/micro-community/auth/models"
-)
-
-func migrate() {
-
-	//Migrate the schema
-	db.AutoMigrate(&models.User{})
-
-	// Create
-	db.Create(&models.User{Key: "D42", ID: 100})
+	"context"
 
-	// Read
-	var user models.User
-
-	db.First(&user, 1)                 // find product with integer primary key
-	db.First(&user, "code = ?", "D42") // find product with code D42
+	"github.com/micro-community/auth/db/migration"
+	"github.com/micro/micro/v3/service/logger"
+)
 
-	// Update - update product's price to 200
-	db.Model(&user).Update("Price", 200)
-	// Update - update multiple fields
-	db.Model(&user).Updates(models.User{ID: 200, Key: "F42"}) // non-zero fields
-	db.Model(&user).Updates(map[string]interface{}{"ID": 200, "Key": "F42"})
-	// Delete - delete product
-	db.Delete(&user, 1)
+//Migrator return the migrations of the db context type, nil for memory
+func Migrator() migration.Source {
+	switch dbContextType {
+	case "mysql", "sqlite":
+		return migration.NewSQL(DB())
+	case "mongo":
+		return migration.NewMongo(MDB())
+	case "dgraph":
+		return migration.NewDgraph(DDB())
+	default:
+		return nil
+	}
+}
 
+//Migrate apply all pending migrations of the db context type,
+//the live schema of dgraph is then checked against the one declared by the migrations, drifts are only logged
+func Migrate(ctx context.Context) error {
+	source := Migrator()
+	if source == nil {
+		return nil
+	}
+	done, err := migration.Up(ctx, source, 0)
+	for _, m := range done {
+		logger.Infof("migrated %s to %d %s", dbContextType, m.Version, m.Name)
+	}
+	if err != nil || dbContextType != "dgraph" {
+		return err
+	}
+
+	want, err := migration.DgraphSchema()
+	if err != nil {
+		return err
+	}
+	drifts, err := DDB().Drifts(ctx, want)
+	for _, drift := range drifts {
+		logger.Warnf("dgraph schema drift: %s", drift)
+	}
+	return err
 }
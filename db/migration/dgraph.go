@@ -0,0 +1,541 @@
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/dgo/v200"
+	"github.com/dgraph-io/dgo/v200/protos/api"
+	"github.com/micro-community/auth/db/nosql"
+	"github.com/micro-community/auth/errs"
+)
+
+//dgraphMeta is the schema of migration records and the lock
+const dgraphMeta = `
+migration.version: int @index(int) @upsert .
+migration.name: string .
+migration.appliedAt: datetime .
+lock.id: int @index(int) @upsert .
+lock.owner: string @index(exact) .
+lock.lockedAt: datetime @index(hour) .
+type SchemaMigration {
+	migration.version
+	migration.name
+	migration.appliedAt
+}
+type SchemaLock {
+	lock.id
+	lock.owner
+	lock.lockedAt
+}
+`
+
+//dgraphStep is a migration of the dgraph schema, fill sets the data of new predicates after the schema is up,
+//down drops the types declared by up and not by down as an alter can not drop them
+type dgraphStep struct {
+	Migration
+	up   string
+	down string
+	fill func(ctx context.Context, d *nosql.DormDB) error
+}
+
+//dgraphSteps in order of version, predicates are the json names of the models,
+//they are the only declaration of the schema, DgraphSchema folds them
+var dgraphSteps = []dgraphStep{
+	{Migration{1, "index predicates of users, roles and resources"},
+		`
+id: int @index(int) @upsert .
+name: string @index(exact) @upsert .
+Name: string @index(exact) @upsert .
+tenantId: int @index(int) .
+TenantId: int @index(int) .
+Stated: int @index(int) .
+Type: int @index(int) .
+createdAt: datetime @index(hour) .
+`,
+		`
+id: int .
+name: string .
+Name: string .
+tenantId: int .
+TenantId: int .
+Stated: int .
+Type: int .
+createdAt: datetime .
+`, nil},
+	{Migration{2, "reverse edges of links"},
+		`
+role: [uid] @reverse .
+resource: [uid] @reverse .
+`,
+		`
+role: [uid] .
+resource: [uid] .
+`, nil},
+	{Migration{3, "index deletion of users, roles and resources"},
+		`
+isSoftDelete: bool @index(bool) .
+deletedAt: datetime @index(hour) .
+`,
+		`
+isSoftDelete: bool .
+deletedAt: datetime .
+`, nil},
+	{Migration{4, "version users, roles and resources"},
+		`
+version: int .
+`,
+		`
+version: int .
+`, fillVersionsV4},
+	{Migration{5, "index names by trigram for prefix filters"},
+		`
+name: string @index(exact, trigram) @upsert .
+Name: string @index(exact, trigram) @upsert .
+`,
+		`
+name: string @index(exact) @upsert .
+Name: string @index(exact) @upsert .
+`, nil},
+	{Migration{6, "index logs"},
+		`
+actor: string @index(exact) .
+kind: int @index(int) .
+entityId: string @index(exact) .
+time: datetime @index(hour) .
+`,
+		`
+actor: string .
+kind: int .
+entityId: string .
+time: datetime .
+`, nil},
+	{Migration{7, "index role instances"},
+		`
+roleId: int @index(int) .
+templateId: int @index(int) .
+`,
+		`
+roleId: int .
+templateId: int .
+`, nil},
+	{Migration{8, "index org units, members and grants"},
+		`
+parentId: int @index(int) .
+userId: int @index(int) .
+deptId: int @index(int) .
+positionId: int @index(int) .
+unitId: int @index(int) .
+`,
+		`
+parentId: int .
+userId: int .
+deptId: int .
+positionId: int .
+unitId: int .
+`, nil},
+	{Migration{9, "index lowercased names by exact for prefix filters of any length"},
+		`
+name: string @index(exact) @upsert .
+Name: string @index(exact) @upsert .
+lowerName: string @index(exact) .
+`,
+		`
+name: string @index(exact, trigram) @upsert .
+Name: string @index(exact, trigram) @upsert .
+lowerName: string .
+`, fillLowerNamesV9},
+	{Migration{10, "declare the types of users, roles, resources, logs, tenants, role templates and org trees"},
+		`
+updatedAt: datetime .
+nickName: string .
+age: int .
+gender: string .
+password: string .
+key: string .
+roles: [int] .
+firstName: string .
+familyName: string .
+phone: string .
+PostionId: int .
+avatar: string .
+email: string .
+Key: string .
+Resources: [uid] .
+updateBy: string .
+addedBy: string .
+action: int .
+targetId: string .
+diff: string .
+requestId: string .
+state: int .
+settings: string .
+grants: string .
+default: bool .
+templateVersion: int .
+scopeId: int .
+type User {
+	id
+	nickName
+	name
+	age
+	gender
+	password
+	key
+	roles
+	tenantId
+	firstName
+	familyName
+	phone
+	roleId
+	deptId
+	PostionId
+	avatar
+	Stated
+	email
+	createdAt
+	updatedAt
+	deletedAt
+	isSoftDelete
+	version
+	role
+	lowerName
+}
+type Role {
+	id
+	Key
+	Name
+	Resources
+	TenantId
+	createdAt
+	updatedAt
+	deletedAt
+	isSoftDelete
+	version
+	resource
+	lowerName
+}
+type Resource {
+	id
+	Key
+	Name
+	TenantId
+	Type
+	updateBy
+	addedBy
+	createdAt
+	updatedAt
+	deletedAt
+	isSoftDelete
+	version
+	lowerName
+}
+type Log {
+	id
+	actor
+	tenantId
+	kind
+	action
+	entityId
+	targetId
+	diff
+	requestId
+	time
+}
+type Tenant {
+	id
+	name
+	state
+	settings
+	createdAt
+	updatedAt
+	deletedAt
+	isSoftDelete
+	version
+}
+type RoleTemplate {
+	id
+	name
+	key
+	grants
+	default
+	createdAt
+	updatedAt
+	deletedAt
+	isSoftDelete
+	version
+}
+type RoleInstance {
+	roleId
+	templateId
+	templateVersion
+	tenantId
+	scopeId
+	createdAt
+}
+type OrgUnit {
+	id
+	kind
+	name
+	parentId
+	tenantId
+	createdAt
+	updatedAt
+	deletedAt
+	isSoftDelete
+	version
+}
+type OrgMember {
+	userId
+	deptId
+	positionId
+	tenantId
+	createdAt
+}
+type OrgGrant {
+	unitId
+	roleId
+	createdAt
+}
+`, "", nil},
+}
+
+//fillVersionsV4 set the version of the nodes stored before versions to 1
+func fillVersionsV4(ctx context.Context, d *nosql.DormDB) error {
+	query := `{ n as var(func: has(dgraph.type)) @filter((type(User) OR type(Role) OR type(Resource)) AND NOT has(version)) }`
+	_, err := d.Upsert(ctx, query, &api.Mutation{SetNquads: []byte(`uid(n) <version> "1" .`)})
+	return err
+}
+
+//fillLowerNamesV9 set the lowercased names of the users, roles and resources stored before them
+func fillLowerNamesV9(ctx context.Context, d *nosql.DormDB) error {
+	resp, err := d.QueryReadOnly(ctx, `{
+		nodes(func: has(dgraph.type)) @filter((type(User) OR type(Role) OR type(Resource)) AND NOT has(lowerName)) {
+			uid
+			name
+			Name
+		}
+	}`)
+	if err != nil {
+		return err
+	}
+	var r struct {
+		Nodes []struct {
+			UID      string `json:"uid"`
+			UserName string `json:"name"`
+			Name     string `json:"Name"`
+		} `json:"nodes"`
+	}
+	if err = json.Unmarshal(resp.Json, &r); err != nil {
+		return err
+	}
+	if len(r.Nodes) == 0 {
+		return nil
+	}
+	nodes := make([]map[string]interface{}, 0, len(r.Nodes))
+	for _, node := range r.Nodes {
+		name := node.Name
+		if name == "" {
+			name = node.UserName
+		}
+		nodes = append(nodes, map[string]interface{}{"uid": node.UID, "lowerName": strings.ToLower(name)})
+	}
+	_, err = d.MutateObject(ctx, nodes)
+	return err
+}
+
+//DgraphSchema return the schema declared by all dgraph migrations
+func DgraphSchema() (nosql.Schema, error) {
+	var schema nosql.Schema
+	for _, step := range dgraphSteps {
+		up, err := nosql.ParseSchema(step.up)
+		if err != nil {
+			return nosql.Schema{}, errs.Wrap(err, errs.Unknown, "parse migration %d", step.Version)
+		}
+		schema = schema.Merge(up)
+	}
+	return schema, nil
+}
+
+//down revert the schema of step, dropping the types declared by up and not by down
+func (s *dgraphSource) down(ctx context.Context, step dgraphStep) error {
+	up, err := nosql.ParseSchema(step.up)
+	if err != nil {
+		return err
+	}
+	down, err := nosql.ParseSchema(step.down)
+	if err != nil {
+		return err
+	}
+	if len(down.Predicates) > 0 || len(down.Types) > 0 {
+		if err = s.d.Alter(ctx, step.down); err != nil {
+			return err
+		}
+	}
+	kept := map[string]bool{}
+	for _, t := range down.Types {
+		kept[t.Name] = true
+	}
+	for _, t := range up.Types {
+		if !kept[t.Name] {
+			if err = s.d.DropType(ctx, t.Name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type dgraphSource struct {
+	d     *nosql.DormDB
+	steps []dgraphStep
+}
+
+//NewDgraph return the migrations of dgraph
+func NewDgraph(d *nosql.DormDB) Source {
+	return &dgraphSource{d: d, steps: dgraphSteps}
+}
+
+func (s *dgraphSource) Migrations() []Migration {
+	migrations := make([]Migration, 0, len(s.steps))
+	for _, step := range s.steps {
+		migrations = append(migrations, step.Migration)
+	}
+	return migrations
+}
+
+//prepare the schema of migrations
+func (s *dgraphSource) prepare(ctx context.Context) error {
+	if err := s.d.Alter(ctx, dgraphMeta); err != nil {
+		return errs.NewUnavailable(err, "prepare migrations error")
+	}
+	return nil
+}
+
+func (s *dgraphSource) Applied(ctx context.Context) (map[int]time.Time, error) {
+	if err := s.prepare(ctx); err != nil {
+		return nil, err
+	}
+	resp, err := s.d.QueryReadOnly(ctx, `{
+		records(func: type(SchemaMigration)) {
+			migration.version
+			migration.appliedAt
+		}
+	}`)
+	if err != nil {
+		return nil, errs.NewUnavailable(err, "query migrations error")
+	}
+
+	var r struct {
+		Records []struct {
+			Version   int       `json:"migration.version"`
+			AppliedAt time.Time `json:"migration.appliedAt"`
+		} `json:"records"`
+	}
+	if err = json.Unmarshal(resp.Json, &r); err != nil {
+		return nil, errs.Wrap(err, errs.Unknown, "json unmarshal migrations error")
+	}
+	applied := map[int]time.Time{}
+	for _, record := range r.Records {
+		applied[record.Version] = record.AppliedAt
+	}
+	return applied, nil
+}
+
+func (s *dgraphSource) Apply(ctx context.Context, version int, up bool) error {
+	for _, step := range s.steps {
+		if step.Version != version {
+			continue
+		}
+		//dgraph can not alter the schema in a transaction, the record follows a successful alter
+		query := fmt.Sprintf(`{ m as var(func: eq(migration.version, %d)) }`, version)
+		mu := &api.Mutation{}
+		if up {
+			if err := s.d.Alter(ctx, step.up); err != nil {
+				return errs.NewUnavailable(err, "migrate up error")
+			}
+			if step.fill != nil {
+				if err := step.fill(ctx, s.d); err != nil {
+					return errs.NewUnavailable(err, "migrate up error")
+				}
+			}
+			mu.Cond = "@if(eq(len(m), 0))"
+			mu.SetNquads = []byte(fmt.Sprintf(`
+				_:m <dgraph.type> "SchemaMigration" .
+				_:m <migration.version> "%d" .
+				_:m <migration.name> %q .
+				_:m <migration.appliedAt> "%s" .`, version, step.Name, time.Now().Format(time.RFC3339)))
+		} else {
+			if err := s.down(ctx, step); err != nil {
+				return errs.NewUnavailable(err, "migrate down error")
+			}
+			mu.DelNquads = []byte(`uid(m) * * .`)
+		}
+		if _, err := s.d.Upsert(ctx, query, mu); err != nil {
+			return errs.NewUnavailable(err, "record migration error")
+		}
+		return nil
+	}
+	return errs.NewNotFound("migration %d not found", version)
+}
+
+func (s *dgraphSource) TryLock(ctx context.Context, owner string, ttl time.Duration) (bool, error) {
+	if err := s.prepare(ctx); err != nil {
+		return false, err
+	}
+	now := time.Now()
+	//create the lock when it is missing and take over a stale one,
+	//the @upsert index of lock.id aborts one of the racers
+	query := fmt.Sprintf(`{
+		l as var(func: eq(lock.id, 1))
+		stale as var(func: uid(l)) @filter(lt(lock.lockedAt, %q))
+	}`, now.Add(-ttl).Format(time.RFC3339))
+	create := &api.Mutation{
+		Cond: "@if(eq(len(l), 0))",
+		SetNquads: []byte(fmt.Sprintf(`
+			_:l <dgraph.type> "SchemaLock" .
+			_:l <lock.id> "1" .
+			_:l <lock.owner> %q .
+			_:l <lock.lockedAt> "%s" .`, owner, now.Format(time.RFC3339))),
+	}
+	takeOver := &api.Mutation{
+		Cond: "@if(eq(len(stale), 1))",
+		SetNquads: []byte(fmt.Sprintf(`
+			uid(stale) <lock.owner> %q .
+			uid(stale) <lock.lockedAt> "%s" .`, owner, now.Format(time.RFC3339))),
+	}
+	_, err := s.d.Upsert(ctx, query, create, takeOver)
+	if errors.Is(err, dgo.ErrAborted) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errs.NewUnavailable(err, "take migration lock error")
+	}
+
+	resp, err := s.d.QueryReadOnly(ctx, `{ lock(func: eq(lock.id, 1)) { lock.owner } }`)
+	if err != nil {
+		return false, errs.NewUnavailable(err, "query migration lock error")
+	}
+	var r struct {
+		Lock []struct {
+			Owner string `json:"lock.owner"`
+		} `json:"lock"`
+	}
+	if err = json.Unmarshal(resp.Json, &r); err != nil {
+		return false, errs.Wrap(err, errs.Unknown, "json unmarshal migration lock error")
+	}
+	return len(r.Lock) == 1 && r.Lock[0].Owner == owner, nil
+}
+
+func (s *dgraphSource) Unlock(ctx context.Context, owner string) error {
+	query := fmt.Sprintf(`{ l as var(func: eq(lock.id, 1)) @filter(eq(lock.owner, %q)) }`, owner)
+	_, err := s.d.Upsert(ctx, query, &api.Mutation{DelNquads: []byte(`uid(l) * * .`)})
+	if err != nil {
+		return errs.NewUnavailable(err, "release migration lock error")
+	}
+	return nil
+}
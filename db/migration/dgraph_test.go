@@ -0,0 +1,31 @@
+package migration
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDgraphSchema(t *testing.T) {
+	schema, err := DgraphSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	declared := map[string]bool{}
+	for _, p := range schema.Predicates {
+		if declared[p.Name] {
+			t.Fatalf("predicate %s is declared twice", p.Name)
+		}
+		declared[p.Name] = true
+	}
+	for _, typ := range schema.Types {
+		for _, f := range typ.Fields {
+			if !declared[f] {
+				t.Fatalf("field %s of %s is not declared", f, typ.Name)
+			}
+		}
+	}
+	if s := schema.String(); !strings.Contains(s, "<role>: [uid] @reverse .") || !strings.Contains(s, "<name>: string @index(exact) @upsert .") ||
+		!strings.Contains(s, "type User {") {
+		t.Fatalf("schema %s", s)
+	}
+}
@@ -0,0 +1,151 @@
+//Package migration apply numbered up/down migrations of a backend,
+//applied versions are recorded in the backend and a lock keeps replicas from racing
+package migration
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+)
+
+const (
+	//lockTTL is how long a lock is held before it is taken as left by a crashed owner
+	lockTTL = 10 * time.Minute
+	//lockRetry is the interval to try a held lock again
+	lockRetry = time.Second
+)
+
+//Migration of a version, versions increase from 1
+type Migration struct {
+	Version int
+	Name    string
+}
+
+//Status of a migration, Known is false for versions applied by a newer build
+type Status struct {
+	Migration
+	Known     bool
+	Applied   bool
+	AppliedAt time.Time
+}
+
+//Source of the migrations of a backend
+type Source interface {
+	//Migrations known by the build in order of version
+	Migrations() []Migration
+	//Applied versions and the time they were applied
+	Applied(ctx context.Context) (map[int]time.Time, error)
+	//Apply run the migration of version up or down and record it
+	Apply(ctx context.Context, version int, up bool) error
+	//TryLock take the lock for owner, a lock older than ttl is taken over
+	TryLock(ctx context.Context, owner string, ttl time.Duration) (bool, error)
+	//Unlock release the lock of owner
+	Unlock(ctx context.Context, owner string) error
+}
+
+//locks counts the locks taken by this process
+var locks int64
+
+//owner of a lock taken by this process, unique among its calls
+func owner() string {
+	host, _ := os.Hostname()
+	return fmt.Sprintf("%s:%d:%d", host, os.Getpid(), atomic.AddInt64(&locks, 1))
+}
+
+//withLock run fn with the lock of s, waiting until ctx is done when others hold it
+func withLock(ctx context.Context, s Source, fn func() error) error {
+	me := owner()
+	for {
+		locked, err := s.TryLock(ctx, me, lockTTL)
+		if err != nil {
+			return err
+		}
+		if locked {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return errs.NewConflict("migrations are locked by another process")
+		case <-time.After(lockRetry):
+		}
+	}
+	defer s.Unlock(context.Background(), me)
+
+	return fn()
+}
+
+//List the status of known and applied migrations in order of version
+func List(ctx context.Context, s Source) ([]Status, error) {
+	applied, err := s.Applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := []Status{}
+	for _, m := range s.Migrations() {
+		at, ok := applied[m.Version]
+		statuses = append(statuses, Status{Migration: m, Known: true, Applied: ok, AppliedAt: at})
+		delete(applied, m.Version)
+	}
+	for version, at := range applied {
+		statuses = append(statuses, Status{Migration: Migration{Version: version}, Applied: true, AppliedAt: at})
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, nil
+}
+
+//Up apply pending migrations up to target (0 for all) in order of version, return the applied ones
+func Up(ctx context.Context, s Source, target int) ([]Migration, error) {
+	var done []Migration
+	err := withLock(ctx, s, func() error {
+		applied, err := s.Applied(ctx)
+		if err != nil {
+			return err
+		}
+		for _, m := range s.Migrations() {
+			if target > 0 && m.Version > target {
+				break
+			}
+			if _, ok := applied[m.Version]; ok {
+				continue
+			}
+			if err = s.Apply(ctx, m.Version, true); err != nil {
+				return errs.Wrap(err, errs.CodeOf(err), "migrate up to %d %s", m.Version, m.Name)
+			}
+			done = append(done, m)
+		}
+		return nil
+	})
+	return done, err
+}
+
+//Down revert the last steps applied migrations in reverse order of version, return the reverted ones
+func Down(ctx context.Context, s Source, steps int) ([]Migration, error) {
+	var done []Migration
+	err := withLock(ctx, s, func() error {
+		statuses, err := List(ctx, s)
+		if err != nil {
+			return err
+		}
+		for i := len(statuses) - 1; i >= 0 && len(done) < steps; i-- {
+			st := statuses[i]
+			if !st.Applied {
+				continue
+			}
+			if !st.Known {
+				return errs.NewConflict("migration %d is applied by a newer build", st.Version)
+			}
+			if err = s.Apply(ctx, st.Version, false); err != nil {
+				return errs.Wrap(err, errs.CodeOf(err), "migrate down from %d %s", st.Version, st.Name)
+			}
+			done = append(done, st.Migration)
+		}
+		return nil
+	})
+	return done, err
+}
@@ -0,0 +1,212 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+//collections of migration records and the lock
+const (
+	mongoMigrations = "schema_migrations"
+	mongoLocks      = "schema_locks"
+	mongoLockID     = "migrations"
+)
+
+//mongoStep is a migration of indexes of mongodb
+type mongoStep struct {
+	Migration
+	up   func(ctx context.Context, db *mongo.Database) error
+	down func(ctx context.Context, db *mongo.Database) error
+}
+
+//mongoSteps in order of version
+var mongoSteps = []mongoStep{
+	{Migration{1, "unique names of users, roles and resources"},
+		createIndexes(true, "name", "users", "roles", "resources"),
+		dropIndexes("name_1", "users", "roles", "resources")},
+	{Migration{2, "unique links of users, roles and resources"},
+		chain(createIndexes(true, "userid,roleid", "user_roles"), createIndexes(true, "roleid,resourceid", "role_resources")),
+		chain(dropIndexes("userid_1_roleid_1", "user_roles"), dropIndexes("roleid_1_resourceid_1", "role_resources"))},
+	{Migration{3, "index deletion of users, roles and resources"},
+		createIndexes(false, "modelextension.issoftdel,modelextension.deletedat", "users", "roles", "resources"),
+		dropIndexes("modelextension.issoftdel_1_modelextension.deletedat_1", "users", "roles", "resources")},
+	{Migration{4, "version users, roles and resources"},
+		updateMany(bson.M{"modelextension.version": bson.M{"$exists": false}}, bson.M{"$set": bson.M{"modelextension.version": 1}}, "users", "roles", "resources"),
+		updateMany(bson.M{}, bson.M{"$unset": bson.M{"modelextension.version": ""}}, "users", "roles", "resources")},
+	{Migration{5, "index logs by actor and entity"},
+		chain(createIndexes(false, "actor,_id", "logs"), createIndexes(false, "entityid,_id", "logs")),
+		chain(dropIndexes("actor_1__id_1", "logs"), dropIndexes("entityid_1__id_1", "logs"))},
+	{Migration{6, "unique names of tenants, index logs by tenant"},
+		chain(createIndexes(true, "name", "tenants"), createIndexes(false, "tenantid,_id", "logs")),
+		chain(dropIndexes("name_1", "tenants"), dropIndexes("tenantid_1__id_1", "logs"))},
+	{Migration{7, "unique names of role templates, instances by role and template"},
+		chain(createIndexes(true, "name", "role_templates"), createIndexes(true, "roleid", "role_instances"), createIndexes(false, "templateid,roleid", "role_instances")),
+		chain(dropIndexes("name_1", "role_templates"), dropIndexes("roleid_1", "role_instances"), dropIndexes("templateid_1_roleid_1", "role_instances"))},
+	{Migration{8, "unique names of org units in their parents, members by user and unit, grants by unit and role"},
+		chain(createIndexes(true, "tenantid,parentid,name", "org_units"), createIndexes(true, "userid", "org_members"),
+			createIndexes(false, "deptid", "org_members"), createIndexes(false, "positionid", "org_members"), createIndexes(true, "unitid,roleid", "org_grants")),
+		chain(dropIndexes("tenantid_1_parentid_1_name_1", "org_units"), dropIndexes("userid_1", "org_members"),
+			dropIndexes("deptid_1", "org_members"), dropIndexes("positionid_1", "org_members"), dropIndexes("unitid_1_roleid_1", "org_grants"))},
+	{Migration{9, "make names of users, roles and resources unique in their tenants"},
+		chain(dropIndexes("name_1", "users", "roles", "resources"), createIndexes(true, "tenantid,name", "users", "roles", "resources")),
+		chain(dropIndexes("tenantid_1_name_1", "users", "roles", "resources"), createIndexes(true, "name", "users", "roles", "resources"))},
+	{Migration{10, "free names of deleted tenants"},
+		chain(dropIndexes("name_1", "tenants"), createIndexes(true, "name,modelextension.deletedat", "tenants")),
+		chain(dropIndexes("name_1_modelextension.deletedat_1", "tenants"), createIndexes(true, "name", "tenants"))},
+}
+
+//updateMany update the documents matched filter in collections
+func updateMany(filter, update bson.M, collections ...string) func(ctx context.Context, db *mongo.Database) error {
+	return func(ctx context.Context, db *mongo.Database) error {
+		for _, name := range collections {
+			if _, err := db.Collection(name).UpdateMany(ctx, filter, update); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func chain(steps ...func(ctx context.Context, db *mongo.Database) error) func(ctx context.Context, db *mongo.Database) error {
+	return func(ctx context.Context, db *mongo.Database) error {
+		for _, step := range steps {
+			if err := step(ctx, db); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+//createIndexes create the index of comma separated keys on collections
+func createIndexes(unique bool, keys string, collections ...string) func(ctx context.Context, db *mongo.Database) error {
+	return func(ctx context.Context, db *mongo.Database) error {
+		index := bson.D{}
+		start := 0
+		for i := 0; i <= len(keys); i++ {
+			if i == len(keys) || keys[i] == ',' {
+				index = append(index, bson.E{Key: keys[start:i], Value: 1})
+				start = i + 1
+			}
+		}
+		for _, name := range collections {
+			_, err := db.Collection(name).Indexes().CreateOne(ctx, mongo.IndexModel{
+				Keys:    index,
+				Options: options.Index().SetUnique(unique),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func dropIndexes(index string, collections ...string) func(ctx context.Context, db *mongo.Database) error {
+	return func(ctx context.Context, db *mongo.Database) error {
+		for _, name := range collections {
+			if _, err := db.Collection(name).Indexes().DropOne(ctx, index); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+type mongoSource struct {
+	db    *mongo.Database
+	steps []mongoStep
+}
+
+//NewMongo return the migrations of mongodb
+func NewMongo(db *mongo.Database) Source {
+	return &mongoSource{db: db, steps: mongoSteps}
+}
+
+func (s *mongoSource) Migrations() []Migration {
+	migrations := make([]Migration, 0, len(s.steps))
+	for _, step := range s.steps {
+		migrations = append(migrations, step.Migration)
+	}
+	return migrations
+}
+
+func (s *mongoSource) Applied(ctx context.Context) (map[int]time.Time, error) {
+	cursor, err := s.db.Collection(mongoMigrations).Find(ctx, bson.M{})
+	if err != nil {
+		return nil, errs.NewUnavailable(err, "query migrations error")
+	}
+	defer cursor.Close(ctx)
+
+	var records []struct {
+		Version   int       `bson:"_id"`
+		AppliedAt time.Time `bson:"appliedat"`
+	}
+	if err = cursor.All(ctx, &records); err != nil {
+		return nil, errs.NewUnavailable(err, "query migrations error")
+	}
+	applied := map[int]time.Time{}
+	for _, r := range records {
+		applied[r.Version] = r.AppliedAt
+	}
+	return applied, nil
+}
+
+func (s *mongoSource) Apply(ctx context.Context, version int, up bool) error {
+	coll := s.db.Collection(mongoMigrations)
+	for _, step := range s.steps {
+		if step.Version != version {
+			continue
+		}
+		if !up {
+			if err := step.down(ctx, s.db); err != nil {
+				return errs.NewUnavailable(err, "migrate down error")
+			}
+			_, err := coll.DeleteOne(ctx, bson.M{"_id": version})
+			return err
+		}
+		if err := step.up(ctx, s.db); err != nil {
+			return errs.NewUnavailable(err, "migrate up error")
+		}
+		_, err := coll.InsertOne(ctx, bson.M{"_id": version, "name": step.Name, "appliedat": time.Now()})
+		return err
+	}
+	return errs.NewNotFound("migration %d not found", version)
+}
+
+func (s *mongoSource) TryLock(ctx context.Context, owner string, ttl time.Duration) (bool, error) {
+	coll := s.db.Collection(mongoLocks)
+	now := time.Now()
+	//take the lock when it is missing or stale, the unique _id lets only one of the racers insert it
+	_, err := coll.UpdateOne(ctx,
+		bson.M{"_id": mongoLockID, "lockedat": bson.M{"$lt": now.Add(-ttl)}},
+		bson.M{"$set": bson.M{"owner": owner, "lockedat": now}},
+		options.Update().SetUpsert(true),
+	)
+	if err == nil {
+		return true, nil
+	}
+	var we mongo.WriteException
+	if errors.As(err, &we) {
+		for _, e := range we.WriteErrors {
+			if e.Code == 11000 {
+				return false, nil
+			}
+		}
+	}
+	return false, errs.NewUnavailable(err, "take migration lock error")
+}
+
+func (s *mongoSource) Unlock(ctx context.Context, owner string) error {
+	_, err := s.db.Collection(mongoLocks).DeleteOne(ctx, bson.M{"_id": mongoLockID, "owner": owner})
+	if err != nil {
+		return errs.NewUnavailable(err, "release migration lock error")
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+//sqlStep is a migration of mysql and sqlite, tables are declared by the snapshot of models at the version,
+//later changes of models must come as new steps
+type sqlStep struct {
+	Migration
+	up   func(tx *gorm.DB) error
+	down func(tx *gorm.DB) error
+}
+
+//schemaMigration record an applied version
+type schemaMigration struct {
+	Version   int `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	AppliedAt time.Time
+}
+
+//schemaLock is the only row of the lock, held while it exists
+type schemaLock struct {
+	ID       int `gorm:"primaryKey;autoIncrement:false"`
+	Owner    string
+	LockedAt time.Time
+}
+
+type sqlSource struct {
+	db    *gorm.DB
+	steps []sqlStep
+}
+
+//NewSQL return the migrations of mysql and sqlite
+func NewSQL(db *gorm.DB) Source {
+	return &sqlSource{db: db, steps: sqlSteps}
+}
+
+func (s *sqlSource) Migrations() []Migration {
+	migrations := make([]Migration, 0, len(s.steps))
+	for _, step := range s.steps {
+		migrations = append(migrations, step.Migration)
+	}
+	return migrations
+}
+
+//prepare the tables of migrations, which may be created by a racing replica at the same time
+func (s *sqlSource) prepare(ctx context.Context) (*gorm.DB, error) {
+	tx := s.db.WithContext(ctx)
+	err := tx.AutoMigrate(&schemaMigration{}, &schemaLock{})
+	if err != nil && !(tx.Migrator().HasTable(&schemaMigration{}) && tx.Migrator().HasTable(&schemaLock{})) {
+		return nil, errs.NewUnavailable(err, "prepare migrations error")
+	}
+	return tx, nil
+}
+
+func (s *sqlSource) Applied(ctx context.Context) (map[int]time.Time, error) {
+	tx, err := s.prepare(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var records []schemaMigration
+	if err = tx.Find(&records).Error; err != nil {
+		return nil, errs.NewUnavailable(err, "query migrations error")
+	}
+	applied := map[int]time.Time{}
+	for _, r := range records {
+		applied[r.Version] = r.AppliedAt
+	}
+	return applied, nil
+}
+
+func (s *sqlSource) Apply(ctx context.Context, version int, up bool) error {
+	for _, step := range s.steps {
+		if step.Version != version {
+			continue
+		}
+		db, err := s.prepare(ctx)
+		if err != nil {
+			return err
+		}
+		return db.Transaction(func(tx *gorm.DB) error {
+			if !up {
+				if err := step.down(tx); err != nil {
+					return err
+				}
+				return tx.Delete(&schemaMigration{}, version).Error
+			}
+			if err := step.up(tx); err != nil {
+				return err
+			}
+			return tx.Create(&schemaMigration{Version: version, Name: step.Name, AppliedAt: time.Now()}).Error
+		})
+	}
+	return errs.NewNotFound("migration %d not found", version)
+}
+
+func (s *sqlSource) TryLock(ctx context.Context, owner string, ttl time.Duration) (bool, error) {
+	tx, err := s.prepare(ctx)
+	if err != nil {
+		return false, err
+	}
+	now := time.Now()
+	//a held lock fails the insert, which is expected and not logged
+	quiet := tx.Session(&gorm.Session{Logger: logger.Discard})
+	if quiet.Create(&schemaLock{ID: 1, Owner: owner, LockedAt: now}).Error == nil {
+		return true, nil
+	}
+
+	var lock schemaLock
+	err = tx.First(&lock, 1).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		//released just now
+		return false, nil
+	case err != nil:
+		return false, errs.NewUnavailable(err, "query migration lock error")
+	case now.Sub(lock.LockedAt) < ttl:
+		return false, nil
+	}
+	//take over a stale lock, only one of the racers updates it
+	result := tx.Model(&schemaLock{}).
+		Where("id = ? AND owner = ? AND locked_at = ?", 1, lock.Owner, lock.LockedAt).
+		Updates(map[string]interface{}{"owner": owner, "locked_at": now})
+	if result.Error != nil {
+		return false, errs.NewUnavailable(result.Error, "take over migration lock error")
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (s *sqlSource) Unlock(ctx context.Context, owner string) error {
+	err := s.db.WithContext(ctx).Where("id = ? AND owner = ?", 1, owner).Delete(&schemaLock{}).Error
+	if err != nil {
+		return errs.NewUnavailable(err, "release migration lock error")
+	}
+	return nil
+}
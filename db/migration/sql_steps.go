@@ -0,0 +1,385 @@
+package migration
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//sqlSteps of mysql and sqlite in order of version
+var sqlSteps = []sqlStep{
+	{Migration{1, "create users, roles and resources"}, createEntitiesV1, dropTables("users", "roles", "resources")},
+	{Migration{2, "create links of users, roles and resources"}, createLinksV2, dropTables("user_roles", "role_resources")},
+	{Migration{3, "index deletion of users, roles and resources"}, createDeletedIndexesV3, dropDeletedIndexesV3},
+	{Migration{4, "version users, roles and resources"}, addVersionsV4, dropVersionsV4},
+	{Migration{5, "create tenants"}, createTenantsV5, dropTables("tenants")},
+	{Migration{6, "create role templates and their instances"}, createRoleTemplatesV6, dropTables("role_templates", "role_instances")},
+	{Migration{7, "create org units, their members and grants"}, createOrgV7, dropTables("org_units", "org_members", "org_grants")},
+	{Migration{8, "create logs"}, createLogsV8, dropTables("logs")},
+	{Migration{9, "make names of users, roles and resources unique in their tenants"}, tenantNamesV9, globalNamesV9},
+	{Migration{10, "free names of deleted tenants"}, liveTenantNamesV10, tenantNamesV10},
+}
+
+func dropTables(tables ...string) func(tx *gorm.DB) error {
+	return func(tx *gorm.DB) error {
+		for _, table := range tables {
+			if err := tx.Migrator().DropTable(table); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+//modelExtensionV1 is the snapshot of models.ModelExtension at version 1
+type modelExtensionV1 struct {
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt time.Time
+	IsSoftDel bool
+}
+
+type userV1 struct {
+	ID         int64            `gorm:"primary_key;AUTO_INCREMENT"`
+	Type       string           `gorm:"size:8"`
+	NickName   string           `gorm:"size:64"`
+	Name       string           `gorm:"size:64;uniqueIndex"`
+	Age        int64            `gorm:"size:3"`
+	Gender     string           `gorm:"size:1;default:'0'"`
+	Password   string           `gorm:"size:128"`
+	Key        string           `gorm:"size:128"`
+	TenantID   int              `gorm:"index"`
+	FirstName  string           `gorm:"size:11"`
+	FamilyName string           `gorm:"size:11"`
+	Phone      string           `gorm:"size:11"`
+	Avatar     string           `gorm:"size:255"`
+	Stated     int              `gorm:"default:2"`
+	Email      string           `gorm:"size:128"`
+	Extension  modelExtensionV1 `gorm:"embedded"`
+}
+
+func (userV1) TableName() string { return "users" }
+
+type roleV1 struct {
+	Type      string           `gorm:"size:8"`
+	ID        int              `gorm:"primary_key;AUTO_INCREMENT"`
+	Key       string           `gorm:"size:128;"`
+	Name      string           `gorm:"size:128;uniqueIndex"`
+	TenantID  int              `gorm:"index"`
+	Extension modelExtensionV1 `gorm:"embedded"`
+}
+
+func (roleV1) TableName() string { return "roles" }
+
+type resourceV1 struct {
+	ID        int              `gorm:"primary_key;AUTO_INCREMENT"`
+	Key       string           `gorm:"size:128;"`
+	Name      string           `gorm:"size:128;uniqueIndex"`
+	TenantID  int              `gorm:"size:128;"`
+	Type      int              `gorm:"size:64;"`
+	UpdateBy  string           `gorm:"size:128;"`
+	AddedBy   string           `gorm:"size:128;"`
+	Extension modelExtensionV1 `gorm:"embedded"`
+}
+
+func (resourceV1) TableName() string { return "resources" }
+
+//createEntitiesV1 also adopt the tables created by AutoMigrate before migrations
+func createEntitiesV1(tx *gorm.DB) error {
+	return tx.AutoMigrate(&userV1{}, &roleV1{}, &resourceV1{})
+}
+
+type userRoleV2 struct {
+	UserID    int64 `gorm:"primaryKey;autoIncrement:false"`
+	RoleID    int   `gorm:"primaryKey;autoIncrement:false;index"`
+	CreatedAt time.Time
+}
+
+func (userRoleV2) TableName() string { return "user_roles" }
+
+type roleResourceV2 struct {
+	RoleID     int `gorm:"primaryKey;autoIncrement:false"`
+	ResourceID int `gorm:"primaryKey;autoIncrement:false;index"`
+	CreatedAt  time.Time
+}
+
+func (roleResourceV2) TableName() string { return "role_resources" }
+
+func createLinksV2(tx *gorm.DB) error {
+	return tx.AutoMigrate(&userRoleV2{}, &roleResourceV2{})
+}
+
+//deletedIndexesV3 of tables to find the deleted rows to purge
+var deletedIndexesV3 = map[string]string{"users": "idx_users_deleted", "roles": "idx_roles_deleted", "resources": "idx_resources_deleted"}
+
+func createDeletedIndexesV3(tx *gorm.DB) error {
+	for table, index := range deletedIndexesV3 {
+		if err := tx.Exec("CREATE INDEX " + index + " ON " + table + " (is_soft_del, deleted_at)").Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func dropDeletedIndexesV3(tx *gorm.DB) error {
+	for table, index := range deletedIndexesV3 {
+		if err := tx.Migrator().DropIndex(table, index); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//versionedTablesV4 have a version stepped by every write, rows stored before are at version 1
+var versionedTablesV4 = []string{"users", "roles", "resources"}
+
+func addVersionsV4(tx *gorm.DB) error {
+	for _, table := range versionedTablesV4 {
+		if err := tx.Exec("ALTER TABLE " + table + " ADD COLUMN version bigint NOT NULL DEFAULT 1").Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//dropVersionsV4 drop the columns, sqlite before 3.35 can not drop a column and rebuilds the tables of version 3
+func dropVersionsV4(tx *gorm.DB) error {
+	if tx.Dialector.Name() != "sqlite" {
+		for _, table := range versionedTablesV4 {
+			if err := tx.Exec("ALTER TABLE " + table + " DROP COLUMN version").Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, model := range []interface{}{&userV1{}, &roleV1{}, &resourceV1{}} {
+		if err := rebuildSQLiteV3(tx, model); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//rebuildSQLiteV3 copy the rows of the table of model to a new one of version 3
+func rebuildSQLiteV3(tx *gorm.DB, model interface{}) error {
+	stmt := &gorm.Statement{DB: tx}
+	if err := stmt.Parse(model); err != nil {
+		return err
+	}
+	table, old := stmt.Table, stmt.Table+"__v4"
+
+	rows, err := tx.Raw("SELECT name FROM sqlite_master WHERE type = ? AND tbl_name = ? AND sql IS NOT NULL", "index", table).Rows()
+	if err != nil {
+		return err
+	}
+	var indexes []string
+	for rows.Next() {
+		var index string
+		if err = rows.Scan(&index); err != nil {
+			rows.Close()
+			return err
+		}
+		indexes = append(indexes, index)
+	}
+	rows.Close()
+
+	//indexes keep their names when the table is renamed, drop them first for the new table
+	queries := []string{}
+	for _, index := range indexes {
+		queries = append(queries, "DROP INDEX "+index)
+	}
+	queries = append(queries, "ALTER TABLE "+table+" RENAME TO "+old)
+	for _, query := range queries {
+		if err = tx.Exec(query).Error; err != nil {
+			return err
+		}
+	}
+	if err = tx.Migrator().CreateTable(model); err != nil {
+		return err
+	}
+	columns := strings.Join(stmt.Schema.DBNames, ", ")
+	queries = []string{
+		"INSERT INTO " + table + " (" + columns + ") SELECT " + columns + " FROM " + old,
+		"DROP TABLE " + old,
+		"CREATE INDEX " + deletedIndexesV3[table] + " ON " + table + " (is_soft_del, deleted_at)",
+	}
+	for _, query := range queries {
+		if err = tx.Exec(query).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type passwordPolicyV5 struct {
+	MinLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+}
+
+type tenantSettingsV5 struct {
+	PasswordPolicy  passwordPolicyV5 `gorm:"embedded;embeddedPrefix:password_"`
+	MFARequired     bool
+	SessionLifetime int64
+}
+
+type tenantV5 struct {
+	ID        int    `gorm:"primary_key;AUTO_INCREMENT"`
+	Name      string `gorm:"size:128;uniqueIndex"`
+	State     int
+	Settings  tenantSettingsV5 `gorm:"embedded;embeddedPrefix:setting_"`
+	Extension modelExtensionV1 `gorm:"embedded"`
+	Version   int64            `gorm:"not null;default:1"`
+}
+
+func (tenantV5) TableName() string { return "tenants" }
+
+//createTenantsV5 never reuse ids of deleted tenants, data of a deleted tenant is kept until it is purged.
+//The rowid of sqlite is reused without AUTOINCREMENT, which gorm does not declare.
+func createTenantsV5(tx *gorm.DB) error {
+	if tx.Dialector.Name() != "sqlite" {
+		return tx.Migrator().CreateTable(&tenantV5{})
+	}
+	queries := []string{
+		"CREATE TABLE `tenants` (`id` integer PRIMARY KEY AUTOINCREMENT,`name` text,`state` integer," +
+			"`setting_password_min_length` integer,`setting_password_require_upper` numeric,`setting_password_require_lower` numeric," +
+			"`setting_password_require_digit` numeric,`setting_password_require_symbol` numeric," +
+			"`setting_mfa_required` numeric,`setting_session_lifetime` integer," +
+			"`created_at` datetime,`updated_at` datetime,`deleted_at` datetime,`is_soft_del` numeric,`version` integer NOT NULL DEFAULT 1)",
+		"CREATE UNIQUE INDEX `idx_tenants_name` ON `tenants`(`name`)",
+	}
+	for _, query := range queries {
+		if err := tx.Exec(query).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type roleTemplateV6 struct {
+	ID        int    `gorm:"primary_key;AUTO_INCREMENT"`
+	Name      string `gorm:"size:128;uniqueIndex"`
+	Key       string `gorm:"size:128"`
+	Grants    string `gorm:"type:text"`
+	Default   bool
+	Extension modelExtensionV1 `gorm:"embedded"`
+	Version   int64            `gorm:"not null;default:1"`
+}
+
+func (roleTemplateV6) TableName() string { return "role_templates" }
+
+type roleInstanceV6 struct {
+	RoleID          int `gorm:"primaryKey;autoIncrement:false"`
+	TemplateID      int `gorm:"index"`
+	TemplateVersion int64
+	TenantID        int
+	ScopeID         int
+	CreatedAt       time.Time
+}
+
+func (roleInstanceV6) TableName() string { return "role_instances" }
+
+func createRoleTemplatesV6(tx *gorm.DB) error {
+	return tx.Migrator().CreateTable(&roleTemplateV6{}, &roleInstanceV6{})
+}
+
+type orgUnitV7 struct {
+	ID        int `gorm:"primary_key;AUTO_INCREMENT"`
+	Kind      int
+	Name      string           `gorm:"size:128;uniqueIndex:idx_org_units_name"`
+	ParentID  int              `gorm:"uniqueIndex:idx_org_units_name"`
+	TenantID  int              `gorm:"uniqueIndex:idx_org_units_name"`
+	Extension modelExtensionV1 `gorm:"embedded"`
+	Version   int64            `gorm:"not null;default:1"`
+}
+
+func (orgUnitV7) TableName() string { return "org_units" }
+
+type orgMemberV7 struct {
+	UserID     int64 `gorm:"primaryKey;autoIncrement:false"`
+	DeptID     int   `gorm:"index"`
+	PositionID int   `gorm:"index"`
+	TenantID   int
+	CreatedAt  time.Time
+}
+
+func (orgMemberV7) TableName() string { return "org_members" }
+
+type orgGrantV7 struct {
+	UnitID    int `gorm:"primaryKey;autoIncrement:false"`
+	RoleID    int `gorm:"primaryKey;autoIncrement:false;index"`
+	CreatedAt time.Time
+}
+
+func (orgGrantV7) TableName() string { return "org_grants" }
+
+func createOrgV7(tx *gorm.DB) error {
+	return tx.Migrator().CreateTable(&orgUnitV7{}, &orgMemberV7{}, &orgGrantV7{})
+}
+
+type logV8 struct {
+	ID        int64  `gorm:"primary_key;AUTO_INCREMENT"`
+	Actor     string `gorm:"size:128;index"`
+	TenantID  int    `gorm:"index"`
+	Kind      int
+	Action    int
+	EntityID  string    `gorm:"size:64;index"`
+	TargetID  string    `gorm:"size:64"`
+	Diff      string    `gorm:"type:text"`
+	RequestID string    `gorm:"size:128"`
+	Time      time.Time `gorm:"index"`
+}
+
+func (logV8) TableName() string { return "logs" }
+
+//createLogsV8 never delete logs, so the ids are never reused
+func createLogsV8(tx *gorm.DB) error {
+	return tx.Migrator().CreateTable(&logV8{})
+}
+
+//namedTablesV9 have names unique in a tenant from version 9, in all tenants before
+var namedTablesV9 = []string{"users", "roles", "resources"}
+
+func tenantNamesV9(tx *gorm.DB) error {
+	for _, table := range namedTablesV9 {
+		if err := tx.Migrator().DropIndex(table, "idx_"+table+"_name"); err != nil {
+			return err
+		}
+		if err := tx.Exec("CREATE UNIQUE INDEX idx_" + table + "_tenant_name ON " + table + " (tenant_id, name)").Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//globalNamesV9 fail when a name is taken in more than one tenant
+func globalNamesV9(tx *gorm.DB) error {
+	for _, table := range namedTablesV9 {
+		if err := tx.Migrator().DropIndex(table, "idx_"+table+"_tenant_name"); err != nil {
+			return err
+		}
+		if err := tx.Exec("CREATE UNIQUE INDEX idx_" + table + "_name ON " + table + " (name)").Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//liveTenantNamesV10 keep the names of deleted tenants unique by the time of deletion, live ones have the zero time
+func liveTenantNamesV10(tx *gorm.DB) error {
+	if err := tx.Migrator().DropIndex("tenants", "idx_tenants_name"); err != nil {
+		return err
+	}
+	return tx.Exec("CREATE UNIQUE INDEX idx_tenants_name_deleted ON tenants (name, deleted_at)").Error
+}
+
+//tenantNamesV10 fail when the name of a deleted tenant is taken again
+func tenantNamesV10(tx *gorm.DB) error {
+	if err := tx.Migrator().DropIndex("tenants", "idx_tenants_name_deleted"); err != nil {
+		return err
+	}
+	return tx.Exec("CREATE UNIQUE INDEX idx_tenants_name ON tenants (name)").Error
+}
@@ -0,0 +1,123 @@
+package migration
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newSQLite(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "auth.db")+"?_busy_timeout=5000&_txlock=immediate"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestUpDown(t *testing.T) {
+	ctx := context.Background()
+	db := newSQLite(t)
+	s := NewSQL(db)
+
+	done, err := Up(ctx, s, 1)
+	if err != nil || len(done) != 1 {
+		t.Fatalf("up to 1: %v %v", done, err)
+	}
+	if !db.Migrator().HasTable("users") || db.Migrator().HasTable("user_roles") {
+		t.Fatal("up to 1 should create users only")
+	}
+	if done, err = Up(ctx, s, 0); err != nil || len(done) != len(sqlSteps)-1 {
+		t.Fatalf("up: %v %v", done, err)
+	}
+	if done, err = Up(ctx, s, 0); err != nil || len(done) != 0 {
+		t.Fatalf("up again: %v %v", done, err)
+	}
+
+	if err = db.Exec("INSERT INTO users (name) VALUES (?)", "a").Error; err != nil {
+		t.Fatal(err)
+	}
+	//down to version 3
+	down := len(sqlSteps) - 3
+	if done, err = Down(ctx, s, down); err != nil || len(done) != down || done[down-1].Version != 4 {
+		t.Fatalf("down: %v %v", done, err)
+	}
+	for _, table := range []string{"tenants", "role_templates", "role_instances", "org_units", "org_members", "org_grants", "logs"} {
+		if db.Migrator().HasTable(table) {
+			t.Fatalf("down should drop %s", table)
+		}
+	}
+	if db.Migrator().HasColumn(&userV1{}, "version") {
+		t.Fatal("down should drop the version of users")
+	}
+	var count int64
+	if db.Table("users").Count(&count); count != 1 {
+		t.Fatalf("down should keep the users, %d left", count)
+	}
+	for _, index := range []string{"idx_users_name", "idx_users_deleted"} {
+		if !db.Migrator().HasIndex("users", index) {
+			t.Fatalf("down should keep %s", index)
+		}
+	}
+	statuses, err := List(ctx, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, st := range statuses {
+		if st.Applied != (st.Version <= 3) {
+			t.Fatalf("status of %d: %+v", st.Version, st)
+		}
+	}
+}
+
+func TestNamesUniqueInTenants(t *testing.T) {
+	ctx := context.Background()
+	db := newSQLite(t)
+	if _, err := Up(ctx, NewSQL(db), 0); err != nil {
+		t.Fatal(err)
+	}
+	for _, table := range namedTablesV9 {
+		insert := "INSERT INTO " + table + " (name, tenant_id) VALUES (?, ?)"
+		if err := db.Exec(insert, "a", 1).Error; err != nil {
+			t.Fatal(err)
+		}
+		if err := db.Exec(insert, "a", 2).Error; err != nil {
+			t.Fatalf("%s of a name taken in another tenant: %v", table, err)
+		}
+		if err := db.Exec(insert, "a", 1).Error; err == nil {
+			t.Fatalf("%s of a name taken in the tenant should fail", table)
+		}
+	}
+}
+
+func TestConcurrentUp(t *testing.T) {
+	ctx := context.Background()
+	db := newSQLite(t)
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		applied int
+	)
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			done, err := Up(ctx, NewSQL(db), 0)
+			if err != nil {
+				t.Error(err)
+			}
+			mu.Lock()
+			applied += len(done)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	if applied != len(sqlSteps) {
+		t.Fatalf("applied %d migrations, want %d", applied, len(sqlSteps))
+	}
+}
@@ -0,0 +1,180 @@
+package nosql
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/micro/micro/v3/service/logger"
+	bolt "go.etcd.io/bbolt"
+)
+
+//BoltOptions of the embedded file database
+type BoltOptions struct {
+	Path            string        // file of the database
+	Timeout         time.Duration // to wait for the lock of the file held by another process
+	CompactInterval time.Duration // compact the file every interval, 0 disables it
+}
+
+//RbacBuckets of users, roles, resources, their names and links in both directions, logs of mutations,
+//tenants, role templates and their instances, org units with their names, members and grants
+var RbacBuckets = []string{
+	"users", "users_names", "roles", "roles_names", "resources", "resources_names",
+	"user_roles", "role_users", "role_resources", "resource_roles", "logs", "tenants", "tenants_names",
+	"role_templates", "role_templates_names", "role_instances", "org_units", "org_units_names", "org_members", "org_grants",
+}
+
+//BoltDB is an embedded database in a file, writes are atomic transactions,
+//snapshots and compaction run while it is in use
+type BoltDB struct {
+	mu      sync.RWMutex // held exclusively to swap the file when compacting
+	db      *bolt.DB
+	path    string
+	options *bolt.Options
+}
+
+//OpenBolt open the database file of config with the buckets, both are created when missing
+func OpenBolt(config *BoltOptions, buckets ...string) (*BoltDB, error) {
+	options := &bolt.Options{Timeout: config.Timeout}
+	db, err := bolt.Open(config.Path, 0600, options)
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range buckets {
+			if _, err := tx.CreateBucketIfNotExists([]byte(name)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &BoltDB{db: db, path: config.Path, options: options}, nil
+}
+
+//Update run fn in a writable transaction, it commits when fn returns nil
+func (b *BoltDB) Update(fn func(tx *bolt.Tx) error) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.db.Update(fn)
+}
+
+//View run fn in a read-only transaction
+func (b *BoltDB) View(fn func(tx *bolt.Tx) error) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.db.View(fn)
+}
+
+//Snapshot write a consistent copy of the database to w, it is a database file itself
+func (b *BoltDB) Snapshot(w io.Writer) (int64, error) {
+	var n int64
+	err := b.View(func(tx *bolt.Tx) error {
+		var err error
+		n, err = tx.WriteTo(w)
+		return err
+	})
+	return n, err
+}
+
+//Compact rewrite the database to a new file without free pages and swap it in,
+//return the sizes of the file before and after
+func (b *BoltDB) Compact() (int64, int64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	before, err := fileSize(b.path)
+	if err != nil {
+		return 0, 0, err
+	}
+	temp := b.path + ".compact"
+	os.Remove(temp)
+	dst, err := bolt.Open(temp, 0600, b.options)
+	if err != nil {
+		return 0, 0, err
+	}
+	err = b.db.View(func(src *bolt.Tx) error {
+		return dst.Update(func(tx *bolt.Tx) error { return copyBuckets(src, tx) })
+	})
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(temp)
+		return 0, 0, err
+	}
+
+	if err = b.db.Close(); err != nil {
+		return 0, 0, err
+	}
+	if err = os.Rename(temp, b.path); err != nil {
+		logger.Errorf("swap compacted %s error: %v", b.path, err)
+	}
+	//reopen the file in place even when the swap failed, the old file is kept then
+	db, openErr := bolt.Open(b.path, 0600, b.options)
+	if openErr != nil {
+		return 0, 0, openErr
+	}
+	b.db = db
+	if err != nil {
+		return 0, 0, err
+	}
+	after, err := fileSize(b.path)
+	return before, after, err
+}
+
+//RunCompaction compact every interval until ctx is done, a zero interval disables it
+func (b *BoltDB) RunCompaction(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			before, after, err := b.Compact()
+			if err != nil {
+				logger.Errorf("compact %s error: %v", b.path, err)
+				continue
+			}
+			logger.Infof("compacted %s from %d to %d bytes", b.path, before, after)
+		}
+	}
+}
+
+//Close the database
+func (b *BoltDB) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.db.Close()
+}
+
+//copyBuckets copy the buckets of src and their sequences to dst, buckets are not nested
+func copyBuckets(src, dst *bolt.Tx) error {
+	return src.ForEach(func(name []byte, from *bolt.Bucket) error {
+		to, err := dst.CreateBucketIfNotExists(name)
+		if err != nil {
+			return err
+		}
+		if err = to.SetSequence(from.Sequence()); err != nil {
+			return err
+		}
+		return from.ForEach(func(k, v []byte) error { return to.Put(k, v) })
+	})
+}
+
+func fileSize(path string) (int64, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
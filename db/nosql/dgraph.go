@@ -3,7 +3,6 @@ package nosql
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 
 	"github.com/dgraph-io/dgo/v200"
 	"github.com/dgraph-io/dgo/v200/protos/api"
@@ -43,37 +42,72 @@ func NewDGraphClient(cfg *DgraphOptions) *DormDB {
 	return &DormDB{dg: dClient}
 }
 
-func (d *DormDB) txn() *dgo.Txn {
-	return d.dg.NewTxn()
+//txnKey is the key of the transaction of WithTxn in a context
+type txnKey struct{}
+
+//WithTxn run fn in a transaction, calls of d with the ctx passed to fn join it.
+//It commits when fn returns nil and discards otherwise, a WithTxn in fn joins the outer transaction,
+//a conflict with a concurrent transaction returns dgo.ErrAborted
+func (d *DormDB) WithTxn(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txnKey{}).(*dgo.Txn); ok {
+		return fn(ctx)
+	}
+	txn := d.dg.NewTxn()
+	defer txn.Discard(context.Background())
+	if err := fn(context.WithValue(ctx, txnKey{}, txn)); err != nil {
+		return err
+	}
+	return txn.Commit(ctx)
+}
+
+//txn return the transaction of ctx, or a new one which commits with its mutation
+func (d *DormDB) txn(ctx context.Context) (txn *dgo.Txn, commitNow bool) {
+	if txn, ok := ctx.Value(txnKey{}).(*dgo.Txn); ok {
+		return txn, false
+	}
+	return d.dg.NewTxn(), true
+}
+
+//readTxn return the transaction of ctx, which sees its own mutations, or a new read only one
+func (d *DormDB) readTxn(ctx context.Context) *dgo.Txn {
+	if txn, ok := ctx.Value(txnKey{}).(*dgo.Txn); ok {
+		return txn
+	}
+	return d.dg.NewReadOnlyTxn()
+}
+
+func (d *DormDB) QueryReadOnly(ctx context.Context, q string) (*api.Response, error) {
+	return d.readTxn(ctx).Query(ctx, q)
 }
 
-func (d *DormDB) QueryReadOnly(q string) (*api.Response, error) {
-	return d.dg.NewReadOnlyTxn().Query(context.Background(), q)
+func (d *DormDB) Query(ctx context.Context, q string) (*api.Response, error) {
+	return d.readTxn(ctx).Query(ctx, q)
 }
 
-func (d *DormDB) Query(q string) (*api.Response, error) {
-	return d.txn().Query(context.Background(), q)
+//QueryWithVars query with variables in a read only transaction
+func (d *DormDB) QueryWithVars(ctx context.Context, queryString string, variables map[string]string) (*api.Response, error) {
+	return d.readTxn(ctx).QueryWithVars(ctx, queryString, variables)
 }
 
 //Query2ID  ..
-func (d *DormDB) Query2ID(id1, id2, queryString string) (*api.Response, error) {
+func (d *DormDB) Query2ID(ctx context.Context, id1, id2, queryString string) (*api.Response, error) {
 	// Assigned uids for nodes which were created would be returned in the resp.AssignedUids map.
-	variables := map[string]string{"$id1": id2, "$id2": id2}
-	resp, err := d.txn().QueryWithVars(context.Background(), queryString, variables)
+	variables := map[string]string{"$id1": id1, "$id2": id2}
+	resp, err := d.readTxn(ctx).QueryWithVars(ctx, queryString, variables)
 	if err != nil {
-		logger.Fatal("query id1: %s id2: %s with error ", id1, id2, err)
+		logger.Errorf("query id1: %s id2: %s with error %v", id1, id2, err)
 	}
 
 	return resp, err
 }
 
 //QueryID  to query a id..
-func (d *DormDB) QueryID(targetID, queryString string) (*api.Response, error) {
+func (d *DormDB) QueryID(ctx context.Context, targetID, queryString string) (*api.Response, error) {
 	// Assigned uids for nodes which were created would be returned in the resp.AssignedUids map.
 	variables := map[string]string{"$id": targetID}
-	resp, err := d.txn().QueryWithVars(context.Background(), queryString, variables)
+	resp, err := d.readTxn(ctx).QueryWithVars(ctx, queryString, variables)
 	if err != nil {
-		logger.Fatal(err)
+		logger.Errorf("query id: %s with error %v", targetID, err)
 	}
 
 	return resp, err
@@ -81,22 +115,23 @@ func (d *DormDB) QueryID(targetID, queryString string) (*api.Response, error) {
 }
 
 //MutateObject is under writing
-func (d *DormDB) MutateObject(typestruct interface{}) (*api.Response, error) {
+func (d *DormDB) MutateObject(ctx context.Context, typestruct interface{}) (*api.Response, error) {
 
 	pb, err := json.Marshal(typestruct)
 	if err != nil {
 		logger.Errorf("json Marshal error: %v", err)
 	}
-	return d.Mutate(pb)
+	return d.Mutate(ctx, pb)
 	// 	if err != nil {
 	// 		logger.Fatalf("dgraph Mutate error: %v", err)
 	// 	}
 }
 
-func (d *DormDB) UpdateRelationShip(subject, predicate, object string, isSetRelationShip bool) (*api.Response, error) {
+func (d *DormDB) UpdateRelationShip(ctx context.Context, subject, predicate, object string, isSetRelationShip bool) (*api.Response, error) {
 
+	txn, commitNow := d.txn(ctx)
 	mu := &api.Mutation{
-		CommitNow: true,
+		CommitNow: commitNow,
 	}
 
 	nq := &api.NQuad{
@@ -111,80 +146,106 @@ func (d *DormDB) UpdateRelationShip(subject, predicate, object string, isSetRela
 		mu.Del = []*api.NQuad{nq}
 	}
 
-	return d.txn().Mutate(context.Background(), mu)
+	return txn.Mutate(ctx, mu)
 }
 
-func (d *DormDB) Mutate(b []byte) (*api.Response, error) {
+func (d *DormDB) Mutate(ctx context.Context, b []byte) (*api.Response, error) {
 
+	txn, commitNow := d.txn(ctx)
 	mu := &api.Mutation{
-		CommitNow: true,
+		CommitNow: commitNow,
 	}
 
 	mu.SetJson = b
-	return d.txn().Mutate(context.Background(), mu)
+	return txn.Mutate(ctx, mu)
 }
 
-func (d *DormDB) BatchDelete(uids []string) error {
-
-	ctx := context.Background()
+//BatchDelete delete all predicates of the nodes of uids in one mutation
+func (d *DormDB) BatchDelete(ctx context.Context, uids []string) error {
 
+	data := make([]map[string]string, 0, len(uids))
 	for _, uid := range uids {
-		data := map[string]string{"uid": uid}
-		//	logger.Info(data)
-		pb, err := json.Marshal(data)
-		if err != nil {
-			return err
-		}
-		_, err = d.txn().Mutate(ctx, &api.Mutation{DeleteJson: pb})
-		if err != nil {
-			return err
-		}
+		data = append(data, map[string]string{"uid": uid})
 	}
-
-	return d.txn().Commit(ctx)
-
+	pb, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	txn, commitNow := d.txn(ctx)
+	_, err = txn.Mutate(ctx, &api.Mutation{DeleteJson: pb, CommitNow: commitNow})
+	return err
 }
 
-func (d *DormDB) Delete(b []byte) error {
-
-	fmt.Println(string(b))
+func (d *DormDB) Delete(ctx context.Context, b []byte) error {
 
+	txn, commitNow := d.txn(ctx)
 	mu := &api.Mutation{
-		CommitNow:  true,
+		CommitNow:  commitNow,
 		DeleteJson: b,
 	}
-	resp, err := d.txn().Mutate(context.Background(), mu)
-
-	fmt.Println(string(resp.Json))
+	_, err := txn.Mutate(ctx, mu)
 
 	return err
 }
 
-func (d *DormDB) Update(set string) error {
+func (d *DormDB) Update(ctx context.Context, set string) error {
 
+	txn, commitNow := d.txn(ctx)
 	mu := &api.Mutation{
-		CommitNow: true,
+		CommitNow: commitNow,
 		SetNquads: []byte(set),
 	}
-	_, err := d.txn().Mutate(context.Background(), mu)
+	_, err := txn.Mutate(ctx, mu)
 	//fmt.Println(string(resp.Json))
 	return err
 }
 
-func (d *DormDB) UpdateWithQuery(query, set string) error {
+func (d *DormDB) UpdateWithQuery(ctx context.Context, query, set string) error {
 
-	mu := &api.Mutation{
-		CommitNow: true,
-	}
+	txn, commitNow := d.txn(ctx)
+	mu := &api.Mutation{}
 
-	req := &api.Request{CommitNow: true}
+	req := &api.Request{CommitNow: commitNow}
 	req.Query = query
 
 	mu.SetNquads = []byte(set)
 	req.Mutations = []*api.Mutation{mu}
-	resp, err := d.txn().Do(context.Background(), req)
-
-	fmt.Println(string(resp.Json))
+	_, err := txn.Do(ctx, req)
 
 	return err
 }
+
+//Alter apply the schema, predicates and types not in schema are kept
+func (d *DormDB) Alter(ctx context.Context, schema string) error {
+	return d.dg.Alter(ctx, &api.Operation{Schema: schema})
+}
+
+//DropType drop the type of name, its predicates are kept
+func (d *DormDB) DropType(ctx context.Context, name string) error {
+	return d.dg.Alter(ctx, &api.Operation{DropOp: api.Operation_TYPE, DropValue: name})
+}
+
+//Upsert run query and the conditional mutations of nquads in one transaction,
+//a conflict with a concurrent transaction returns dgo.ErrAborted
+func (d *DormDB) Upsert(ctx context.Context, query string, mutations ...*api.Mutation) (*api.Response, error) {
+	txn, commitNow := d.txn(ctx)
+	req := &api.Request{
+		Query:     query,
+		Mutations: mutations,
+		CommitNow: commitNow,
+	}
+	return txn.Do(ctx, req)
+}
+
+//RunUpsert run q and the conditional mutations in one transaction like Upsert
+func (d *DormDB) RunUpsert(ctx context.Context, q *DQL, mutations ...*api.Mutation) (*api.Response, error) {
+	query, values := q.Build()
+	txn, commitNow := d.txn(ctx)
+	req := &api.Request{
+		Query:     query,
+		Vars:      values,
+		Mutations: mutations,
+		CommitNow: commitNow,
+	}
+	return txn.Do(ctx, req)
+}
@@ -1,9 +1,12 @@
+// +build dgraph
+
 package nosql
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"log"
+	"os"
 	"testing"
 )
 
@@ -17,15 +20,20 @@ var (
 	dg              *DormDB
 )
 
+//init connect the dgraph server of DGRAPH_URL, these tests only run with the dgraph build tag
 func init() {
-	dg = NewDGraphClient(&DgraphOptions{Url: "127.0.0.1:8090"})
+	url := os.Getenv("DGRAPH_URL")
+	if url == "" {
+		url = "127.0.0.1:8090"
+	}
+	dg = NewDGraphClient(&DgraphOptions{Url: url})
 }
 
 func TestGraphQuery(t *testing.T) {
 
-	resp, err := dg.Query(someQueryString)
+	resp, err := dg.Query(context.Background(), someQueryString)
 	if err != nil {
-		log.Fatal(err)
+		t.Fatal(err)
 	}
 	var result DefaultResult
 	_ = json.Unmarshal(resp.GetJson(), &result)
@@ -35,9 +43,9 @@ func TestGraphQuery(t *testing.T) {
 
 func TestDGraphReadonlyQuery(t *testing.T) {
 
-	resp, err := dg.QueryReadOnly(someQueryString)
+	resp, err := dg.QueryReadOnly(context.Background(), someQueryString)
 	if err != nil {
-		log.Fatal(err)
+		t.Fatal(err)
 	}
 
 	var result DefaultResult
@@ -48,9 +56,9 @@ func TestDGraphReadonlyQuery(t *testing.T) {
 
 func TestColumnExistQueryString(t *testing.T) {
 
-	resp, err := dg.QueryReadOnly(someQueryString)
+	resp, err := dg.QueryReadOnly(context.Background(), someQueryString)
 	if err != nil {
-		log.Fatal(err)
+		t.Fatal(err)
 	}
 	var result DefaultResult
 	_ = json.Unmarshal(resp.GetJson(), &result)
@@ -0,0 +1,358 @@
+package nosql
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/dgraph-io/dgo/v200/protos/api"
+)
+
+//names of predicates, types, aliases and value variables, ~ leads a reverse edge, anything else is not a name
+//and panics as a bug of the caller, values must be bound by the typed variables of DQL
+var dqlName = regexp.MustCompile(`^~?[A-Za-z_][A-Za-z0-9_.]*$`)
+
+func mustName(name string) string {
+	if !dqlName.MatchString(name) {
+		panic(fmt.Sprintf("dql: invalid name %q", name))
+	}
+	return name
+}
+
+//Var is a declared variable of a query, its value is sent apart from the query
+type Var string
+
+//DQL build a parameterized query, values are only bound by declared typed variables:
+//	q := nosql.NewDQL("find")
+//	q.Block("find", nosql.Eq("id", q.Int(1))).Filter(nosql.OfType("User")).Fields("uid", "name")
+type DQL struct {
+	name   string
+	params []string
+	values map[string]string
+	blocks []*Block
+}
+
+//NewDQL return an empty query of name
+func NewDQL(name string) *DQL {
+	return &DQL{name: mustName(name), values: map[string]string{}}
+}
+
+func (q *DQL) bind(typ, value string) Var {
+	v := fmt.Sprintf("$v%d", len(q.params)+1)
+	q.params = append(q.params, fmt.Sprintf("%s: %s", v, typ))
+	q.values[v] = value
+	return Var(v)
+}
+
+//Int declare an int variable of value
+func (q *DQL) Int(value int64) Var { return q.bind("int", strconv.FormatInt(value, 10)) }
+
+//Float declare a float variable of value
+func (q *DQL) Float(value float64) Var {
+	return q.bind("float", strconv.FormatFloat(value, 'g', -1, 64))
+}
+
+//Bool declare a bool variable of value
+func (q *DQL) Bool(value bool) Var { return q.bind("bool", strconv.FormatBool(value)) }
+
+//Str declare a string variable of value
+func (q *DQL) Str(value string) Var { return q.bind("string", value) }
+
+//Time declare a datetime variable of value, dgraph takes it as a string
+func (q *DQL) Time(value time.Time) Var { return q.bind("string", value.Format(time.RFC3339Nano)) }
+
+//Block add a query block of alias with the root function, a zero root is a block of value variables only
+func (q *DQL) Block(alias string, root Func) *Block {
+	b := &Block{top: true, head: mustName(alias), root: root}
+	q.blocks = append(q.blocks, b)
+	return b
+}
+
+//VarBlock add a var block with the root function, its results are only kept by value variables
+func (q *DQL) VarBlock(root Func) *Block {
+	b := &Block{top: true, head: "var", root: root}
+	q.blocks = append(q.blocks, b)
+	return b
+}
+
+//Build the query and the values of its variables
+func (q *DQL) Build() (string, map[string]string) {
+	var sb strings.Builder
+	if len(q.params) > 0 {
+		fmt.Fprintf(&sb, "query %s(%s) {\n", q.name, strings.Join(q.params, ", "))
+	} else {
+		sb.WriteString("{\n")
+	}
+	for _, b := range q.blocks {
+		b.write(&sb, 1)
+	}
+	sb.WriteString("}")
+
+	values := make(map[string]string, len(q.values))
+	for k, v := range q.values {
+		values[k] = v
+	}
+	return sb.String(), values
+}
+
+func (q *DQL) String() string {
+	s, _ := q.Build()
+	return s
+}
+
+//Func is a root function or a filter of DQL
+type Func struct {
+	expr string
+}
+
+//OfType match nodes of the dgraph type name
+func OfType(name string) Func { return Func{fmt.Sprintf("type(%s)", mustName(name))} }
+
+//UID match nodes of value variables
+func UID(names ...string) Func {
+	for _, name := range names {
+		mustName(name)
+	}
+	return Func{fmt.Sprintf("uid(%s)", strings.Join(names, ", "))}
+}
+
+//UIDOf match the node of the uid bound to v
+func UIDOf(v Var) Func { return Func{fmt.Sprintf("uid(%s)", v)} }
+
+//Has match nodes with the predicate
+func Has(predicate string) Func { return Func{fmt.Sprintf("has(%s)", mustName(predicate))} }
+
+func compare(fn, predicate string, v Var) Func {
+	return Func{fmt.Sprintf("%s(%s, %s)", fn, mustName(predicate), v)}
+}
+
+//Eq match nodes whose predicate equals v
+func Eq(predicate string, v Var) Func { return compare("eq", predicate, v) }
+
+//Lt match nodes whose predicate is less than v
+func Lt(predicate string, v Var) Func { return compare("lt", predicate, v) }
+
+//Le match nodes whose predicate is less than or equal to v
+func Le(predicate string, v Var) Func { return compare("le", predicate, v) }
+
+//Gt match nodes whose predicate is greater than v
+func Gt(predicate string, v Var) Func { return compare("gt", predicate, v) }
+
+//Ge match nodes whose predicate is greater than or equal to v
+func Ge(predicate string, v Var) Func { return compare("ge", predicate, v) }
+
+//In match nodes whose predicate equals any of vs, only for filters
+func In(predicate string, vs ...Var) Func {
+	fs := make([]Func, 0, len(vs))
+	for _, v := range vs {
+		fs = append(fs, Eq(predicate, v))
+	}
+	return Or(fs...)
+}
+
+//HasPrefix match nodes whose string predicate begins with prefix by the range from prefix to its successor,
+//both bound by variables of q, the predicate needs an exact index to compare strings
+func HasPrefix(q *DQL, predicate, prefix string) Func {
+	from := Ge(predicate, q.Str(prefix))
+	to, ok := successor(prefix)
+	if !ok {
+		return from
+	}
+	return And(from, Lt(predicate, q.Str(to)))
+}
+
+//successor return the least string greater than every string beginning with prefix,
+//false when there is none as prefix is empty or made of utf8.MaxRune only
+func successor(prefix string) (string, bool) {
+	runes := []rune(prefix)
+	for i := len(runes) - 1; i >= 0; i-- {
+		if runes[i] == utf8.MaxRune {
+			continue
+		}
+		runes[i]++
+		//surrogates are not valid in strings
+		if runes[i] >= 0xD800 && runes[i] <= 0xDFFF {
+			runes[i] = 0xE000
+		}
+		return string(runes[:i+1]), true
+	}
+	return "", false
+}
+
+func join(op string, fs []Func) Func {
+	exprs := make([]string, 0, len(fs))
+	for _, f := range fs {
+		exprs = append(exprs, f.expr)
+	}
+	if len(exprs) == 1 {
+		return Func{exprs[0]}
+	}
+	return Func{"(" + strings.Join(exprs, " "+op+" ") + ")"}
+}
+
+//And match nodes matched by all fs
+func And(fs ...Func) Func { return join("AND", fs) }
+
+//Or match nodes matched by any of fs
+func Or(fs ...Func) Func { return join("OR", fs) }
+
+//Not match nodes not matched by f
+func Not(f Func) Func { return Func{"NOT " + f.expr} }
+
+//Block of a query or an edge of its parent block
+type Block struct {
+	top        bool
+	head       string
+	as         string
+	root       Func
+	args       []string
+	filters    []Func
+	directives []string
+	fields     []string
+	edges      []*Block
+}
+
+//As keep the nodes of the block by the value variable name
+func (b *Block) As(name string) *Block {
+	b.as = mustName(name)
+	return b
+}
+
+//Filter the nodes by all fs
+func (b *Block) Filter(fs ...Func) *Block {
+	b.filters = append(b.filters, fs...)
+	return b
+}
+
+//OrderAsc order the nodes by predicate, the first order is the primary one
+func (b *Block) OrderAsc(predicate string) *Block {
+	b.args = append(b.args, "orderasc: "+mustName(predicate))
+	return b
+}
+
+//OrderDesc order the nodes by predicate in reverse, the first order is the primary one
+func (b *Block) OrderDesc(predicate string) *Block {
+	b.args = append(b.args, "orderdesc: "+mustName(predicate))
+	return b
+}
+
+//First take the first v nodes
+func (b *Block) First(v Var) *Block {
+	b.args = append(b.args, fmt.Sprintf("first: %s", v))
+	return b
+}
+
+//Offset skip the first v nodes
+func (b *Block) Offset(v Var) *Block {
+	b.args = append(b.args, fmt.Sprintf("offset: %s", v))
+	return b
+}
+
+//Normalize flatten the results to the aliased fields
+func (b *Block) Normalize() *Block {
+	b.directives = append(b.directives, "@normalize")
+	return b
+}
+
+//Recurse follow the edges of the block up to depth, loop allows visiting a node again
+func (b *Block) Recurse(depth int, loop bool) *Block {
+	b.directives = append(b.directives, fmt.Sprintf("@recurse(depth: %d, loop: %t)", depth, loop))
+	return b
+}
+
+//Fields of the nodes, uid included
+func (b *Block) Fields(predicates ...string) *Block {
+	for _, p := range predicates {
+		b.fields = append(b.fields, mustName(p))
+	}
+	return b
+}
+
+//Alias a field of the nodes, which @normalize keeps
+func (b *Block) Alias(alias, predicate string) *Block {
+	b.fields = append(b.fields, fmt.Sprintf("%s: %s", mustName(alias), mustName(predicate)))
+	return b
+}
+
+//ValueAs keep the values of predicate by the value variable name
+func (b *Block) ValueAs(name, predicate string) *Block {
+	b.fields = append(b.fields, fmt.Sprintf("%s as %s", mustName(name), mustName(predicate)))
+	return b
+}
+
+//Count the edges of predicate, uid counts the nodes of the block
+func (b *Block) Count(alias, predicate string) *Block {
+	b.fields = append(b.fields, fmt.Sprintf("%s: count(%s)", mustName(alias), mustName(predicate)))
+	return b
+}
+
+//Max of the value variable name
+func (b *Block) Max(alias, name string) *Block {
+	b.fields = append(b.fields, fmt.Sprintf("%s: max(val(%s))", mustName(alias), mustName(name)))
+	return b
+}
+
+//ExpandAll fetch all predicates of the dgraph types of the nodes
+func (b *Block) ExpandAll() *Block {
+	b.fields = append(b.fields, "expand(_all_)")
+	return b
+}
+
+//Edge add the nested block of the edge predicate, return the nested block
+func (b *Block) Edge(predicate string) *Block {
+	e := &Block{head: mustName(predicate)}
+	b.edges = append(b.edges, e)
+	return e
+}
+
+//EdgeAs add the nested block of the edge predicate named alias in results, return the nested block
+func (b *Block) EdgeAs(alias, predicate string) *Block {
+	e := &Block{head: fmt.Sprintf("%s: %s", mustName(alias), mustName(predicate))}
+	b.edges = append(b.edges, e)
+	return e
+}
+
+func (b *Block) write(sb *strings.Builder, depth int) {
+	indent := strings.Repeat("\t", depth)
+	sb.WriteString(indent)
+	if b.as != "" {
+		sb.WriteString(b.as + " as ")
+	}
+	sb.WriteString(b.head)
+
+	args := b.args
+	if b.root.expr != "" {
+		args = append([]string{"func: " + b.root.expr}, args...)
+	}
+	if len(args) > 0 || b.top {
+		sb.WriteString("(" + strings.Join(args, ", ") + ")")
+	}
+	if len(b.filters) > 0 {
+		exprs := make([]string, 0, len(b.filters))
+		for _, f := range b.filters {
+			exprs = append(exprs, f.expr)
+		}
+		sb.WriteString(" @filter(" + strings.Join(exprs, " AND ") + ")")
+	}
+	for _, d := range b.directives {
+		sb.WriteString(" " + d)
+	}
+	sb.WriteString(" {\n")
+	for _, f := range b.fields {
+		sb.WriteString(indent + "\t" + f + "\n")
+	}
+	for _, e := range b.edges {
+		e.write(sb, depth+1)
+	}
+	sb.WriteString(indent + "}\n")
+}
+
+//Run the query in a read only transaction, or the transaction of ctx
+func (d *DormDB) Run(ctx context.Context, q *DQL) (*api.Response, error) {
+	query, values := q.Build()
+	return d.readTxn(ctx).QueryWithVars(ctx, query, values)
+}
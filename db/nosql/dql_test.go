@@ -0,0 +1,121 @@
+package nosql
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestDQL(t *testing.T) {
+	q := NewDQL("links")
+	find := q.Block("find", Eq("id", q.Int(7))).Filter(OfType("User"), Or(Eq("name", q.Str("a")), Not(Has("Stated")))).First(q.Int(1))
+	find.Fields("uid").EdgeAs("linked", "role").OrderAsc("id").Offset(q.Int(2)).Normalize().Alias("id", "id")
+	q.VarBlock(UID("x")).Recurse(3, false).ValueAs("ids", "id")
+
+	query, values := q.Build()
+	want := `query links($v1: int, $v2: string, $v3: int, $v4: int) {
+	find(func: eq(id, $v1), first: $v3) @filter(type(User) AND (eq(name, $v2) OR NOT has(Stated))) {
+		uid
+		linked: role(orderasc: id, offset: $v4) @normalize {
+			id: id
+		}
+	}
+	var(func: uid(x)) @recurse(depth: 3, loop: false) {
+		ids as id
+	}
+}`
+	if query != want {
+		t.Fatalf("query:\n%s\nwant:\n%s", query, want)
+	}
+	expected := map[string]string{"$v1": "7", "$v2": "a", "$v3": "1", "$v4": "2"}
+	if len(values) != len(expected) {
+		t.Fatalf("values %v, want %v", values, expected)
+	}
+	for k, v := range expected {
+		if values[k] != v {
+			t.Fatalf("values %v, want %v", values, expected)
+		}
+	}
+
+	//every variable used is declared
+	for _, used := range regexp.MustCompile(`\$v\d+`).FindAllString(query, -1) {
+		if _, ok := values[used]; !ok {
+			t.Fatalf("%s is not declared", used)
+		}
+	}
+}
+
+func TestDQLWithoutVariables(t *testing.T) {
+	q := NewDQL("next")
+	q.VarBlock(OfType("Role")).ValueAs("ids", "id")
+	q.Block("next", Func{}).Max("max", "ids")
+	want := `{
+	var(func: type(Role)) {
+		ids as id
+	}
+	next() {
+		max: max(val(ids))
+	}
+}`
+	if query, _ := q.Build(); query != want {
+		t.Fatalf("query:\n%s\nwant:\n%s", query, want)
+	}
+}
+
+func TestDQLRejectsInvalidNames(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("an injected predicate should panic")
+		}
+	}()
+	q := NewDQL("find")
+	q.Block("find", Eq("id) { uid } bad(func: has(id)", q.Int(1)))
+}
+
+func TestHasPrefixBindsPrefix(t *testing.T) {
+	q := NewDQL("list")
+	q.Block("list", OfType("User")).Filter(HasPrefix(q, "name", "a.b/c"))
+	want := `query list($v1: string, $v2: string) {
+	list(func: type(User)) @filter((ge(name, $v1) AND lt(name, $v2))) {
+	}
+}`
+	query, values := q.Build()
+	if query != want {
+		t.Fatalf("query:\n%s\nwant:\n%s", query, want)
+	}
+	if values["$v1"] != "a.b/c" || values["$v2"] != "a.b/d" {
+		t.Fatalf("values %v", values)
+	}
+}
+
+func TestDQLReverseEdge(t *testing.T) {
+	q := NewDQL("remove")
+	q.VarBlock(UIDOf(q.Str("0x1"))).As("node").Edge("~role").As("from").Fields("uid")
+	want := `query remove($v1: string) {
+	node as var(func: uid($v1)) {
+		from as ~role {
+			uid
+		}
+	}
+}`
+	if query, _ := q.Build(); query != want {
+		t.Fatalf("query:\n%s\nwant:\n%s", query, want)
+	}
+}
+
+func TestSuccessor(t *testing.T) {
+	tests := []struct {
+		prefix, want string
+		ok           bool
+	}{
+		{"ab", "ac", true},
+		{"a\U0010FFFF", "b", true},
+		{"\uD7FF", "\uE000", true},
+		{"\U0010FFFF", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		if got, ok := successor(tt.prefix); got != tt.want || ok != tt.ok {
+			t.Errorf("successor of %q: %q %v, want %q %v", tt.prefix, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,281 @@
+package nosql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/micro-community/auth/errs"
+)
+
+//Predicate of a dgraph schema, Type is the scalar or uid type without the list brackets
+type Predicate struct {
+	Name    string
+	Type    string
+	List    bool
+	Index   []string //tokenizers of the index
+	Upsert  bool
+	Reverse bool
+}
+
+func (p Predicate) String() string {
+	typ := p.Type
+	if p.List {
+		typ = "[" + typ + "]"
+	}
+	s := fmt.Sprintf("<%s>: %s", p.Name, typ)
+	if len(p.Index) > 0 {
+		s += fmt.Sprintf(" @index(%s)", strings.Join(p.Index, ", "))
+	}
+	if p.Upsert {
+		s += " @upsert"
+	}
+	if p.Reverse {
+		s += " @reverse"
+	}
+	return s + " ."
+}
+
+//Type of a dgraph schema with the predicates of its nodes
+type Type struct {
+	Name   string
+	Fields []string
+}
+
+func (t Type) String() string {
+	fields := make([]string, 0, len(t.Fields))
+	for _, f := range t.Fields {
+		fields = append(fields, "\t"+f)
+	}
+	return fmt.Sprintf("type %s {\n%s\n}", t.Name, strings.Join(fields, "\n"))
+}
+
+//Schema of dgraph, declared predicates and types
+type Schema struct {
+	Predicates []Predicate
+	Types      []Type
+}
+
+//String of the schema to alter dgraph
+func (s Schema) String() string {
+	lines := make([]string, 0, len(s.Predicates)+len(s.Types))
+	for _, p := range s.Predicates {
+		lines = append(lines, p.String())
+	}
+	for _, t := range s.Types {
+		lines = append(lines, t.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+//Merge return the schema declared by s then next, a declaration of next replaces the one of s of the same name
+func (s Schema) Merge(next Schema) Schema {
+	merged := Schema{
+		Predicates: append([]Predicate{}, s.Predicates...),
+		Types:      append([]Type{}, s.Types...),
+	}
+	for _, p := range next.Predicates {
+		i := 0
+		for i < len(merged.Predicates) && merged.Predicates[i].Name != p.Name {
+			i++
+		}
+		if i == len(merged.Predicates) {
+			merged.Predicates = append(merged.Predicates, p)
+		} else {
+			merged.Predicates[i] = p
+		}
+	}
+	for _, t := range next.Types {
+		i := 0
+		for i < len(merged.Types) && merged.Types[i].Name != t.Name {
+			i++
+		}
+		if i == len(merged.Types) {
+			merged.Types = append(merged.Types, t)
+		} else {
+			merged.Types[i] = t
+		}
+	}
+	return merged
+}
+
+//schemaPredicate is a line of a predicate like "name: string @index(exact) @upsert ."
+var (
+	schemaPredicate = regexp.MustCompile(`^<?([^<>:\s]+)>?\s*:\s*(\[)?(\w+)\]?((?:\s*@\w+(?:\([^)]*\))?)*)\s*\.$`)
+	schemaDirective = regexp.MustCompile(`@(\w+)(?:\(([^)]*)\))?`)
+)
+
+//ParseSchema parse the schema of an alter, predicates of a line each and types of a line each of their fields
+func ParseSchema(s string) (Schema, error) {
+	var schema Schema
+	lines := strings.Split(s, "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "type ") && strings.HasSuffix(line, "{"):
+			t := Type{Name: strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(line, "type "), "{"))}
+			for i++; i < len(lines) && strings.TrimSpace(lines[i]) != "}"; i++ {
+				if field := strings.TrimSpace(lines[i]); field != "" {
+					t.Fields = append(t.Fields, field)
+				}
+			}
+			if i == len(lines) {
+				return Schema{}, errs.NewInvalidArgument("type %s is not closed", t.Name)
+			}
+			schema.Types = append(schema.Types, t)
+		default:
+			m := schemaPredicate.FindStringSubmatch(line)
+			if m == nil {
+				return Schema{}, errs.NewInvalidArgument("invalid schema line %q", line)
+			}
+			p := Predicate{Name: m[1], Type: m[3], List: m[2] != ""}
+			for _, d := range schemaDirective.FindAllStringSubmatch(m[4], -1) {
+				switch d[1] {
+				case "index":
+					for _, tokenizer := range strings.Split(d[2], ",") {
+						p.Index = append(p.Index, strings.TrimSpace(tokenizer))
+					}
+				case "upsert":
+					p.Upsert = true
+				case "reverse":
+					p.Reverse = true
+				default:
+					return Schema{}, errs.NewInvalidArgument("unknown directive @%s of %s", d[1], p.Name)
+				}
+			}
+			schema.Predicates = append(schema.Predicates, p)
+		}
+	}
+	return schema, nil
+}
+
+//Drift of a predicate or type between the declared and the live schema,
+//an incompatible drift changes the type of a predicate, which alter refuses with data
+type Drift struct {
+	Name       string
+	Want       string
+	Live       string
+	Compatible bool
+}
+
+func (d Drift) String() string {
+	if d.Live == "" {
+		return fmt.Sprintf("%s is missing, want %q", d.Name, d.Want)
+	}
+	return fmt.Sprintf("%s is %q, want %q", d.Name, d.Live, d.Want)
+}
+
+//Diff the declared schema want against live, predicates and types not declared are ignored
+func Diff(want, live Schema) []Drift {
+	var drifts []Drift
+
+	livePredicates := map[string]Predicate{}
+	for _, p := range live.Predicates {
+		livePredicates[p.Name] = p
+	}
+	for _, p := range want.Predicates {
+		l, ok := livePredicates[p.Name]
+		switch {
+		case !ok:
+			drifts = append(drifts, Drift{Name: "predicate " + p.Name, Want: p.String(), Compatible: true})
+		case l.Type != p.Type || l.List != p.List:
+			drifts = append(drifts, Drift{Name: "predicate " + p.Name, Want: p.String(), Live: l.String()})
+		case !sameSet(l.Index, p.Index) || l.Upsert != p.Upsert || l.Reverse != p.Reverse:
+			drifts = append(drifts, Drift{Name: "predicate " + p.Name, Want: p.String(), Live: l.String(), Compatible: true})
+		}
+	}
+
+	liveTypes := map[string]Type{}
+	for _, t := range live.Types {
+		liveTypes[t.Name] = t
+	}
+	for _, t := range want.Types {
+		l, ok := liveTypes[t.Name]
+		switch {
+		case !ok:
+			drifts = append(drifts, Drift{Name: "type " + t.Name, Want: t.String(), Compatible: true})
+		case !sameSet(l.Fields, t.Fields):
+			drifts = append(drifts, Drift{Name: "type " + t.Name, Want: t.String(), Live: l.String(), Compatible: true})
+		}
+	}
+	return drifts
+}
+
+func sameSet(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = append([]string{}, a...)
+	b = append([]string{}, b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+//Schema query the live schema
+func (d *DormDB) Schema(ctx context.Context) (Schema, error) {
+	resp, err := d.QueryReadOnly(ctx, `schema {}`)
+	if err != nil {
+		return Schema{}, errs.NewUnavailable(err, "query dgraph schema error")
+	}
+
+	var r struct {
+		Schema []struct {
+			Predicate string   `json:"predicate"`
+			Type      string   `json:"type"`
+			List      bool     `json:"list"`
+			Tokenizer []string `json:"tokenizer"`
+			Upsert    bool     `json:"upsert"`
+			Reverse   bool     `json:"reverse"`
+		} `json:"schema"`
+		Types []struct {
+			Name   string `json:"name"`
+			Fields []struct {
+				Name string `json:"name"`
+			} `json:"fields"`
+		} `json:"types"`
+	}
+	if err = json.Unmarshal(resp.Json, &r); err != nil {
+		return Schema{}, errs.Wrap(err, errs.Unknown, "json unmarshal dgraph schema error")
+	}
+
+	var live Schema
+	for _, p := range r.Schema {
+		live.Predicates = append(live.Predicates, Predicate{
+			Name: p.Predicate, Type: p.Type, List: p.List, Index: p.Tokenizer, Upsert: p.Upsert, Reverse: p.Reverse,
+		})
+	}
+	for _, t := range r.Types {
+		typ := Type{Name: t.Name}
+		for _, f := range t.Fields {
+			typ.Fields = append(typ.Fields, f.Name)
+		}
+		live.Types = append(live.Types, typ)
+	}
+	return live, nil
+}
+
+//Drifts of the live schema from the declared one want, an incompatible drift changes the type of a predicate
+//and returns an errs.Conflict error
+func (d *DormDB) Drifts(ctx context.Context, want Schema) ([]Drift, error) {
+	live, err := d.Schema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	drifts := Diff(want, live)
+	for _, drift := range drifts {
+		if !drift.Compatible {
+			return drifts, errs.NewConflict("dgraph schema drifts incompatibly: %s", drift)
+		}
+	}
+	return drifts, nil
+}
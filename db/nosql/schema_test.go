@@ -0,0 +1,102 @@
+package nosql
+
+import (
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	live := Schema{
+		Predicates: []Predicate{
+			{Name: "id", Type: "int", Index: []string{"int"}, Upsert: true},
+			{Name: "name", Type: "string"},
+			{Name: "Type", Type: "string"},
+			{Name: "dgraph.type", Type: "string", List: true, Index: []string{"exact"}},
+		},
+		Types: []Type{{Name: "User", Fields: []string{"name", "id"}}},
+	}
+	want := Schema{
+		Predicates: []Predicate{
+			{Name: "id", Type: "int", Index: []string{"int"}, Upsert: true},
+			{Name: "name", Type: "string", Index: []string{"exact"}},
+			{Name: "Type", Type: "int"},
+			{Name: "role", Type: "uid", List: true, Reverse: true},
+		},
+		Types: []Type{{Name: "User", Fields: []string{"id", "name"}}, {Name: "Role", Fields: []string{"id"}}},
+	}
+
+	drifts := Diff(want, live)
+	got := map[string]bool{}
+	for _, d := range drifts {
+		got[d.Name] = d.Compatible
+	}
+	expected := map[string]bool{
+		"predicate name": true,
+		"predicate Type": false,
+		"predicate role": true,
+		"type Role":      true,
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("drifts %v, want %v", drifts, expected)
+	}
+	for name, compatible := range expected {
+		if c, ok := got[name]; !ok || c != compatible {
+			t.Fatalf("drifts %v, want %v", drifts, expected)
+		}
+	}
+
+	if drifts = Diff(want, want); len(drifts) != 0 {
+		t.Fatalf("no drift of the same schema, got %v", drifts)
+	}
+}
+
+func TestParseSchema(t *testing.T) {
+	s := `
+name: string @index(exact, trigram) @upsert .
+<role>: [uid] @reverse .
+type User {
+	name
+	role
+}
+`
+	schema, err := ParseSchema(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Schema{
+		Predicates: []Predicate{
+			{Name: "name", Type: "string", Index: []string{"exact", "trigram"}, Upsert: true},
+			{Name: "role", Type: "uid", List: true, Reverse: true},
+		},
+		Types: []Type{{Name: "User", Fields: []string{"name", "role"}}},
+	}
+	if schema.String() != want.String() {
+		t.Fatalf("schema:\n%s\nwant:\n%s", schema, want)
+	}
+
+	for _, invalid := range []string{"name string .", "name: string @lang .", "type User {\n\tname"} {
+		if _, err = ParseSchema(invalid); err == nil {
+			t.Errorf("%q should not parse", invalid)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	first := Schema{
+		Predicates: []Predicate{{Name: "name", Type: "string", Index: []string{"exact", "trigram"}}, {Name: "id", Type: "int"}},
+		Types:      []Type{{Name: "User", Fields: []string{"id"}}},
+	}
+	next := Schema{
+		Predicates: []Predicate{{Name: "name", Type: "string", Index: []string{"exact"}}, {Name: "version", Type: "int"}},
+		Types:      []Type{{Name: "User", Fields: []string{"id", "name"}}},
+	}
+	want := Schema{
+		Predicates: []Predicate{{Name: "name", Type: "string", Index: []string{"exact"}}, {Name: "id", Type: "int"}, {Name: "version", Type: "int"}},
+		Types:      []Type{{Name: "User", Fields: []string{"id", "name"}}},
+	}
+	if merged := first.Merge(next); merged.String() != want.String() {
+		t.Fatalf("merged:\n%s\nwant:\n%s", merged, want)
+	}
+	if len(first.Predicates) != 2 || len(first.Types[0].Fields) != 1 {
+		t.Fatalf("merge should not modify the schema, got %s", first)
+	}
+}
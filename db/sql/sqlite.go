@@ -1,7 +1,9 @@
 package sql
 
 import (
-	_ "gorm.io/driver/mysql"
+	"os"
+	"path/filepath"
+
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
@@ -11,8 +13,8 @@ type SQLiteOptions struct {
 	Password  string
 	Host      string
 	Port      int
-	DBName    string
-	Path      string
+	DBName    string // file name of the database, ":memory:" for a memory database
+	Path      string // directory of the database file
 	LogDetail bool
 }
 
@@ -20,24 +22,32 @@ func (cfg *SQLiteOptions) WithSQLiteDefault() *SQLiteOptions {
 	if cfg == nil {
 		return nil
 	}
-	if cfg.Port == 0 {
-		cfg.Port = 3306
+
+	c := *cfg
+
+	if cfg.DBName == "" {
+		c.DBName = "auth.db"
 	}
-	if cfg.Host == "" {
-		cfg.Host = "127.0.0.1"
+	return &c
+}
+
+//DSN of the database file, writers wait for the lock instead of failing at once,
+//transactions take the write lock at begin so that a reader never fails to upgrade
+func (c SQLiteOptions) DSN() string {
+	if c.DBName == ":memory:" {
+		return "file::memory:?cache=shared&_busy_timeout=5000&_txlock=immediate"
 	}
-	return cfg
+	return filepath.Join(c.Path, c.DBName) + "?_busy_timeout=5000&_txlock=immediate&_journal_mode=WAL"
 }
 
-func NewSQLite(cfg *SQLiteOptions) *gorm.DB {
+func NewSQLite(cfg *SQLiteOptions) (*gorm.DB, error) {
 
 	c := cfg.WithSQLiteDefault()
-	// 返回一个连接池
-	db, err := gorm.Open(sqlite.Open(c.DBName), &gorm.Config{})
-
-	if err != nil {
-		panic("failed to connect database")
+	if c.Path != "" {
+		if err := os.MkdirAll(c.Path, 0755); err != nil {
+			return nil, err
+		}
 	}
-
-	return db
+	// 返回一个连接池
+	return gorm.Open(sqlite.Open(c.DSN()), &gorm.Config{})
 }
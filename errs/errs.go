@@ -0,0 +1,141 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+)
+
+//Code of domain errors
+type Code int
+
+const (
+	Unknown Code = iota
+	NotFound
+	AlreadyExists
+	InvalidArgument
+	InvalidCredentials
+	PermissionDenied
+	Conflict
+	Unavailable
+	Unimplemented
+)
+
+//reasons are stable strings of codes for clients to branch on, do not change them
+var reasons = map[Code]string{
+	Unknown:            "UNKNOWN",
+	NotFound:           "NOT_FOUND",
+	AlreadyExists:      "ALREADY_EXISTS",
+	InvalidArgument:    "INVALID_ARGUMENT",
+	InvalidCredentials: "INVALID_CREDENTIALS",
+	PermissionDenied:   "PERMISSION_DENIED",
+	Conflict:           "CONFLICT",
+	Unavailable:        "UNAVAILABLE",
+	Unimplemented:      "UNIMPLEMENTED",
+}
+
+//Reason return the stable string of code
+func (c Code) Reason() string {
+	if reason, ok := reasons[c]; ok {
+		return reason
+	}
+	return reasons[Unknown]
+}
+
+func (c Code) String() string {
+	return c.Reason()
+}
+
+//Error of domain, returned by service and repository
+type Error struct {
+	Code    Code
+	Message string
+	Err     error // cause, e.g. the error of a database driver
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+//Is match errors of the same code, so errors.Is(err, errs.ErrNotFound) works for any message
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code && t.Message == ""
+}
+
+//sentinels to match codes by errors.Is
+var (
+	ErrNotFound           = &Error{Code: NotFound}
+	ErrAlreadyExists      = &Error{Code: AlreadyExists}
+	ErrInvalidArgument    = &Error{Code: InvalidArgument}
+	ErrInvalidCredentials = &Error{Code: InvalidCredentials}
+	ErrPermissionDenied   = &Error{Code: PermissionDenied}
+	ErrConflict           = &Error{Code: Conflict}
+	ErrUnavailable        = &Error{Code: Unavailable}
+	ErrUnimplemented      = &Error{Code: Unimplemented}
+)
+
+//New return an error of code
+func New(code Code, format string, a ...interface{}) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, a...)}
+}
+
+//Wrap err as the cause of an error of code
+func Wrap(err error, code Code, format string, a ...interface{}) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, a...), Err: err}
+}
+
+//CodeOf err, Unknown for errors out of domain
+func CodeOf(err error) Code {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	return Unknown
+}
+
+//NewNotFound return a NotFound error
+func NewNotFound(format string, a ...interface{}) *Error {
+	return New(NotFound, format, a...)
+}
+
+//NewAlreadyExists return an AlreadyExists error
+func NewAlreadyExists(format string, a ...interface{}) *Error {
+	return New(AlreadyExists, format, a...)
+}
+
+//NewInvalidArgument return an InvalidArgument error
+func NewInvalidArgument(format string, a ...interface{}) *Error {
+	return New(InvalidArgument, format, a...)
+}
+
+//NewInvalidCredentials return an InvalidCredentials error
+func NewInvalidCredentials(format string, a ...interface{}) *Error {
+	return New(InvalidCredentials, format, a...)
+}
+
+//NewPermissionDenied return a PermissionDenied error
+func NewPermissionDenied(format string, a ...interface{}) *Error {
+	return New(PermissionDenied, format, a...)
+}
+
+//NewConflict return a Conflict error
+func NewConflict(format string, a ...interface{}) *Error {
+	return New(Conflict, format, a...)
+}
+
+//NewUnavailable wrap err of a backend as an Unavailable error
+func NewUnavailable(err error, format string, a ...interface{}) *Error {
+	return Wrap(err, Unavailable, format, a...)
+}
+
+//NewUnimplemented return an Unimplemented error
+func NewUnimplemented(format string, a ...interface{}) *Error {
+	return New(Unimplemented, format, a...)
+}
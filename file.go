@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/micro-community/auth/config"
+	"github.com/micro-community/auth/db/nosql"
+	"github.com/urfave/cli/v2"
+)
+
+//fileCommand snapshot or compact the embedded database file, the service holds a lock of the file,
+//so it must be stopped first, a running service compacts itself every CompactInterval:
+//	auth file --path auth.bolt snapshot --out backup.bolt|compact
+func fileCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "file",
+		Usage: "manage the embedded database file",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "path", Usage: "the database file", Value: config.Default.File.Path},
+			&cli.DurationFlag{Name: "timeout", Usage: "time to wait for the lock of the file", Value: 5 * time.Second},
+		},
+		Subcommands: []*cli.Command{
+			{
+				Name:  "snapshot",
+				Usage: "write a consistent copy of the database",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "out", Usage: "file of the copy", Required: true},
+				},
+				Action: func(c *cli.Context) error {
+					return withFile(c, func(b *nosql.BoltDB) error {
+						out, err := os.OpenFile(c.String("out"), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+						if err != nil {
+							return err
+						}
+						n, err := b.Snapshot(out)
+						if closeErr := out.Close(); err == nil {
+							err = closeErr
+						}
+						if err != nil {
+							os.Remove(c.String("out"))
+							return err
+						}
+						fmt.Printf("wrote %d bytes to %s\n", n, c.String("out"))
+						return nil
+					})
+				},
+			},
+			{
+				Name:  "compact",
+				Usage: "rewrite the database without free pages",
+				Action: func(c *cli.Context) error {
+					return withFile(c, func(b *nosql.BoltDB) error {
+						before, after, err := b.Compact()
+						if err != nil {
+							return err
+						}
+						fmt.Printf("compacted %d to %d bytes\n", before, after)
+						return nil
+					})
+				},
+			},
+		},
+	}
+}
+
+func withFile(c *cli.Context, fn func(b *nosql.BoltDB) error) error {
+	if _, err := os.Stat(c.String("path")); err != nil {
+		return err
+	}
+	b, err := nosql.OpenBolt(&nosql.BoltOptions{Path: c.String("path"), Timeout: c.Duration("timeout")})
+	if err != nil {
+		return fmt.Errorf("open %s: %v", c.String("path"), err)
+	}
+	defer b.Close()
+	return fn(b)
+}
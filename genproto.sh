@@ -16,22 +16,38 @@ trap 'trap_handler ${LINENO} ${$?}' ERR
 echo "Checking tools dependencies..."
 which protoc
 which protoc-gen-go
+which protoc-gen-micro
 which protoc-gen-validate
 
 
-GO_IMPORT_SPACES=Mgoogle/protobuf/any.proto=github.com/golang/protobuf/ptypes/any,\
-	Mgoogle/protobuf/duration.proto=github.com/golang/protobuf/ptypes/duration,\
-	Mgoogle/protobuf/struct.proto=github.com/golang/protobuf/ptypes/struct,\
-	Mgoogle/protobuf/timestamp.proto=github.com/golang/protobuf/ptypes/timestamp,\
-	Mgoogle/protobuf/wrappers.proto=github.com/golang/protobuf/ptypes/wrappers,\
-	Mgoogle/protobuf/descriptor.proto=github.com/golang/protobuf/protoc-gen-go/descriptor
-
-
-#MOD=Mgoogle/protobuf/any.proto=github.com/golang/protobuf/ptypes/any
-#Mgoogle/protobuf/descriptor.proto=github.com/golang/protobuf/protoc-gen-go/descriptor
-protoc -Iprotos --go_out=${GO_IMPORT_SPACES},plugins=grpc:protos user.proto
-
-protoc -I "${GOROOT}/include" -I protos/rbac 	-I "${GOPATH}/src" --go_out="${GO_IMPORT}:protos/rbac"--micro_out="${GO_IMPORT}:protos/rbac" --validate_out="lang=go:protos/rbac" rbac.proto
+GO_IMPORT_SPACES="Mgoogle/protobuf/any.proto=github.com/golang/protobuf/ptypes/any,
+	Mgoogle/protobuf/duration.proto=github.com/golang/protobuf/ptypes/duration,
+	Mgoogle/protobuf/struct.proto=github.com/golang/protobuf/ptypes/struct,
+	Mgoogle/protobuf/timestamp.proto=github.com/golang/protobuf/ptypes/timestamp,
+	Mgoogle/protobuf/wrappers.proto=github.com/golang/protobuf/ptypes/wrappers,
+	Mgoogle/protobuf/descriptor.proto=github.com/golang/protobuf/protoc-gen-go/descriptor"
+GO_IMPORT=$(echo "${GO_IMPORT_SPACES}" | tr -d '[:space:]')
+
+
+# validate.proto is imported by its module path, include the version of go.mod from the module cache,
+# protoc-gen-validate must be of the same version to generate ValidateAll:
+# go install github.com/envoyproxy/protoc-gen-validate@$(go list -m -f '{{.Version}}' github.com/envoyproxy/protoc-gen-validate)
+PGV_DIR=$(go list -m -f '{{.Dir}}' github.com/envoyproxy/protoc-gen-validate)
+INCLUDE=$(mktemp -d)
+trap 'rm -rf "${INCLUDE}"' EXIT
+mkdir -p "${INCLUDE}/github.com/envoyproxy"
+ln -s "${PGV_DIR}" "${INCLUDE}/github.com/envoyproxy/protoc-gen-validate"
+
+
+# every directory of protos, protos of a directory share their go package
+for dir in $(find protos -name '*.proto' -exec dirname {} \; | sort -u); do
+  echo "Generating ${dir}..."
+  (cd "${dir}" && protoc -I . -I "${INCLUDE}" \
+    --go_out="${GO_IMPORT}:." \
+    --micro_out="${GO_IMPORT}:." \
+    --validate_out="lang=go:." \
+    *.proto)
+done
 
 # 增加valid 校验
 # protoc-go-inject-tag -input=protos/user.pb.go
@@ -8,22 +8,26 @@ replace google.golang.org/grpc => google.golang.org/grpc v1.26.0
 
 require (
 	github.com/dgraph-io/dgo/v200 v200.0.0-20200916081436-9ff368ad829a
-	github.com/envoyproxy/protoc-gen-validate v0.4.1
+	github.com/envoyproxy/protoc-gen-validate v1.0.2
 	github.com/go-redis/redis/v8 v8.3.1
-	github.com/golang/protobuf v1.4.3
+	github.com/go-sql-driver/mysql v1.5.0
+	github.com/golang/protobuf v1.5.2
 	github.com/gomodule/redigo/redis v0.0.0-20200429221454-e14091dffc1b
 	github.com/hashicorp/go-version v1.2.1
+	github.com/mattn/go-sqlite3 v1.14.3
 	github.com/micro/micro/v3 v3.0.0-beta.6.0.20201014170732-9bd296d435bc
 	github.com/olivere/elastic/v7 v7.0.20
 	github.com/sirupsen/logrus v1.7.0
 	github.com/urfave/cli/v2 v2.2.0
+	go.etcd.io/bbolt v1.3.5
 	go.mongodb.org/mongo-driver v1.4.2
 	go.uber.org/dig v1.10.0
 	go.uber.org/zap v1.16.0
-	golang.org/x/crypto v0.0.0-20201012173705-84dcc777aaee
-	golang.org/x/sync v0.0.0-20201008141435-b3e1573b7520
+	golang.org/x/crypto v0.10.0
+	golang.org/x/sync v0.3.0
+	golang.org/x/text v0.13.0 // indirect
 	google.golang.org/grpc v1.32.0
-	google.golang.org/protobuf v1.25.0
+	google.golang.org/protobuf v1.30.0
 	gopkg.in/sohlich/elogrus.v7 v7.0.0
 	gorm.io/driver/mysql v1.0.2
 	gorm.io/driver/sqlite v1.1.3
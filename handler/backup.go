@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/micro-community/auth/errs"
+	rbac "github.com/micro-community/auth/protos/rbac"
+	"github.com/micro-community/auth/repository/backup"
+	"github.com/micro/micro/v3/service/logger"
+)
+
+//chunkSize of the data of a BackupChunk
+const chunkSize = 64 << 10
+
+//chunkWriter send what is written as chunks of a stream
+type chunkWriter struct {
+	stream rbac.Rbac_BackupStream
+}
+
+func (w chunkWriter) Write(p []byte) (int, error) {
+	data := append([]byte(nil), p...)
+	if err := w.stream.Send(&rbac.BackupChunk{Data: data}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+//chunkReader read the data of the chunks received from a stream until the client closes it
+type chunkReader struct {
+	recv func() (*rbac.BackupChunk, error)
+	data []byte
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.data) == 0 {
+		chunk, err := r.recv()
+		if err != nil {
+			return 0, err
+		}
+		r.data = chunk.Data
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+// Backup stream a backup of the database in chunks, it is a consistent snapshot on backends with transactions.
+// The backup is spooled to a temporary file first, so the snapshot is not held while a slow client receives it
+func (r *RbacHandler) Backup(ctx context.Context, req *rbac.BackupRequest, stream rbac.Rbac_BackupStream) error {
+	logger.Infof("Received RbacHandler.Backup request")
+
+	spool, err := ioutil.TempFile("", "auth-backup-*")
+	if err != nil {
+		return errs.Wrap(err, errs.Unknown, "create backup spool error")
+	}
+	defer os.Remove(spool.Name())
+	defer spool.Close()
+
+	w := bufio.NewWriterSize(spool, chunkSize)
+	if _, err = r.BackupSrv.Backup(ctx, w); err != nil {
+		return err
+	}
+	if err = w.Flush(); err != nil {
+		return errs.Wrap(err, errs.Unknown, "write backup spool error")
+	}
+	if _, err = spool.Seek(0, io.SeekStart); err != nil {
+		return errs.Wrap(err, errs.Unknown, "read backup spool error")
+	}
+	//hide the WriterTo of the file, so chunks are as large as the buffer
+	_, err = io.CopyBuffer(chunkWriter{stream: stream}, struct{ io.Reader }{spool}, make([]byte, chunkSize))
+	return err
+}
+
+// Restore a backup received in chunks to the empty database
+func (r *RbacHandler) Restore(ctx context.Context, stream rbac.Rbac_RestoreStream) error {
+	logger.Infof("Received RbacHandler.Restore request")
+
+	counts, err := r.BackupSrv.Restore(ctx, &chunkReader{recv: stream.Recv})
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(&rbac.RestoreResult{Counts: backupCounts(counts)})
+}
+
+// VerifyBackup restore a backup received in chunks to memory and return the differences from the backup
+func (r *RbacHandler) VerifyBackup(ctx context.Context, stream rbac.Rbac_VerifyBackupStream) error {
+	logger.Infof("Received RbacHandler.VerifyBackup request")
+
+	diffs, counts, err := r.BackupSrv.Verify(ctx, &chunkReader{recv: stream.Recv})
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(&rbac.RestoreResult{Counts: backupCounts(counts), Differences: diffs})
+}
+
+func backupCounts(c backup.Counts) *rbac.BackupCounts {
+	return &rbac.BackupCounts{
+		Users:         c.Users,
+		Roles:         c.Roles,
+		Resources:     c.Resources,
+		UserRoles:     c.UserRoles,
+		RoleResources: c.RoleResources,
+		Logs:          c.Logs,
+	}
+}
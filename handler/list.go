@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/micro-community/auth/repository"
+)
+
+//listOptions build the common filters and sort order of list requests
+func listOptions(namePrefix string, tenantID, createdAfter, createdBefore int64, sortBy string, desc bool) (repository.ListOptions, error) {
+	sortField, err := repository.ParseSortField(sortBy)
+	if err != nil {
+		return repository.ListOptions{}, err
+	}
+	opts := repository.ListOptions{
+		NamePrefix: namePrefix,
+		TenantID:   int(tenantID),
+		SortBy:     sortField,
+		Desc:       desc,
+	}
+	if createdAfter > 0 {
+		opts.CreatedAfter = time.Unix(createdAfter, 0)
+	}
+	if createdBefore > 0 {
+		opts.CreatedBefore = time.Unix(createdBefore, 0)
+	}
+	return opts, nil
+}
+
+//withDeleted return ctx showing deleted entities when asked
+func withDeleted(ctx context.Context, deleted bool) context.Context {
+	if deleted {
+		return repository.WithDeleted(ctx)
+	}
+	return ctx
+}
+
+//unixTime of t, 0 for zero time
+func unixTime(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
@@ -0,0 +1,208 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/micro-community/auth/models"
+	pb "github.com/micro-community/auth/protos/org"
+	"github.com/micro-community/auth/service"
+	mService "github.com/micro/micro/v3/service"
+	"github.com/micro/micro/v3/service/logger"
+)
+
+//OrgHandler implements the org proto interface
+type OrgHandler struct {
+	Name string
+	srv  *service.OrgService
+}
+
+// NewOrg returns an org handler
+func NewOrg(service *mService.Service, orgService *service.OrgService) *OrgHandler {
+	return &OrgHandler{
+		Name: "OrgHandler",
+		srv:  orgService,
+	}
+}
+
+// CreateUnit create an organization, or a department or a position under its parent
+func (o *OrgHandler) CreateUnit(ctx context.Context, req *pb.CreateUnitRequest, rsp *pb.UnitInfo) error {
+	logger.Infof("Received OrgHandler.CreateUnit request, Kind: %s, Name: %s, ParentID: %d", req.Kind, req.Name, req.ParentId)
+
+	unit := &models.OrgUnit{
+		Kind:     models.OrgUnitKind(req.Kind),
+		Name:     req.Name,
+		ParentID: int(req.ParentId),
+	}
+	if err := o.srv.Create(ctx, unit); err != nil {
+		return err
+	}
+	toUnitInfo(unit, rsp)
+	return nil
+}
+
+// GetUnit return an org unit by id
+func (o *OrgHandler) GetUnit(ctx context.Context, req *pb.GetUnitRequest, rsp *pb.UnitInfo) error {
+	logger.Infof("Received OrgHandler.GetUnit request, ID: %d", req.Id)
+
+	unit, err := o.srv.Get(ctx, int(req.Id))
+	if err != nil {
+		return err
+	}
+	toUnitInfo(unit, rsp)
+	return nil
+}
+
+// ListUnits return all org units of the tenant
+func (o *OrgHandler) ListUnits(ctx context.Context, req *pb.ListUnitsRequest, rsp *pb.ListUnitsResponse) error {
+	logger.Infof("Received OrgHandler.ListUnits request")
+
+	units, err := o.srv.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, unit := range units {
+		info := &pb.UnitInfo{}
+		toUnitInfo(unit, info)
+		rsp.Units = append(rsp.Units, info)
+	}
+	return nil
+}
+
+// RenameUnit rename an org unit
+func (o *OrgHandler) RenameUnit(ctx context.Context, req *pb.RenameUnitRequest, rsp *pb.UnitInfo) error {
+	logger.Infof("Received OrgHandler.RenameUnit request, ID: %d, Name: %s", req.Id, req.Name)
+
+	unit, err := o.srv.Rename(ctx, int(req.Id), req.Name, req.Version)
+	if err != nil {
+		return err
+	}
+	toUnitInfo(unit, rsp)
+	return nil
+}
+
+// MoveUnit move a department or a position under another parent
+func (o *OrgHandler) MoveUnit(ctx context.Context, req *pb.MoveUnitRequest, rsp *pb.UnitInfo) error {
+	logger.Infof("Received OrgHandler.MoveUnit request, ID: %d, ParentID: %d", req.Id, req.ParentId)
+
+	unit, err := o.srv.Move(ctx, int(req.Id), int(req.ParentId), req.Version)
+	if err != nil {
+		return err
+	}
+	toUnitInfo(unit, rsp)
+	return nil
+}
+
+// MergeUnit merge an org unit into another of its kind
+func (o *OrgHandler) MergeUnit(ctx context.Context, req *pb.MergeUnitRequest, rsp *pb.MergeUnitResponse) error {
+	logger.Infof("Received OrgHandler.MergeUnit request, ID: %d, IntoID: %d", req.Id, req.IntoId)
+
+	merge, err := o.srv.Merge(ctx, int(req.Id), int(req.IntoId), req.Version)
+	if err != nil {
+		return err
+	}
+	rsp.Into = &pb.UnitInfo{}
+	toUnitInfo(merge.Into, rsp.Into)
+	rsp.Units = int64(merge.Units)
+	rsp.Members = int64(merge.Members)
+	rsp.Grants = int64(merge.Grants)
+	return nil
+}
+
+// DeleteUnit delete an org unit without sub units or members
+func (o *OrgHandler) DeleteUnit(ctx context.Context, req *pb.DeleteUnitRequest, rsp *pb.DeleteUnitResponse) error {
+	logger.Infof("Received OrgHandler.DeleteUnit request, ID: %d", req.Id)
+
+	return o.srv.Delete(ctx, int(req.Id), req.Version)
+}
+
+// Assign a user to a department and a position
+func (o *OrgHandler) Assign(ctx context.Context, req *pb.AssignRequest, rsp *pb.MemberInfo) error {
+	logger.Infof("Received OrgHandler.Assign request, UserID: %d, DeptID: %d, PositionID: %d", req.UserId, req.DeptId, req.PositionId)
+
+	member, err := o.srv.Assign(ctx, req.UserId, int(req.DeptId), int(req.PositionId))
+	if err != nil {
+		return err
+	}
+	toMemberInfo(member, rsp)
+	return nil
+}
+
+// Unassign a user from its department and position
+func (o *OrgHandler) Unassign(ctx context.Context, req *pb.UnassignRequest, rsp *pb.UnassignResponse) error {
+	logger.Infof("Received OrgHandler.Unassign request, UserID: %d", req.UserId)
+
+	return o.srv.Unassign(ctx, req.UserId)
+}
+
+// GetMember return the department and position of a user
+func (o *OrgHandler) GetMember(ctx context.Context, req *pb.GetMemberRequest, rsp *pb.MemberInfo) error {
+	logger.Infof("Received OrgHandler.GetMember request, UserID: %d", req.UserId)
+
+	member, err := o.srv.MemberOf(ctx, req.UserId)
+	if err != nil {
+		return err
+	}
+	toMemberInfo(member, rsp)
+	return nil
+}
+
+// ListMembers return the users in a department or of a position
+func (o *OrgHandler) ListMembers(ctx context.Context, req *pb.ListMembersRequest, rsp *pb.ListMembersResponse) error {
+	logger.Infof("Received OrgHandler.ListMembers request, UnitID: %d", req.UnitId)
+
+	members, err := o.srv.Members(ctx, int(req.UnitId))
+	if err != nil {
+		return err
+	}
+	for _, member := range members {
+		info := &pb.MemberInfo{}
+		toMemberInfo(member, info)
+		rsp.Members = append(rsp.Members, info)
+	}
+	return nil
+}
+
+// GrantRole grant a role to the members of an org unit and of the units below it
+func (o *OrgHandler) GrantRole(ctx context.Context, req *pb.GrantRequest, rsp *pb.GrantResponse) error {
+	logger.Infof("Received OrgHandler.GrantRole request, UnitID: %d, RoleID: %d", req.UnitId, req.RoleId)
+
+	return o.srv.GrantRole(ctx, int(req.UnitId), int(req.RoleId))
+}
+
+// RevokeRole revoke a role granted to an org unit
+func (o *OrgHandler) RevokeRole(ctx context.Context, req *pb.GrantRequest, rsp *pb.GrantResponse) error {
+	logger.Infof("Received OrgHandler.RevokeRole request, UnitID: %d, RoleID: %d", req.UnitId, req.RoleId)
+
+	return o.srv.RevokeRole(ctx, int(req.UnitId), int(req.RoleId))
+}
+
+// ListGrants return the roles granted to an org unit
+func (o *OrgHandler) ListGrants(ctx context.Context, req *pb.ListGrantsRequest, rsp *pb.ListGrantsResponse) error {
+	logger.Infof("Received OrgHandler.ListGrants request, UnitID: %d", req.UnitId)
+
+	roles, err := o.srv.Grants(ctx, int(req.UnitId))
+	if err != nil {
+		return err
+	}
+	for _, role := range roles {
+		rsp.Roles = append(rsp.Roles, &pb.GrantedRole{Id: int64(role.ID), Key: role.Key, Name: role.Name})
+	}
+	return nil
+}
+
+func toUnitInfo(unit *models.OrgUnit, info *pb.UnitInfo) {
+	info.Id = int64(unit.ID)
+	info.Kind = pb.UnitKind(unit.Kind)
+	info.Name = unit.Name
+	info.ParentId = int64(unit.ParentID)
+	info.TenantId = int64(unit.TenantID)
+	info.CreatedAt = unixTime(unit.CreatedAt)
+	info.Version = unit.Version
+}
+
+func toMemberInfo(member *models.OrgMember, info *pb.MemberInfo) {
+	info.UserId = member.UserID
+	info.DeptId = int64(member.DeptID)
+	info.PositionId = int64(member.PositionID)
+	info.CreatedAt = unixTime(member.CreatedAt)
+}
@@ -10,8 +10,13 @@ package handler
 
 import (
 	"context"
+	"strconv"
+	"time"
 
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
 	rbac "github.com/micro-community/auth/protos/rbac"
+	"github.com/micro-community/auth/repository"
 	"github.com/micro-community/auth/service"
 	mService "github.com/micro/micro/v3/service"
 	"github.com/micro/micro/v3/service/logger"
@@ -24,40 +29,66 @@ type RbacHandler struct {
 	UserSrv     *service.UserService     // instance of the user service
 	RoleSrv     *service.RoleService     // instance of the role service
 	ResourceSrv *service.ResourceService // instance of the resource service
+	RbacSrv     *service.RbacService     // instance of the links service
+	Feed        *service.ChangeFeed      // changes of users, roles and resources
+	Auditor     *service.Auditor         // logs of mutations
+	BackupSrv   *service.BackupService   // backups of the database
 }
 
 func NewRBAC(service *mService.Service,
 	user *service.UserService,
 	role *service.RoleService,
-	resource *service.ResourceService) *RbacHandler {
+	resource *service.ResourceService,
+	rbacSrv *service.RbacService,
+	feed *service.ChangeFeed,
+	auditor *service.Auditor,
+	backupSrv *service.BackupService) *RbacHandler {
 	return &RbacHandler{
 		Name:        service.Name(),
 		UserSrv:     user,
 		RoleSrv:     role,
 		ResourceSrv: resource,
+		RbacSrv:     rbacSrv,
+		Feed:        feed,
+		Auditor:     auditor,
+		BackupSrv:   backupSrv,
 	}
 }
 
-// AddUser is a single request handler called via client.AddUser or the generated client code
+// AddUser register a user of the name and password, the id of the user is returned as msg
 func (r *RbacHandler) AddUser(ctx context.Context, req *rbac.User, rsp *rbac.Response) error {
 	logger.Infof("Received RbacHandler.AddUser request, ID: %s, Name: %s", req.Id, req.Name)
 
-	//	rsp.Msg = fmt.Sprintf("person created, id: %s,  uid: %s", req.Id, result.Uids[req.Id])
+	user, err := r.UserSrv.Register(ctx, req.Name, req.Password)
+	if err != nil {
+		return err
+	}
+	rsp.Msg = strconv.FormatInt(user.ID, 10)
 	return nil
 }
 
-// RemoveUser is a single request handler called via client.RemoveUser or the generated client code
+// RemoveUser is not implemented, users are deleted at their version by User.DeleteUser
 func (r *RbacHandler) RemoveUser(ctx context.Context, req *rbac.Request, rsp *rbac.Response) error {
 	logger.Infof("Received RbacHandler.RemoveUser request, ID: %s", req.Id)
 
-	rsp.Msg = "OK"
-	return nil
+	return errs.NewUnimplemented("RbacHandler.RemoveUser is not implemented, users are deleted at their version by User.DeleteUser")
 }
 
 // QueryUserRoles is a single request handler called via client.QueryUserRoles or the generated client code
 func (r *RbacHandler) QueryUserRoles(ctx context.Context, req *rbac.Request, rsp *rbac.Roles) error {
 	logger.Infof("Received RbacHandler.QueryUserRoles request, ID: %s", req.Id)
 
+	userID, err := parseID("id", req.Id)
+	if err != nil {
+		return err
+	}
+	roles, err := r.RbacSrv.UserRoles(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, role := range roles {
+		rsp.Roles = append(rsp.Roles, &rbac.Role{Id: strconv.Itoa(role.ID), Name: role.Name, Version: role.Version})
+	}
 	return nil
 }
 
@@ -65,13 +96,30 @@ func (r *RbacHandler) QueryUserRoles(ctx context.Context, req *rbac.Request, rsp
 func (r *RbacHandler) QueryUserResources(ctx context.Context, req *rbac.Request, rsp *rbac.Resources) error {
 	logger.Infof("Received RbacHandler.QueryUserResources request, ID: %s", req.Id)
 
+	userID, err := parseID("id", req.Id)
+	if err != nil {
+		return err
+	}
+	resources, err := r.RbacSrv.UserResources(ctx, userID)
+	if err != nil {
+		return err
+	}
+	rsp.Resources = toRbacResources(resources)
 	return nil
 }
 
 // LinkUserRole is a single request handler called via client.LinkUserRole or the generated client code
 func (r *RbacHandler) LinkUserRole(ctx context.Context, req *rbac.LinkRequest, rsp *rbac.Response) error {
-	logger.Info("Received RbacHandler.LinkUserRole(Add a role for user) request: id1: %s, id2: %s", req.Id1, req.Id2)
+	logger.Infof("Received RbacHandler.LinkUserRole(Add a role for user) request: id1: %s, id2: %s", req.Id1, req.Id2)
 
+	userID, roleID, err := parseLink(req)
+	if err != nil {
+		return err
+	}
+	if err = r.RbacSrv.LinkUserRole(ctx, userID, int(roleID)); err != nil {
+		return err
+	}
+	rsp.Msg = "OK"
 	return nil
 }
 
@@ -79,55 +127,198 @@ func (r *RbacHandler) LinkUserRole(ctx context.Context, req *rbac.LinkRequest, r
 func (r *RbacHandler) UnlinkUserRole(ctx context.Context, req *rbac.LinkRequest, rsp *rbac.Response) error {
 	logger.Infof("Received RbacHandler.UnlinkUserRole(Remove a role from user) request: id1: %s, id2: %s", req.Id1, req.Id2)
 
+	userID, roleID, err := parseLink(req)
+	if err != nil {
+		return err
+	}
+	if err = r.RbacSrv.UnlinkUserRole(ctx, userID, int(roleID)); err != nil {
+		return err
+	}
+	rsp.Msg = "OK"
 	return nil
 }
 
-// AddRole is a single request handler called via client.AddRole or the generated client code
+// AddRole create a role of the name linked to no resource, the id of the role is returned as msg
 func (r *RbacHandler) AddRole(ctx context.Context, req *rbac.Role, rsp *rbac.Response) error {
 	logger.Infof("Received RbacHandler.AddRole request, ID: %s, Name: %s", req.Id, req.Name)
 
+	role := &models.Role{Name: req.Name}
+	if err := r.RoleSrv.Create(ctx, role, nil); err != nil {
+		return err
+	}
+	rsp.Msg = strconv.Itoa(role.ID)
 	return nil
 }
 
-// RemoveRole is a single request handler called via client.RemoveRole or the generated client code
+// RemoveRole is not implemented, roles are deleted at their version by Role.DeleteRole
 func (r *RbacHandler) RemoveRole(ctx context.Context, req *rbac.Request, rsp *rbac.Response) error {
 	logger.Infof("Received RbacHandler.RemoveRole request, ID: %s", req.Id)
 
-	return nil
+	return errs.NewUnimplemented("RbacHandler.RemoveRole is not implemented, roles are deleted at their version by Role.DeleteRole")
 }
 
 // QueryRoleResources is a single request handler called via client.QueryRoleResources or the generated client code
 func (r *RbacHandler) QueryRoleResources(ctx context.Context, req *rbac.Request, rsp *rbac.Resources) error {
 	logger.Infof("Received RbacHandler.QueryRoleResources request, ID: %s", req.Id)
 
+	roleID, err := parseID("id", req.Id)
+	if err != nil {
+		return err
+	}
+	resources, err := r.RbacSrv.RoleResources(ctx, int(roleID))
+	if err != nil {
+		return err
+	}
+	rsp.Resources = toRbacResources(resources)
 	return nil
 }
 
 // LinkRoleResource is a single request handler called via client.LinkRoleResource or the generated client code
 func (r *RbacHandler) LinkRoleResource(ctx context.Context, req *rbac.LinkRequest, rsp *rbac.Response) error {
-	logger.Info("Received RbacHandler.LinkRoleResource request: id1: %s, id2: %s", req.Id1, req.Id2)
+	logger.Infof("Received RbacHandler.LinkRoleResource request: id1: %s, id2: %s", req.Id1, req.Id2)
 
+	roleID, resourceID, err := parseLink(req)
+	if err != nil {
+		return err
+	}
+	if err = r.RbacSrv.LinkRoleResource(ctx, int(roleID), int(resourceID)); err != nil {
+		return err
+	}
+	rsp.Msg = "OK"
 	return nil
 }
 
 // UnlinkRoleResource is a single request handler called via client.UnlinkRoleResource or the generated client code
 func (r *RbacHandler) UnlinkRoleResource(ctx context.Context, req *rbac.LinkRequest, rsp *rbac.Response) error {
-	logger.Info("Received RbacHandler.UnlinkRoleResource request: id1: %s, id2: %s", req.Id1, req.Id2)
+	logger.Infof("Received RbacHandler.UnlinkRoleResource request: id1: %s, id2: %s", req.Id1, req.Id2)
 
+	roleID, resourceID, err := parseLink(req)
+	if err != nil {
+		return err
+	}
+	if err = r.RbacSrv.UnlinkRoleResource(ctx, int(roleID), int(resourceID)); err != nil {
+		return err
+	}
+	rsp.Msg = "OK"
 	return nil
 }
 
-// AddResource is a single request handler called via client.AddResource or the generated client code
+// AddResource is not implemented, resources are created by Resource.Create
 func (r *RbacHandler) AddResource(ctx context.Context, req *rbac.Resource, rsp *rbac.Response) error {
 	logger.Infof("Received RbacHandler.AddResource request, ID: %s, Name: %s", req.Id, req.Name)
 
-	return nil
+	return errs.NewUnimplemented("RbacHandler.AddResource is not implemented, resources are created by Resource.Create")
 }
 
-// RemoveResource is a single request handler called via client.RemoveResource or the generated client code
+// RemoveResource is not implemented, resources are deleted at their version by Resource.Delete
 func (r *RbacHandler) RemoveResource(ctx context.Context, req *rbac.Request, rsp *rbac.Response) error {
 	logger.Infof("Received RbacHandler.RemoveResource request, ID: %s", req.Id)
 
-	rsp.Msg = "OK"
+	return errs.NewUnimplemented("RbacHandler.RemoveResource is not implemented, resources are deleted at their version by Resource.Delete")
+}
+
+// Watch stream changes after the revision of request, clients resume by the epoch and revision of the last event they got
+func (r *RbacHandler) Watch(ctx context.Context, req *rbac.WatchRequest, stream rbac.Rbac_WatchStream) error {
+	logger.Infof("Received RbacHandler.Watch request, Epoch: %s, Revision: %d, Kinds: %v", req.Epoch, req.Revision, req.Kinds)
+
+	changes, err := r.Feed.Watch(ctx, req.Epoch, req.Revision)
+	if err != nil {
+		return errs.NewConflict("%v, current epoch: %s, revision: %d", err, r.Feed.Epoch(), r.Feed.Revision())
+	}
+
+	kinds := map[models.ChangeKind]bool{}
+	for _, kind := range req.Kinds {
+		kinds[models.ChangeKind(kind)] = true
+	}
+	for change := range changes {
+		if len(kinds) > 0 && !kinds[change.Kind] {
+			continue
+		}
+		err = stream.Send(&rbac.WatchEvent{
+			Revision:  change.Revision,
+			Kind:      rbac.Kind(change.Kind),
+			Action:    rbac.Action(change.Action),
+			Id:        change.ID,
+			TargetId:  change.TargetID,
+			Timestamp: change.Time.UnixNano() / int64(time.Millisecond),
+			Epoch:     change.Epoch,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if ctx.Err() != nil {
+		return nil
+	}
+	return errs.NewConflict("watcher falls behind, resume from the last revision")
+}
+
+// QueryLogs return logs of mutations by the filters of request with cursor pagination,
+// access of auditors is granted by the auth rules of the endpoint
+func (r *RbacHandler) QueryLogs(ctx context.Context, req *rbac.LogsRequest, rsp *rbac.Logs) error {
+	logger.Infof("Received RbacHandler.QueryLogs request, Actor: %s, EntityId: %s, Cursor: %s", req.Actor, req.EntityId, req.Cursor)
+
+	query := repository.LogQuery{Actor: req.Actor, EntityID: req.EntityId}
+	for _, kind := range req.Kinds {
+		query.Kinds = append(query.Kinds, models.ChangeKind(kind))
+	}
+	if req.Since > 0 {
+		query.Since = time.Unix(req.Since, 0)
+	}
+	if req.Until > 0 {
+		query.Until = time.Unix(req.Until, 0)
+	}
+
+	logs, next, err := r.Auditor.Query(ctx, query, req.Cursor, int(req.PageSize))
+	if err != nil {
+		return err
+	}
+	for _, log := range logs {
+		item := &rbac.Log{
+			Id:        log.ID,
+			Actor:     log.Actor,
+			Kind:      rbac.Kind(log.Kind),
+			Action:    rbac.Action(log.Action),
+			EntityId:  log.EntityID,
+			TargetId:  log.TargetID,
+			RequestId: log.RequestID,
+			Timestamp: log.Time.UnixNano() / int64(time.Millisecond),
+		}
+		for _, d := range log.Diff {
+			item.Diff = append(item.Diff, &rbac.FieldDiff{Field: d.Field, Before: d.Before, After: d.After})
+		}
+		rsp.Logs = append(rsp.Logs, item)
+	}
+	rsp.NextCursor = next
 	return nil
 }
+
+//parseID of a request field
+func parseID(field, id string) (int64, error) {
+	v, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || v <= 0 {
+		return 0, errs.NewInvalidArgument("invalid %s %q", field, id)
+	}
+	return v, nil
+}
+
+//parseLink return both ids of a link request
+func parseLink(req *rbac.LinkRequest) (int64, int64, error) {
+	id1, err := parseID("id1", req.Id1)
+	if err != nil {
+		return 0, 0, err
+	}
+	id2, err := parseID("id2", req.Id2)
+	if err != nil {
+		return 0, 0, err
+	}
+	return id1, id2, nil
+}
+
+func toRbacResources(resources []*models.Resource) []*rbac.Resource {
+	result := make([]*rbac.Resource, 0, len(resources))
+	for _, resource := range resources {
+		result = append(result, &rbac.Resource{Id: strconv.Itoa(resource.ID), Name: resource.Name, Version: resource.Version})
+	}
+	return result
+}
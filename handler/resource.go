@@ -1,16 +1,19 @@
 package handler
 
 import (
+	"context"
+
+	"github.com/micro-community/auth/models"
+	pb "github.com/micro-community/auth/protos/resource"
 	"github.com/micro-community/auth/service"
 	mService "github.com/micro/micro/v3/service"
-	mservice "github.com/micro/micro/v3/service"
+	"github.com/micro/micro/v3/service/logger"
 )
 
-//ResourceHandler implements the auth service interface
+//ResourceHandler implements the resource proto interface
 type ResourceHandler struct {
-	mService *mservice.Service
-	Name     string
-	srv      *service.ResourceService // instance of the user service
+	Name string
+	srv  *service.ResourceService // instance of the resource service
 }
 
 //Resource 资源需要定义,如：设备、物品、组织、空间、资产等
@@ -23,3 +26,131 @@ func NewResource(service *mService.Service, resourceService *service.ResourceSer
 		srv:  resourceService,
 	}
 }
+
+// Create register a resource owned by the caller
+func (r *ResourceHandler) Create(ctx context.Context, req *pb.CreateRequest, rsp *pb.ResourceInfo) error {
+	logger.Infof("Received ResourceHandler.Create request, Key: %s, Name: %s", req.Key, req.Name)
+
+	resource := &models.Resource{
+		Key:      req.Key,
+		Name:     req.Name,
+		TenantID: int(req.TenantId),
+		Type:     int(req.Type),
+	}
+	if err := r.srv.Create(ctx, resource); err != nil {
+		return err
+	}
+	toResourceInfo(resource, rsp)
+	return nil
+}
+
+// Get return a resource by id
+func (r *ResourceHandler) Get(ctx context.Context, req *pb.GetRequest, rsp *pb.ResourceInfo) error {
+	logger.Infof("Received ResourceHandler.Get request, ID: %d", req.Id)
+
+	resource, err := r.srv.Get(ctx, req.Id)
+	if err != nil {
+		return err
+	}
+	toResourceInfo(resource, rsp)
+	return nil
+}
+
+// Update modify a resource, the type is kept unless set
+func (r *ResourceHandler) Update(ctx context.Context, req *pb.UpdateRequest, rsp *pb.ResourceInfo) error {
+	logger.Infof("Received ResourceHandler.Update request, ID: %d", req.Id)
+
+	_, withType := req.TypeUpdate.(*pb.UpdateRequest_Type)
+	resource, err := r.srv.Update(ctx, &models.Resource{
+		ID:             int(req.Id),
+		Name:           req.Name,
+		Type:           int(req.GetType()),
+		ModelExtension: models.ModelExtension{Version: req.Version},
+	}, withType)
+	if err != nil {
+		return err
+	}
+	toResourceInfo(resource, rsp)
+	return nil
+}
+
+// Delete remove a resource
+func (r *ResourceHandler) Delete(ctx context.Context, req *pb.DeleteRequest, rsp *pb.DeleteResponse) error {
+	logger.Infof("Received ResourceHandler.Delete request, ID: %d", req.Id)
+
+	if err := r.srv.Delete(ctx, req.Id, req.Version); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Restore a deleted resource with the links of roles to it
+func (r *ResourceHandler) Restore(ctx context.Context, req *pb.RestoreRequest, rsp *pb.ResourceInfo) error {
+	logger.Infof("Received ResourceHandler.Restore request, ID: %d", req.Id)
+
+	resource, err := r.srv.Restore(ctx, req.Id)
+	if err != nil {
+		return err
+	}
+	toResourceInfo(resource, rsp)
+	return nil
+}
+
+// Search resources by catalog types and tenant
+func (r *ResourceHandler) Search(ctx context.Context, req *pb.SearchRequest, rsp *pb.SearchResponse) error {
+	logger.Infof("Received ResourceHandler.Search request, TenantID: %d, Types: %v", req.TenantId, req.Types)
+
+	types := make([]models.ResourceCatalog, 0, len(req.Types))
+	for _, t := range req.Types {
+		types = append(types, models.ResourceCatalog(t))
+	}
+	resources, err := r.srv.Search(ctx, int(req.TenantId), types...)
+	if err != nil {
+		return err
+	}
+	for _, resource := range resources {
+		info := &pb.ResourceInfo{}
+		toResourceInfo(resource, info)
+		rsp.Resources = append(rsp.Resources, info)
+	}
+	return nil
+}
+
+// List resources by filters with cursor pagination
+func (r *ResourceHandler) List(ctx context.Context, req *pb.ListRequest, rsp *pb.ListResponse) error {
+	logger.Infof("Received ResourceHandler.List request, Cursor: %s, PageSize: %d", req.Cursor, req.PageSize)
+
+	opts, err := listOptions(req.NamePrefix, req.TenantId, req.CreatedAfter, req.CreatedBefore, req.SortBy, req.Desc)
+	if err != nil {
+		return err
+	}
+	for _, t := range req.Types {
+		opts.Types = append(opts.Types, models.ResourceCatalog(t))
+	}
+
+	resources, page, err := r.srv.List(withDeleted(ctx, req.WithDeleted), opts, req.Cursor, int(req.PageSize))
+	if err != nil {
+		return err
+	}
+	for _, resource := range resources {
+		info := &pb.ResourceInfo{}
+		toResourceInfo(resource, info)
+		rsp.Resources = append(rsp.Resources, info)
+	}
+	rsp.NextCursor = page.NextCursor
+	rsp.Total = page.Total
+	return nil
+}
+
+func toResourceInfo(resource *models.Resource, info *pb.ResourceInfo) {
+	info.Id = int64(resource.ID)
+	info.Key = resource.Key
+	info.Name = resource.Name
+	info.TenantId = int64(resource.TenantID)
+	info.Type = pb.Catalog(resource.Type)
+	info.AddedBy = resource.AddedBy
+	info.UpdateBy = resource.UpdateBy
+	info.CreatedAt = unixTime(resource.CreatedAt)
+	info.DeletedAt = unixTime(resource.DeletedAt)
+	info.Version = resource.Version
+}
@@ -3,9 +3,11 @@ package handler
 import (
 	"context"
 
+	"github.com/micro-community/auth/models"
 	role "github.com/micro-community/auth/protos"
 	"github.com/micro-community/auth/service"
 	mservice "github.com/micro/micro/v3/service"
+	"github.com/micro/micro/v3/service/logger"
 )
 
 //RoleHandler implements the role proto interface
@@ -23,18 +25,93 @@ func NewRole(service *mservice.Service, roleService *service.RoleService) *RoleH
 	}
 }
 
-func (r *RoleHandler) GetRole(ctx context.Context, req *role.GetRoleRequest, resp *role.GetRoleResponse) error {
-	panic("no implemention")
+//GetRole return a role by id
+func (r *RoleHandler) GetRole(ctx context.Context, req *role.GetRoleRequest, resp *role.RoleInfo) error {
+	logger.Infof("Received RoleHandler.GetRole request, RoleId: %d", req.RoleId)
+	item, err := r.service.Get(ctx, req.RoleId)
+	if err != nil {
+		return err
+	}
+	toRoleInfo(item, resp)
+	return nil
 }
 
+//InsertRole create a role linked to the resources
 func (r *RoleHandler) InsertRole(ctx context.Context, req *role.InsertRoleRequest, resp *role.InsertRoleResponse) error {
-	panic("no implemention")
+	logger.Infof("Received RoleHandler.InsertRole request, Name: %s", req.Name)
+	resourceIDs := make([]int, 0, len(req.ResourceIds))
+	for _, id := range req.ResourceIds {
+		resourceIDs = append(resourceIDs, int(id))
+	}
+	item := &models.Role{Key: req.Key, Name: req.Name}
+	if err := r.service.Create(ctx, item, resourceIDs); err != nil {
+		return err
+	}
+	resp.RoleId, resp.Version = int64(item.ID), item.Version
+	return nil
 }
 
+//DeleteRole delete a role at the version with its links
 func (r *RoleHandler) DeleteRole(ctx context.Context, req *role.DeleteRoleRequest, resp *role.DeleteRoleResponse) error {
-	panic("no implemention")
+	logger.Infof("Received RoleHandler.DeleteRole request, RoleId: %d", req.RoleId)
+	return r.service.Delete(ctx, req.RoleId, req.Version)
+}
+
+//UpdateRole modify the name of a role at the version
+func (r *RoleHandler) UpdateRole(ctx context.Context, req *role.UpdateRoleRequest, resp *role.RoleInfo) error {
+	logger.Infof("Received RoleHandler.UpdateRole request, RoleId: %d", req.RoleId)
+	item, err := r.service.Update(ctx, &models.Role{
+		ID:             int(req.RoleId),
+		Name:           req.Name,
+		ModelExtension: models.ModelExtension{Version: req.Version},
+	})
+	if err != nil {
+		return err
+	}
+	toRoleInfo(item, resp)
+	return nil
+}
+
+// ListRoles by filters with cursor pagination
+func (r *RoleHandler) ListRoles(ctx context.Context, req *role.ListRolesRequest, resp *role.ListRolesResponse) error {
+	logger.Infof("Received RoleHandler.ListRoles request, Cursor: %s, PageSize: %d", req.Cursor, req.PageSize)
+
+	opts, err := listOptions(req.NamePrefix, req.TenantId, req.CreatedAfter, req.CreatedBefore, req.SortBy, req.Desc)
+	if err != nil {
+		return err
+	}
+
+	roles, page, err := r.service.List(withDeleted(ctx, req.WithDeleted), opts, req.Cursor, int(req.PageSize))
+	if err != nil {
+		return err
+	}
+	for _, item := range roles {
+		info := &role.RoleInfo{}
+		toRoleInfo(item, info)
+		resp.Roles = append(resp.Roles, info)
+	}
+	resp.NextCursor = page.NextCursor
+	resp.Total = page.Total
+	return nil
+}
+
+//RestoreRole restore a deleted role with its links
+func (r *RoleHandler) RestoreRole(ctx context.Context, req *role.RestoreRoleRequest, resp *role.RoleInfo) error {
+	logger.Infof("Received RoleHandler.RestoreRole request, RoleId: %d", req.RoleId)
+	item, err := r.service.Restore(ctx, req.RoleId)
+	if err != nil {
+		return err
+	}
+	toRoleInfo(item, resp)
+	return nil
 }
 
-func (r *RoleHandler) UpdateRole(ctx context.Context, req *role.UpdateRoleRequest, resp *role.UpdateRoleResponse) error {
-	panic("no implemention")
+func toRoleInfo(item *models.Role, info *role.RoleInfo) {
+	info.Id = int64(item.ID)
+	info.Key = item.Key
+	info.Name = item.Name
+	info.TenantId = int64(item.TenantID)
+	info.CreatedAt = unixTime(item.CreatedAt)
+	info.DeletedAt = unixTime(item.DeletedAt)
+	info.Version = item.Version
 }
@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/micro-community/auth/models"
+	pb "github.com/micro-community/auth/protos/template"
+	"github.com/micro-community/auth/service"
+	mService "github.com/micro/micro/v3/service"
+	"github.com/micro/micro/v3/service/logger"
+)
+
+//RoleTemplateHandler implements the role template proto interface
+type RoleTemplateHandler struct {
+	Name string
+	srv  *service.RoleTemplateService
+}
+
+// NewRoleTemplate returns a role template handler
+func NewRoleTemplate(service *mService.Service, templateService *service.RoleTemplateService) *RoleTemplateHandler {
+	return &RoleTemplateHandler{
+		Name: "RoleTemplateHandler",
+		srv:  templateService,
+	}
+}
+
+// Create define a role template
+func (t *RoleTemplateHandler) Create(ctx context.Context, req *pb.CreateRequest, rsp *pb.TemplateInfo) error {
+	logger.Infof("Received RoleTemplateHandler.Create request, Name: %s, Key: %s", req.Name, req.Key)
+
+	template := &models.RoleTemplate{
+		Name:    req.Name,
+		Key:     req.Key,
+		Grants:  req.Grants,
+		Default: req.Default,
+	}
+	if err := t.srv.Create(ctx, template); err != nil {
+		return err
+	}
+	toTemplateInfo(template, rsp)
+	return nil
+}
+
+// Get return a role template by id
+func (t *RoleTemplateHandler) Get(ctx context.Context, req *pb.GetRequest, rsp *pb.TemplateInfo) error {
+	logger.Infof("Received RoleTemplateHandler.Get request, ID: %d", req.Id)
+
+	template, err := t.srv.Get(ctx, int(req.Id))
+	if err != nil {
+		return err
+	}
+	toTemplateInfo(template, rsp)
+	return nil
+}
+
+// List all role templates
+func (t *RoleTemplateHandler) List(ctx context.Context, req *pb.ListRequest, rsp *pb.ListResponse) error {
+	logger.Infof("Received RoleTemplateHandler.List request")
+
+	templates, err := t.srv.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, template := range templates {
+		info := &pb.TemplateInfo{}
+		toTemplateInfo(template, info)
+		rsp.Templates = append(rsp.Templates, info)
+	}
+	return nil
+}
+
+// Update a role template to a new version, its roles are changed by Sync
+func (t *RoleTemplateHandler) Update(ctx context.Context, req *pb.UpdateRequest, rsp *pb.TemplateInfo) error {
+	logger.Infof("Received RoleTemplateHandler.Update request, ID: %d", req.Id)
+
+	template, err := t.srv.Update(ctx, &models.RoleTemplate{
+		ID:             int(req.Id),
+		Name:           req.Name,
+		Grants:         req.Grants,
+		Default:        req.Default,
+		ModelExtension: models.ModelExtension{Version: req.Version},
+	})
+	if err != nil {
+		return err
+	}
+	toTemplateInfo(template, rsp)
+	return nil
+}
+
+// Delete a role template, its roles are kept
+func (t *RoleTemplateHandler) Delete(ctx context.Context, req *pb.DeleteRequest, rsp *pb.DeleteResponse) error {
+	logger.Infof("Received RoleTemplateHandler.Delete request, ID: %d", req.Id)
+
+	return t.srv.Delete(ctx, int(req.Id), req.Version)
+}
+
+// Provision a role of every template in the tenant of the request
+func (t *RoleTemplateHandler) Provision(ctx context.Context, req *pb.ProvisionRequest, rsp *pb.ProvisionResponse) error {
+	logger.Infof("Received RoleTemplateHandler.Provision request, Templates: %v, ScopeID: %d", req.TemplateIds, req.ScopeId)
+
+	ids := make([]int, 0, len(req.TemplateIds))
+	for _, id := range req.TemplateIds {
+		ids = append(ids, int(id))
+	}
+	roles, err := t.srv.Provision(ctx, ids, int(req.ScopeId))
+	if err != nil {
+		return err
+	}
+	for i, role := range roles {
+		rsp.Roles = append(rsp.Roles, &pb.ProvisionedRole{
+			Id:         int64(role.ID),
+			Key:        role.Key,
+			Name:       role.Name,
+			TemplateId: req.TemplateIds[i],
+		})
+	}
+	return nil
+}
+
+// Sync the roles of a template to its version
+func (t *RoleTemplateHandler) Sync(ctx context.Context, req *pb.SyncRequest, rsp *pb.SyncResponse) error {
+	logger.Infof("Received RoleTemplateHandler.Sync request, ID: %d", req.Id)
+
+	result, err := t.srv.Sync(ctx, int(req.Id))
+	if err != nil {
+		return err
+	}
+	rsp.Synced = int64(result.Synced)
+	rsp.Missing = int64(result.Missing)
+	rsp.Linked = int64(result.Linked)
+	rsp.Unlinked = int64(result.Unlinked)
+	rsp.Renamed = int64(result.Renamed)
+	return nil
+}
+
+func toTemplateInfo(template *models.RoleTemplate, info *pb.TemplateInfo) {
+	info.Id = int64(template.ID)
+	info.Name = template.Name
+	info.Key = template.Key
+	info.Grants = template.Grants
+	info.Default = template.Default
+	info.CreatedAt = unixTime(template.CreatedAt)
+	info.Version = template.Version
+}
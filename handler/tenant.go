@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/micro-community/auth/models"
+	pb "github.com/micro-community/auth/protos/tenant"
+	"github.com/micro-community/auth/service"
+	mService "github.com/micro/micro/v3/service"
+	"github.com/micro/micro/v3/service/logger"
+)
+
+//TenantHandler implements the tenant proto interface
+type TenantHandler struct {
+	Name string
+	srv  *service.TenantService
+}
+
+// NewTenant returns a tenant handler
+func NewTenant(service *mService.Service, tenantService *service.TenantService) *TenantHandler {
+	return &TenantHandler{
+		Name: "TenantHandler",
+		srv:  tenantService,
+	}
+}
+
+// Create provision a tenant with its admin user and admin role
+func (t *TenantHandler) Create(ctx context.Context, req *pb.CreateRequest, rsp *pb.TenantInfo) error {
+	logger.Infof("Received TenantHandler.Create request, Name: %s, Admin: %s", req.Name, req.AdminName)
+
+	tenant := &models.Tenant{Name: req.Name, Settings: toSettings(req.Settings)}
+	admin := &models.User{Name: req.AdminName, Password: req.AdminPassword}
+	if err := t.srv.Create(ctx, tenant, admin); err != nil {
+		return err
+	}
+	toTenantInfo(tenant, rsp)
+	return nil
+}
+
+// Get return a tenant by id
+func (t *TenantHandler) Get(ctx context.Context, req *pb.GetRequest, rsp *pb.TenantInfo) error {
+	logger.Infof("Received TenantHandler.Get request, ID: %d", req.Id)
+
+	tenant, err := t.srv.Get(ctx, int(req.Id))
+	if err != nil {
+		return err
+	}
+	toTenantInfo(tenant, rsp)
+	return nil
+}
+
+// List all tenants
+func (t *TenantHandler) List(ctx context.Context, req *pb.ListRequest, rsp *pb.ListResponse) error {
+	logger.Infof("Received TenantHandler.List request")
+
+	tenants, err := t.srv.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, tenant := range tenants {
+		info := &pb.TenantInfo{}
+		toTenantInfo(tenant, info)
+		rsp.Tenants = append(rsp.Tenants, info)
+	}
+	return nil
+}
+
+// UpdateSettings replace the settings of a tenant
+func (t *TenantHandler) UpdateSettings(ctx context.Context, req *pb.UpdateSettingsRequest, rsp *pb.TenantInfo) error {
+	logger.Infof("Received TenantHandler.UpdateSettings request, ID: %d", req.Id)
+
+	tenant, err := t.srv.UpdateSettings(ctx, int(req.Id), toSettings(req.Settings), req.Version)
+	if err != nil {
+		return err
+	}
+	toTenantInfo(tenant, rsp)
+	return nil
+}
+
+// Suspend a tenant, requests in it are rejected until it is resumed
+func (t *TenantHandler) Suspend(ctx context.Context, req *pb.StateRequest, rsp *pb.TenantInfo) error {
+	logger.Infof("Received TenantHandler.Suspend request, ID: %d", req.Id)
+
+	tenant, err := t.srv.Suspend(ctx, int(req.Id), req.Version)
+	if err != nil {
+		return err
+	}
+	toTenantInfo(tenant, rsp)
+	return nil
+}
+
+// Resume a suspended tenant
+func (t *TenantHandler) Resume(ctx context.Context, req *pb.StateRequest, rsp *pb.TenantInfo) error {
+	logger.Infof("Received TenantHandler.Resume request, ID: %d", req.Id)
+
+	tenant, err := t.srv.Resume(ctx, int(req.Id), req.Version)
+	if err != nil {
+		return err
+	}
+	toTenantInfo(tenant, rsp)
+	return nil
+}
+
+// Delete a tenant with its users, roles and resources
+func (t *TenantHandler) Delete(ctx context.Context, req *pb.DeleteRequest, rsp *pb.DeleteResponse) error {
+	logger.Infof("Received TenantHandler.Delete request, ID: %d", req.Id)
+
+	deletion, err := t.srv.Delete(ctx, int(req.Id), req.Version)
+	if err != nil {
+		return err
+	}
+	rsp.Users = int64(deletion.Users)
+	rsp.Roles = int64(deletion.Roles)
+	rsp.Resources = int64(deletion.Resources)
+	rsp.OrgUnits = int64(deletion.OrgUnits)
+	return nil
+}
+
+func toSettings(settings *pb.Settings) models.TenantSettings {
+	policy := settings.GetPasswordPolicy()
+	return models.TenantSettings{
+		PasswordPolicy: models.PasswordPolicy{
+			MinLength:     int(policy.GetMinLength()),
+			RequireUpper:  policy.GetRequireUpper(),
+			RequireLower:  policy.GetRequireLower(),
+			RequireDigit:  policy.GetRequireDigit(),
+			RequireSymbol: policy.GetRequireSymbol(),
+		},
+		MFARequired:     settings.GetMfaRequired(),
+		SessionLifetime: time.Duration(settings.GetSessionLifetime()) * time.Second,
+	}
+}
+
+func toTenantInfo(tenant *models.Tenant, info *pb.TenantInfo) {
+	policy := tenant.Settings.PasswordPolicy
+	info.Id = int64(tenant.ID)
+	info.Name = tenant.Name
+	info.State = pb.State(tenant.State)
+	info.Settings = &pb.Settings{
+		PasswordPolicy: &pb.PasswordPolicy{
+			MinLength:     int32(policy.MinLength),
+			RequireUpper:  policy.RequireUpper,
+			RequireLower:  policy.RequireLower,
+			RequireDigit:  policy.RequireDigit,
+			RequireSymbol: policy.RequireSymbol,
+		},
+		MfaRequired:     tenant.Settings.MFARequired,
+		SessionLifetime: int64(tenant.Settings.SessionLifetime / time.Second),
+	}
+	info.CreatedAt = unixTime(tenant.CreatedAt)
+	info.Version = tenant.Version
+}
@@ -2,12 +2,22 @@ package handler
 
 import (
 	"context"
+	"io"
+	"time"
 
+	"github.com/micro-community/auth/models"
 	user "github.com/micro-community/auth/protos"
 	"github.com/micro-community/auth/service"
 	mservice "github.com/micro/micro/v3/service"
+	"github.com/micro/micro/v3/service/auth"
+	"github.com/micro/micro/v3/service/errors"
+	"github.com/micro/micro/v3/service/logger"
+	"google.golang.org/protobuf/proto"
 )
 
+//heartbeatTimeout of PingPong sessions
+const heartbeatTimeout = 30 * time.Second
+
 //UserHandler implements the user proto interface,User : people、tenant(orgs、company)
 type UserHandler struct {
 	mService *mservice.Service
@@ -24,48 +34,186 @@ func NewUser(mservice *mservice.Service, userService *service.UserService) *User
 	}
 }
 
-//GetUser return User By ID
-func (u *UserHandler) GetUser(ctx context.Context, req *user.GetUserRequest, resp *user.UserInfo) error {
-	//	var user models.User
-	// user.ID = req.UserId
+//Call greet the caller
+func (u *UserHandler) Call(ctx context.Context, req *user.Request, rsp *user.Response) error {
+	logger.Infof("Received UserHandler.Call request, Name: %s", req.Name)
+	rsp.Msg = "Hello " + req.Name
+	return nil
+}
 
-	// _, err := u.srv.Login()
-	// if err != nil {
-	// 	logger.Error(err)
-	// 	return err
-	// }
+//Stream export users matched the filters one by one, to sync large directories without paging
+func (u *UserHandler) Stream(ctx context.Context, req *user.StreamingRequest, stream user.User_StreamStream) error {
+	logger.Infof("Received UserHandler.Stream request, Count: %d", req.Count)
 
+	opts, err := listOptions(req.NamePrefix, req.TenantId, req.CreatedAfter, req.CreatedBefore, "", false)
+	if err != nil {
+		return err
+	}
+	opts.Status = int(req.Status)
+
+	var count int64
+	err = u.srv.Export(ctx, opts, req.Count, func(item *models.User) error {
+		count++
+		return stream.Send(&user.StreamingResponse{Count: count, User: toUserInfo(item)})
+	})
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
-func (u *UserHandler) InsertUser(ctx context.Context, req *user.InsertUserRequest, resp *user.InsertUserResponse) error {
-	//	var user models.User
+//PingPong keep a session alive by heartbeats, the session is closed when no ping comes in heartbeatTimeout
+func (u *UserHandler) PingPong(ctx context.Context, stream user.User_PingPongStream) error {
+	if acc, ok := auth.AccountFromContext(ctx); ok {
+		logger.Infof("Received UserHandler.PingPong request, Account: %s", acc.ID)
+	}
 
-	// id, err := user.Insert()
-	// if err != nil {
-	// 	logger.Error(err)
-	// 	return err
-	// }
+	//done is closed when the handler returns, as ctx is not cancelled before the stream is closed,
+	//so the receiving goroutine never blocks on a ping nobody reads
+	pings := make(chan *user.Ping)
+	errs := make(chan error, 1)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			ping, err := stream.Recv()
+			if err != nil {
+				errs <- err
+				return
+			}
+			select {
+			case pings <- ping:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	timer := time.NewTimer(heartbeatTimeout)
+	defer timer.Stop()
+	for {
+		select {
+		case ping := <-pings:
+			if !timer.Stop() {
+				<-timer.C
+			}
+			timer.Reset(heartbeatTimeout)
+			pong := &user.Pong{
+				Stroke:     ping.Stroke,
+				ServerTime: time.Now().UnixNano() / int64(time.Millisecond),
+				Timeout:    int64(heartbeatTimeout / time.Second),
+			}
+			if err := stream.Send(pong); err != nil {
+				return err
+			}
+		case err := <-errs:
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		case <-timer.C:
+			return errors.Timeout("UserHandler.PingPong", "no ping in %v", heartbeatTimeout)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
 
-	// resp.UserId = id
+//GetUser return a user by id
+func (u *UserHandler) GetUser(ctx context.Context, req *user.GetUserRequest, resp *user.UserInfo) error {
+	logger.Infof("Received UserHandler.GetUser request, UserId: %d", req.UserId)
+	item, err := u.srv.Get(ctx, req.UserId)
+	if err != nil {
+		return err
+	}
+	proto.Merge(resp, toUserInfo(item))
+	return nil
+}
 
+//InsertUser create a user linked to the roles
+func (u *UserHandler) InsertUser(ctx context.Context, req *user.InsertUserRequest, resp *user.InsertUserResponse) error {
+	logger.Infof("Received UserHandler.InsertUser request, Name: %s", req.Name)
+	roleIDs := make([]int, 0, len(req.RoleIds))
+	for _, id := range req.RoleIds {
+		roleIDs = append(roleIDs, int(id))
+	}
+	item := &models.User{
+		Name:        req.Name,
+		Password:    req.Password,
+		NickName:    req.NickName,
+		UserDetails: models.UserDetails{Email: req.Email, Phone: req.Phone},
+	}
+	if err := u.srv.Create(ctx, item, roleIDs); err != nil {
+		return err
+	}
+	resp.UserId, resp.Version = item.ID, item.Version
 	return nil
 }
 
+//DeleteUser delete a user with the links to its roles
 func (u *UserHandler) DeleteUser(ctx context.Context, req *user.DeleteUserRequest, resp *user.DeleteUserResponse) error {
-	panic("implement me")
+	logger.Infof("Received UserHandler.DeleteUser request, UserId: %d", req.UserId)
+	return u.srv.Delete(ctx, req.UserId, req.Version)
+}
 
+//RestoreUser restore a deleted user with the links to its roles
+func (u *UserHandler) RestoreUser(ctx context.Context, req *user.RestoreUserRequest, resp *user.UserInfo) error {
+	logger.Infof("Received UserHandler.RestoreUser request, UserId: %d", req.UserId)
+	item, err := u.srv.Restore(ctx, req.UserId)
+	if err != nil {
+		return err
+	}
+	proto.Merge(resp, toUserInfo(item))
+	return nil
 }
 
+//UpdateUser modify the profile of a user at the version
 func (u *UserHandler) UpdateUser(ctx context.Context, req *user.UpdateUserRequest, resp *user.UserInfo) error {
-	//	var data models.User
+	logger.Infof("Received UserHandler.UpdateUser request, UserId: %d", req.UserId)
+	item, err := u.srv.Update(ctx, &models.User{
+		ID:             req.UserId,
+		NickName:       req.NickName,
+		UserDetails:    models.UserDetails{Stated: int(req.Status), Email: req.Email, Phone: req.Phone},
+		ModelExtension: models.ModelExtension{Version: req.Version},
+	})
+	if err != nil {
+		return err
+	}
+	proto.Merge(resp, toUserInfo(item))
+	return nil
+}
 
-	// result, err := data.Update(req.UserId)
-	// if err != nil {
-	// 	logger.Error(err)
-	// 	return err
-	// }
-	// resp = result.ToView()
+// ListUsers by filters with cursor pagination
+func (u *UserHandler) ListUsers(ctx context.Context, req *user.ListUsersRequest, resp *user.ListUsersResponse) error {
+	logger.Infof("Received UserHandler.ListUsers request, Cursor: %s, PageSize: %d", req.Cursor, req.PageSize)
 
+	opts, err := listOptions(req.NamePrefix, req.TenantId, req.CreatedAfter, req.CreatedBefore, req.SortBy, req.Desc)
+	if err != nil {
+		return err
+	}
+	opts.Status = int(req.Status)
+
+	users, page, err := u.srv.List(withDeleted(ctx, req.WithDeleted), opts, req.Cursor, int(req.PageSize))
+	if err != nil {
+		return err
+	}
+	for _, item := range users {
+		resp.Users = append(resp.Users, toUserInfo(item))
+	}
+	resp.NextCursor = page.NextCursor
+	resp.Total = page.Total
 	return nil
 }
+
+func toUserInfo(item *models.User) *user.UserInfo {
+	return &user.UserInfo{
+		Id:        item.ID,
+		Name:      item.Name,
+		NickName:  item.NickName,
+		TenantId:  int64(item.TenantID),
+		Status:    int32(item.Stated),
+		CreatedAt: unixTime(item.CreatedAt),
+		DeletedAt: unixTime(item.DeletedAt),
+		Version:   item.Version,
+	}
+}
@@ -1,8 +1,12 @@
 package main
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/micro-community/auth/config"
 	"github.com/micro-community/auth/pubsub"
+	"github.com/micro-community/auth/wrapper"
 	"github.com/micro/micro/v3/cmd"
 	"github.com/micro/micro/v3/service"
 	"github.com/micro/micro/v3/service/logger"
@@ -14,9 +18,16 @@ import (
 
 func main() {
 
+	// manage migrations, the database file, transfers or backups without running the service
+	if runCommand(os.Args) {
+		return
+	}
+
 	srv := service.New(
 		service.Name("micro-v3-starter"),
 		service.Version("latest"),
+		// translate domain errors, validate requests by their generated validators, then scope them to their tenant
+		service.WrapHandler(wrapper.Errors(), wrapper.Validator(), wrapper.Tenant(config.Default)),
 	)
 
 	// add customer Flags
@@ -46,3 +57,25 @@ func main() {
 		logger.Fatal(err)
 	}
 }
+
+//runCommand run the command named by the first argument, return false when it is not one of them
+func runCommand(args []string) bool {
+	if len(args) < 2 {
+		return false
+	}
+	for _, command := range []*cli.Command{migrateCommand(), fileCommand(), transferCommand(), backupCommand(), restoreCommand()} {
+		if command.Name != args[1] {
+			continue
+		}
+		app := &cli.App{
+			Name:     args[0],
+			Commands: []*cli.Command{command},
+		}
+		if err := app.Run(args); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return true
+	}
+	return false
+}
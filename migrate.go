@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/micro-community/auth/config"
+	"github.com/micro-community/auth/db"
+	"github.com/micro-community/auth/db/migration"
+	"github.com/urfave/cli/v2"
+)
+
+//migrateCommand manage the migrations of a database without running the service:
+//	auth migrate --db sqlite status|up [--to N]|down [--steps N]
+func migrateCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "migrate",
+		Usage: "manage migrations of the database",
+		Flags: append(dbFlags(""),
+			&cli.StringFlag{Name: "db", Usage: "type of the database: mysql, sqlite, mongo or dgraph", EnvVars: []string{"MICRO_STARTER_DB_TYPE"}, Value: config.Default.DBType},
+			&cli.DurationFlag{Name: "timeout", Usage: "time to wait for the migration lock", Value: time.Minute},
+		),
+		Before: func(c *cli.Context) error {
+			return buildMigrateConfig(c, config.Default)
+		},
+		Subcommands: []*cli.Command{
+			{
+				Name:  "status",
+				Usage: "list known and applied migrations",
+				Action: func(c *cli.Context) error {
+					return withMigrator(c, func(ctx context.Context, s migration.Source) error {
+						statuses, err := migration.List(ctx, s)
+						if err != nil {
+							return err
+						}
+						for _, st := range statuses {
+							state := "pending"
+							if st.Applied {
+								state = "applied " + st.AppliedAt.Format(time.RFC3339)
+							}
+							if !st.Known {
+								st.Name = "(unknown to this build)"
+							}
+							fmt.Printf("%4d  %-50s %s\n", st.Version, st.Name, state)
+						}
+						return nil
+					})
+				},
+			},
+			{
+				Name:  "up",
+				Usage: "apply pending migrations",
+				Flags: []cli.Flag{
+					&cli.IntFlag{Name: "to", Usage: "version to migrate up to, 0 for the latest"},
+				},
+				Action: func(c *cli.Context) error {
+					return withMigrator(c, func(ctx context.Context, s migration.Source) error {
+						done, err := migration.Up(ctx, s, c.Int("to"))
+						for _, m := range done {
+							fmt.Printf("up   %4d  %s\n", m.Version, m.Name)
+						}
+						return err
+					})
+				},
+			},
+			{
+				Name:  "down",
+				Usage: "revert applied migrations",
+				Flags: []cli.Flag{
+					&cli.IntFlag{Name: "steps", Usage: "number of migrations to revert", Value: 1},
+				},
+				Action: func(c *cli.Context) error {
+					return withMigrator(c, func(ctx context.Context, s migration.Source) error {
+						done, err := migration.Down(ctx, s, c.Int("steps"))
+						for _, m := range done {
+							fmt.Printf("down %4d  %s\n", m.Version, m.Name)
+						}
+						return err
+					})
+				},
+			},
+		},
+	}
+}
+
+//buildMigrateConfig apply the flags of the database to conf
+func buildMigrateConfig(c *cli.Context, conf *config.Options) error {
+	conf.DBType = c.String("db")
+	switch conf.DBType {
+	case "mysql", "sqlite", "mongo", "dgraph":
+		applyDBFlags(c, "", conf.DBType, conf)
+	default:
+		return fmt.Errorf("%q has no migrations, use mysql, sqlite, mongo or dgraph", conf.DBType)
+	}
+	return nil
+}
+
+//dbFlags of the connection to a database, named with the prefix
+func dbFlags(prefix string) []cli.Flag {
+	env := "MICRO_STARTER_" + strings.ToUpper(strings.ReplaceAll(prefix, "-", "_")) + "DB_PASSWORD"
+	return []cli.Flag{
+		&cli.StringFlag{Name: prefix + "host", Usage: "host of mysql or mongo"},
+		&cli.IntFlag{Name: prefix + "port", Usage: "port of mysql or mongo"},
+		&cli.StringFlag{Name: prefix + "user", Usage: "user of mysql or mongo"},
+		&cli.StringFlag{Name: prefix + "password", Usage: "password of mysql or mongo", EnvVars: []string{env}},
+		&cli.StringFlag{Name: prefix + "name", Usage: "database name, or file name of sqlite"},
+		&cli.StringFlag{Name: prefix + "path", Usage: "directory of the sqlite file, or the embedded database file"},
+		&cli.StringFlag{Name: prefix + "url", Usage: "url of dgraph"},
+	}
+}
+
+//applyDBFlags apply the flags of dbFlags(prefix) to the options of the database type in conf
+func applyDBFlags(c *cli.Context, prefix, dbType string, conf *config.Options) {
+	set := func(name string, s *string) {
+		if c.IsSet(prefix + name) {
+			*s = c.String(prefix + name)
+		}
+	}
+	setInt := func(name string, i *int) {
+		if c.IsSet(prefix + name) {
+			*i = c.Int(prefix + name)
+		}
+	}
+
+	switch dbType {
+	case "mysql":
+		set("host", &conf.MySQL.Host)
+		setInt("port", &conf.MySQL.Port)
+		set("user", &conf.MySQL.User)
+		set("password", &conf.MySQL.Password)
+		set("name", &conf.MySQL.DBName)
+	case "sqlite":
+		set("name", &conf.SQLite.DBName)
+		set("path", &conf.SQLite.Path)
+	case "mongo":
+		set("host", &conf.Mongodb.Host)
+		setInt("port", &conf.Mongodb.Port)
+		set("user", &conf.Mongodb.User)
+		set("password", &conf.Mongodb.Password)
+		set("name", &conf.Mongodb.DBName)
+	case "dgraph":
+		set("url", &conf.Dgraph.Url)
+	case "file":
+		set("path", &conf.File.Path)
+	}
+}
+
+func withMigrator(c *cli.Context, fn func(ctx context.Context, s migration.Source) error) error {
+	db.BuildDBContext(config.Default.DBType)
+	ctx, cancel := context.WithTimeout(context.Background(), c.Duration("timeout"))
+	defer cancel()
+	return fn(ctx, db.Migrator())
+}
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+//ChangeKind of the changed entity
+type ChangeKind int
+
+const (
+	UserChange ChangeKind = iota
+	RoleChange
+	ResourceChange
+	OrgChange
+)
+
+//ChangeAction on the changed entity
+type ChangeAction int
+
+const (
+	Created ChangeAction = iota
+	Updated
+	Deleted
+	Linked
+	Unlinked
+	Restored
+)
+
+//Change event of rbac data, Revision increase one by one from 1 in an Epoch
+type Change struct {
+	Epoch    string
+	Revision int64
+	Kind     ChangeKind
+	Action   ChangeAction
+	ID       string // id of the changed entity
+	TargetID string // id of the role or resource linked to, for Linked/Unlinked; of the role granted to an org unit
+	TenantID int    // tenant the change was made in
+	Time     time.Time
+}
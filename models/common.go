@@ -7,4 +7,5 @@ type ModelExtension struct {
 	UpdatedAt time.Time `json:"updatedAt"`
 	DeletedAt time.Time `json:"deletedAt"`
 	IsSoftDel bool      `json:"isSoftDelete"` //软删除
+	Version   int64     `json:"version" gorm:"not null;default:1"` //版本，每次修改加一
 }
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+//UserRole link a user to a role
+type UserRole struct {
+	UserID    int64     `gorm:"primaryKey;autoIncrement:false" json:"userId"`
+	RoleID    int       `gorm:"primaryKey;autoIncrement:false;index" json:"roleId"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+//RoleResource link a role to a resource
+type RoleResource struct {
+	RoleID     int       `gorm:"primaryKey;autoIncrement:false" json:"roleId"`
+	ResourceID int       `gorm:"primaryKey;autoIncrement:false;index" json:"resourceId"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
@@ -1,15 +1,55 @@
 package models
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"time"
-
-	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+//Log of a mutation for auditors, it is appended once and never changed
 type Log struct {
-	ID        primitive.ObjectID `bson:"_id,omitempty" json:"_id"`
-	UserID    primitive.ObjectID `bson:"_userId,omitempty" json:"_userId"`
-	EventType int32              `bson:"eventType,omitempty" json:"eventType"`
-	Created   time.Time          `bson:"created,omitempty" json:"created"`
-	Updated   time.Time          `bson:"updated,omitempty" json:"updated"`
+	ID        int64        `bson:"_id" json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Actor     string       `bson:"actor" json:"actor" gorm:"size:128;index"` // account id of the caller, empty for internal calls
+	TenantID  int          `bson:"tenantid" json:"tenantId" gorm:"index"`    // tenant the caller acted in
+	Kind      ChangeKind   `bson:"kind" json:"kind"`
+	Action    ChangeAction `bson:"action" json:"action"`
+	EntityID  string       `bson:"entityid" json:"entityId" gorm:"size:64;index"`
+	TargetID  string       `bson:"targetid,omitempty" json:"targetId,omitempty" gorm:"size:64"` // the role or resource linked to, for Linked/Unlinked
+	Diff      FieldDiffs   `bson:"diff,omitempty" json:"diff,omitempty" gorm:"type:text"`
+	RequestID string       `bson:"requestid,omitempty" json:"requestId,omitempty" gorm:"size:128"`
+	Time      time.Time    `bson:"time" json:"time" gorm:"index"`
+}
+
+//FieldDiff of a field changed by a mutation, values are json encoded, empty when the field is missing
+type FieldDiff struct {
+	Field  string `bson:"field" json:"field"`
+	Before string `bson:"before" json:"before"`
+	After  string `bson:"after" json:"after"`
+}
+
+//FieldDiffs of a log, kept as a json array in sql
+type FieldDiffs []FieldDiff
+
+//Value of diffs in sql
+func (d FieldDiffs) Value() (driver.Value, error) {
+	if d == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal(d)
+	return string(data), err
+}
+
+//Scan diffs from sql
+func (d *FieldDiffs) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*d = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, d)
+	case string:
+		return json.Unmarshal([]byte(v), d)
+	}
+	return fmt.Errorf("can not scan %T into diffs", value)
 }
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+//OrgUnitKind of OrgUnit
+type OrgUnitKind int
+
+const (
+	OrgOrganization OrgUnitKind = iota + 1 // root of an org tree
+	OrgDepartment                          // under an organization or a department
+	OrgPosition                            // under an organization or a department, held by users
+)
+
+//OrgUnit is an organization, a department or a position in the org tree of a tenant
+type OrgUnit struct {
+	ID       int         `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Kind     OrgUnitKind `json:"kind"`
+	Name     string      `json:"name" gorm:"size:128;uniqueIndex:idx_org_units_name"` // unique among the units of its parent
+	ParentID int         `json:"parentId" gorm:"uniqueIndex:idx_org_units_name"`      // 0 for an organization
+	TenantID int         `json:"tenantId" gorm:"uniqueIndex:idx_org_units_name"`      // 租户ID
+	ModelExtension
+}
+
+//OrgMember assign a user to a department, with the position the user holds, the user inherits the roles
+//granted to them and to the departments and organization above
+type OrgMember struct {
+	UserID     int64     `json:"userId" gorm:"primaryKey;autoIncrement:false"`
+	DeptID     int       `json:"deptId" gorm:"index"`     // 部门编码
+	PositionID int       `json:"positionId" gorm:"index"` // 职位编码, 0 for none
+	TenantID   int       `json:"tenantId"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+//OrgGrant grant a role to the members of an org unit
+type OrgGrant struct {
+	UnitID    int       `json:"unitId" gorm:"primaryKey;autoIncrement:false"`
+	RoleID    int       `json:"roleId" gorm:"primaryKey;autoIncrement:false;index"`
+	CreatedAt time.Time `json:"createdAt"`
+}
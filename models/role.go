@@ -4,10 +4,11 @@ package models
 type Role struct {
 	Uid       string     `json:"uid,omitempty" gorm:"-"`
 	Type      string     `gorm:"size:8" json:"dgraph.type,omitempty"`
-	ID        int        `json:"id,omitempty" gorm:"primary_key;AUTO_INCREMENT"` // 角色编码
-	Key       string     `json:"Key,omitempty" gorm:"size:128;"`                 //角色代码
-	Name      string     `json:"Name,omitempty" gorm:"size:128;"`                // 角色名称
-	Resources []Resource `json:"Resources,omitempty" gorm:"size:128;"`           // 角色拥有的资源
+	ID        int        `json:"id,omitempty" gorm:"primary_key;AUTO_INCREMENT"`                               // 角色编码
+	Key       string     `json:"Key,omitempty" gorm:"size:128;"`                                               //角色代码
+	Name      string     `json:"Name,omitempty" gorm:"size:128;uniqueIndex:idx_roles_tenant_name,priority:2"`  // 角色名称, unique in its tenant
+	Resources []Resource `json:"Resources,omitempty" gorm:"-"`                                                 // 角色拥有的资源
+	TenantID  int        `json:"TenantId,omitempty" gorm:"index;uniqueIndex:idx_roles_tenant_name,priority:1"` // 租户ID
 	ModelExtension
 }
 
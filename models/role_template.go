@@ -0,0 +1,55 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+//RoleTemplate define a role once to instantiate it into tenants or resource scopes,
+//its Version is the version instances are synced to
+type RoleTemplate struct {
+	ID      int    `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Name    string `json:"name" gorm:"size:128;uniqueIndex"` // the names of roles instantiated end with it
+	Key     string `json:"key" gorm:"size:128"`              // key of the roles instantiated
+	Grants  Keys   `json:"grants" gorm:"type:text"`          // keys of the resources granted to the roles instantiated
+	Default bool   `json:"default"`                          // instantiated into every new tenant
+	ModelExtension
+}
+
+//RoleInstance bind a role to the template it is instantiated from
+type RoleInstance struct {
+	RoleID          int       `json:"roleId" gorm:"primaryKey;autoIncrement:false"`
+	TemplateID      int       `json:"templateId" gorm:"index"`
+	TemplateVersion int64     `json:"templateVersion"` // version of the template the role is synced to
+	TenantID        int       `json:"tenantId"`
+	ScopeID         int       `json:"scopeId"` // resource the role is scoped to, 0 for its tenant
+	CreatedAt       time.Time `json:"createdAt"`
+}
+
+//Keys of resources, kept as a json array in sql
+type Keys []string
+
+//Value of keys in sql
+func (k Keys) Value() (driver.Value, error) {
+	if k == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal(k)
+	return string(data), err
+}
+
+//Scan keys from sql
+func (k *Keys) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*k = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, k)
+	case string:
+		return json.Unmarshal([]byte(v), k)
+	}
+	return fmt.Errorf("can not scan %T into keys", value)
+}
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+//SuperTenant administer the platform, it sees and writes entities of every tenant,
+//entities created before tenancy was turned on belong to it
+const SuperTenant = 0
+
+//TenantState of a tenant, requests of a suspended tenant are rejected
+type TenantState int
+
+const (
+	TenantActive TenantState = iota + 1
+	TenantSuspended
+)
+
+//Tenant own the users, roles and resources of its id, they are invisible to other tenants
+type Tenant struct {
+	ID       int            `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Name     string         `json:"name" gorm:"size:128"` // unique among live tenants
+	State    TenantState    `json:"state"`
+	Settings TenantSettings `json:"settings" gorm:"embedded;embeddedPrefix:setting_"`
+	ModelExtension
+}
+
+//TenantSettings apply to the users of a tenant
+type TenantSettings struct {
+	PasswordPolicy  PasswordPolicy `json:"passwordPolicy" gorm:"embedded;embeddedPrefix:password_"`
+	MFARequired     bool           `json:"mfaRequired"`
+	SessionLifetime time.Duration  `json:"sessionLifetime"` // 0 for the default of the platform
+}
+
+//PasswordPolicy of the passwords of users, the zero value accepts any password
+type PasswordPolicy struct {
+	MinLength     int  `json:"minLength"`
+	RequireUpper  bool `json:"requireUpper"`
+	RequireLower  bool `json:"requireLower"`
+	RequireDigit  bool `json:"requireDigit"`
+	RequireSymbol bool `json:"requireSymbol"`
+}
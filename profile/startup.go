@@ -9,23 +9,49 @@
 package profile
 
 import (
+	"context"
+
 	"github.com/micro-community/auth/config"
 	"github.com/micro-community/auth/db"
 	"github.com/micro-community/auth/handler"
+	userpb "github.com/micro-community/auth/protos"
+	orgpb "github.com/micro-community/auth/protos/org"
+	rbacpb "github.com/micro-community/auth/protos/rbac"
+	resourcepb "github.com/micro-community/auth/protos/resource"
+	templatepb "github.com/micro-community/auth/protos/template"
+	tenantpb "github.com/micro-community/auth/protos/tenant"
+	"github.com/micro-community/auth/pubsub"
+	"github.com/micro-community/auth/repository"
 	"github.com/micro-community/auth/repository/dgraph"
+	"github.com/micro-community/auth/repository/file"
 	"github.com/micro-community/auth/repository/memory"
+	"github.com/micro-community/auth/repository/mongo"
+	"github.com/micro-community/auth/repository/sql"
+	"github.com/micro-community/auth/repository/store"
+	"github.com/micro-community/auth/repository/tenant"
 	"github.com/micro-community/auth/service"
+	"github.com/micro-community/auth/wrapper"
 	mservice "github.com/micro/micro/v3/service"
 	"github.com/micro/micro/v3/service/logger"
+	mstore "github.com/micro/micro/v3/service/store"
 	"go.uber.org/dig"
 )
 
 //serviceCollection for DI ,all DI　All Service Instance will be created Here
 type serviceCollection struct {
 	dig.In
-	roleService     *service.RoleService
-	userService     *service.UserService
-	resourceService *service.ResourceService
+	RoleService     *service.RoleService
+	UserService     *service.UserService
+	ResourceService *service.ResourceService
+	RbacService     *service.RbacService
+	ChangeFeed      *service.ChangeFeed
+	Auditor         *service.Auditor
+	Purger          *service.Purger
+	BackupService   *service.BackupService
+	TenantService   *service.TenantService
+	TemplateService *service.RoleTemplateService
+	OrgService      *service.OrgService
+	Tenants         repository.ITenant
 
 	// .... 其他的service
 }
@@ -39,41 +65,164 @@ func BuildingStartupService(srv *mservice.Service, conf *config.Options) {
 	buildDataContext(c, conf)
 
 	//service : aggregate repository service and logic proc to provide service ability for handler
+	c.Provide(service.NewChangeFeed)
+	c.Provide(service.NewAuditor)
 	c.Provide(service.NewUser)
 	c.Provide(service.NewRole)
 	c.Provide(service.NewResource)
+	c.Provide(service.NewRbac)
+	c.Provide(service.NewPurger)
+	c.Provide(service.NewBackup)
+	c.Provide(service.NewTenant)
+	c.Provide(service.NewRoleTemplate)
+	c.Provide(service.NewOrg)
+	c.Provide(func(conf *config.Options) *pubsub.Publisher { return pubsub.NewPublisher(conf.Pubsub) })
+	c.Provide(func() *config.Options { return conf })
 
 	// begin to handle service object instance
-	c.Invoke(func(sc *serviceCollection) {
-
-		if sc == nil {
-			logger.Warnf("no service got in DI Container")
-		}
+	err := c.Invoke(func(sc serviceCollection) {
 
-		srv.Handle(handler.NewRBAC(srv, sc.userService, sc.roleService, sc.resourceService))
-		// handle user
-		srv.Handle(handler.NewUser(srv, sc.userService))
+		// handle rbac, registered by its proto service name for the streaming endpoints
+		rbacpb.RegisterRbacHandler(srv.Server(), handler.NewRBAC(srv, sc.UserService, sc.RoleService, sc.ResourceService, sc.RbacService, sc.ChangeFeed, sc.Auditor, sc.BackupService))
+		// handle user, registered by its proto service name for the streaming endpoints
+		userpb.RegisterUserHandler(srv.Server(), handler.NewUser(srv, sc.UserService))
 		// handle role
-		srv.Handle(handler.NewRole(srv, sc.roleService))
-		// handle resource
-		srv.Handle(handler.NewResource(srv, sc.resourceService))
+		srv.Handle(handler.NewRole(srv, sc.RoleService))
+		// handle resource, registered by its proto service name for other microservices
+		resourcepb.RegisterResourceHandler(srv.Server(), handler.NewResource(srv, sc.ResourceService))
+		// handle tenant lifecycle
+		tenantpb.RegisterTenantHandler(srv.Server(), handler.NewTenant(srv, sc.TenantService))
+		// handle role templates
+		templatepb.RegisterRoleTemplateHandler(srv.Server(), handler.NewRoleTemplate(srv, sc.TemplateService))
+		// handle org trees
+		orgpb.RegisterOrgHandler(srv.Server(), handler.NewOrg(srv, sc.OrgService))
+
+		// reject requests of suspended or unknown tenants, once the tenant of requests is resolved
+		srv.Init(mservice.WrapHandler(wrapper.ActiveTenant(sc.Tenants)))
+
+		// purge deleted entities after their retention
+		go sc.Purger.Run(context.Background())
 
 	})
+	if err != nil {
+		logger.Fatalf("no service got in DI Container: %v", err)
+	}
 
 }
 
 func buildDataContext(c *dig.Container, conf *config.Options) {
 
 	db.BuildDBContext(conf.DBType)
+	if err := db.Migrate(context.Background()); err != nil {
+		logger.Fatalf("migrate %s error: %v", conf.DBType, err)
+	}
 
 	switch conf.DBType {
+	case "mysql", "sqlite":
+		c.Provide(db.DB)
+		c.Provide(sql.NewUserRepository, backend)
+		c.Provide(sql.NewRoleRepository, backend)
+		c.Provide(sql.NewResourceRepository, backend)
+		c.Provide(sql.NewLinkRepository, backend)
+		c.Provide(sql.NewOrgRepository, backend)
+		c.Provide(sql.NewUnitOfWork)
+		c.Provide(sql.NewLogRepository, backend)
+		c.Provide(sql.NewTenantRepository)
+		c.Provide(sql.NewRoleTemplateRepository)
+	case "mongo":
+		c.Provide(db.MDB)
+		c.Provide(mongo.NewUserRepository, backend)
+		c.Provide(mongo.NewRoleRepository, backend)
+		c.Provide(mongo.NewResourceRepository, backend)
+		c.Provide(mongo.NewLinkRepository, backend)
+		c.Provide(mongo.NewOrgRepository, backend)
+		c.Provide(mongo.NewUnitOfWork)
+		c.Provide(mongo.NewLogRepository, backend)
+		c.Provide(mongo.NewTenantRepository)
+		c.Provide(mongo.NewRoleTemplateRepository)
 	case "dgraph":
-		c.Provide(dgraph.NewRBACRepository)
+		c.Provide(dgraph.NewUserRepository, backend)
+		c.Provide(dgraph.NewRoleRepository, backend)
+		c.Provide(dgraph.NewResourceRepository, backend)
+		c.Provide(dgraph.NewLinkRepository, backend)
+		c.Provide(dgraph.NewOrgRepository, backend)
+		c.Provide(dgraph.NewUnitOfWork)
+		c.Provide(dgraph.NewLogRepository, backend)
+		c.Provide(dgraph.NewTenantRepository)
+		c.Provide(dgraph.NewRoleTemplateRepository)
+	case "store":
+		// the store of the runtime, e.g. memory of the dev profile
+		c.Provide(func() mstore.Store { return mstore.DefaultStore })
+		c.Provide(store.NewUserRepository, backend)
+		c.Provide(store.NewRoleRepository, backend)
+		c.Provide(store.NewResourceRepository, backend)
+		c.Provide(store.NewLinkRepository, backend)
+		c.Provide(store.NewOrgRepository, backend)
+		c.Provide(store.NewUnitOfWork)
+		c.Provide(store.NewLogRepository, backend)
+		c.Provide(store.NewTenantRepository)
+		c.Provide(store.NewRoleTemplateRepository)
+	case "file":
+		// the embedded database file for a single binary, compacted in the background
+		c.Provide(db.FDB)
+		c.Provide(file.NewUserRepository, backend)
+		c.Provide(file.NewRoleRepository, backend)
+		c.Provide(file.NewResourceRepository, backend)
+		c.Provide(file.NewLinkRepository, backend)
+		c.Provide(file.NewOrgRepository, backend)
+		c.Provide(file.NewUnitOfWork)
+		c.Provide(file.NewLogRepository, backend)
+		c.Provide(file.NewTenantRepository)
+		c.Provide(file.NewRoleTemplateRepository)
+		go db.FDB().RunCompaction(context.Background(), conf.File.CompactInterval)
 	default:
 		// 默认memory
-		c.Provide(memory.NewUserRepository)
+		c.Provide(memory.NewUserRepository, backend)
+		c.Provide(memory.NewRoleRepository, backend)
+		c.Provide(memory.NewResourceRepository, backend)
+		c.Provide(func(r backendEntities) repository.ILink {
+			return memory.NewLinkRepository(r.Users, r.Roles, r.Resources)
+		}, backend)
+		c.Provide(memory.NewOrgRepository, backend)
+		c.Provide(memory.NewUnitOfWork)
+		c.Provide(memory.NewLogRepository, backend)
+		c.Provide(memory.NewTenantRepository)
+		c.Provide(memory.NewRoleTemplateRepository)
 	}
 
+	// every repository is scoped to the tenant of requests
+	c.Provide(scopeToTenant)
+
 	db.InitCache(conf)
 
 }
+
+//backend name repositories of the database type, they are only provided scoped to tenants
+var backend = dig.Name("backend")
+
+type backendEntities struct {
+	dig.In
+	Users     repository.IUser     `name:"backend"`
+	Roles     repository.IRole     `name:"backend"`
+	Resources repository.IResource `name:"backend"`
+}
+
+type backendRepositories struct {
+	dig.In
+	Users     repository.IUser     `name:"backend"`
+	Roles     repository.IRole     `name:"backend"`
+	Resources repository.IResource `name:"backend"`
+	Links     repository.ILink     `name:"backend"`
+	Logs      repository.ILog      `name:"backend"`
+	Org       repository.IOrg      `name:"backend"`
+}
+
+func scopeToTenant(r backendRepositories) (repository.IUser, repository.IRole, repository.IResource, repository.ILink, repository.ILog,
+	repository.IOrg) {
+	return tenant.NewUserRepository(r.Users),
+		tenant.NewRoleRepository(r.Roles),
+		tenant.NewResourceRepository(r.Resources),
+		tenant.NewLinkRepository(r.Links, r.Users, r.Roles, r.Resources),
+		tenant.NewLogRepository(r.Logs),
+		tenant.NewOrgRepository(r.Org, r.Users, r.Roles)
+}
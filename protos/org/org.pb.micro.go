@@ -0,0 +1,315 @@
+// Code generated by protoc-gen-micro. DO NOT EDIT.
+// source: org.proto
+
+package org
+
+import (
+	fmt "fmt"
+	_ "github.com/envoyproxy/protoc-gen-validate/validate"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+import (
+	context "context"
+	api "github.com/micro/micro/v3/service/api"
+	client "github.com/micro/micro/v3/service/client"
+	server "github.com/micro/micro/v3/service/server"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ api.Endpoint
+var _ context.Context
+var _ client.Option
+var _ server.Option
+
+// Api Endpoints for Org service
+
+func NewOrgEndpoints() []*api.Endpoint {
+	return []*api.Endpoint{}
+}
+
+// Client API for Org service
+
+type OrgService interface {
+	CreateUnit(ctx context.Context, in *CreateUnitRequest, opts ...client.CallOption) (*UnitInfo, error)
+	GetUnit(ctx context.Context, in *GetUnitRequest, opts ...client.CallOption) (*UnitInfo, error)
+	ListUnits(ctx context.Context, in *ListUnitsRequest, opts ...client.CallOption) (*ListUnitsResponse, error)
+	RenameUnit(ctx context.Context, in *RenameUnitRequest, opts ...client.CallOption) (*UnitInfo, error)
+	MoveUnit(ctx context.Context, in *MoveUnitRequest, opts ...client.CallOption) (*UnitInfo, error)
+	MergeUnit(ctx context.Context, in *MergeUnitRequest, opts ...client.CallOption) (*MergeUnitResponse, error)
+	DeleteUnit(ctx context.Context, in *DeleteUnitRequest, opts ...client.CallOption) (*DeleteUnitResponse, error)
+	Assign(ctx context.Context, in *AssignRequest, opts ...client.CallOption) (*MemberInfo, error)
+	Unassign(ctx context.Context, in *UnassignRequest, opts ...client.CallOption) (*UnassignResponse, error)
+	GetMember(ctx context.Context, in *GetMemberRequest, opts ...client.CallOption) (*MemberInfo, error)
+	ListMembers(ctx context.Context, in *ListMembersRequest, opts ...client.CallOption) (*ListMembersResponse, error)
+	GrantRole(ctx context.Context, in *GrantRequest, opts ...client.CallOption) (*GrantResponse, error)
+	RevokeRole(ctx context.Context, in *GrantRequest, opts ...client.CallOption) (*GrantResponse, error)
+	ListGrants(ctx context.Context, in *ListGrantsRequest, opts ...client.CallOption) (*ListGrantsResponse, error)
+}
+
+type orgService struct {
+	c    client.Client
+	name string
+}
+
+func NewOrgService(name string, c client.Client) OrgService {
+	return &orgService{
+		c:    c,
+		name: name,
+	}
+}
+
+func (c *orgService) CreateUnit(ctx context.Context, in *CreateUnitRequest, opts ...client.CallOption) (*UnitInfo, error) {
+	req := c.c.NewRequest(c.name, "Org.CreateUnit", in)
+	out := new(UnitInfo)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orgService) GetUnit(ctx context.Context, in *GetUnitRequest, opts ...client.CallOption) (*UnitInfo, error) {
+	req := c.c.NewRequest(c.name, "Org.GetUnit", in)
+	out := new(UnitInfo)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orgService) ListUnits(ctx context.Context, in *ListUnitsRequest, opts ...client.CallOption) (*ListUnitsResponse, error) {
+	req := c.c.NewRequest(c.name, "Org.ListUnits", in)
+	out := new(ListUnitsResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orgService) RenameUnit(ctx context.Context, in *RenameUnitRequest, opts ...client.CallOption) (*UnitInfo, error) {
+	req := c.c.NewRequest(c.name, "Org.RenameUnit", in)
+	out := new(UnitInfo)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orgService) MoveUnit(ctx context.Context, in *MoveUnitRequest, opts ...client.CallOption) (*UnitInfo, error) {
+	req := c.c.NewRequest(c.name, "Org.MoveUnit", in)
+	out := new(UnitInfo)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orgService) MergeUnit(ctx context.Context, in *MergeUnitRequest, opts ...client.CallOption) (*MergeUnitResponse, error) {
+	req := c.c.NewRequest(c.name, "Org.MergeUnit", in)
+	out := new(MergeUnitResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orgService) DeleteUnit(ctx context.Context, in *DeleteUnitRequest, opts ...client.CallOption) (*DeleteUnitResponse, error) {
+	req := c.c.NewRequest(c.name, "Org.DeleteUnit", in)
+	out := new(DeleteUnitResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orgService) Assign(ctx context.Context, in *AssignRequest, opts ...client.CallOption) (*MemberInfo, error) {
+	req := c.c.NewRequest(c.name, "Org.Assign", in)
+	out := new(MemberInfo)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orgService) Unassign(ctx context.Context, in *UnassignRequest, opts ...client.CallOption) (*UnassignResponse, error) {
+	req := c.c.NewRequest(c.name, "Org.Unassign", in)
+	out := new(UnassignResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orgService) GetMember(ctx context.Context, in *GetMemberRequest, opts ...client.CallOption) (*MemberInfo, error) {
+	req := c.c.NewRequest(c.name, "Org.GetMember", in)
+	out := new(MemberInfo)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orgService) ListMembers(ctx context.Context, in *ListMembersRequest, opts ...client.CallOption) (*ListMembersResponse, error) {
+	req := c.c.NewRequest(c.name, "Org.ListMembers", in)
+	out := new(ListMembersResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orgService) GrantRole(ctx context.Context, in *GrantRequest, opts ...client.CallOption) (*GrantResponse, error) {
+	req := c.c.NewRequest(c.name, "Org.GrantRole", in)
+	out := new(GrantResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orgService) RevokeRole(ctx context.Context, in *GrantRequest, opts ...client.CallOption) (*GrantResponse, error) {
+	req := c.c.NewRequest(c.name, "Org.RevokeRole", in)
+	out := new(GrantResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orgService) ListGrants(ctx context.Context, in *ListGrantsRequest, opts ...client.CallOption) (*ListGrantsResponse, error) {
+	req := c.c.NewRequest(c.name, "Org.ListGrants", in)
+	out := new(ListGrantsResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Org service
+
+type OrgHandler interface {
+	CreateUnit(context.Context, *CreateUnitRequest, *UnitInfo) error
+	GetUnit(context.Context, *GetUnitRequest, *UnitInfo) error
+	ListUnits(context.Context, *ListUnitsRequest, *ListUnitsResponse) error
+	RenameUnit(context.Context, *RenameUnitRequest, *UnitInfo) error
+	MoveUnit(context.Context, *MoveUnitRequest, *UnitInfo) error
+	MergeUnit(context.Context, *MergeUnitRequest, *MergeUnitResponse) error
+	DeleteUnit(context.Context, *DeleteUnitRequest, *DeleteUnitResponse) error
+	Assign(context.Context, *AssignRequest, *MemberInfo) error
+	Unassign(context.Context, *UnassignRequest, *UnassignResponse) error
+	GetMember(context.Context, *GetMemberRequest, *MemberInfo) error
+	ListMembers(context.Context, *ListMembersRequest, *ListMembersResponse) error
+	GrantRole(context.Context, *GrantRequest, *GrantResponse) error
+	RevokeRole(context.Context, *GrantRequest, *GrantResponse) error
+	ListGrants(context.Context, *ListGrantsRequest, *ListGrantsResponse) error
+}
+
+func RegisterOrgHandler(s server.Server, hdlr OrgHandler, opts ...server.HandlerOption) error {
+	type org interface {
+		CreateUnit(ctx context.Context, in *CreateUnitRequest, out *UnitInfo) error
+		GetUnit(ctx context.Context, in *GetUnitRequest, out *UnitInfo) error
+		ListUnits(ctx context.Context, in *ListUnitsRequest, out *ListUnitsResponse) error
+		RenameUnit(ctx context.Context, in *RenameUnitRequest, out *UnitInfo) error
+		MoveUnit(ctx context.Context, in *MoveUnitRequest, out *UnitInfo) error
+		MergeUnit(ctx context.Context, in *MergeUnitRequest, out *MergeUnitResponse) error
+		DeleteUnit(ctx context.Context, in *DeleteUnitRequest, out *DeleteUnitResponse) error
+		Assign(ctx context.Context, in *AssignRequest, out *MemberInfo) error
+		Unassign(ctx context.Context, in *UnassignRequest, out *UnassignResponse) error
+		GetMember(ctx context.Context, in *GetMemberRequest, out *MemberInfo) error
+		ListMembers(ctx context.Context, in *ListMembersRequest, out *ListMembersResponse) error
+		GrantRole(ctx context.Context, in *GrantRequest, out *GrantResponse) error
+		RevokeRole(ctx context.Context, in *GrantRequest, out *GrantResponse) error
+		ListGrants(ctx context.Context, in *ListGrantsRequest, out *ListGrantsResponse) error
+	}
+	type Org struct {
+		org
+	}
+	h := &orgHandler{hdlr}
+	return s.Handle(s.NewHandler(&Org{h}, opts...))
+}
+
+type orgHandler struct {
+	OrgHandler
+}
+
+func (h *orgHandler) CreateUnit(ctx context.Context, in *CreateUnitRequest, out *UnitInfo) error {
+	return h.OrgHandler.CreateUnit(ctx, in, out)
+}
+
+func (h *orgHandler) GetUnit(ctx context.Context, in *GetUnitRequest, out *UnitInfo) error {
+	return h.OrgHandler.GetUnit(ctx, in, out)
+}
+
+func (h *orgHandler) ListUnits(ctx context.Context, in *ListUnitsRequest, out *ListUnitsResponse) error {
+	return h.OrgHandler.ListUnits(ctx, in, out)
+}
+
+func (h *orgHandler) RenameUnit(ctx context.Context, in *RenameUnitRequest, out *UnitInfo) error {
+	return h.OrgHandler.RenameUnit(ctx, in, out)
+}
+
+func (h *orgHandler) MoveUnit(ctx context.Context, in *MoveUnitRequest, out *UnitInfo) error {
+	return h.OrgHandler.MoveUnit(ctx, in, out)
+}
+
+func (h *orgHandler) MergeUnit(ctx context.Context, in *MergeUnitRequest, out *MergeUnitResponse) error {
+	return h.OrgHandler.MergeUnit(ctx, in, out)
+}
+
+func (h *orgHandler) DeleteUnit(ctx context.Context, in *DeleteUnitRequest, out *DeleteUnitResponse) error {
+	return h.OrgHandler.DeleteUnit(ctx, in, out)
+}
+
+func (h *orgHandler) Assign(ctx context.Context, in *AssignRequest, out *MemberInfo) error {
+	return h.OrgHandler.Assign(ctx, in, out)
+}
+
+func (h *orgHandler) Unassign(ctx context.Context, in *UnassignRequest, out *UnassignResponse) error {
+	return h.OrgHandler.Unassign(ctx, in, out)
+}
+
+func (h *orgHandler) GetMember(ctx context.Context, in *GetMemberRequest, out *MemberInfo) error {
+	return h.OrgHandler.GetMember(ctx, in, out)
+}
+
+func (h *orgHandler) ListMembers(ctx context.Context, in *ListMembersRequest, out *ListMembersResponse) error {
+	return h.OrgHandler.ListMembers(ctx, in, out)
+}
+
+func (h *orgHandler) GrantRole(ctx context.Context, in *GrantRequest, out *GrantResponse) error {
+	return h.OrgHandler.GrantRole(ctx, in, out)
+}
+
+func (h *orgHandler) RevokeRole(ctx context.Context, in *GrantRequest, out *GrantResponse) error {
+	return h.OrgHandler.RevokeRole(ctx, in, out)
+}
+
+func (h *orgHandler) ListGrants(ctx context.Context, in *ListGrantsRequest, out *ListGrantsResponse) error {
+	return h.OrgHandler.ListGrants(ctx, in, out)
+}
@@ -0,0 +1,2774 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: org.proto
+
+package org
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/golang/protobuf/ptypes"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = ptypes.DynamicAny{}
+)
+
+// define the regex for a UUID once up-front
+var _org_uuidPattern = regexp.MustCompile("^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$")
+
+// Validate checks the field values on UnitInfo with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *UnitInfo) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UnitInfo with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in UnitInfoMultiError, or nil
+// if none found.
+func (m *UnitInfo) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UnitInfo) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Kind
+
+	// no validation rules for Name
+
+	// no validation rules for ParentId
+
+	// no validation rules for TenantId
+
+	// no validation rules for CreatedAt
+
+	// no validation rules for Version
+
+	if len(errors) > 0 {
+		return UnitInfoMultiError(errors)
+	}
+	return nil
+}
+
+// UnitInfoMultiError is an error wrapping multiple validation errors returned
+// by UnitInfo.ValidateAll() if the designated constraints aren't met.
+type UnitInfoMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UnitInfoMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UnitInfoMultiError) AllErrors() []error { return m }
+
+// UnitInfoValidationError is the validation error returned by
+// UnitInfo.Validate if the designated constraints aren't met.
+type UnitInfoValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UnitInfoValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UnitInfoValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UnitInfoValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UnitInfoValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UnitInfoValidationError) ErrorName() string { return "UnitInfoValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UnitInfoValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUnitInfo.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UnitInfoValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UnitInfoValidationError{}
+
+// Validate checks the field values on CreateUnitRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *CreateUnitRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateUnitRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateUnitRequestMultiError, or nil if none found.
+func (m *CreateUnitRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateUnitRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if _, ok := _CreateUnitRequest_Kind_NotInLookup[m.GetKind()]; ok {
+		err := CreateUnitRequestValidationError{
+			field:  "Kind",
+			reason: "value must not be in list [0]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := UnitKind_name[int32(m.GetKind())]; !ok {
+		err := CreateUnitRequestValidationError{
+			field:  "Kind",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if l := utf8.RuneCountInString(m.GetName()); l < 1 || l > 128 {
+		err := CreateUnitRequestValidationError{
+			field:  "Name",
+			reason: "value length must be between 1 and 128 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetParentId() < 0 {
+		err := CreateUnitRequestValidationError{
+			field:  "ParentId",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return CreateUnitRequestMultiError(errors)
+	}
+	return nil
+}
+
+// CreateUnitRequestMultiError is an error wrapping multiple validation errors
+// returned by CreateUnitRequest.ValidateAll() if the designated constraints
+// aren't met.
+type CreateUnitRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateUnitRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateUnitRequestMultiError) AllErrors() []error { return m }
+
+// CreateUnitRequestValidationError is the validation error returned by
+// CreateUnitRequest.Validate if the designated constraints aren't met.
+type CreateUnitRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateUnitRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateUnitRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateUnitRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateUnitRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateUnitRequestValidationError) ErrorName() string {
+	return "CreateUnitRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateUnitRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateUnitRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateUnitRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateUnitRequestValidationError{}
+
+var _CreateUnitRequest_Kind_NotInLookup = map[UnitKind]struct{}{
+	0: {},
+}
+
+// Validate checks the field values on GetUnitRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *GetUnitRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetUnitRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in GetUnitRequestMultiError,
+// or nil if none found.
+func (m *GetUnitRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetUnitRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetId() <= 0 {
+		err := GetUnitRequestValidationError{
+			field:  "Id",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetUnitRequestMultiError(errors)
+	}
+	return nil
+}
+
+// GetUnitRequestMultiError is an error wrapping multiple validation errors
+// returned by GetUnitRequest.ValidateAll() if the designated constraints
+// aren't met.
+type GetUnitRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetUnitRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetUnitRequestMultiError) AllErrors() []error { return m }
+
+// GetUnitRequestValidationError is the validation error returned by
+// GetUnitRequest.Validate if the designated constraints aren't met.
+type GetUnitRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetUnitRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetUnitRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetUnitRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetUnitRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetUnitRequestValidationError) ErrorName() string { return "GetUnitRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GetUnitRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetUnitRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetUnitRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetUnitRequestValidationError{}
+
+// Validate checks the field values on ListUnitsRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ListUnitsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListUnitsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListUnitsRequestMultiError, or nil if none found.
+func (m *ListUnitsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListUnitsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return ListUnitsRequestMultiError(errors)
+	}
+	return nil
+}
+
+// ListUnitsRequestMultiError is an error wrapping multiple validation errors
+// returned by ListUnitsRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ListUnitsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListUnitsRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListUnitsRequestMultiError) AllErrors() []error { return m }
+
+// ListUnitsRequestValidationError is the validation error returned by
+// ListUnitsRequest.Validate if the designated constraints aren't met.
+type ListUnitsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListUnitsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListUnitsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListUnitsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListUnitsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListUnitsRequestValidationError) ErrorName() string { return "ListUnitsRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ListUnitsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListUnitsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListUnitsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListUnitsRequestValidationError{}
+
+// Validate checks the field values on ListUnitsResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ListUnitsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListUnitsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListUnitsResponseMultiError, or nil if none found.
+func (m *ListUnitsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListUnitsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetUnits() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListUnitsResponseValidationError{
+						field:  fmt.Sprintf("Units[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListUnitsResponseValidationError{
+						field:  fmt.Sprintf("Units[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListUnitsResponseValidationError{
+					field:  fmt.Sprintf("Units[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListUnitsResponseMultiError(errors)
+	}
+	return nil
+}
+
+// ListUnitsResponseMultiError is an error wrapping multiple validation errors
+// returned by ListUnitsResponse.ValidateAll() if the designated constraints
+// aren't met.
+type ListUnitsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListUnitsResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListUnitsResponseMultiError) AllErrors() []error { return m }
+
+// ListUnitsResponseValidationError is the validation error returned by
+// ListUnitsResponse.Validate if the designated constraints aren't met.
+type ListUnitsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListUnitsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListUnitsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListUnitsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListUnitsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListUnitsResponseValidationError) ErrorName() string {
+	return "ListUnitsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListUnitsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListUnitsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListUnitsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListUnitsResponseValidationError{}
+
+// Validate checks the field values on RenameUnitRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *RenameUnitRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RenameUnitRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RenameUnitRequestMultiError, or nil if none found.
+func (m *RenameUnitRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RenameUnitRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetId() <= 0 {
+		err := RenameUnitRequestValidationError{
+			field:  "Id",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if l := utf8.RuneCountInString(m.GetName()); l < 1 || l > 128 {
+		err := RenameUnitRequestValidationError{
+			field:  "Name",
+			reason: "value length must be between 1 and 128 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetVersion() <= 0 {
+		err := RenameUnitRequestValidationError{
+			field:  "Version",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return RenameUnitRequestMultiError(errors)
+	}
+	return nil
+}
+
+// RenameUnitRequestMultiError is an error wrapping multiple validation errors
+// returned by RenameUnitRequest.ValidateAll() if the designated constraints
+// aren't met.
+type RenameUnitRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RenameUnitRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RenameUnitRequestMultiError) AllErrors() []error { return m }
+
+// RenameUnitRequestValidationError is the validation error returned by
+// RenameUnitRequest.Validate if the designated constraints aren't met.
+type RenameUnitRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RenameUnitRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RenameUnitRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RenameUnitRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RenameUnitRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RenameUnitRequestValidationError) ErrorName() string {
+	return "RenameUnitRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RenameUnitRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRenameUnitRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RenameUnitRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RenameUnitRequestValidationError{}
+
+// Validate checks the field values on MoveUnitRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *MoveUnitRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on MoveUnitRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// MoveUnitRequestMultiError, or nil if none found.
+func (m *MoveUnitRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *MoveUnitRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetId() <= 0 {
+		err := MoveUnitRequestValidationError{
+			field:  "Id",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetParentId() <= 0 {
+		err := MoveUnitRequestValidationError{
+			field:  "ParentId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetVersion() <= 0 {
+		err := MoveUnitRequestValidationError{
+			field:  "Version",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return MoveUnitRequestMultiError(errors)
+	}
+	return nil
+}
+
+// MoveUnitRequestMultiError is an error wrapping multiple validation errors
+// returned by MoveUnitRequest.ValidateAll() if the designated constraints
+// aren't met.
+type MoveUnitRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m MoveUnitRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m MoveUnitRequestMultiError) AllErrors() []error { return m }
+
+// MoveUnitRequestValidationError is the validation error returned by
+// MoveUnitRequest.Validate if the designated constraints aren't met.
+type MoveUnitRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e MoveUnitRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e MoveUnitRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e MoveUnitRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e MoveUnitRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e MoveUnitRequestValidationError) ErrorName() string { return "MoveUnitRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e MoveUnitRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sMoveUnitRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = MoveUnitRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = MoveUnitRequestValidationError{}
+
+// Validate checks the field values on MergeUnitRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *MergeUnitRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on MergeUnitRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// MergeUnitRequestMultiError, or nil if none found.
+func (m *MergeUnitRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *MergeUnitRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetId() <= 0 {
+		err := MergeUnitRequestValidationError{
+			field:  "Id",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetIntoId() <= 0 {
+		err := MergeUnitRequestValidationError{
+			field:  "IntoId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetVersion() <= 0 {
+		err := MergeUnitRequestValidationError{
+			field:  "Version",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return MergeUnitRequestMultiError(errors)
+	}
+	return nil
+}
+
+// MergeUnitRequestMultiError is an error wrapping multiple validation errors
+// returned by MergeUnitRequest.ValidateAll() if the designated constraints
+// aren't met.
+type MergeUnitRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m MergeUnitRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m MergeUnitRequestMultiError) AllErrors() []error { return m }
+
+// MergeUnitRequestValidationError is the validation error returned by
+// MergeUnitRequest.Validate if the designated constraints aren't met.
+type MergeUnitRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e MergeUnitRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e MergeUnitRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e MergeUnitRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e MergeUnitRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e MergeUnitRequestValidationError) ErrorName() string { return "MergeUnitRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e MergeUnitRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sMergeUnitRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = MergeUnitRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = MergeUnitRequestValidationError{}
+
+// Validate checks the field values on MergeUnitResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *MergeUnitResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on MergeUnitResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// MergeUnitResponseMultiError, or nil if none found.
+func (m *MergeUnitResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *MergeUnitResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetInto()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, MergeUnitResponseValidationError{
+					field:  "Into",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, MergeUnitResponseValidationError{
+					field:  "Into",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetInto()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return MergeUnitResponseValidationError{
+				field:  "Into",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Units
+
+	// no validation rules for Members
+
+	// no validation rules for Grants
+
+	if len(errors) > 0 {
+		return MergeUnitResponseMultiError(errors)
+	}
+	return nil
+}
+
+// MergeUnitResponseMultiError is an error wrapping multiple validation errors
+// returned by MergeUnitResponse.ValidateAll() if the designated constraints
+// aren't met.
+type MergeUnitResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m MergeUnitResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m MergeUnitResponseMultiError) AllErrors() []error { return m }
+
+// MergeUnitResponseValidationError is the validation error returned by
+// MergeUnitResponse.Validate if the designated constraints aren't met.
+type MergeUnitResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e MergeUnitResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e MergeUnitResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e MergeUnitResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e MergeUnitResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e MergeUnitResponseValidationError) ErrorName() string {
+	return "MergeUnitResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e MergeUnitResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sMergeUnitResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = MergeUnitResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = MergeUnitResponseValidationError{}
+
+// Validate checks the field values on DeleteUnitRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *DeleteUnitRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DeleteUnitRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DeleteUnitRequestMultiError, or nil if none found.
+func (m *DeleteUnitRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DeleteUnitRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetId() <= 0 {
+		err := DeleteUnitRequestValidationError{
+			field:  "Id",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetVersion() <= 0 {
+		err := DeleteUnitRequestValidationError{
+			field:  "Version",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return DeleteUnitRequestMultiError(errors)
+	}
+	return nil
+}
+
+// DeleteUnitRequestMultiError is an error wrapping multiple validation errors
+// returned by DeleteUnitRequest.ValidateAll() if the designated constraints
+// aren't met.
+type DeleteUnitRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DeleteUnitRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DeleteUnitRequestMultiError) AllErrors() []error { return m }
+
+// DeleteUnitRequestValidationError is the validation error returned by
+// DeleteUnitRequest.Validate if the designated constraints aren't met.
+type DeleteUnitRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DeleteUnitRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DeleteUnitRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DeleteUnitRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DeleteUnitRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DeleteUnitRequestValidationError) ErrorName() string {
+	return "DeleteUnitRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DeleteUnitRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDeleteUnitRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DeleteUnitRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DeleteUnitRequestValidationError{}
+
+// Validate checks the field values on DeleteUnitResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DeleteUnitResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DeleteUnitResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DeleteUnitResponseMultiError, or nil if none found.
+func (m *DeleteUnitResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DeleteUnitResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return DeleteUnitResponseMultiError(errors)
+	}
+	return nil
+}
+
+// DeleteUnitResponseMultiError is an error wrapping multiple validation errors
+// returned by DeleteUnitResponse.ValidateAll() if the designated constraints
+// aren't met.
+type DeleteUnitResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DeleteUnitResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DeleteUnitResponseMultiError) AllErrors() []error { return m }
+
+// DeleteUnitResponseValidationError is the validation error returned by
+// DeleteUnitResponse.Validate if the designated constraints aren't met.
+type DeleteUnitResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DeleteUnitResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DeleteUnitResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DeleteUnitResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DeleteUnitResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DeleteUnitResponseValidationError) ErrorName() string {
+	return "DeleteUnitResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DeleteUnitResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDeleteUnitResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DeleteUnitResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DeleteUnitResponseValidationError{}
+
+// Validate checks the field values on MemberInfo with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *MemberInfo) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on MemberInfo with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in MemberInfoMultiError, or
+// nil if none found.
+func (m *MemberInfo) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *MemberInfo) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserId
+
+	// no validation rules for DeptId
+
+	// no validation rules for PositionId
+
+	// no validation rules for CreatedAt
+
+	if len(errors) > 0 {
+		return MemberInfoMultiError(errors)
+	}
+	return nil
+}
+
+// MemberInfoMultiError is an error wrapping multiple validation errors
+// returned by MemberInfo.ValidateAll() if the designated constraints aren't met.
+type MemberInfoMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m MemberInfoMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m MemberInfoMultiError) AllErrors() []error { return m }
+
+// MemberInfoValidationError is the validation error returned by
+// MemberInfo.Validate if the designated constraints aren't met.
+type MemberInfoValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e MemberInfoValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e MemberInfoValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e MemberInfoValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e MemberInfoValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e MemberInfoValidationError) ErrorName() string { return "MemberInfoValidationError" }
+
+// Error satisfies the builtin error interface
+func (e MemberInfoValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sMemberInfo.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = MemberInfoValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = MemberInfoValidationError{}
+
+// Validate checks the field values on AssignRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *AssignRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AssignRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in AssignRequestMultiError, or
+// nil if none found.
+func (m *AssignRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AssignRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetUserId() <= 0 {
+		err := AssignRequestValidationError{
+			field:  "UserId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetDeptId() <= 0 {
+		err := AssignRequestValidationError{
+			field:  "DeptId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetPositionId() < 0 {
+		err := AssignRequestValidationError{
+			field:  "PositionId",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return AssignRequestMultiError(errors)
+	}
+	return nil
+}
+
+// AssignRequestMultiError is an error wrapping multiple validation errors
+// returned by AssignRequest.ValidateAll() if the designated constraints
+// aren't met.
+type AssignRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AssignRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AssignRequestMultiError) AllErrors() []error { return m }
+
+// AssignRequestValidationError is the validation error returned by
+// AssignRequest.Validate if the designated constraints aren't met.
+type AssignRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AssignRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AssignRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AssignRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AssignRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AssignRequestValidationError) ErrorName() string { return "AssignRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AssignRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAssignRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AssignRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AssignRequestValidationError{}
+
+// Validate checks the field values on UnassignRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *UnassignRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UnassignRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UnassignRequestMultiError, or nil if none found.
+func (m *UnassignRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UnassignRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetUserId() <= 0 {
+		err := UnassignRequestValidationError{
+			field:  "UserId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return UnassignRequestMultiError(errors)
+	}
+	return nil
+}
+
+// UnassignRequestMultiError is an error wrapping multiple validation errors
+// returned by UnassignRequest.ValidateAll() if the designated constraints
+// aren't met.
+type UnassignRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UnassignRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UnassignRequestMultiError) AllErrors() []error { return m }
+
+// UnassignRequestValidationError is the validation error returned by
+// UnassignRequest.Validate if the designated constraints aren't met.
+type UnassignRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UnassignRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UnassignRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UnassignRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UnassignRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UnassignRequestValidationError) ErrorName() string { return "UnassignRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UnassignRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUnassignRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UnassignRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UnassignRequestValidationError{}
+
+// Validate checks the field values on UnassignResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *UnassignResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UnassignResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UnassignResponseMultiError, or nil if none found.
+func (m *UnassignResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UnassignResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return UnassignResponseMultiError(errors)
+	}
+	return nil
+}
+
+// UnassignResponseMultiError is an error wrapping multiple validation errors
+// returned by UnassignResponse.ValidateAll() if the designated constraints
+// aren't met.
+type UnassignResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UnassignResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UnassignResponseMultiError) AllErrors() []error { return m }
+
+// UnassignResponseValidationError is the validation error returned by
+// UnassignResponse.Validate if the designated constraints aren't met.
+type UnassignResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UnassignResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UnassignResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UnassignResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UnassignResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UnassignResponseValidationError) ErrorName() string { return "UnassignResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UnassignResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUnassignResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UnassignResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UnassignResponseValidationError{}
+
+// Validate checks the field values on GetMemberRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *GetMemberRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetMemberRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetMemberRequestMultiError, or nil if none found.
+func (m *GetMemberRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetMemberRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetUserId() <= 0 {
+		err := GetMemberRequestValidationError{
+			field:  "UserId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetMemberRequestMultiError(errors)
+	}
+	return nil
+}
+
+// GetMemberRequestMultiError is an error wrapping multiple validation errors
+// returned by GetMemberRequest.ValidateAll() if the designated constraints
+// aren't met.
+type GetMemberRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetMemberRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetMemberRequestMultiError) AllErrors() []error { return m }
+
+// GetMemberRequestValidationError is the validation error returned by
+// GetMemberRequest.Validate if the designated constraints aren't met.
+type GetMemberRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetMemberRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetMemberRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetMemberRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetMemberRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetMemberRequestValidationError) ErrorName() string { return "GetMemberRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GetMemberRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetMemberRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetMemberRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetMemberRequestValidationError{}
+
+// Validate checks the field values on ListMembersRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListMembersRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListMembersRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListMembersRequestMultiError, or nil if none found.
+func (m *ListMembersRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListMembersRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetUnitId() <= 0 {
+		err := ListMembersRequestValidationError{
+			field:  "UnitId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ListMembersRequestMultiError(errors)
+	}
+	return nil
+}
+
+// ListMembersRequestMultiError is an error wrapping multiple validation errors
+// returned by ListMembersRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ListMembersRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListMembersRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListMembersRequestMultiError) AllErrors() []error { return m }
+
+// ListMembersRequestValidationError is the validation error returned by
+// ListMembersRequest.Validate if the designated constraints aren't met.
+type ListMembersRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListMembersRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListMembersRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListMembersRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListMembersRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListMembersRequestValidationError) ErrorName() string {
+	return "ListMembersRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListMembersRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListMembersRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListMembersRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListMembersRequestValidationError{}
+
+// Validate checks the field values on ListMembersResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListMembersResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListMembersResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListMembersResponseMultiError, or nil if none found.
+func (m *ListMembersResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListMembersResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetMembers() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListMembersResponseValidationError{
+						field:  fmt.Sprintf("Members[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListMembersResponseValidationError{
+						field:  fmt.Sprintf("Members[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListMembersResponseValidationError{
+					field:  fmt.Sprintf("Members[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListMembersResponseMultiError(errors)
+	}
+	return nil
+}
+
+// ListMembersResponseMultiError is an error wrapping multiple validation
+// errors returned by ListMembersResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ListMembersResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListMembersResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListMembersResponseMultiError) AllErrors() []error { return m }
+
+// ListMembersResponseValidationError is the validation error returned by
+// ListMembersResponse.Validate if the designated constraints aren't met.
+type ListMembersResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListMembersResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListMembersResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListMembersResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListMembersResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListMembersResponseValidationError) ErrorName() string {
+	return "ListMembersResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListMembersResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListMembersResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListMembersResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListMembersResponseValidationError{}
+
+// Validate checks the field values on GrantRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *GrantRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GrantRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in GrantRequestMultiError, or
+// nil if none found.
+func (m *GrantRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GrantRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetUnitId() <= 0 {
+		err := GrantRequestValidationError{
+			field:  "UnitId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetRoleId() <= 0 {
+		err := GrantRequestValidationError{
+			field:  "RoleId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GrantRequestMultiError(errors)
+	}
+	return nil
+}
+
+// GrantRequestMultiError is an error wrapping multiple validation errors
+// returned by GrantRequest.ValidateAll() if the designated constraints aren't met.
+type GrantRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GrantRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GrantRequestMultiError) AllErrors() []error { return m }
+
+// GrantRequestValidationError is the validation error returned by
+// GrantRequest.Validate if the designated constraints aren't met.
+type GrantRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GrantRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GrantRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GrantRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GrantRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GrantRequestValidationError) ErrorName() string { return "GrantRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GrantRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGrantRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GrantRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GrantRequestValidationError{}
+
+// Validate checks the field values on GrantResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *GrantResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GrantResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in GrantResponseMultiError, or
+// nil if none found.
+func (m *GrantResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GrantResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return GrantResponseMultiError(errors)
+	}
+	return nil
+}
+
+// GrantResponseMultiError is an error wrapping multiple validation errors
+// returned by GrantResponse.ValidateAll() if the designated constraints
+// aren't met.
+type GrantResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GrantResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GrantResponseMultiError) AllErrors() []error { return m }
+
+// GrantResponseValidationError is the validation error returned by
+// GrantResponse.Validate if the designated constraints aren't met.
+type GrantResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GrantResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GrantResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GrantResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GrantResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GrantResponseValidationError) ErrorName() string { return "GrantResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GrantResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGrantResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GrantResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GrantResponseValidationError{}
+
+// Validate checks the field values on ListGrantsRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ListGrantsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListGrantsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListGrantsRequestMultiError, or nil if none found.
+func (m *ListGrantsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListGrantsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetUnitId() <= 0 {
+		err := ListGrantsRequestValidationError{
+			field:  "UnitId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ListGrantsRequestMultiError(errors)
+	}
+	return nil
+}
+
+// ListGrantsRequestMultiError is an error wrapping multiple validation errors
+// returned by ListGrantsRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ListGrantsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListGrantsRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListGrantsRequestMultiError) AllErrors() []error { return m }
+
+// ListGrantsRequestValidationError is the validation error returned by
+// ListGrantsRequest.Validate if the designated constraints aren't met.
+type ListGrantsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListGrantsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListGrantsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListGrantsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListGrantsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListGrantsRequestValidationError) ErrorName() string {
+	return "ListGrantsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListGrantsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListGrantsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListGrantsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListGrantsRequestValidationError{}
+
+// Validate checks the field values on GrantedRole with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *GrantedRole) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GrantedRole with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in GrantedRoleMultiError, or
+// nil if none found.
+func (m *GrantedRole) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GrantedRole) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Key
+
+	// no validation rules for Name
+
+	if len(errors) > 0 {
+		return GrantedRoleMultiError(errors)
+	}
+	return nil
+}
+
+// GrantedRoleMultiError is an error wrapping multiple validation errors
+// returned by GrantedRole.ValidateAll() if the designated constraints aren't met.
+type GrantedRoleMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GrantedRoleMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GrantedRoleMultiError) AllErrors() []error { return m }
+
+// GrantedRoleValidationError is the validation error returned by
+// GrantedRole.Validate if the designated constraints aren't met.
+type GrantedRoleValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GrantedRoleValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GrantedRoleValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GrantedRoleValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GrantedRoleValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GrantedRoleValidationError) ErrorName() string { return "GrantedRoleValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GrantedRoleValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGrantedRole.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GrantedRoleValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GrantedRoleValidationError{}
+
+// Validate checks the field values on ListGrantsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListGrantsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListGrantsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListGrantsResponseMultiError, or nil if none found.
+func (m *ListGrantsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListGrantsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetRoles() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListGrantsResponseValidationError{
+						field:  fmt.Sprintf("Roles[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListGrantsResponseValidationError{
+						field:  fmt.Sprintf("Roles[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListGrantsResponseValidationError{
+					field:  fmt.Sprintf("Roles[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListGrantsResponseMultiError(errors)
+	}
+	return nil
+}
+
+// ListGrantsResponseMultiError is an error wrapping multiple validation errors
+// returned by ListGrantsResponse.ValidateAll() if the designated constraints
+// aren't met.
+type ListGrantsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListGrantsResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListGrantsResponseMultiError) AllErrors() []error { return m }
+
+// ListGrantsResponseValidationError is the validation error returned by
+// ListGrantsResponse.Validate if the designated constraints aren't met.
+type ListGrantsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListGrantsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListGrantsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListGrantsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListGrantsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListGrantsResponseValidationError) ErrorName() string {
+	return "ListGrantsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListGrantsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListGrantsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListGrantsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListGrantsResponseValidationError{}
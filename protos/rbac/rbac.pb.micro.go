@@ -56,6 +56,16 @@ type RbacService interface {
 	UnlinkRoleResource(ctx context.Context, in *LinkRequest, opts ...client.CallOption) (*Response, error)
 	AddResource(ctx context.Context, in *Resource, opts ...client.CallOption) (*Response, error)
 	RemoveResource(ctx context.Context, in *Request, opts ...client.CallOption) (*Response, error)
+	// Watch streams changes of users, roles and resources after a revision
+	Watch(ctx context.Context, in *WatchRequest, opts ...client.CallOption) (Rbac_WatchService, error)
+	// QueryLogs return logs of mutations for auditors, newest first
+	QueryLogs(ctx context.Context, in *LogsRequest, opts ...client.CallOption) (*Logs, error)
+	// Backup streams a consistent backup of users, roles, resources, links and logs in chunks
+	Backup(ctx context.Context, in *BackupRequest, opts ...client.CallOption) (Rbac_BackupService, error)
+	// Restore a backup streamed in chunks to the empty database
+	Restore(ctx context.Context, opts ...client.CallOption) (Rbac_RestoreService, error)
+	// VerifyBackup restore a backup streamed in chunks to memory and return the differences
+	VerifyBackup(ctx context.Context, opts ...client.CallOption) (Rbac_VerifyBackupService, error)
 }
 
 type rbacService struct {
@@ -200,6 +210,206 @@ func (c *rbacService) RemoveResource(ctx context.Context, in *Request, opts ...c
 	return out, nil
 }
 
+func (c *rbacService) Watch(ctx context.Context, in *WatchRequest, opts ...client.CallOption) (Rbac_WatchService, error) {
+	req := c.c.NewRequest(c.name, "Rbac.Watch", &WatchRequest{})
+	stream, err := c.c.Stream(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.Send(in); err != nil {
+		return nil, err
+	}
+	return &rbacServiceWatch{stream}, nil
+}
+
+type Rbac_WatchService interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Recv() (*WatchEvent, error)
+}
+
+type rbacServiceWatch struct {
+	stream client.Stream
+}
+
+func (x *rbacServiceWatch) Close() error {
+	return x.stream.Close()
+}
+
+func (x *rbacServiceWatch) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *rbacServiceWatch) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *rbacServiceWatch) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *rbacServiceWatch) Recv() (*WatchEvent, error) {
+	m := new(WatchEvent)
+	err := x.stream.Recv(m)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *rbacService) QueryLogs(ctx context.Context, in *LogsRequest, opts ...client.CallOption) (*Logs, error) {
+	req := c.c.NewRequest(c.name, "Rbac.QueryLogs", in)
+	out := new(Logs)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rbacService) Backup(ctx context.Context, in *BackupRequest, opts ...client.CallOption) (Rbac_BackupService, error) {
+	req := c.c.NewRequest(c.name, "Rbac.Backup", &BackupRequest{})
+	stream, err := c.c.Stream(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.Send(in); err != nil {
+		return nil, err
+	}
+	return &rbacServiceBackup{stream}, nil
+}
+
+type Rbac_BackupService interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Recv() (*BackupChunk, error)
+}
+
+type rbacServiceBackup struct {
+	stream client.Stream
+}
+
+func (x *rbacServiceBackup) Close() error {
+	return x.stream.Close()
+}
+
+func (x *rbacServiceBackup) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *rbacServiceBackup) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *rbacServiceBackup) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *rbacServiceBackup) Recv() (*BackupChunk, error) {
+	m := new(BackupChunk)
+	err := x.stream.Recv(m)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *rbacService) Restore(ctx context.Context, opts ...client.CallOption) (Rbac_RestoreService, error) {
+	req := c.c.NewRequest(c.name, "Rbac.Restore", &BackupChunk{})
+	stream, err := c.c.Stream(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &rbacServiceRestore{stream}, nil
+}
+
+type Rbac_RestoreService interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	CloseAndRecv() (*RestoreResult, error)
+	Send(*BackupChunk) error
+}
+
+type rbacServiceRestore struct {
+	stream client.Stream
+}
+
+func (x *rbacServiceRestore) CloseAndRecv() (*RestoreResult, error) {
+	if err := x.stream.Close(); err != nil {
+		return nil, err
+	}
+	r := new(RestoreResult)
+	err := x.RecvMsg(r)
+	return r, err
+}
+
+func (x *rbacServiceRestore) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *rbacServiceRestore) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *rbacServiceRestore) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *rbacServiceRestore) Send(m *BackupChunk) error {
+	return x.stream.Send(m)
+}
+
+func (c *rbacService) VerifyBackup(ctx context.Context, opts ...client.CallOption) (Rbac_VerifyBackupService, error) {
+	req := c.c.NewRequest(c.name, "Rbac.VerifyBackup", &BackupChunk{})
+	stream, err := c.c.Stream(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &rbacServiceVerifyBackup{stream}, nil
+}
+
+type Rbac_VerifyBackupService interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	CloseAndRecv() (*RestoreResult, error)
+	Send(*BackupChunk) error
+}
+
+type rbacServiceVerifyBackup struct {
+	stream client.Stream
+}
+
+func (x *rbacServiceVerifyBackup) CloseAndRecv() (*RestoreResult, error) {
+	if err := x.stream.Close(); err != nil {
+		return nil, err
+	}
+	r := new(RestoreResult)
+	err := x.RecvMsg(r)
+	return r, err
+}
+
+func (x *rbacServiceVerifyBackup) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *rbacServiceVerifyBackup) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *rbacServiceVerifyBackup) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *rbacServiceVerifyBackup) Send(m *BackupChunk) error {
+	return x.stream.Send(m)
+}
+
 // Server API for Rbac service
 
 type RbacHandler interface {
@@ -216,6 +426,16 @@ type RbacHandler interface {
 	UnlinkRoleResource(context.Context, *LinkRequest, *Response) error
 	AddResource(context.Context, *Resource, *Response) error
 	RemoveResource(context.Context, *Request, *Response) error
+	// Watch streams changes of users, roles and resources after a revision
+	Watch(context.Context, *WatchRequest, Rbac_WatchStream) error
+	// QueryLogs return logs of mutations for auditors, newest first
+	QueryLogs(context.Context, *LogsRequest, *Logs) error
+	// Backup streams a consistent backup of users, roles, resources, links and logs in chunks
+	Backup(context.Context, *BackupRequest, Rbac_BackupStream) error
+	// Restore a backup streamed in chunks to the empty database
+	Restore(context.Context, Rbac_RestoreStream) error
+	// VerifyBackup restore a backup streamed in chunks to memory and return the differences
+	VerifyBackup(context.Context, Rbac_VerifyBackupStream) error
 }
 
 func RegisterRbacHandler(s server.Server, hdlr RbacHandler, opts ...server.HandlerOption) error {
@@ -233,6 +453,11 @@ func RegisterRbacHandler(s server.Server, hdlr RbacHandler, opts ...server.Handl
 		UnlinkRoleResource(ctx context.Context, in *LinkRequest, out *Response) error
 		AddResource(ctx context.Context, in *Resource, out *Response) error
 		RemoveResource(ctx context.Context, in *Request, out *Response) error
+		Watch(ctx context.Context, stream server.Stream) error
+		QueryLogs(ctx context.Context, in *LogsRequest, out *Logs) error
+		Backup(ctx context.Context, stream server.Stream) error
+		Restore(ctx context.Context, stream server.Stream) error
+		VerifyBackup(ctx context.Context, stream server.Stream) error
 	}
 	type Rbac struct {
 		rbac
@@ -296,3 +521,173 @@ func (h *rbacHandler) AddResource(ctx context.Context, in *Resource, out *Respon
 func (h *rbacHandler) RemoveResource(ctx context.Context, in *Request, out *Response) error {
 	return h.RbacHandler.RemoveResource(ctx, in, out)
 }
+
+func (h *rbacHandler) Watch(ctx context.Context, stream server.Stream) error {
+	m := new(WatchRequest)
+	if err := stream.Recv(m); err != nil {
+		return err
+	}
+	return h.RbacHandler.Watch(ctx, m, &rbacWatchStream{stream})
+}
+
+type Rbac_WatchStream interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Send(*WatchEvent) error
+}
+
+type rbacWatchStream struct {
+	stream server.Stream
+}
+
+func (x *rbacWatchStream) Close() error {
+	return x.stream.Close()
+}
+
+func (x *rbacWatchStream) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *rbacWatchStream) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *rbacWatchStream) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *rbacWatchStream) Send(m *WatchEvent) error {
+	return x.stream.Send(m)
+}
+
+func (h *rbacHandler) QueryLogs(ctx context.Context, in *LogsRequest, out *Logs) error {
+	return h.RbacHandler.QueryLogs(ctx, in, out)
+}
+
+func (h *rbacHandler) Backup(ctx context.Context, stream server.Stream) error {
+	m := new(BackupRequest)
+	if err := stream.Recv(m); err != nil {
+		return err
+	}
+	return h.RbacHandler.Backup(ctx, m, &rbacBackupStream{stream})
+}
+
+type Rbac_BackupStream interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Send(*BackupChunk) error
+}
+
+type rbacBackupStream struct {
+	stream server.Stream
+}
+
+func (x *rbacBackupStream) Close() error {
+	return x.stream.Close()
+}
+
+func (x *rbacBackupStream) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *rbacBackupStream) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *rbacBackupStream) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *rbacBackupStream) Send(m *BackupChunk) error {
+	return x.stream.Send(m)
+}
+
+func (h *rbacHandler) Restore(ctx context.Context, stream server.Stream) error {
+	return h.RbacHandler.Restore(ctx, &rbacRestoreStream{stream})
+}
+
+type Rbac_RestoreStream interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	SendAndClose(*RestoreResult) error
+	Recv() (*BackupChunk, error)
+}
+
+type rbacRestoreStream struct {
+	stream server.Stream
+}
+
+func (x *rbacRestoreStream) SendAndClose(in *RestoreResult) error {
+	if err := x.SendMsg(in); err != nil {
+		return err
+	}
+	return x.stream.Close()
+}
+
+func (x *rbacRestoreStream) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *rbacRestoreStream) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *rbacRestoreStream) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *rbacRestoreStream) Recv() (*BackupChunk, error) {
+	m := new(BackupChunk)
+	if err := x.stream.Recv(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (h *rbacHandler) VerifyBackup(ctx context.Context, stream server.Stream) error {
+	return h.RbacHandler.VerifyBackup(ctx, &rbacVerifyBackupStream{stream})
+}
+
+type Rbac_VerifyBackupStream interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	SendAndClose(*RestoreResult) error
+	Recv() (*BackupChunk, error)
+}
+
+type rbacVerifyBackupStream struct {
+	stream server.Stream
+}
+
+func (x *rbacVerifyBackupStream) SendAndClose(in *RestoreResult) error {
+	if err := x.SendMsg(in); err != nil {
+		return err
+	}
+	return x.stream.Close()
+}
+
+func (x *rbacVerifyBackupStream) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *rbacVerifyBackupStream) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *rbacVerifyBackupStream) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *rbacVerifyBackupStream) Recv() (*BackupChunk, error) {
+	m := new(BackupChunk)
+	if err := x.stream.Recv(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
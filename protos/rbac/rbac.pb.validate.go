@@ -37,22 +37,59 @@ var (
 var _rbac_uuidPattern = regexp.MustCompile("^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$")
 
 // Validate checks the field values on Request with the rules defined in the
-// proto definition for this message. If any rules are violated, an error is returned.
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
 func (m *Request) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Request with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in RequestMultiError, or nil if none found.
+func (m *Request) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Request) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
+	var errors []error
+
 	if utf8.RuneCountInString(m.GetId()) > 36 {
-		return RequestValidationError{
+		err := RequestValidationError{
 			field:  "Id",
 			reason: "value length must be at most 36 runes",
 		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
 	}
 
+	if len(errors) > 0 {
+		return RequestMultiError(errors)
+	}
 	return nil
 }
 
+// RequestMultiError is an error wrapping multiple validation errors returned
+// by Request.ValidateAll() if the designated constraints aren't met.
+type RequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RequestMultiError) AllErrors() []error { return m }
+
 // RequestValidationError is the validation error returned by Request.Validate
 // if the designated constraints aren't met.
 type RequestValidationError struct {
@@ -108,30 +145,71 @@ var _ interface {
 } = RequestValidationError{}
 
 // Validate checks the field values on LinkRequest with the rules defined in
-// the proto definition for this message. If any rules are violated, an error
-// is returned.
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
 func (m *LinkRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on LinkRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in LinkRequestMultiError, or
+// nil if none found.
+func (m *LinkRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *LinkRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
+	var errors []error
+
 	if utf8.RuneCountInString(m.GetId1()) > 36 {
-		return LinkRequestValidationError{
+		err := LinkRequestValidationError{
 			field:  "Id1",
 			reason: "value length must be at most 36 runes",
 		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
 	}
 
 	if utf8.RuneCountInString(m.GetId2()) > 36 {
-		return LinkRequestValidationError{
+		err := LinkRequestValidationError{
 			field:  "Id2",
 			reason: "value length must be at most 36 runes",
 		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
 	}
 
+	if len(errors) > 0 {
+		return LinkRequestMultiError(errors)
+	}
 	return nil
 }
 
+// LinkRequestMultiError is an error wrapping multiple validation errors
+// returned by LinkRequest.ValidateAll() if the designated constraints aren't met.
+type LinkRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m LinkRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m LinkRequestMultiError) AllErrors() []error { return m }
+
 // LinkRequestValidationError is the validation error returned by
 // LinkRequest.Validate if the designated constraints aren't met.
 type LinkRequestValidationError struct {
@@ -187,17 +265,51 @@ var _ interface {
 } = LinkRequestValidationError{}
 
 // Validate checks the field values on Response with the rules defined in the
-// proto definition for this message. If any rules are violated, an error is returned.
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
 func (m *Response) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Response with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ResponseMultiError, or nil
+// if none found.
+func (m *Response) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Response) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
+	var errors []error
+
 	// no validation rules for Msg
 
+	if len(errors) > 0 {
+		return ResponseMultiError(errors)
+	}
 	return nil
 }
 
+// ResponseMultiError is an error wrapping multiple validation errors returned
+// by Response.ValidateAll() if the designated constraints aren't met.
+type ResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ResponseMultiError) AllErrors() []error { return m }
+
 // ResponseValidationError is the validation error returned by
 // Response.Validate if the designated constraints aren't met.
 type ResponseValidationError struct {
@@ -253,43 +365,103 @@ var _ interface {
 } = ResponseValidationError{}
 
 // Validate checks the field values on User with the rules defined in the proto
-// definition for this message. If any rules are violated, an error is returned.
+// definition for this message. If any rules are violated, the first error
+// encountered is returned, or nil if there are no violations.
 func (m *User) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on User with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in UserMultiError, or nil if none found.
+func (m *User) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *User) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
+	var errors []error
+
 	if utf8.RuneCountInString(m.GetId()) > 36 {
-		return UserValidationError{
+		err := UserValidationError{
 			field:  "Id",
 			reason: "value length must be at most 36 runes",
 		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
 	}
 
 	if l := utf8.RuneCountInString(m.GetName()); l < 2 || l > 10 {
-		return UserValidationError{
+		err := UserValidationError{
 			field:  "Name",
 			reason: "value length must be between 2 and 10 runes, inclusive",
 		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
 	}
 
 	if val := m.GetAge(); val <= 0 || val > 180 {
-		return UserValidationError{
+		err := UserValidationError{
 			field:  "Age",
 			reason: "value must be inside range (0, 180]",
 		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
 	}
 
 	if _, ok := _User_Gender_InLookup[m.GetGender()]; !ok {
-		return UserValidationError{
+		err := UserValidationError{
 			field:  "Gender",
 			reason: "value must be in list [0 1 2]",
 		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetPassword()) > 128 {
+		err := UserValidationError{
+			field:  "Password",
+			reason: "value length must be at most 128 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
 	}
 
+	if len(errors) > 0 {
+		return UserMultiError(errors)
+	}
 	return nil
 }
 
+// UserMultiError is an error wrapping multiple validation errors returned by
+// User.ValidateAll() if the designated constraints aren't met.
+type UserMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UserMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UserMultiError) AllErrors() []error { return m }
+
 // UserValidationError is the validation error returned by User.Validate if the
 // designated constraints aren't met.
 type UserValidationError struct {
@@ -351,29 +523,72 @@ var _User_Gender_InLookup = map[int32]struct{}{
 }
 
 // Validate checks the field values on Role with the rules defined in the proto
-// definition for this message. If any rules are violated, an error is returned.
+// definition for this message. If any rules are violated, the first error
+// encountered is returned, or nil if there are no violations.
 func (m *Role) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Role with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in RoleMultiError, or nil if none found.
+func (m *Role) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Role) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
+	var errors []error
+
 	if utf8.RuneCountInString(m.GetId()) > 36 {
-		return RoleValidationError{
+		err := RoleValidationError{
 			field:  "Id",
 			reason: "value length must be at most 36 runes",
 		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
 	}
 
 	if l := utf8.RuneCountInString(m.GetName()); l < 2 || l > 10 {
-		return RoleValidationError{
+		err := RoleValidationError{
 			field:  "Name",
 			reason: "value length must be between 2 and 10 runes, inclusive",
 		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
 	}
 
+	// no validation rules for Version
+
+	if len(errors) > 0 {
+		return RoleMultiError(errors)
+	}
 	return nil
 }
 
+// RoleMultiError is an error wrapping multiple validation errors returned by
+// Role.ValidateAll() if the designated constraints aren't met.
+type RoleMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RoleMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RoleMultiError) AllErrors() []error { return m }
+
 // RoleValidationError is the validation error returned by Role.Validate if the
 // designated constraints aren't met.
 type RoleValidationError struct {
@@ -429,16 +644,49 @@ var _ interface {
 } = RoleValidationError{}
 
 // Validate checks the field values on Roles with the rules defined in the
-// proto definition for this message. If any rules are violated, an error is returned.
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
 func (m *Roles) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Roles with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in RolesMultiError, or nil if none found.
+func (m *Roles) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Roles) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
+	var errors []error
+
 	for idx, item := range m.GetRoles() {
 		_, _ = idx, item
 
-		if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, RolesValidationError{
+						field:  fmt.Sprintf("Roles[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, RolesValidationError{
+						field:  fmt.Sprintf("Roles[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
 			if err := v.Validate(); err != nil {
 				return RolesValidationError{
 					field:  fmt.Sprintf("Roles[%v]", idx),
@@ -450,9 +698,28 @@ func (m *Roles) Validate() error {
 
 	}
 
+	if len(errors) > 0 {
+		return RolesMultiError(errors)
+	}
 	return nil
 }
 
+// RolesMultiError is an error wrapping multiple validation errors returned by
+// Roles.ValidateAll() if the designated constraints aren't met.
+type RolesMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RolesMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RolesMultiError) AllErrors() []error { return m }
+
 // RolesValidationError is the validation error returned by Roles.Validate if
 // the designated constraints aren't met.
 type RolesValidationError struct {
@@ -508,29 +775,73 @@ var _ interface {
 } = RolesValidationError{}
 
 // Validate checks the field values on Resource with the rules defined in the
-// proto definition for this message. If any rules are violated, an error is returned.
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
 func (m *Resource) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Resource with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ResourceMultiError, or nil
+// if none found.
+func (m *Resource) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Resource) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
+	var errors []error
+
 	if utf8.RuneCountInString(m.GetId()) > 36 {
-		return ResourceValidationError{
+		err := ResourceValidationError{
 			field:  "Id",
 			reason: "value length must be at most 36 runes",
 		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
 	}
 
 	if l := utf8.RuneCountInString(m.GetName()); l < 2 || l > 10 {
-		return ResourceValidationError{
+		err := ResourceValidationError{
 			field:  "Name",
 			reason: "value length must be between 2 and 10 runes, inclusive",
 		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
 	}
 
+	// no validation rules for Version
+
+	if len(errors) > 0 {
+		return ResourceMultiError(errors)
+	}
 	return nil
 }
 
+// ResourceMultiError is an error wrapping multiple validation errors returned
+// by Resource.ValidateAll() if the designated constraints aren't met.
+type ResourceMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ResourceMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ResourceMultiError) AllErrors() []error { return m }
+
 // ResourceValidationError is the validation error returned by
 // Resource.Validate if the designated constraints aren't met.
 type ResourceValidationError struct {
@@ -586,16 +897,50 @@ var _ interface {
 } = ResourceValidationError{}
 
 // Validate checks the field values on Resources with the rules defined in the
-// proto definition for this message. If any rules are violated, an error is returned.
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
 func (m *Resources) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Resources with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ResourcesMultiError, or nil
+// if none found.
+func (m *Resources) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Resources) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
+	var errors []error
+
 	for idx, item := range m.GetResources() {
 		_, _ = idx, item
 
-		if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ResourcesValidationError{
+						field:  fmt.Sprintf("Resources[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ResourcesValidationError{
+						field:  fmt.Sprintf("Resources[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
 			if err := v.Validate(); err != nil {
 				return ResourcesValidationError{
 					field:  fmt.Sprintf("Resources[%v]", idx),
@@ -607,9 +952,28 @@ func (m *Resources) Validate() error {
 
 	}
 
+	if len(errors) > 0 {
+		return ResourcesMultiError(errors)
+	}
 	return nil
 }
 
+// ResourcesMultiError is an error wrapping multiple validation errors returned
+// by Resources.ValidateAll() if the designated constraints aren't met.
+type ResourcesMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ResourcesMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ResourcesMultiError) AllErrors() []error { return m }
+
 // ResourcesValidationError is the validation error returned by
 // Resources.Validate if the designated constraints aren't met.
 type ResourcesValidationError struct {
@@ -663,3 +1027,1243 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = ResourcesValidationError{}
+
+// Validate checks the field values on WatchRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *WatchRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on WatchRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in WatchRequestMultiError, or
+// nil if none found.
+func (m *WatchRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *WatchRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetRevision() < 0 {
+		err := WatchRequestValidationError{
+			field:  "Revision",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetKinds() {
+		_, _ = idx, item
+
+		if _, ok := Kind_name[int32(item)]; !ok {
+			err := WatchRequestValidationError{
+				field:  fmt.Sprintf("Kinds[%v]", idx),
+				reason: "value must be one of the defined enum values",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if utf8.RuneCountInString(m.GetEpoch()) > 32 {
+		err := WatchRequestValidationError{
+			field:  "Epoch",
+			reason: "value length must be at most 32 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return WatchRequestMultiError(errors)
+	}
+	return nil
+}
+
+// WatchRequestMultiError is an error wrapping multiple validation errors
+// returned by WatchRequest.ValidateAll() if the designated constraints aren't met.
+type WatchRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m WatchRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m WatchRequestMultiError) AllErrors() []error { return m }
+
+// WatchRequestValidationError is the validation error returned by
+// WatchRequest.Validate if the designated constraints aren't met.
+type WatchRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e WatchRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e WatchRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e WatchRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e WatchRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e WatchRequestValidationError) ErrorName() string { return "WatchRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e WatchRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sWatchRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = WatchRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = WatchRequestValidationError{}
+
+// Validate checks the field values on WatchEvent with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *WatchEvent) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on WatchEvent with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in WatchEventMultiError, or
+// nil if none found.
+func (m *WatchEvent) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *WatchEvent) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Revision
+
+	// no validation rules for Kind
+
+	// no validation rules for Action
+
+	// no validation rules for Id
+
+	// no validation rules for TargetId
+
+	// no validation rules for Timestamp
+
+	// no validation rules for Epoch
+
+	if len(errors) > 0 {
+		return WatchEventMultiError(errors)
+	}
+	return nil
+}
+
+// WatchEventMultiError is an error wrapping multiple validation errors
+// returned by WatchEvent.ValidateAll() if the designated constraints aren't met.
+type WatchEventMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m WatchEventMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m WatchEventMultiError) AllErrors() []error { return m }
+
+// WatchEventValidationError is the validation error returned by
+// WatchEvent.Validate if the designated constraints aren't met.
+type WatchEventValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e WatchEventValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e WatchEventValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e WatchEventValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e WatchEventValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e WatchEventValidationError) ErrorName() string { return "WatchEventValidationError" }
+
+// Error satisfies the builtin error interface
+func (e WatchEventValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sWatchEvent.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = WatchEventValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = WatchEventValidationError{}
+
+// Validate checks the field values on LogsRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *LogsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on LogsRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in LogsRequestMultiError, or
+// nil if none found.
+func (m *LogsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *LogsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetActor()) > 64 {
+		err := LogsRequestValidationError{
+			field:  "Actor",
+			reason: "value length must be at most 64 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetKinds() {
+		_, _ = idx, item
+
+		if _, ok := Kind_name[int32(item)]; !ok {
+			err := LogsRequestValidationError{
+				field:  fmt.Sprintf("Kinds[%v]", idx),
+				reason: "value must be one of the defined enum values",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if utf8.RuneCountInString(m.GetEntityId()) > 36 {
+		err := LogsRequestValidationError{
+			field:  "EntityId",
+			reason: "value length must be at most 36 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetSince() < 0 {
+		err := LogsRequestValidationError{
+			field:  "Since",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetUntil() < 0 {
+		err := LogsRequestValidationError{
+			field:  "Until",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if val := m.GetPageSize(); val < 0 || val > 100 {
+		err := LogsRequestValidationError{
+			field:  "PageSize",
+			reason: "value must be inside range [0, 100]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Cursor
+
+	if len(errors) > 0 {
+		return LogsRequestMultiError(errors)
+	}
+	return nil
+}
+
+// LogsRequestMultiError is an error wrapping multiple validation errors
+// returned by LogsRequest.ValidateAll() if the designated constraints aren't met.
+type LogsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m LogsRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m LogsRequestMultiError) AllErrors() []error { return m }
+
+// LogsRequestValidationError is the validation error returned by
+// LogsRequest.Validate if the designated constraints aren't met.
+type LogsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e LogsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e LogsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e LogsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e LogsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e LogsRequestValidationError) ErrorName() string { return "LogsRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e LogsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLogsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = LogsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = LogsRequestValidationError{}
+
+// Validate checks the field values on FieldDiff with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *FieldDiff) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on FieldDiff with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in FieldDiffMultiError, or nil
+// if none found.
+func (m *FieldDiff) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *FieldDiff) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Field
+
+	// no validation rules for Before
+
+	// no validation rules for After
+
+	if len(errors) > 0 {
+		return FieldDiffMultiError(errors)
+	}
+	return nil
+}
+
+// FieldDiffMultiError is an error wrapping multiple validation errors returned
+// by FieldDiff.ValidateAll() if the designated constraints aren't met.
+type FieldDiffMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m FieldDiffMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m FieldDiffMultiError) AllErrors() []error { return m }
+
+// FieldDiffValidationError is the validation error returned by
+// FieldDiff.Validate if the designated constraints aren't met.
+type FieldDiffValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e FieldDiffValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e FieldDiffValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e FieldDiffValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e FieldDiffValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e FieldDiffValidationError) ErrorName() string { return "FieldDiffValidationError" }
+
+// Error satisfies the builtin error interface
+func (e FieldDiffValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sFieldDiff.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = FieldDiffValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = FieldDiffValidationError{}
+
+// Validate checks the field values on Log with the rules defined in the proto
+// definition for this message. If any rules are violated, the first error
+// encountered is returned, or nil if there are no violations.
+func (m *Log) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Log with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in LogMultiError, or nil if none found.
+func (m *Log) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Log) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Actor
+
+	// no validation rules for Kind
+
+	// no validation rules for Action
+
+	// no validation rules for EntityId
+
+	// no validation rules for TargetId
+
+	for idx, item := range m.GetDiff() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, LogValidationError{
+						field:  fmt.Sprintf("Diff[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, LogValidationError{
+						field:  fmt.Sprintf("Diff[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return LogValidationError{
+					field:  fmt.Sprintf("Diff[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for RequestId
+
+	// no validation rules for Timestamp
+
+	if len(errors) > 0 {
+		return LogMultiError(errors)
+	}
+	return nil
+}
+
+// LogMultiError is an error wrapping multiple validation errors returned by
+// Log.ValidateAll() if the designated constraints aren't met.
+type LogMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m LogMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m LogMultiError) AllErrors() []error { return m }
+
+// LogValidationError is the validation error returned by Log.Validate if the
+// designated constraints aren't met.
+type LogValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e LogValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e LogValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e LogValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e LogValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e LogValidationError) ErrorName() string { return "LogValidationError" }
+
+// Error satisfies the builtin error interface
+func (e LogValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLog.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = LogValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = LogValidationError{}
+
+// Validate checks the field values on Logs with the rules defined in the proto
+// definition for this message. If any rules are violated, the first error
+// encountered is returned, or nil if there are no violations.
+func (m *Logs) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Logs with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in LogsMultiError, or nil if none found.
+func (m *Logs) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Logs) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetLogs() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, LogsValidationError{
+						field:  fmt.Sprintf("Logs[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, LogsValidationError{
+						field:  fmt.Sprintf("Logs[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return LogsValidationError{
+					field:  fmt.Sprintf("Logs[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for NextCursor
+
+	if len(errors) > 0 {
+		return LogsMultiError(errors)
+	}
+	return nil
+}
+
+// LogsMultiError is an error wrapping multiple validation errors returned by
+// Logs.ValidateAll() if the designated constraints aren't met.
+type LogsMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m LogsMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m LogsMultiError) AllErrors() []error { return m }
+
+// LogsValidationError is the validation error returned by Logs.Validate if the
+// designated constraints aren't met.
+type LogsValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e LogsValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e LogsValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e LogsValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e LogsValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e LogsValidationError) ErrorName() string { return "LogsValidationError" }
+
+// Error satisfies the builtin error interface
+func (e LogsValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLogs.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = LogsValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = LogsValidationError{}
+
+// Validate checks the field values on BackupRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *BackupRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BackupRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in BackupRequestMultiError, or
+// nil if none found.
+func (m *BackupRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BackupRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return BackupRequestMultiError(errors)
+	}
+	return nil
+}
+
+// BackupRequestMultiError is an error wrapping multiple validation errors
+// returned by BackupRequest.ValidateAll() if the designated constraints
+// aren't met.
+type BackupRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BackupRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BackupRequestMultiError) AllErrors() []error { return m }
+
+// BackupRequestValidationError is the validation error returned by
+// BackupRequest.Validate if the designated constraints aren't met.
+type BackupRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BackupRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BackupRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BackupRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BackupRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BackupRequestValidationError) ErrorName() string { return "BackupRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e BackupRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBackupRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BackupRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BackupRequestValidationError{}
+
+// Validate checks the field values on BackupChunk with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *BackupChunk) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BackupChunk with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in BackupChunkMultiError, or
+// nil if none found.
+func (m *BackupChunk) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BackupChunk) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Data
+
+	if len(errors) > 0 {
+		return BackupChunkMultiError(errors)
+	}
+	return nil
+}
+
+// BackupChunkMultiError is an error wrapping multiple validation errors
+// returned by BackupChunk.ValidateAll() if the designated constraints aren't met.
+type BackupChunkMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BackupChunkMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BackupChunkMultiError) AllErrors() []error { return m }
+
+// BackupChunkValidationError is the validation error returned by
+// BackupChunk.Validate if the designated constraints aren't met.
+type BackupChunkValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BackupChunkValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BackupChunkValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BackupChunkValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BackupChunkValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BackupChunkValidationError) ErrorName() string { return "BackupChunkValidationError" }
+
+// Error satisfies the builtin error interface
+func (e BackupChunkValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBackupChunk.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BackupChunkValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BackupChunkValidationError{}
+
+// Validate checks the field values on BackupCounts with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *BackupCounts) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BackupCounts with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in BackupCountsMultiError, or
+// nil if none found.
+func (m *BackupCounts) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BackupCounts) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Users
+
+	// no validation rules for Roles
+
+	// no validation rules for Resources
+
+	// no validation rules for UserRoles
+
+	// no validation rules for RoleResources
+
+	// no validation rules for Logs
+
+	if len(errors) > 0 {
+		return BackupCountsMultiError(errors)
+	}
+	return nil
+}
+
+// BackupCountsMultiError is an error wrapping multiple validation errors
+// returned by BackupCounts.ValidateAll() if the designated constraints aren't met.
+type BackupCountsMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BackupCountsMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BackupCountsMultiError) AllErrors() []error { return m }
+
+// BackupCountsValidationError is the validation error returned by
+// BackupCounts.Validate if the designated constraints aren't met.
+type BackupCountsValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BackupCountsValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BackupCountsValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BackupCountsValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BackupCountsValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BackupCountsValidationError) ErrorName() string { return "BackupCountsValidationError" }
+
+// Error satisfies the builtin error interface
+func (e BackupCountsValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBackupCounts.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BackupCountsValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BackupCountsValidationError{}
+
+// Validate checks the field values on RestoreResult with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *RestoreResult) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RestoreResult with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in RestoreResultMultiError, or
+// nil if none found.
+func (m *RestoreResult) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RestoreResult) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetCounts()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, RestoreResultValidationError{
+					field:  "Counts",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, RestoreResultValidationError{
+					field:  "Counts",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCounts()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return RestoreResultValidationError{
+				field:  "Counts",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return RestoreResultMultiError(errors)
+	}
+	return nil
+}
+
+// RestoreResultMultiError is an error wrapping multiple validation errors
+// returned by RestoreResult.ValidateAll() if the designated constraints
+// aren't met.
+type RestoreResultMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RestoreResultMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RestoreResultMultiError) AllErrors() []error { return m }
+
+// RestoreResultValidationError is the validation error returned by
+// RestoreResult.Validate if the designated constraints aren't met.
+type RestoreResultValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RestoreResultValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RestoreResultValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RestoreResultValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RestoreResultValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RestoreResultValidationError) ErrorName() string { return "RestoreResultValidationError" }
+
+// Error satisfies the builtin error interface
+func (e RestoreResultValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRestoreResult.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RestoreResultValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RestoreResultValidationError{}
@@ -0,0 +1,196 @@
+// Code generated by protoc-gen-micro. DO NOT EDIT.
+// source: resource.proto
+
+package resource
+
+import (
+	fmt "fmt"
+	_ "github.com/envoyproxy/protoc-gen-validate/validate"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+import (
+	context "context"
+	api "github.com/micro/micro/v3/service/api"
+	client "github.com/micro/micro/v3/service/client"
+	server "github.com/micro/micro/v3/service/server"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ api.Endpoint
+var _ context.Context
+var _ client.Option
+var _ server.Option
+
+// Api Endpoints for Resource service
+
+func NewResourceEndpoints() []*api.Endpoint {
+	return []*api.Endpoint{}
+}
+
+// Client API for Resource service
+
+type ResourceService interface {
+	Create(ctx context.Context, in *CreateRequest, opts ...client.CallOption) (*ResourceInfo, error)
+	Get(ctx context.Context, in *GetRequest, opts ...client.CallOption) (*ResourceInfo, error)
+	Update(ctx context.Context, in *UpdateRequest, opts ...client.CallOption) (*ResourceInfo, error)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...client.CallOption) (*DeleteResponse, error)
+	Search(ctx context.Context, in *SearchRequest, opts ...client.CallOption) (*SearchResponse, error)
+	List(ctx context.Context, in *ListRequest, opts ...client.CallOption) (*ListResponse, error)
+	Restore(ctx context.Context, in *RestoreRequest, opts ...client.CallOption) (*ResourceInfo, error)
+}
+
+type resourceService struct {
+	c    client.Client
+	name string
+}
+
+func NewResourceService(name string, c client.Client) ResourceService {
+	return &resourceService{
+		c:    c,
+		name: name,
+	}
+}
+
+func (c *resourceService) Create(ctx context.Context, in *CreateRequest, opts ...client.CallOption) (*ResourceInfo, error) {
+	req := c.c.NewRequest(c.name, "Resource.Create", in)
+	out := new(ResourceInfo)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourceService) Get(ctx context.Context, in *GetRequest, opts ...client.CallOption) (*ResourceInfo, error) {
+	req := c.c.NewRequest(c.name, "Resource.Get", in)
+	out := new(ResourceInfo)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourceService) Update(ctx context.Context, in *UpdateRequest, opts ...client.CallOption) (*ResourceInfo, error) {
+	req := c.c.NewRequest(c.name, "Resource.Update", in)
+	out := new(ResourceInfo)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourceService) Delete(ctx context.Context, in *DeleteRequest, opts ...client.CallOption) (*DeleteResponse, error) {
+	req := c.c.NewRequest(c.name, "Resource.Delete", in)
+	out := new(DeleteResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourceService) Search(ctx context.Context, in *SearchRequest, opts ...client.CallOption) (*SearchResponse, error) {
+	req := c.c.NewRequest(c.name, "Resource.Search", in)
+	out := new(SearchResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourceService) List(ctx context.Context, in *ListRequest, opts ...client.CallOption) (*ListResponse, error) {
+	req := c.c.NewRequest(c.name, "Resource.List", in)
+	out := new(ListResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourceService) Restore(ctx context.Context, in *RestoreRequest, opts ...client.CallOption) (*ResourceInfo, error) {
+	req := c.c.NewRequest(c.name, "Resource.Restore", in)
+	out := new(ResourceInfo)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Resource service
+
+type ResourceHandler interface {
+	Create(context.Context, *CreateRequest, *ResourceInfo) error
+	Get(context.Context, *GetRequest, *ResourceInfo) error
+	Update(context.Context, *UpdateRequest, *ResourceInfo) error
+	Delete(context.Context, *DeleteRequest, *DeleteResponse) error
+	Search(context.Context, *SearchRequest, *SearchResponse) error
+	List(context.Context, *ListRequest, *ListResponse) error
+	Restore(context.Context, *RestoreRequest, *ResourceInfo) error
+}
+
+func RegisterResourceHandler(s server.Server, hdlr ResourceHandler, opts ...server.HandlerOption) error {
+	type resource interface {
+		Create(ctx context.Context, in *CreateRequest, out *ResourceInfo) error
+		Get(ctx context.Context, in *GetRequest, out *ResourceInfo) error
+		Update(ctx context.Context, in *UpdateRequest, out *ResourceInfo) error
+		Delete(ctx context.Context, in *DeleteRequest, out *DeleteResponse) error
+		Search(ctx context.Context, in *SearchRequest, out *SearchResponse) error
+		List(ctx context.Context, in *ListRequest, out *ListResponse) error
+		Restore(ctx context.Context, in *RestoreRequest, out *ResourceInfo) error
+	}
+	type Resource struct {
+		resource
+	}
+	h := &resourceHandler{hdlr}
+	return s.Handle(s.NewHandler(&Resource{h}, opts...))
+}
+
+type resourceHandler struct {
+	ResourceHandler
+}
+
+func (h *resourceHandler) Create(ctx context.Context, in *CreateRequest, out *ResourceInfo) error {
+	return h.ResourceHandler.Create(ctx, in, out)
+}
+
+func (h *resourceHandler) Get(ctx context.Context, in *GetRequest, out *ResourceInfo) error {
+	return h.ResourceHandler.Get(ctx, in, out)
+}
+
+func (h *resourceHandler) Update(ctx context.Context, in *UpdateRequest, out *ResourceInfo) error {
+	return h.ResourceHandler.Update(ctx, in, out)
+}
+
+func (h *resourceHandler) Delete(ctx context.Context, in *DeleteRequest, out *DeleteResponse) error {
+	return h.ResourceHandler.Delete(ctx, in, out)
+}
+
+func (h *resourceHandler) Search(ctx context.Context, in *SearchRequest, out *SearchResponse) error {
+	return h.ResourceHandler.Search(ctx, in, out)
+}
+
+func (h *resourceHandler) List(ctx context.Context, in *ListRequest, out *ListResponse) error {
+	return h.ResourceHandler.List(ctx, in, out)
+}
+
+func (h *resourceHandler) Restore(ctx context.Context, in *RestoreRequest, out *ResourceInfo) error {
+	return h.ResourceHandler.Restore(ctx, in, out)
+}
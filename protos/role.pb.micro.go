@@ -5,6 +5,7 @@ package protos
 
 import (
 	fmt "fmt"
+	_ "github.com/envoyproxy/protoc-gen-validate/validate"
 	proto "github.com/golang/protobuf/proto"
 	math "math"
 )
@@ -43,10 +44,12 @@ func NewRoleEndpoints() []*api.Endpoint {
 
 type RoleService interface {
 	//  Role
-	GetRole(ctx context.Context, in *GetRoleRequest, opts ...client.CallOption) (*GetRoleResponse, error)
+	GetRole(ctx context.Context, in *GetRoleRequest, opts ...client.CallOption) (*RoleInfo, error)
 	InsertRole(ctx context.Context, in *InsertRoleRequest, opts ...client.CallOption) (*InsertRoleResponse, error)
 	DeleteRole(ctx context.Context, in *DeleteRoleRequest, opts ...client.CallOption) (*DeleteRoleResponse, error)
-	UpdateRole(ctx context.Context, in *UpdateRoleRequest, opts ...client.CallOption) (*UpdateRoleResponse, error)
+	UpdateRole(ctx context.Context, in *UpdateRoleRequest, opts ...client.CallOption) (*RoleInfo, error)
+	ListRoles(ctx context.Context, in *ListRolesRequest, opts ...client.CallOption) (*ListRolesResponse, error)
+	RestoreRole(ctx context.Context, in *RestoreRoleRequest, opts ...client.CallOption) (*RoleInfo, error)
 }
 
 type roleService struct {
@@ -61,9 +64,9 @@ func NewRoleService(name string, c client.Client) RoleService {
 	}
 }
 
-func (c *roleService) GetRole(ctx context.Context, in *GetRoleRequest, opts ...client.CallOption) (*GetRoleResponse, error) {
+func (c *roleService) GetRole(ctx context.Context, in *GetRoleRequest, opts ...client.CallOption) (*RoleInfo, error) {
 	req := c.c.NewRequest(c.name, "Role.GetRole", in)
-	out := new(GetRoleResponse)
+	out := new(RoleInfo)
 	err := c.c.Call(ctx, req, out, opts...)
 	if err != nil {
 		return nil, err
@@ -91,9 +94,29 @@ func (c *roleService) DeleteRole(ctx context.Context, in *DeleteRoleRequest, opt
 	return out, nil
 }
 
-func (c *roleService) UpdateRole(ctx context.Context, in *UpdateRoleRequest, opts ...client.CallOption) (*UpdateRoleResponse, error) {
+func (c *roleService) UpdateRole(ctx context.Context, in *UpdateRoleRequest, opts ...client.CallOption) (*RoleInfo, error) {
 	req := c.c.NewRequest(c.name, "Role.UpdateRole", in)
-	out := new(UpdateRoleResponse)
+	out := new(RoleInfo)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleService) ListRoles(ctx context.Context, in *ListRolesRequest, opts ...client.CallOption) (*ListRolesResponse, error) {
+	req := c.c.NewRequest(c.name, "Role.ListRoles", in)
+	out := new(ListRolesResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleService) RestoreRole(ctx context.Context, in *RestoreRoleRequest, opts ...client.CallOption) (*RoleInfo, error) {
+	req := c.c.NewRequest(c.name, "Role.RestoreRole", in)
+	out := new(RoleInfo)
 	err := c.c.Call(ctx, req, out, opts...)
 	if err != nil {
 		return nil, err
@@ -105,18 +128,22 @@ func (c *roleService) UpdateRole(ctx context.Context, in *UpdateRoleRequest, opt
 
 type RoleHandler interface {
 	//  Role
-	GetRole(context.Context, *GetRoleRequest, *GetRoleResponse) error
+	GetRole(context.Context, *GetRoleRequest, *RoleInfo) error
 	InsertRole(context.Context, *InsertRoleRequest, *InsertRoleResponse) error
 	DeleteRole(context.Context, *DeleteRoleRequest, *DeleteRoleResponse) error
-	UpdateRole(context.Context, *UpdateRoleRequest, *UpdateRoleResponse) error
+	UpdateRole(context.Context, *UpdateRoleRequest, *RoleInfo) error
+	ListRoles(context.Context, *ListRolesRequest, *ListRolesResponse) error
+	RestoreRole(context.Context, *RestoreRoleRequest, *RoleInfo) error
 }
 
 func RegisterRoleHandler(s server.Server, hdlr RoleHandler, opts ...server.HandlerOption) error {
 	type role interface {
-		GetRole(ctx context.Context, in *GetRoleRequest, out *GetRoleResponse) error
+		GetRole(ctx context.Context, in *GetRoleRequest, out *RoleInfo) error
 		InsertRole(ctx context.Context, in *InsertRoleRequest, out *InsertRoleResponse) error
 		DeleteRole(ctx context.Context, in *DeleteRoleRequest, out *DeleteRoleResponse) error
-		UpdateRole(ctx context.Context, in *UpdateRoleRequest, out *UpdateRoleResponse) error
+		UpdateRole(ctx context.Context, in *UpdateRoleRequest, out *RoleInfo) error
+		ListRoles(ctx context.Context, in *ListRolesRequest, out *ListRolesResponse) error
+		RestoreRole(ctx context.Context, in *RestoreRoleRequest, out *RoleInfo) error
 	}
 	type Role struct {
 		role
@@ -129,7 +156,7 @@ type roleHandler struct {
 	RoleHandler
 }
 
-func (h *roleHandler) GetRole(ctx context.Context, in *GetRoleRequest, out *GetRoleResponse) error {
+func (h *roleHandler) GetRole(ctx context.Context, in *GetRoleRequest, out *RoleInfo) error {
 	return h.RoleHandler.GetRole(ctx, in, out)
 }
 
@@ -141,6 +168,14 @@ func (h *roleHandler) DeleteRole(ctx context.Context, in *DeleteRoleRequest, out
 	return h.RoleHandler.DeleteRole(ctx, in, out)
 }
 
-func (h *roleHandler) UpdateRole(ctx context.Context, in *UpdateRoleRequest, out *UpdateRoleResponse) error {
+func (h *roleHandler) UpdateRole(ctx context.Context, in *UpdateRoleRequest, out *RoleInfo) error {
 	return h.RoleHandler.UpdateRole(ctx, in, out)
 }
+
+func (h *roleHandler) ListRoles(ctx context.Context, in *ListRolesRequest, out *ListRolesResponse) error {
+	return h.RoleHandler.ListRoles(ctx, in, out)
+}
+
+func (h *roleHandler) RestoreRole(ctx context.Context, in *RestoreRoleRequest, out *RoleInfo) error {
+	return h.RoleHandler.RestoreRole(ctx, in, out)
+}
@@ -37,18 +37,61 @@ var (
 var _role_uuidPattern = regexp.MustCompile("^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$")
 
 // Validate checks the field values on GetRoleRequest with the rules defined in
-// the proto definition for this message. If any rules are violated, an error
-// is returned.
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
 func (m *GetRoleRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetRoleRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in GetRoleRequestMultiError,
+// or nil if none found.
+func (m *GetRoleRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetRoleRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
-	// no validation rules for RoleId
+	var errors []error
+
+	if m.GetRoleId() <= 0 {
+		err := GetRoleRequestValidationError{
+			field:  "RoleId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
 
+	if len(errors) > 0 {
+		return GetRoleRequestMultiError(errors)
+	}
 	return nil
 }
 
+// GetRoleRequestMultiError is an error wrapping multiple validation errors
+// returned by GetRoleRequest.ValidateAll() if the designated constraints
+// aren't met.
+type GetRoleRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetRoleRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetRoleRequestMultiError) AllErrors() []error { return m }
+
 // GetRoleRequestValidationError is the validation error returned by
 // GetRoleRequest.Validate if the designated constraints aren't met.
 type GetRoleRequestValidationError struct {
@@ -103,82 +146,89 @@ var _ interface {
 	ErrorName() string
 } = GetRoleRequestValidationError{}
 
-// Validate checks the field values on GetRoleResponse with the rules defined
-// in the proto definition for this message. If any rules are violated, an
-// error is returned.
-func (m *GetRoleResponse) Validate() error {
-	if m == nil {
-		return nil
-	}
-
-	return nil
+// Validate checks the field values on InsertRoleRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *InsertRoleRequest) Validate() error {
+	return m.validate(false)
 }
 
-// GetRoleResponseValidationError is the validation error returned by
-// GetRoleResponse.Validate if the designated constraints aren't met.
-type GetRoleResponseValidationError struct {
-	field  string
-	reason string
-	cause  error
-	key    bool
+// ValidateAll checks the field values on InsertRoleRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// InsertRoleRequestMultiError, or nil if none found.
+func (m *InsertRoleRequest) ValidateAll() error {
+	return m.validate(true)
 }
 
-// Field function returns field value.
-func (e GetRoleResponseValidationError) Field() string { return e.field }
+func (m *InsertRoleRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
 
-// Reason function returns reason value.
-func (e GetRoleResponseValidationError) Reason() string { return e.reason }
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetKey()) > 128 {
+		err := InsertRoleRequestValidationError{
+			field:  "Key",
+			reason: "value length must be at most 128 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
 
-// Cause function returns cause value.
-func (e GetRoleResponseValidationError) Cause() error { return e.cause }
+	if l := utf8.RuneCountInString(m.GetName()); l < 1 || l > 128 {
+		err := InsertRoleRequestValidationError{
+			field:  "Name",
+			reason: "value length must be between 1 and 128 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
 
-// Key function returns key value.
-func (e GetRoleResponseValidationError) Key() bool { return e.key }
+	for idx, item := range m.GetResourceIds() {
+		_, _ = idx, item
 
-// ErrorName returns error name.
-func (e GetRoleResponseValidationError) ErrorName() string { return "GetRoleResponseValidationError" }
+		if item <= 0 {
+			err := InsertRoleRequestValidationError{
+				field:  fmt.Sprintf("ResourceIds[%v]", idx),
+				reason: "value must be greater than 0",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
 
-// Error satisfies the builtin error interface
-func (e GetRoleResponseValidationError) Error() string {
-	cause := ""
-	if e.cause != nil {
-		cause = fmt.Sprintf(" | caused by: %v", e.cause)
 	}
 
-	key := ""
-	if e.key {
-		key = "key for "
+	if len(errors) > 0 {
+		return InsertRoleRequestMultiError(errors)
 	}
-
-	return fmt.Sprintf(
-		"invalid %sGetRoleResponse.%s: %s%s",
-		key,
-		e.field,
-		e.reason,
-		cause)
+	return nil
 }
 
-var _ error = GetRoleResponseValidationError{}
-
-var _ interface {
-	Field() string
-	Reason() string
-	Key() bool
-	Cause() error
-	ErrorName() string
-} = GetRoleResponseValidationError{}
+// InsertRoleRequestMultiError is an error wrapping multiple validation errors
+// returned by InsertRoleRequest.ValidateAll() if the designated constraints
+// aren't met.
+type InsertRoleRequestMultiError []error
 
-// Validate checks the field values on InsertRoleRequest with the rules defined
-// in the proto definition for this message. If any rules are violated, an
-// error is returned.
-func (m *InsertRoleRequest) Validate() error {
-	if m == nil {
-		return nil
+// Error returns a concatenation of all the error messages it wraps.
+func (m InsertRoleRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
 	}
-
-	return nil
+	return strings.Join(msgs, "; ")
 }
 
+// AllErrors returns a list of validation violation errors.
+func (m InsertRoleRequestMultiError) AllErrors() []error { return m }
+
 // InsertRoleRequestValidationError is the validation error returned by
 // InsertRoleRequest.Validate if the designated constraints aren't met.
 type InsertRoleRequestValidationError struct {
@@ -237,15 +287,53 @@ var _ interface {
 
 // Validate checks the field values on InsertRoleResponse with the rules
 // defined in the proto definition for this message. If any rules are
-// violated, an error is returned.
+// violated, the first error encountered is returned, or nil if there are no violations.
 func (m *InsertRoleResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on InsertRoleResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// InsertRoleResponseMultiError, or nil if none found.
+func (m *InsertRoleResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *InsertRoleResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
+	var errors []error
+
+	// no validation rules for RoleId
+
+	// no validation rules for Version
+
+	if len(errors) > 0 {
+		return InsertRoleResponseMultiError(errors)
+	}
 	return nil
 }
 
+// InsertRoleResponseMultiError is an error wrapping multiple validation errors
+// returned by InsertRoleResponse.ValidateAll() if the designated constraints
+// aren't met.
+type InsertRoleResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m InsertRoleResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m InsertRoleResponseMultiError) AllErrors() []error { return m }
+
 // InsertRoleResponseValidationError is the validation error returned by
 // InsertRoleResponse.Validate if the designated constraints aren't met.
 type InsertRoleResponseValidationError struct {
@@ -303,18 +391,72 @@ var _ interface {
 } = InsertRoleResponseValidationError{}
 
 // Validate checks the field values on DeleteRoleRequest with the rules defined
-// in the proto definition for this message. If any rules are violated, an
-// error is returned.
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
 func (m *DeleteRoleRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DeleteRoleRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DeleteRoleRequestMultiError, or nil if none found.
+func (m *DeleteRoleRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DeleteRoleRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
-	// no validation rules for RoleId
+	var errors []error
+
+	if m.GetRoleId() <= 0 {
+		err := DeleteRoleRequestValidationError{
+			field:  "RoleId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetVersion() <= 0 {
+		err := DeleteRoleRequestValidationError{
+			field:  "Version",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
 
+	if len(errors) > 0 {
+		return DeleteRoleRequestMultiError(errors)
+	}
 	return nil
 }
 
+// DeleteRoleRequestMultiError is an error wrapping multiple validation errors
+// returned by DeleteRoleRequest.ValidateAll() if the designated constraints
+// aren't met.
+type DeleteRoleRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DeleteRoleRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DeleteRoleRequestMultiError) AllErrors() []error { return m }
+
 // DeleteRoleRequestValidationError is the validation error returned by
 // DeleteRoleRequest.Validate if the designated constraints aren't met.
 type DeleteRoleRequestValidationError struct {
@@ -373,15 +515,49 @@ var _ interface {
 
 // Validate checks the field values on DeleteRoleResponse with the rules
 // defined in the proto definition for this message. If any rules are
-// violated, an error is returned.
+// violated, the first error encountered is returned, or nil if there are no violations.
 func (m *DeleteRoleResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DeleteRoleResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DeleteRoleResponseMultiError, or nil if none found.
+func (m *DeleteRoleResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DeleteRoleResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
+	var errors []error
+
+	if len(errors) > 0 {
+		return DeleteRoleResponseMultiError(errors)
+	}
 	return nil
 }
 
+// DeleteRoleResponseMultiError is an error wrapping multiple validation errors
+// returned by DeleteRoleResponse.ValidateAll() if the designated constraints
+// aren't met.
+type DeleteRoleResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DeleteRoleResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DeleteRoleResponseMultiError) AllErrors() []error { return m }
+
 // DeleteRoleResponseValidationError is the validation error returned by
 // DeleteRoleResponse.Validate if the designated constraints aren't met.
 type DeleteRoleResponseValidationError struct {
@@ -438,19 +614,187 @@ var _ interface {
 	ErrorName() string
 } = DeleteRoleResponseValidationError{}
 
+// Validate checks the field values on RestoreRoleRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RestoreRoleRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RestoreRoleRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RestoreRoleRequestMultiError, or nil if none found.
+func (m *RestoreRoleRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RestoreRoleRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for RoleId
+
+	if len(errors) > 0 {
+		return RestoreRoleRequestMultiError(errors)
+	}
+	return nil
+}
+
+// RestoreRoleRequestMultiError is an error wrapping multiple validation errors
+// returned by RestoreRoleRequest.ValidateAll() if the designated constraints
+// aren't met.
+type RestoreRoleRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RestoreRoleRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RestoreRoleRequestMultiError) AllErrors() []error { return m }
+
+// RestoreRoleRequestValidationError is the validation error returned by
+// RestoreRoleRequest.Validate if the designated constraints aren't met.
+type RestoreRoleRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RestoreRoleRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RestoreRoleRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RestoreRoleRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RestoreRoleRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RestoreRoleRequestValidationError) ErrorName() string {
+	return "RestoreRoleRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RestoreRoleRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRestoreRoleRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RestoreRoleRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RestoreRoleRequestValidationError{}
+
 // Validate checks the field values on UpdateRoleRequest with the rules defined
-// in the proto definition for this message. If any rules are violated, an
-// error is returned.
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
 func (m *UpdateRoleRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UpdateRoleRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UpdateRoleRequestMultiError, or nil if none found.
+func (m *UpdateRoleRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UpdateRoleRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
-	// no validation rules for RoleId
+	var errors []error
+
+	if m.GetRoleId() <= 0 {
+		err := UpdateRoleRequestValidationError{
+			field:  "RoleId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
 
+	if m.GetVersion() <= 0 {
+		err := UpdateRoleRequestValidationError{
+			field:  "Version",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetName()) > 128 {
+		err := UpdateRoleRequestValidationError{
+			field:  "Name",
+			reason: "value length must be at most 128 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return UpdateRoleRequestMultiError(errors)
+	}
 	return nil
 }
 
+// UpdateRoleRequestMultiError is an error wrapping multiple validation errors
+// returned by UpdateRoleRequest.ValidateAll() if the designated constraints
+// aren't met.
+type UpdateRoleRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UpdateRoleRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UpdateRoleRequestMultiError) AllErrors() []error { return m }
+
 // UpdateRoleRequestValidationError is the validation error returned by
 // UpdateRoleRequest.Validate if the designated constraints aren't met.
 type UpdateRoleRequestValidationError struct {
@@ -507,20 +851,346 @@ var _ interface {
 	ErrorName() string
 } = UpdateRoleRequestValidationError{}
 
-// Validate checks the field values on UpdateRoleResponse with the rules
+// Validate checks the field values on RoleInfo with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *RoleInfo) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RoleInfo with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in RoleInfoMultiError, or nil
+// if none found.
+func (m *RoleInfo) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RoleInfo) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Key
+
+	// no validation rules for Name
+
+	// no validation rules for TenantId
+
+	// no validation rules for CreatedAt
+
+	// no validation rules for DeletedAt
+
+	// no validation rules for Version
+
+	if len(errors) > 0 {
+		return RoleInfoMultiError(errors)
+	}
+	return nil
+}
+
+// RoleInfoMultiError is an error wrapping multiple validation errors returned
+// by RoleInfo.ValidateAll() if the designated constraints aren't met.
+type RoleInfoMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RoleInfoMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RoleInfoMultiError) AllErrors() []error { return m }
+
+// RoleInfoValidationError is the validation error returned by
+// RoleInfo.Validate if the designated constraints aren't met.
+type RoleInfoValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RoleInfoValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RoleInfoValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RoleInfoValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RoleInfoValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RoleInfoValidationError) ErrorName() string { return "RoleInfoValidationError" }
+
+// Error satisfies the builtin error interface
+func (e RoleInfoValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRoleInfo.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RoleInfoValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RoleInfoValidationError{}
+
+// Validate checks the field values on ListRolesRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ListRolesRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListRolesRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListRolesRequestMultiError, or nil if none found.
+func (m *ListRolesRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListRolesRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if val := m.GetPageSize(); val < 0 || val > 100 {
+		err := ListRolesRequestValidationError{
+			field:  "PageSize",
+			reason: "value must be inside range [0, 100]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Cursor
+
+	// no validation rules for NamePrefix
+
+	// no validation rules for TenantId
+
+	// no validation rules for CreatedAfter
+
+	// no validation rules for CreatedBefore
+
+	if _, ok := _ListRolesRequest_SortBy_InLookup[m.GetSortBy()]; !ok {
+		err := ListRolesRequestValidationError{
+			field:  "SortBy",
+			reason: "value must be in list [ id name created_at]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Desc
+
+	// no validation rules for WithDeleted
+
+	if len(errors) > 0 {
+		return ListRolesRequestMultiError(errors)
+	}
+	return nil
+}
+
+// ListRolesRequestMultiError is an error wrapping multiple validation errors
+// returned by ListRolesRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ListRolesRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListRolesRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListRolesRequestMultiError) AllErrors() []error { return m }
+
+// ListRolesRequestValidationError is the validation error returned by
+// ListRolesRequest.Validate if the designated constraints aren't met.
+type ListRolesRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListRolesRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListRolesRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListRolesRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListRolesRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListRolesRequestValidationError) ErrorName() string { return "ListRolesRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ListRolesRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListRolesRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListRolesRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListRolesRequestValidationError{}
+
+var _ListRolesRequest_SortBy_InLookup = map[string]struct{}{
+	"":           {},
+	"id":         {},
+	"name":       {},
+	"created_at": {},
+}
+
+// Validate checks the field values on ListRolesResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ListRolesResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListRolesResponse with the rules
 // defined in the proto definition for this message. If any rules are
-// violated, an error is returned.
-func (m *UpdateRoleResponse) Validate() error {
+// violated, the result is a list of violation errors wrapped in
+// ListRolesResponseMultiError, or nil if none found.
+func (m *ListRolesResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListRolesResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
+	var errors []error
+
+	for idx, item := range m.GetRoles() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListRolesResponseValidationError{
+						field:  fmt.Sprintf("Roles[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListRolesResponseValidationError{
+						field:  fmt.Sprintf("Roles[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListRolesResponseValidationError{
+					field:  fmt.Sprintf("Roles[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for NextCursor
+
+	// no validation rules for Total
+
+	if len(errors) > 0 {
+		return ListRolesResponseMultiError(errors)
+	}
 	return nil
 }
 
-// UpdateRoleResponseValidationError is the validation error returned by
-// UpdateRoleResponse.Validate if the designated constraints aren't met.
-type UpdateRoleResponseValidationError struct {
+// ListRolesResponseMultiError is an error wrapping multiple validation errors
+// returned by ListRolesResponse.ValidateAll() if the designated constraints
+// aren't met.
+type ListRolesResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListRolesResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListRolesResponseMultiError) AllErrors() []error { return m }
+
+// ListRolesResponseValidationError is the validation error returned by
+// ListRolesResponse.Validate if the designated constraints aren't met.
+type ListRolesResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -528,24 +1198,24 @@ type UpdateRoleResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e UpdateRoleResponseValidationError) Field() string { return e.field }
+func (e ListRolesResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e UpdateRoleResponseValidationError) Reason() string { return e.reason }
+func (e ListRolesResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e UpdateRoleResponseValidationError) Cause() error { return e.cause }
+func (e ListRolesResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e UpdateRoleResponseValidationError) Key() bool { return e.key }
+func (e ListRolesResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e UpdateRoleResponseValidationError) ErrorName() string {
-	return "UpdateRoleResponseValidationError"
+func (e ListRolesResponseValidationError) ErrorName() string {
+	return "ListRolesResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e UpdateRoleResponseValidationError) Error() string {
+func (e ListRolesResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -557,14 +1227,14 @@ func (e UpdateRoleResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sUpdateRoleResponse.%s: %s%s",
+		"invalid %sListRolesResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = UpdateRoleResponseValidationError{}
+var _ error = ListRolesResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -572,4 +1242,4 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = UpdateRoleResponseValidationError{}
+} = ListRolesResponseValidationError{}
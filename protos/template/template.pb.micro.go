@@ -0,0 +1,196 @@
+// Code generated by protoc-gen-micro. DO NOT EDIT.
+// source: template.proto
+
+package template
+
+import (
+	fmt "fmt"
+	_ "github.com/envoyproxy/protoc-gen-validate/validate"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+import (
+	context "context"
+	api "github.com/micro/micro/v3/service/api"
+	client "github.com/micro/micro/v3/service/client"
+	server "github.com/micro/micro/v3/service/server"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ api.Endpoint
+var _ context.Context
+var _ client.Option
+var _ server.Option
+
+// Api Endpoints for RoleTemplate service
+
+func NewRoleTemplateEndpoints() []*api.Endpoint {
+	return []*api.Endpoint{}
+}
+
+// Client API for RoleTemplate service
+
+type RoleTemplateService interface {
+	Create(ctx context.Context, in *CreateRequest, opts ...client.CallOption) (*TemplateInfo, error)
+	Get(ctx context.Context, in *GetRequest, opts ...client.CallOption) (*TemplateInfo, error)
+	List(ctx context.Context, in *ListRequest, opts ...client.CallOption) (*ListResponse, error)
+	Update(ctx context.Context, in *UpdateRequest, opts ...client.CallOption) (*TemplateInfo, error)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...client.CallOption) (*DeleteResponse, error)
+	Provision(ctx context.Context, in *ProvisionRequest, opts ...client.CallOption) (*ProvisionResponse, error)
+	Sync(ctx context.Context, in *SyncRequest, opts ...client.CallOption) (*SyncResponse, error)
+}
+
+type roleTemplateService struct {
+	c    client.Client
+	name string
+}
+
+func NewRoleTemplateService(name string, c client.Client) RoleTemplateService {
+	return &roleTemplateService{
+		c:    c,
+		name: name,
+	}
+}
+
+func (c *roleTemplateService) Create(ctx context.Context, in *CreateRequest, opts ...client.CallOption) (*TemplateInfo, error) {
+	req := c.c.NewRequest(c.name, "RoleTemplate.Create", in)
+	out := new(TemplateInfo)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleTemplateService) Get(ctx context.Context, in *GetRequest, opts ...client.CallOption) (*TemplateInfo, error) {
+	req := c.c.NewRequest(c.name, "RoleTemplate.Get", in)
+	out := new(TemplateInfo)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleTemplateService) List(ctx context.Context, in *ListRequest, opts ...client.CallOption) (*ListResponse, error) {
+	req := c.c.NewRequest(c.name, "RoleTemplate.List", in)
+	out := new(ListResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleTemplateService) Update(ctx context.Context, in *UpdateRequest, opts ...client.CallOption) (*TemplateInfo, error) {
+	req := c.c.NewRequest(c.name, "RoleTemplate.Update", in)
+	out := new(TemplateInfo)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleTemplateService) Delete(ctx context.Context, in *DeleteRequest, opts ...client.CallOption) (*DeleteResponse, error) {
+	req := c.c.NewRequest(c.name, "RoleTemplate.Delete", in)
+	out := new(DeleteResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleTemplateService) Provision(ctx context.Context, in *ProvisionRequest, opts ...client.CallOption) (*ProvisionResponse, error) {
+	req := c.c.NewRequest(c.name, "RoleTemplate.Provision", in)
+	out := new(ProvisionResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleTemplateService) Sync(ctx context.Context, in *SyncRequest, opts ...client.CallOption) (*SyncResponse, error) {
+	req := c.c.NewRequest(c.name, "RoleTemplate.Sync", in)
+	out := new(SyncResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for RoleTemplate service
+
+type RoleTemplateHandler interface {
+	Create(context.Context, *CreateRequest, *TemplateInfo) error
+	Get(context.Context, *GetRequest, *TemplateInfo) error
+	List(context.Context, *ListRequest, *ListResponse) error
+	Update(context.Context, *UpdateRequest, *TemplateInfo) error
+	Delete(context.Context, *DeleteRequest, *DeleteResponse) error
+	Provision(context.Context, *ProvisionRequest, *ProvisionResponse) error
+	Sync(context.Context, *SyncRequest, *SyncResponse) error
+}
+
+func RegisterRoleTemplateHandler(s server.Server, hdlr RoleTemplateHandler, opts ...server.HandlerOption) error {
+	type roleTemplate interface {
+		Create(ctx context.Context, in *CreateRequest, out *TemplateInfo) error
+		Get(ctx context.Context, in *GetRequest, out *TemplateInfo) error
+		List(ctx context.Context, in *ListRequest, out *ListResponse) error
+		Update(ctx context.Context, in *UpdateRequest, out *TemplateInfo) error
+		Delete(ctx context.Context, in *DeleteRequest, out *DeleteResponse) error
+		Provision(ctx context.Context, in *ProvisionRequest, out *ProvisionResponse) error
+		Sync(ctx context.Context, in *SyncRequest, out *SyncResponse) error
+	}
+	type RoleTemplate struct {
+		roleTemplate
+	}
+	h := &roleTemplateHandler{hdlr}
+	return s.Handle(s.NewHandler(&RoleTemplate{h}, opts...))
+}
+
+type roleTemplateHandler struct {
+	RoleTemplateHandler
+}
+
+func (h *roleTemplateHandler) Create(ctx context.Context, in *CreateRequest, out *TemplateInfo) error {
+	return h.RoleTemplateHandler.Create(ctx, in, out)
+}
+
+func (h *roleTemplateHandler) Get(ctx context.Context, in *GetRequest, out *TemplateInfo) error {
+	return h.RoleTemplateHandler.Get(ctx, in, out)
+}
+
+func (h *roleTemplateHandler) List(ctx context.Context, in *ListRequest, out *ListResponse) error {
+	return h.RoleTemplateHandler.List(ctx, in, out)
+}
+
+func (h *roleTemplateHandler) Update(ctx context.Context, in *UpdateRequest, out *TemplateInfo) error {
+	return h.RoleTemplateHandler.Update(ctx, in, out)
+}
+
+func (h *roleTemplateHandler) Delete(ctx context.Context, in *DeleteRequest, out *DeleteResponse) error {
+	return h.RoleTemplateHandler.Delete(ctx, in, out)
+}
+
+func (h *roleTemplateHandler) Provision(ctx context.Context, in *ProvisionRequest, out *ProvisionResponse) error {
+	return h.RoleTemplateHandler.Provision(ctx, in, out)
+}
+
+func (h *roleTemplateHandler) Sync(ctx context.Context, in *SyncRequest, out *SyncResponse) error {
+	return h.RoleTemplateHandler.Sync(ctx, in, out)
+}
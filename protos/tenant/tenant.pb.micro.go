@@ -0,0 +1,196 @@
+// Code generated by protoc-gen-micro. DO NOT EDIT.
+// source: tenant.proto
+
+package tenant
+
+import (
+	fmt "fmt"
+	_ "github.com/envoyproxy/protoc-gen-validate/validate"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+import (
+	context "context"
+	api "github.com/micro/micro/v3/service/api"
+	client "github.com/micro/micro/v3/service/client"
+	server "github.com/micro/micro/v3/service/server"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ api.Endpoint
+var _ context.Context
+var _ client.Option
+var _ server.Option
+
+// Api Endpoints for Tenant service
+
+func NewTenantEndpoints() []*api.Endpoint {
+	return []*api.Endpoint{}
+}
+
+// Client API for Tenant service
+
+type TenantService interface {
+	Create(ctx context.Context, in *CreateRequest, opts ...client.CallOption) (*TenantInfo, error)
+	Get(ctx context.Context, in *GetRequest, opts ...client.CallOption) (*TenantInfo, error)
+	List(ctx context.Context, in *ListRequest, opts ...client.CallOption) (*ListResponse, error)
+	UpdateSettings(ctx context.Context, in *UpdateSettingsRequest, opts ...client.CallOption) (*TenantInfo, error)
+	Suspend(ctx context.Context, in *StateRequest, opts ...client.CallOption) (*TenantInfo, error)
+	Resume(ctx context.Context, in *StateRequest, opts ...client.CallOption) (*TenantInfo, error)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...client.CallOption) (*DeleteResponse, error)
+}
+
+type tenantService struct {
+	c    client.Client
+	name string
+}
+
+func NewTenantService(name string, c client.Client) TenantService {
+	return &tenantService{
+		c:    c,
+		name: name,
+	}
+}
+
+func (c *tenantService) Create(ctx context.Context, in *CreateRequest, opts ...client.CallOption) (*TenantInfo, error) {
+	req := c.c.NewRequest(c.name, "Tenant.Create", in)
+	out := new(TenantInfo)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tenantService) Get(ctx context.Context, in *GetRequest, opts ...client.CallOption) (*TenantInfo, error) {
+	req := c.c.NewRequest(c.name, "Tenant.Get", in)
+	out := new(TenantInfo)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tenantService) List(ctx context.Context, in *ListRequest, opts ...client.CallOption) (*ListResponse, error) {
+	req := c.c.NewRequest(c.name, "Tenant.List", in)
+	out := new(ListResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tenantService) UpdateSettings(ctx context.Context, in *UpdateSettingsRequest, opts ...client.CallOption) (*TenantInfo, error) {
+	req := c.c.NewRequest(c.name, "Tenant.UpdateSettings", in)
+	out := new(TenantInfo)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tenantService) Suspend(ctx context.Context, in *StateRequest, opts ...client.CallOption) (*TenantInfo, error) {
+	req := c.c.NewRequest(c.name, "Tenant.Suspend", in)
+	out := new(TenantInfo)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tenantService) Resume(ctx context.Context, in *StateRequest, opts ...client.CallOption) (*TenantInfo, error) {
+	req := c.c.NewRequest(c.name, "Tenant.Resume", in)
+	out := new(TenantInfo)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tenantService) Delete(ctx context.Context, in *DeleteRequest, opts ...client.CallOption) (*DeleteResponse, error) {
+	req := c.c.NewRequest(c.name, "Tenant.Delete", in)
+	out := new(DeleteResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Tenant service
+
+type TenantHandler interface {
+	Create(context.Context, *CreateRequest, *TenantInfo) error
+	Get(context.Context, *GetRequest, *TenantInfo) error
+	List(context.Context, *ListRequest, *ListResponse) error
+	UpdateSettings(context.Context, *UpdateSettingsRequest, *TenantInfo) error
+	Suspend(context.Context, *StateRequest, *TenantInfo) error
+	Resume(context.Context, *StateRequest, *TenantInfo) error
+	Delete(context.Context, *DeleteRequest, *DeleteResponse) error
+}
+
+func RegisterTenantHandler(s server.Server, hdlr TenantHandler, opts ...server.HandlerOption) error {
+	type tenant interface {
+		Create(ctx context.Context, in *CreateRequest, out *TenantInfo) error
+		Get(ctx context.Context, in *GetRequest, out *TenantInfo) error
+		List(ctx context.Context, in *ListRequest, out *ListResponse) error
+		UpdateSettings(ctx context.Context, in *UpdateSettingsRequest, out *TenantInfo) error
+		Suspend(ctx context.Context, in *StateRequest, out *TenantInfo) error
+		Resume(ctx context.Context, in *StateRequest, out *TenantInfo) error
+		Delete(ctx context.Context, in *DeleteRequest, out *DeleteResponse) error
+	}
+	type Tenant struct {
+		tenant
+	}
+	h := &tenantHandler{hdlr}
+	return s.Handle(s.NewHandler(&Tenant{h}, opts...))
+}
+
+type tenantHandler struct {
+	TenantHandler
+}
+
+func (h *tenantHandler) Create(ctx context.Context, in *CreateRequest, out *TenantInfo) error {
+	return h.TenantHandler.Create(ctx, in, out)
+}
+
+func (h *tenantHandler) Get(ctx context.Context, in *GetRequest, out *TenantInfo) error {
+	return h.TenantHandler.Get(ctx, in, out)
+}
+
+func (h *tenantHandler) List(ctx context.Context, in *ListRequest, out *ListResponse) error {
+	return h.TenantHandler.List(ctx, in, out)
+}
+
+func (h *tenantHandler) UpdateSettings(ctx context.Context, in *UpdateSettingsRequest, out *TenantInfo) error {
+	return h.TenantHandler.UpdateSettings(ctx, in, out)
+}
+
+func (h *tenantHandler) Suspend(ctx context.Context, in *StateRequest, out *TenantInfo) error {
+	return h.TenantHandler.Suspend(ctx, in, out)
+}
+
+func (h *tenantHandler) Resume(ctx context.Context, in *StateRequest, out *TenantInfo) error {
+	return h.TenantHandler.Resume(ctx, in, out)
+}
+
+func (h *tenantHandler) Delete(ctx context.Context, in *DeleteRequest, out *DeleteResponse) error {
+	return h.TenantHandler.Delete(ctx, in, out)
+}
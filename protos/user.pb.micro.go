@@ -5,6 +5,7 @@ package protos
 
 import (
 	fmt "fmt"
+	_ "github.com/envoyproxy/protoc-gen-validate/validate"
 	proto "github.com/golang/protobuf/proto"
 	math "math"
 )
@@ -50,6 +51,8 @@ type UserService interface {
 	InsertUser(ctx context.Context, in *InsertUserRequest, opts ...client.CallOption) (*InsertUserResponse, error)
 	DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...client.CallOption) (*DeleteUserResponse, error)
 	UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...client.CallOption) (*UserInfo, error)
+	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...client.CallOption) (*ListUsersResponse, error)
+	RestoreUser(ctx context.Context, in *RestoreUserRequest, opts ...client.CallOption) (*UserInfo, error)
 }
 
 type userService struct {
@@ -214,6 +217,26 @@ func (c *userService) UpdateUser(ctx context.Context, in *UpdateUserRequest, opt
 	return out, nil
 }
 
+func (c *userService) ListUsers(ctx context.Context, in *ListUsersRequest, opts ...client.CallOption) (*ListUsersResponse, error) {
+	req := c.c.NewRequest(c.name, "User.ListUsers", in)
+	out := new(ListUsersResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userService) RestoreUser(ctx context.Context, in *RestoreUserRequest, opts ...client.CallOption) (*UserInfo, error) {
+	req := c.c.NewRequest(c.name, "User.RestoreUser", in)
+	out := new(UserInfo)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for User service
 
 type UserHandler interface {
@@ -225,6 +248,8 @@ type UserHandler interface {
 	InsertUser(context.Context, *InsertUserRequest, *InsertUserResponse) error
 	DeleteUser(context.Context, *DeleteUserRequest, *DeleteUserResponse) error
 	UpdateUser(context.Context, *UpdateUserRequest, *UserInfo) error
+	ListUsers(context.Context, *ListUsersRequest, *ListUsersResponse) error
+	RestoreUser(context.Context, *RestoreUserRequest, *UserInfo) error
 }
 
 func RegisterUserHandler(s server.Server, hdlr UserHandler, opts ...server.HandlerOption) error {
@@ -236,6 +261,8 @@ func RegisterUserHandler(s server.Server, hdlr UserHandler, opts ...server.Handl
 		InsertUser(ctx context.Context, in *InsertUserRequest, out *InsertUserResponse) error
 		DeleteUser(ctx context.Context, in *DeleteUserRequest, out *DeleteUserResponse) error
 		UpdateUser(ctx context.Context, in *UpdateUserRequest, out *UserInfo) error
+		ListUsers(ctx context.Context, in *ListUsersRequest, out *ListUsersResponse) error
+		RestoreUser(ctx context.Context, in *RestoreUserRequest, out *UserInfo) error
 	}
 	type User struct {
 		user
@@ -352,3 +379,11 @@ func (h *userHandler) DeleteUser(ctx context.Context, in *DeleteUserRequest, out
 func (h *userHandler) UpdateUser(ctx context.Context, in *UpdateUserRequest, out *UserInfo) error {
 	return h.UserHandler.UpdateUser(ctx, in, out)
 }
+
+func (h *userHandler) ListUsers(ctx context.Context, in *ListUsersRequest, out *ListUsersResponse) error {
+	return h.UserHandler.ListUsers(ctx, in, out)
+}
+
+func (h *userHandler) RestoreUser(ctx context.Context, in *RestoreUserRequest, out *UserInfo) error {
+	return h.UserHandler.RestoreUser(ctx, in, out)
+}
@@ -0,0 +1,60 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	event "github.com/micro-community/auth/protos/message"
+	"github.com/micro/micro/v3/service"
+	log "github.com/micro/micro/v3/service/logger"
+)
+
+//SystemType of the messages published by the service
+const SystemType = "auth"
+
+//Publisher publish events of the service to every topic of PubTopics
+type Publisher struct {
+	topics map[string]*service.Event
+}
+
+//NewPublisher return a Publisher to the topics of options, it publishes nothing without topics
+func NewPublisher(options *Options) *Publisher {
+	p := &Publisher{topics: map[string]*service.Event{}}
+	if options == nil {
+		return p
+	}
+	for _, topic := range options.PubTopics {
+		p.topics[topic] = service.NewEvent(topic)
+	}
+	return p
+}
+
+//Publish an event of the type about the tenant with body json encoded.
+//The event follows a change already made, so a failed publish is logged instead of returned.
+func (p *Publisher) Publish(ctx context.Context, tenantID int, eventType string, body interface{}) {
+	if len(p.topics) == 0 {
+		return
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		log.Errorf("encode event %s of tenant %d error: %v", eventType, tenantID, err)
+		return
+	}
+	now := time.Now()
+	msg := &event.Message{
+		ID:             strconv.FormatInt(now.UnixNano(), 10),
+		CreateDatetime: now.Unix(),
+		TenantID:       strconv.Itoa(tenantID),
+		SystemType:     SystemType,
+		EventType:      eventType,
+		EventStatus:    "success",
+		Body:           data,
+	}
+	for topic, ev := range p.topics {
+		if err = ev.Publish(ctx, msg); err != nil {
+			log.Errorf("publish event %s of tenant %d to %s error: %v", eventType, tenantID, topic, err)
+		}
+	}
+}
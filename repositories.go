@@ -0,0 +1,81 @@
+package main
+
+import (
+	"github.com/micro-community/auth/db"
+	"github.com/micro-community/auth/repository/backup"
+	"github.com/micro-community/auth/repository/dgraph"
+	"github.com/micro-community/auth/repository/file"
+	"github.com/micro-community/auth/repository/mongo"
+	"github.com/micro-community/auth/repository/sql"
+	"github.com/micro-community/auth/repository/transfer"
+	"gorm.io/gorm"
+)
+
+//openRepositories of the database type on the connections of the db context, like the service does
+func openRepositories(dbType string) backup.Repositories {
+	switch dbType {
+	case "mysql", "sqlite":
+		return sqlRepositories(db.DB())
+	case "mongo":
+		m := db.MDB()
+		return backup.Repositories{
+			Repositories: transfer.Repositories{
+				Tenants:   mongo.NewTenantRepository(m),
+				Users:     mongo.NewUserRepository(m),
+				Roles:     mongo.NewRoleRepository(m),
+				Resources: mongo.NewResourceRepository(m),
+				Links:     mongo.NewLinkRepository(m),
+				Templates: mongo.NewRoleTemplateRepository(m),
+				Org:       mongo.NewOrgRepository(m),
+			},
+			Logs: mongo.NewLogRepository(m),
+			Work: mongo.NewUnitOfWork(m),
+		}
+	case "dgraph":
+		return backup.Repositories{
+			Repositories: transfer.Repositories{
+				Tenants:   dgraph.NewTenantRepository(),
+				Users:     dgraph.NewUserRepository(),
+				Roles:     dgraph.NewRoleRepository(),
+				Resources: dgraph.NewResourceRepository(),
+				Links:     dgraph.NewLinkRepository(),
+				Templates: dgraph.NewRoleTemplateRepository(),
+				Org:       dgraph.NewOrgRepository(),
+			},
+			Logs: dgraph.NewLogRepository(),
+			Work: dgraph.NewUnitOfWork(),
+		}
+	default:
+		f := db.FDB()
+		return backup.Repositories{
+			Repositories: transfer.Repositories{
+				Tenants:   file.NewTenantRepository(f),
+				Users:     file.NewUserRepository(f),
+				Roles:     file.NewRoleRepository(f),
+				Resources: file.NewResourceRepository(f),
+				Links:     file.NewLinkRepository(f),
+				Templates: file.NewRoleTemplateRepository(f),
+				Org:       file.NewOrgRepository(f),
+			},
+			Logs: file.NewLogRepository(f),
+			Work: file.NewUnitOfWork(f),
+		}
+	}
+}
+
+//sqlRepositories on g
+func sqlRepositories(g *gorm.DB) backup.Repositories {
+	return backup.Repositories{
+		Repositories: transfer.Repositories{
+			Tenants:   sql.NewTenantRepository(g),
+			Users:     sql.NewUserRepository(g),
+			Roles:     sql.NewRoleRepository(g),
+			Resources: sql.NewResourceRepository(g),
+			Links:     sql.NewLinkRepository(g),
+			Templates: sql.NewRoleTemplateRepository(g),
+			Org:       sql.NewOrgRepository(g),
+		},
+		Logs: sql.NewLogRepository(g),
+		Work: sql.NewUnitOfWork(g),
+	}
+}
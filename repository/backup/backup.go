@@ -0,0 +1,496 @@
+package backup
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	"github.com/micro-community/auth/repository/transfer"
+)
+
+//Repositories of a backend to back up or restore
+type Repositories struct {
+	transfer.Repositories
+	Logs repository.ILog
+	Work repository.UnitOfWork
+}
+
+//batchSize of reads from the repositories
+const batchSize = 100
+
+//Backup write all tenants, role templates and their instances, org units, their members and grants,
+//and users, roles, resources, their links and logs, deleted or not, to w.
+//Reads run in a unit of work, so the backup is a consistent snapshot on backends with transactions,
+//a read-only one when the backend has it, so writes are not held while the backup is streamed.
+func Backup(ctx context.Context, r Repositories, source string, w io.Writer) (Counts, error) {
+	bw, err := NewWriter(w, source)
+	if err != nil {
+		return Counts{}, err
+	}
+	snapshot := r.Work.Do
+	if work, ok := r.Work.(repository.ReadOnlyWork); ok {
+		snapshot = work.View
+	}
+	err = snapshot(repository.WithDeleted(ctx), func(ctx context.Context) error {
+		return backup(ctx, r, bw)
+	})
+	if err != nil {
+		return Counts{}, err
+	}
+	return bw.Close()
+}
+
+func backup(ctx context.Context, r Repositories, w *Writer) error {
+	tenants, err := r.Tenants.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, tenant := range tenants {
+		if err = w.Write(&Record{Kind: TenantRecord, Tenant: tenant}); err != nil {
+			return err
+		}
+	}
+
+	var userIDs, roleIDs []int64
+	for offset := 0; ; offset += batchSize {
+		users, _, err := r.Users.List(ctx, page(offset))
+		if err != nil {
+			return err
+		}
+		for _, user := range users {
+			userIDs = append(userIDs, user.ID)
+			if err = w.Write(&Record{Kind: UserRecord, User: user}); err != nil {
+				return err
+			}
+		}
+		if len(users) < batchSize {
+			break
+		}
+	}
+	for offset := 0; ; offset += batchSize {
+		roles, _, err := r.Roles.List(ctx, page(offset))
+		if err != nil {
+			return err
+		}
+		for _, role := range roles {
+			roleIDs = append(roleIDs, int64(role.ID))
+			if err = w.Write(&Record{Kind: RoleRecord, Role: role}); err != nil {
+				return err
+			}
+		}
+		if len(roles) < batchSize {
+			break
+		}
+	}
+	for offset := 0; ; offset += batchSize {
+		resources, _, err := r.Resources.List(ctx, page(offset))
+		if err != nil {
+			return err
+		}
+		for _, resource := range resources {
+			if err = w.Write(&Record{Kind: ResourceRecord, Resource: resource}); err != nil {
+				return err
+			}
+		}
+		if len(resources) < batchSize {
+			break
+		}
+	}
+
+	for _, id := range userIDs {
+		roles, err := r.Links.UserRoles(ctx, id)
+		if err != nil {
+			return err
+		}
+		for _, role := range roles {
+			if err = w.Write(&Record{Kind: UserRoleRecord, Link: &Link{From: id, To: int64(role.ID)}}); err != nil {
+				return err
+			}
+		}
+	}
+	for _, id := range roleIDs {
+		resources, err := r.Links.RoleResources(ctx, int(id))
+		if err != nil {
+			return err
+		}
+		for _, resource := range resources {
+			if err = w.Write(&Record{Kind: RoleResourceRecord, Link: &Link{From: id, To: int64(resource.ID)}}); err != nil {
+				return err
+			}
+		}
+	}
+
+	//instances follow their templates, after the roles and resources they refer to
+	templates, err := r.Templates.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, template := range templates {
+		if err = w.Write(&Record{Kind: TemplateRecord, Template: template}); err != nil {
+			return err
+		}
+		instances, err := r.Templates.Instances(ctx, template.ID)
+		if err != nil {
+			return err
+		}
+		for _, instance := range instances {
+			if err = w.Write(&Record{Kind: InstanceRecord, Instance: instance}); err != nil {
+				return err
+			}
+		}
+	}
+
+	//units are written parents first, so a restore adds the parents of units before them
+	units, err := r.Org.List(ctx)
+	if err != nil {
+		return err
+	}
+	units = transfer.ParentsFirst(units)
+	for _, unit := range units {
+		if err = w.Write(&Record{Kind: UnitRecord, Unit: unit}); err != nil {
+			return err
+		}
+	}
+	for _, unit := range units {
+		members, err := r.Org.Members(ctx, unit.ID)
+		if err != nil {
+			return err
+		}
+		for _, member := range members {
+			//holders of a position are written with their departments
+			if member.DeptID != unit.ID {
+				continue
+			}
+			if err = w.Write(&Record{Kind: MemberRecord, Member: member}); err != nil {
+				return err
+			}
+		}
+		roleIDs, err := r.Org.Grants(ctx, unit.ID)
+		if err != nil {
+			return err
+		}
+		for _, id := range roleIDs {
+			if err = w.Write(&Record{Kind: UnitRoleRecord, Link: &Link{From: int64(unit.ID), To: int64(id)}}); err != nil {
+				return err
+			}
+		}
+	}
+
+	//logs are queried from the newest and written from the oldest, so a restore appends them in order
+	var logs []*models.Log
+	query := repository.LogQuery{Limit: batchSize}
+	for {
+		batch, err := r.Logs.Query(ctx, query)
+		if err != nil {
+			return err
+		}
+		logs = append(logs, batch...)
+		if len(batch) < batchSize {
+			break
+		}
+		query.BeforeID = batch[len(batch)-1].ID
+	}
+	for i := len(logs) - 1; i >= 0; i-- {
+		if err := w.Write(&Record{Kind: LogRecord, Log: logs[i]}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func page(offset int) repository.ListOptions {
+	return repository.ListOptions{SortBy: repository.SortByID, Offset: offset, Limit: batchSize}
+}
+
+//Restore the backup of r to the empty repositories in a unit of work, which rolls back on failure on backends
+//with transactions. Every backend assigns its own ids, so ids of entities and in logs are mapped to the new ones.
+func Restore(ctx context.Context, r *Reader, dst Repositories) (Counts, error) {
+	ctx = repository.WithDeleted(ctx)
+	if err := mustBeEmpty(ctx, dst); err != nil {
+		return Counts{}, err
+	}
+	err := dst.Work.Do(ctx, func(ctx context.Context) error {
+		return restore(ctx, r.Next, dst)
+	})
+	return r.Counts(), err
+}
+
+func mustBeEmpty(ctx context.Context, r Repositories) error {
+	tenants, err := r.Tenants.List(ctx)
+	if err != nil {
+		return err
+	}
+	_, users, err := r.Users.List(ctx, repository.ListOptions{Limit: 1})
+	if err != nil {
+		return err
+	}
+	_, roles, err := r.Roles.List(ctx, repository.ListOptions{Limit: 1})
+	if err != nil {
+		return err
+	}
+	_, resources, err := r.Resources.List(ctx, repository.ListOptions{Limit: 1})
+	if err != nil {
+		return err
+	}
+	templates, err := r.Templates.List(ctx)
+	if err != nil {
+		return err
+	}
+	units, err := r.Org.List(ctx)
+	if err != nil {
+		return err
+	}
+	if int64(len(tenants)+len(templates)+len(units))+users+roles+resources > 0 {
+		return errs.NewConflict("restore needs an empty database, "+
+			"it has %d tenants, %d users, %d roles, %d resources, %d role templates and %d org units",
+			len(tenants), users, roles, resources, len(templates), len(units))
+	}
+	return nil
+}
+
+//restorer map ids of the backup to the ids of the restored entities
+type restorer struct {
+	dst       Repositories
+	tenants   map[int64]int64
+	users     map[int64]int64
+	roles     map[int64]int64
+	resources map[int64]int64
+	templates map[int64]restoredTemplate
+	units     map[int64]int64
+	//deleted entities are added live to link them, then deleted at the end
+	deleted []func(ctx context.Context) error
+}
+
+//restore the records of next until io.EOF
+func restore(ctx context.Context, next func() (*Record, error), dst Repositories) error {
+	rs := &restorer{dst: dst, tenants: map[int64]int64{}, users: map[int64]int64{}, roles: map[int64]int64{}, resources: map[int64]int64{},
+		templates: map[int64]restoredTemplate{}, units: map[int64]int64{}}
+	for {
+		record, err := next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err = rs.restore(ctx, record); err != nil {
+			return err
+		}
+	}
+	for _, del := range rs.deleted {
+		if err := del(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (rs *restorer) restore(ctx context.Context, record *Record) error {
+	switch record.Kind {
+	case TenantRecord:
+		tenant := *record.Tenant
+		live(&tenant.ModelExtension)
+		if err := rs.dst.Tenants.Add(ctx, &tenant); err != nil {
+			return err
+		}
+		rs.tenants[int64(record.Tenant.ID)] = int64(tenant.ID)
+	case UserRecord:
+		user := *record.User
+		user.Uid, user.Type, user.Roles = "", "", nil
+		user.TenantID = rs.tenant(user.TenantID)
+		deleted := live(&user.ModelExtension)
+		if err := rs.dst.Users.Add(ctx, &user); err != nil {
+			return err
+		}
+		rs.users[record.User.ID] = user.ID
+		if deleted {
+			rs.deleted = append(rs.deleted, func(ctx context.Context) error { return rs.dst.Users.Delete(ctx, user.ID, user.Version) })
+		}
+	case RoleRecord:
+		role := *record.Role
+		role.Uid, role.Type, role.Resources = "", "", nil
+		role.TenantID = rs.tenant(role.TenantID)
+		deleted := live(&role.ModelExtension)
+		if err := rs.dst.Roles.Add(ctx, &role); err != nil {
+			return err
+		}
+		rs.roles[int64(record.Role.ID)] = int64(role.ID)
+		if deleted {
+			rs.deleted = append(rs.deleted, func(ctx context.Context) error { return rs.dst.Roles.Delete(ctx, int64(role.ID), role.Version) })
+		}
+	case ResourceRecord:
+		resource := *record.Resource
+		resource.Uid = ""
+		resource.TenantID = rs.tenant(resource.TenantID)
+		deleted := live(&resource.ModelExtension)
+		if err := rs.dst.Resources.Add(ctx, &resource); err != nil {
+			return err
+		}
+		rs.resources[int64(record.Resource.ID)] = int64(resource.ID)
+		if deleted {
+			rs.deleted = append(rs.deleted, func(ctx context.Context) error {
+				return rs.dst.Resources.Delete(ctx, int64(resource.ID), resource.Version)
+			})
+		}
+	case UserRoleRecord:
+		from, to, err := mapLink(record.Link, rs.users, rs.roles)
+		if err != nil {
+			return err
+		}
+		return rs.dst.Links.LinkUserRole(ctx, from, int(to))
+	case RoleResourceRecord:
+		from, to, err := mapLink(record.Link, rs.roles, rs.resources)
+		if err != nil {
+			return err
+		}
+		return rs.dst.Links.LinkRoleResource(ctx, int(from), int(to))
+	case TemplateRecord:
+		template := *record.Template
+		live(&template.ModelExtension)
+		if err := rs.dst.Templates.Add(ctx, &template); err != nil {
+			return err
+		}
+		rs.templates[int64(record.Template.ID)] = restoredTemplate{template.ID, template.Version, record.Template.Version}
+	case InstanceRecord:
+		return rs.instance(ctx, *record.Instance)
+	case UnitRecord:
+		unit := *record.Unit
+		unit.TenantID = rs.tenant(unit.TenantID)
+		live(&unit.ModelExtension)
+		if unit.ParentID != 0 {
+			parentID, ok := rs.units[int64(unit.ParentID)]
+			if !ok {
+				return errs.NewInvalidArgument("backup has org unit %d under a missing unit %d", unit.ID, unit.ParentID)
+			}
+			unit.ParentID = int(parentID)
+		}
+		if err := rs.dst.Org.Add(ctx, &unit); err != nil {
+			return err
+		}
+		rs.units[int64(record.Unit.ID)] = int64(unit.ID)
+	case MemberRecord:
+		return rs.member(ctx, *record.Member)
+	case UnitRoleRecord:
+		unitID, roleID, err := mapLink(record.Link, rs.units, rs.roles)
+		if err != nil {
+			return err
+		}
+		return rs.dst.Org.Grant(ctx, int(unitID), int(roleID))
+	case LogRecord:
+		log := *record.Log
+		log.ID = 0
+		log.TenantID = rs.tenant(log.TenantID)
+		rs.mapLog(&log)
+		return rs.dst.Logs.Append(ctx, &log)
+	}
+	return nil
+}
+
+//restoredTemplate of a template in the backup, with the version it has in the backup
+type restoredTemplate struct {
+	id            int
+	version       int64
+	backupVersion int64
+}
+
+//instance restore an instance to the restored template and role, an instance behind its template stays behind,
+//as the target numbers the versions of the template anew
+func (rs *restorer) instance(ctx context.Context, instance models.RoleInstance) error {
+	template, ok := rs.templates[int64(instance.TemplateID)]
+	if !ok {
+		return errs.NewInvalidArgument("backup instantiates a missing role template %d", instance.TemplateID)
+	}
+	roleID, ok := rs.roles[int64(instance.RoleID)]
+	if !ok {
+		return errs.NewInvalidArgument("backup instantiates a missing role %d", instance.RoleID)
+	}
+	synced := instance.TemplateVersion >= template.backupVersion
+	instance.RoleID, instance.TemplateID, instance.TemplateVersion = int(roleID), template.id, template.version
+	if !synced {
+		instance.TemplateVersion--
+	}
+	instance.TenantID = rs.tenant(instance.TenantID)
+	if scopeID, ok := rs.resources[int64(instance.ScopeID)]; ok {
+		instance.ScopeID = int(scopeID)
+	}
+	return rs.dst.Templates.SaveInstance(ctx, &instance)
+}
+
+//member restore a member to the restored user, department and position
+func (rs *restorer) member(ctx context.Context, member models.OrgMember) error {
+	userID, ok := rs.users[member.UserID]
+	if !ok {
+		return errs.NewInvalidArgument("backup assigns a missing user %d", member.UserID)
+	}
+	deptID, ok := rs.units[int64(member.DeptID)]
+	if !ok {
+		return errs.NewInvalidArgument("backup assigns user %d to a missing org unit %d", member.UserID, member.DeptID)
+	}
+	if member.PositionID != 0 {
+		positionID, ok := rs.units[int64(member.PositionID)]
+		if !ok {
+			return errs.NewInvalidArgument("backup assigns user %d to a missing org unit %d", member.UserID, member.PositionID)
+		}
+		member.PositionID = int(positionID)
+	}
+	member.UserID, member.DeptID = userID, int(deptID)
+	member.TenantID = rs.tenant(member.TenantID)
+	return rs.dst.Org.SetMember(ctx, &member)
+}
+
+//tenant return the restored id of the tenant id in the backup, the super tenant and deleted tenants keep their ids
+func (rs *restorer) tenant(id int) int {
+	if mapped, ok := rs.tenants[int64(id)]; ok {
+		return int(mapped)
+	}
+	return id
+}
+
+//live reset the fields of ext kept by the target, return whether it was deleted
+func live(ext *models.ModelExtension) bool {
+	deleted := ext.IsSoftDel
+	ext.IsSoftDel, ext.DeletedAt, ext.Version = false, time.Time{}, 0
+	return deleted
+}
+
+func mapLink(link *Link, from, to map[int64]int64) (int64, int64, error) {
+	fromID, ok := from[link.From]
+	if !ok {
+		return 0, 0, errs.NewInvalidArgument("backup links a missing entity %d", link.From)
+	}
+	toID, ok := to[link.To]
+	if !ok {
+		return 0, 0, errs.NewInvalidArgument("backup links a missing entity %d", link.To)
+	}
+	return fromID, toID, nil
+}
+
+//mapLog map the ids of the entity and target of the log, ids of purged entities are kept
+func (rs *restorer) mapLog(log *models.Log) {
+	entities, targets := rs.users, rs.roles
+	switch log.Kind {
+	case models.RoleChange:
+		entities, targets = rs.roles, rs.resources
+	case models.ResourceChange:
+		entities, targets = rs.resources, nil
+	case models.OrgChange:
+		entities, targets = rs.units, rs.roles
+	}
+	log.EntityID = mapID(log.EntityID, entities)
+	log.TargetID = mapID(log.TargetID, targets)
+}
+
+func mapID(id string, ids map[int64]int64) string {
+	old, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return id
+	}
+	if mapped, ok := ids[old]; ok {
+		return strconv.FormatInt(mapped, 10)
+	}
+	return id
+}
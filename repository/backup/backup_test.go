@@ -0,0 +1,255 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+)
+
+func newMemory(t *testing.T) Repositories {
+	r, err := emptyMemory(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+//seed users linked to roles linked to resources with a log of each user, the last user is deleted.
+//The entities are of a tenant, whose id is not the first one. The first two roles are instantiated
+//from a template, the second is scoped to a resource and behind the template. The first user holds a position
+//in a department granted the second role, which is moved under an organization added after it
+func seed(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	gone, tenant := &models.Tenant{Name: "gone"}, &models.Tenant{Name: "acme", State: models.TenantActive}
+	for _, err := range []error{r.Tenants.Add(ctx, gone), r.Tenants.Delete(ctx, gone.ID, 1), r.Tenants.Add(ctx, tenant)} {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i < 3; i++ {
+		resource := &models.Resource{Name: fmt.Sprint("resource", i), TenantID: tenant.ID}
+		role := &models.Role{Name: fmt.Sprint("role", i), Key: fmt.Sprint("key", i), TenantID: tenant.ID}
+		user := &models.User{Name: fmt.Sprint("user", i), Age: int64(20 + i), TenantID: tenant.ID}
+		for _, err := range []error{
+			r.Resources.Add(ctx, resource),
+			r.Roles.Add(ctx, role),
+			r.Users.Add(ctx, user),
+			r.Links.LinkRoleResource(ctx, role.ID, resource.ID),
+			r.Links.LinkUserRole(ctx, user.ID, role.ID),
+			r.Logs.Append(ctx, &models.Log{TenantID: tenant.ID, Kind: models.UserChange, Action: models.Created, EntityID: strconv.FormatInt(user.ID, 10)}),
+		} {
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	acme := repository.WithTenant(ctx, tenant.ID)
+	user, _ := r.Users.FindByName(acme, "user2")
+	if err := r.Users.Delete(ctx, user.ID, user.Version); err != nil {
+		t.Fatal(err)
+	}
+
+	template := &models.RoleTemplate{Name: "admin", Key: "admin", Grants: models.Keys{"key0"}}
+	if err := r.Templates.Add(ctx, template); err != nil {
+		t.Fatal(err)
+	}
+	template.Grants = append(template.Grants, "key1")
+	if err := r.Templates.Update(ctx, template); err != nil {
+		t.Fatal(err)
+	}
+	role0, _ := r.Roles.FindByName(acme, "role0")
+	role1, _ := r.Roles.FindByName(acme, "role1")
+	resource1, _ := r.Resources.FindByName(acme, "resource1")
+	for _, instance := range []*models.RoleInstance{
+		{RoleID: role0.ID, TemplateID: template.ID, TemplateVersion: template.Version, TenantID: tenant.ID},
+		{RoleID: role1.ID, TemplateID: template.ID, TemplateVersion: template.Version - 1, TenantID: tenant.ID, ScopeID: resource1.ID},
+	} {
+		if err := r.Templates.SaveInstance(ctx, instance); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	hq := &models.OrgUnit{Kind: models.OrgOrganization, Name: "hq", TenantID: tenant.ID}
+	dev := &models.OrgUnit{Kind: models.OrgDepartment, Name: "dev", TenantID: tenant.ID}
+	branch := &models.OrgUnit{Kind: models.OrgOrganization, Name: "branch", TenantID: tenant.ID}
+	lead := &models.OrgUnit{Kind: models.OrgPosition, Name: "lead", TenantID: tenant.ID}
+	user0, _ := r.Users.FindByName(acme, "user0")
+	for _, fn := range []func() error{
+		func() error { return r.Org.Add(ctx, hq) },
+		func() error { dev.ParentID = hq.ID; return r.Org.Add(ctx, dev) },
+		func() error { return r.Org.Add(ctx, branch) },
+		func() error { dev.ParentID = branch.ID; return r.Org.Update(ctx, dev) },
+		func() error { lead.ParentID = dev.ID; return r.Org.Add(ctx, lead) },
+		func() error {
+			return r.Org.SetMember(ctx, &models.OrgMember{UserID: user0.ID, DeptID: dev.ID, PositionID: lead.ID, TenantID: tenant.ID})
+		},
+		func() error { return r.Org.Grant(ctx, dev.ID, role1.ID) },
+		func() error {
+			return r.Logs.Append(ctx, &models.Log{TenantID: tenant.ID, Kind: models.OrgChange, Action: models.Linked,
+				EntityID: strconv.Itoa(dev.ID), TargetID: strconv.Itoa(role1.ID)})
+		},
+	} {
+		if err := fn(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestBackupRestore(t *testing.T) {
+	ctx := context.Background()
+	src := newMemory(t)
+	seed(t, src)
+	var buf bytes.Buffer
+	counts, err := Backup(ctx, src, "memory", &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Counts{Tenants: 1, Users: 3, Roles: 3, Resources: 3, UserRoles: 3, RoleResources: 3, Templates: 1, Instances: 2,
+		Units: 4, Members: 1, UnitRoles: 1, Logs: 4}
+	if counts != want {
+		t.Fatalf("counts %+v, want %+v", counts, want)
+	}
+
+	r, err := NewReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	diffs, _, err := Verify(ctx, r)
+	if err != nil || len(diffs) > 0 {
+		t.Fatalf("verify: %v %v", diffs, err)
+	}
+
+	dst := newMemory(t)
+	if err = dst.Users.Add(ctx, &models.User{Name: "existing"}); err != nil {
+		t.Fatal(err)
+	}
+	r, _ = NewReader(bytes.NewReader(buf.Bytes()))
+	if _, err = Restore(ctx, r, dst); err == nil {
+		t.Fatal("restore to a database with data should fail")
+	}
+
+	dst = newMemory(t)
+	r, _ = NewReader(bytes.NewReader(buf.Bytes()))
+	if _, err = Restore(ctx, r, dst); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	tenant, err := dst.Tenants.FindByName(ctx, "acme")
+	if err != nil || tenant.State != models.TenantActive {
+		t.Fatalf("tenant should be restored: %+v %v", tenant, err)
+	}
+	acme := repository.WithTenant(ctx, tenant.ID)
+	user, err := dst.Users.FindByName(repository.WithDeleted(acme), "user2")
+	if err != nil || !user.IsSoftDel {
+		t.Fatalf("user2 should be restored deleted to tenant %d: %v", tenant.ID, err)
+	}
+	logs, err := dst.Logs.Query(ctx, repository.LogQuery{TenantID: tenant.ID})
+	if err != nil || len(logs) != 4 {
+		t.Errorf("logs should be restored to tenant %d, got %d %v", tenant.ID, len(logs), err)
+	}
+
+	template, err := dst.Templates.FindByName(ctx, "admin")
+	if err != nil {
+		t.Fatalf("template should be restored: %v", err)
+	}
+	instances, err := dst.Templates.Instances(ctx, template.ID)
+	if err != nil || len(instances) != 2 {
+		t.Fatalf("instances should be restored, got %d %v", len(instances), err)
+	}
+	role0, _ := dst.Roles.FindByName(acme, "role0")
+	role1, _ := dst.Roles.FindByName(acme, "role1")
+	resource1, _ := dst.Resources.FindByName(acme, "resource1")
+	want0 := models.RoleInstance{RoleID: role0.ID, TemplateID: template.ID, TemplateVersion: template.Version, TenantID: tenant.ID}
+	want1 := models.RoleInstance{RoleID: role1.ID, TemplateID: template.ID, TemplateVersion: template.Version - 1, TenantID: tenant.ID,
+		ScopeID: resource1.ID}
+	for i, want := range []models.RoleInstance{want0, want1} {
+		got := *instances[i]
+		got.CreatedAt = want.CreatedAt
+		if got != want {
+			t.Errorf("instance %d restored as %+v, want %+v", i, got, want)
+		}
+	}
+
+	user0, _ := dst.Users.FindByName(acme, "user0")
+	member, err := dst.Org.MemberOf(ctx, user0.ID)
+	if err != nil {
+		t.Fatalf("member should be restored: %v", err)
+	}
+	dev, _ := dst.Org.FindById(ctx, member.DeptID)
+	branch, _ := dst.Org.FindById(ctx, dev.ParentID)
+	lead, _ := dst.Org.FindById(ctx, member.PositionID)
+	if dev.Name != "dev" || branch.Name != "branch" || lead.Name != "lead" || lead.ParentID != dev.ID || dev.TenantID != tenant.ID {
+		t.Errorf("org units restored as %+v %+v %+v", branch, dev, lead)
+	}
+	if roleIDs, err := dst.Org.Grants(ctx, dev.ID); err != nil || len(roleIDs) != 1 || roleIDs[0] != role1.ID {
+		t.Errorf("role1 should be granted to dev, got %v %v", roleIDs, err)
+	}
+	//the newest log is the grant
+	if logs[0].EntityID != strconv.Itoa(dev.ID) || logs[0].TargetID != strconv.Itoa(role1.ID) {
+		t.Errorf("log of the grant should be mapped to unit %d and role %d, got %+v", dev.ID, role1.ID, logs[0])
+	}
+}
+
+func TestCorruptedBackup(t *testing.T) {
+	src := newMemory(t)
+	seed(t, src)
+	var buf bytes.Buffer
+	if _, err := Backup(context.Background(), src, "memory", &buf); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	if _, _, err := Check(bytes.NewReader(data[:len(data)/2])); err == nil {
+		t.Errorf("truncated backup should fail the check")
+	}
+	var tampered bytes.Buffer
+	w, _ := NewWriter(&tampered, "memory")
+	r, _ := NewReader(bytes.NewReader(data))
+	for record, err := r.Next(); err == nil; record, err = r.Next() {
+		if record.Kind == UserRecord {
+			record.User.Age++
+		}
+		w.enc.Encode(record)
+	}
+	//the checksum of the end record does not match the tampered lines
+	end := &Record{Kind: EndRecord, Counts: &w.counts, Checksum: "0"}
+	w.enc.Encode(end)
+	w.gz.Close()
+	if _, _, err := Check(&tampered); err == nil {
+		t.Errorf("tampered backup should fail the check")
+	}
+}
+
+//readOnlyWork record the kind of transactions units of work run in
+type readOnlyWork struct {
+	repository.UnitOfWork
+	views, writes int
+}
+
+func (w *readOnlyWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	w.writes++
+	return w.UnitOfWork.Do(ctx, fn)
+}
+
+func (w *readOnlyWork) View(ctx context.Context, fn func(ctx context.Context) error) error {
+	w.views++
+	return fn(ctx)
+}
+
+func TestBackupReadsInReadOnlyWork(t *testing.T) {
+	src := newMemory(t)
+	seed(t, src)
+	work := &readOnlyWork{UnitOfWork: src.Work}
+	src.Work = work
+	var buf bytes.Buffer
+	if _, err := Backup(context.Background(), src, "file", &buf); err != nil {
+		t.Fatal(err)
+	}
+	if work.views != 1 || work.writes != 0 {
+		t.Errorf("backup ran %d read-only and %d writable units of work, want only a read-only one", work.views, work.writes)
+	}
+}
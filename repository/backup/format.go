@@ -0,0 +1,256 @@
+//Package backup write tenants, users, roles, resources, their links, role templates, their instances,
+//org units, their members and grants, and logs of any backend to a backup, and restore a backup to any backend.
+//
+//A backup is gzip compressed json lines: a Header, the records of tenants, users, roles, resources, links,
+//templates, instances, units, members, grants and logs,
+//and an end record of their counts and the sha256 checksum of all lines before it.
+package backup
+
+import (
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
+	"io"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+)
+
+const (
+	//Format of the header of a backup
+	Format = "micro-auth-backup"
+	//Version of the format written, backups of any version up to it are read.
+	//Version 2 adds tenants, role templates, their instances, org units, their members and grants
+	Version = 2
+)
+
+//Header of a backup
+type Header struct {
+	Format    string    `json:"format"`
+	Version   int       `json:"version"`
+	Source    string    `json:"source"` // DBType backed up
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+//Kind of a record
+type Kind string
+
+const (
+	TenantRecord       Kind = "tenant"
+	UserRecord         Kind = "user"
+	RoleRecord         Kind = "role"
+	ResourceRecord     Kind = "resource"
+	UserRoleRecord     Kind = "user_role"
+	RoleResourceRecord Kind = "role_resource"
+	TemplateRecord     Kind = "role_template"
+	InstanceRecord     Kind = "role_instance"
+	UnitRecord         Kind = "org_unit"
+	MemberRecord       Kind = "org_member"
+	UnitRoleRecord     Kind = "unit_role"
+	LogRecord          Kind = "log"
+	EndRecord          Kind = "end"
+)
+
+//Link of a user to a role, a role to a resource or an org unit to a role by the ids in the backup
+type Link struct {
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+}
+
+//Counts of the records in a backup
+type Counts struct {
+	Tenants       int64 `json:"tenants"`
+	Users         int64 `json:"users"`
+	Roles         int64 `json:"roles"`
+	Resources     int64 `json:"resources"`
+	UserRoles     int64 `json:"userRoles"`
+	RoleResources int64 `json:"roleResources"`
+	Templates     int64 `json:"templates"`
+	Instances     int64 `json:"instances"`
+	Units         int64 `json:"units"`
+	Members       int64 `json:"members"`
+	UnitRoles     int64 `json:"unitRoles"`
+	Logs          int64 `json:"logs"`
+}
+
+//add a record of the kind
+func (c *Counts) add(kind Kind) {
+	switch kind {
+	case TenantRecord:
+		c.Tenants++
+	case UserRecord:
+		c.Users++
+	case RoleRecord:
+		c.Roles++
+	case ResourceRecord:
+		c.Resources++
+	case UserRoleRecord:
+		c.UserRoles++
+	case RoleResourceRecord:
+		c.RoleResources++
+	case TemplateRecord:
+		c.Templates++
+	case InstanceRecord:
+		c.Instances++
+	case UnitRecord:
+		c.Units++
+	case MemberRecord:
+		c.Members++
+	case UnitRoleRecord:
+		c.UnitRoles++
+	case LogRecord:
+		c.Logs++
+	}
+}
+
+//Record of a backup, the field of its kind is set
+type Record struct {
+	Kind     Kind                 `json:"kind"`
+	Tenant   *models.Tenant       `json:"tenant,omitempty"`
+	User     *models.User         `json:"user,omitempty"`
+	Role     *models.Role         `json:"role,omitempty"`
+	Resource *models.Resource     `json:"resource,omitempty"`
+	Link     *Link                `json:"link,omitempty"`
+	Template *models.RoleTemplate `json:"template,omitempty"`
+	Instance *models.RoleInstance `json:"instance,omitempty"`
+	Unit     *models.OrgUnit      `json:"unit,omitempty"`
+	Member   *models.OrgMember    `json:"member,omitempty"`
+	Log      *models.Log          `json:"log,omitempty"`
+	Counts   *Counts              `json:"counts,omitempty"`
+	Checksum string               `json:"checksum,omitempty"`
+}
+
+//Writer of a backup
+type Writer struct {
+	gz     *gzip.Writer
+	sum    hash.Hash
+	enc    *json.Encoder
+	counts Counts
+}
+
+//NewWriter write the header of a backup of the source to w
+func NewWriter(w io.Writer, source string) (*Writer, error) {
+	gz := gzip.NewWriter(w)
+	sum := sha256.New()
+	bw := &Writer{gz: gz, sum: sum, enc: json.NewEncoder(io.MultiWriter(gz, sum))}
+	header := Header{Format: Format, Version: Version, Source: source, CreatedAt: time.Now()}
+	if err := bw.enc.Encode(header); err != nil {
+		return nil, err
+	}
+	return bw, nil
+}
+
+//Write a record
+func (w *Writer) Write(record *Record) error {
+	w.counts.add(record.Kind)
+	return w.enc.Encode(record)
+}
+
+//Close write the end record and flush the backup, the underlying writer is not closed
+func (w *Writer) Close() (Counts, error) {
+	counts := w.counts
+	end := &Record{Kind: EndRecord, Counts: &counts, Checksum: hex.EncodeToString(w.sum.Sum(nil))}
+	if err := json.NewEncoder(w.gz).Encode(end); err != nil {
+		return counts, err
+	}
+	return counts, w.gz.Close()
+}
+
+//Reader of a backup, the checksum and counts are verified at the end record
+type Reader struct {
+	Header Header
+	lines  *bufio.Reader
+	sum    hash.Hash
+	counts Counts
+	done   bool
+}
+
+//NewReader read the header of a backup from r
+func NewReader(r io.Reader) (*Reader, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errs.Wrap(err, errs.InvalidArgument, "not a backup")
+	}
+	br := &Reader{lines: bufio.NewReader(gz), sum: sha256.New()}
+	line, err := br.line()
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(line, &br.Header); err != nil || br.Header.Format != Format {
+		return nil, errs.NewInvalidArgument("not a backup")
+	}
+	if br.Header.Version > Version {
+		return nil, errs.NewInvalidArgument("backup version %d is newer than %d", br.Header.Version, Version)
+	}
+	return br, nil
+}
+
+//line read a line into the checksum
+func (r *Reader) line() ([]byte, error) {
+	line, err := r.lines.ReadBytes('\n')
+	if err == io.EOF {
+		return nil, errs.NewInvalidArgument("backup is truncated")
+	}
+	if err != nil {
+		return nil, errs.Wrap(err, errs.InvalidArgument, "read backup")
+	}
+	r.sum.Write(line)
+	return line, nil
+}
+
+//Next record of the backup, io.EOF after the end record is verified
+func (r *Reader) Next() (*Record, error) {
+	if r.done {
+		return nil, io.EOF
+	}
+	checksum := hex.EncodeToString(r.sum.Sum(nil))
+	line, err := r.line()
+	if err != nil {
+		return nil, err
+	}
+	record := &Record{}
+	if err = json.Unmarshal(line, record); err != nil {
+		return nil, errs.Wrap(err, errs.InvalidArgument, "corrupted backup record")
+	}
+	if record.Kind != EndRecord {
+		r.counts.add(record.Kind)
+		return record, nil
+	}
+
+	r.done = true
+	if record.Checksum != checksum {
+		return nil, errs.NewInvalidArgument("backup checksum mismatch")
+	}
+	if record.Counts == nil || *record.Counts != r.counts {
+		return nil, errs.NewInvalidArgument("backup counts mismatch")
+	}
+	if _, err = r.lines.ReadByte(); err != io.EOF {
+		return nil, errs.NewInvalidArgument("data after the end of backup")
+	}
+	return nil, io.EOF
+}
+
+//Counts of the records read
+func (r *Reader) Counts() Counts {
+	return r.counts
+}
+
+//Check read a backup to the end to verify its checksum and counts
+func Check(r io.Reader) (Header, Counts, error) {
+	br, err := NewReader(r)
+	if err != nil {
+		return Header{}, Counts{}, err
+	}
+	for {
+		if _, err = br.Next(); err == io.EOF {
+			return br.Header, br.Counts(), nil
+		}
+		if err != nil {
+			return br.Header, br.Counts(), err
+		}
+	}
+}
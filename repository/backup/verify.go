@@ -0,0 +1,375 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	"github.com/micro-community/auth/repository/memory"
+	"github.com/micro-community/auth/repository/transfer"
+)
+
+//volatile fields of entities assigned by the backend a backup is restored to,
+//tenants are assigned new ids too, so entities are compared by the names of their tenants, and units by their paths
+var volatile = []string{"id", "uid", "dgraph.type", "version", "deletedAt", "roles", "Resources", "tenantId", "TenantId", "parentId"}
+
+//Verify restore the backup of r to the memory backend and compare the restored data with the backup,
+//return the differences found
+func Verify(ctx context.Context, r *Reader) ([]string, Counts, error) {
+	var records []*Record
+	for {
+		record, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, r.Counts(), err
+		}
+		records = append(records, record)
+	}
+
+	ctx = repository.WithDeleted(ctx)
+	dst, err := emptyMemory(ctx)
+	if err != nil {
+		return nil, r.Counts(), err
+	}
+	i := 0
+	next := func() (*Record, error) {
+		if i == len(records) {
+			return nil, io.EOF
+		}
+		i++
+		return records[i-1], nil
+	}
+	if err = restore(ctx, next, dst); err != nil {
+		return nil, r.Counts(), err
+	}
+	diffs, err := diff(ctx, records, dst)
+	return diffs, r.Counts(), err
+}
+
+//emptyMemory return repositories of the memory backend without the entities it is seeded with
+func emptyMemory(ctx context.Context) (Repositories, error) {
+	users, roles, resources := memory.NewUserRepository(), memory.NewRoleRepository(), memory.NewResourceRepository()
+	r := Repositories{
+		Repositories: transfer.Repositories{
+			Tenants:   memory.NewTenantRepository(),
+			Users:     users,
+			Roles:     roles,
+			Resources: resources,
+			Links:     memory.NewLinkRepository(users, roles, resources),
+			Templates: memory.NewRoleTemplateRepository(),
+			Org:       memory.NewOrgRepository(),
+		},
+		Logs: memory.NewLogRepository(),
+		Work: memory.NewUnitOfWork(),
+	}
+	seededUsers, _, err := users.List(ctx, repository.ListOptions{})
+	if err != nil {
+		return r, err
+	}
+	for _, user := range seededUsers {
+		if err = users.Delete(ctx, user.ID, user.Version); err != nil {
+			return r, err
+		}
+	}
+	seededRoles, _, err := roles.List(ctx, repository.ListOptions{})
+	if err != nil {
+		return r, err
+	}
+	for _, role := range seededRoles {
+		if err = roles.Delete(ctx, int64(role.ID), role.Version); err != nil {
+			return r, err
+		}
+	}
+	purgeAll := time.Now().Add(time.Second)
+	if _, err = users.Purge(ctx, purgeAll); err != nil {
+		return r, err
+	}
+	_, err = roles.Purge(ctx, purgeAll)
+	return r, err
+}
+
+//named entity of a tenant in the backup, names of users, roles and resources are unique in their tenants
+type named struct {
+	tenant int
+	name   string
+}
+
+//label of the entity with the name of its tenant, entities of the super tenant and deleted tenants have none
+func (n named) label(tenantNames map[int]string) string {
+	if name, ok := tenantNames[n.tenant]; ok {
+		return name + "/" + n.name
+	}
+	return n.name
+}
+
+//restoredIn return a ctx of the restored tenant the entity is looked up by name in,
+//entities of the super tenant and deleted tenants keep their tenant ids
+func restoredIn(ctx context.Context, r Repositories, tenantNames map[int]string, n named) context.Context {
+	tenant := n.tenant
+	if name, ok := tenantNames[n.tenant]; ok {
+		if restored, err := r.Tenants.FindByName(ctx, name); err == nil {
+			tenant = restored.ID
+		}
+	}
+	return repository.WithTenant(ctx, tenant)
+}
+
+//diff the records of a backup with the repositories restored from it, entities are matched by name in their tenants
+func diff(ctx context.Context, records []*Record, r Repositories) ([]string, error) {
+	var diffs []string
+	tenantNames := map[int]string{}
+	userNames, roleNames, resourceNames := map[int64]named{}, map[int64]named{}, map[int64]named{}
+	userRoles, roleResources := map[named][]string{}, map[named][]string{}
+	templateNames, templateRoles := map[int]string{}, map[string][]string{}
+	var units []*models.OrgUnit
+	var members []*models.OrgMember
+	unitRoles := map[int][]string{}
+	var logs int
+	in := func(n named) context.Context { return restoredIn(ctx, r, tenantNames, n) }
+	label := func(n named) string { return n.label(tenantNames) }
+	for _, record := range records {
+		var restored interface{}
+		var err error
+		switch record.Kind {
+		case TenantRecord:
+			tenantNames[record.Tenant.ID] = record.Tenant.Name
+			restored, err = r.Tenants.FindByName(ctx, record.Tenant.Name)
+			err = compare(&diffs, "tenant "+record.Tenant.Name, record.Tenant, restored, err)
+		case UserRecord:
+			n := named{record.User.TenantID, record.User.Name}
+			userNames[record.User.ID] = n
+			userRoles[n] = []string{}
+			restored, err = r.Users.FindByName(in(n), n.name)
+			err = compare(&diffs, "user "+label(n), record.User, restored, err)
+		case RoleRecord:
+			n := named{record.Role.TenantID, record.Role.Name}
+			roleNames[int64(record.Role.ID)] = n
+			roleResources[n] = []string{}
+			restored, err = r.Roles.FindByName(in(n), n.name)
+			err = compare(&diffs, "role "+label(n), record.Role, restored, err)
+		case ResourceRecord:
+			n := named{record.Resource.TenantID, record.Resource.Name}
+			resourceNames[int64(record.Resource.ID)] = n
+			restored, err = r.Resources.FindByName(in(n), n.name)
+			err = compare(&diffs, "resource "+label(n), record.Resource, restored, err)
+		case UserRoleRecord:
+			n := userNames[record.Link.From]
+			userRoles[n] = append(userRoles[n], roleNames[record.Link.To].name)
+		case RoleResourceRecord:
+			n := roleNames[record.Link.From]
+			roleResources[n] = append(roleResources[n], resourceNames[record.Link.To].name)
+		case TemplateRecord:
+			templateNames[record.Template.ID] = record.Template.Name
+			templateRoles[record.Template.Name] = []string{}
+			restored, err = r.Templates.FindByName(ctx, record.Template.Name)
+			err = compare(&diffs, "role template "+record.Template.Name, record.Template, restored, err)
+		case InstanceRecord:
+			name := templateNames[record.Instance.TemplateID]
+			templateRoles[name] = append(templateRoles[name], roleNames[int64(record.Instance.RoleID)].name)
+		case UnitRecord:
+			units = append(units, record.Unit)
+			unitRoles[record.Unit.ID] = []string{}
+		case MemberRecord:
+			members = append(members, record.Member)
+		case UnitRoleRecord:
+			unitRoles[int(record.Link.From)] = append(unitRoles[int(record.Link.From)], roleNames[record.Link.To].name)
+		case LogRecord:
+			logs++
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for n, want := range userRoles {
+		user, err := r.Users.FindByName(in(n), n.name)
+		if err != nil {
+			continue
+		}
+		roles, err := r.Links.UserRoles(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		var got []string
+		for _, role := range roles {
+			got = append(got, role.Name)
+		}
+		compareNames(&diffs, "roles of user "+label(n), want, got)
+	}
+	for n, want := range roleResources {
+		role, err := r.Roles.FindByName(in(n), n.name)
+		if err != nil {
+			continue
+		}
+		resources, err := r.Links.RoleResources(ctx, role.ID)
+		if err != nil {
+			return nil, err
+		}
+		var got []string
+		for _, resource := range resources {
+			got = append(got, resource.Name)
+		}
+		compareNames(&diffs, "resources of role "+label(n), want, got)
+	}
+	for name, want := range templateRoles {
+		template, err := r.Templates.FindByName(ctx, name)
+		if err != nil {
+			continue
+		}
+		instances, err := r.Templates.Instances(ctx, template.ID)
+		if err != nil {
+			return nil, err
+		}
+		var got []string
+		for _, instance := range instances {
+			if role, err := r.Roles.FindById(ctx, int64(instance.RoleID)); err == nil {
+				got = append(got, role.Name)
+			}
+		}
+		compareNames(&diffs, "roles instantiated from template "+name, want, got)
+	}
+
+	orgDiffs, err := diffOrg(ctx, r, units, members, unitRoles, tenantNames, userNames)
+	if err != nil {
+		return nil, err
+	}
+	diffs = append(diffs, orgDiffs...)
+
+	restoredLogs, err := r.Logs.Query(ctx, repository.LogQuery{})
+	if err != nil {
+		return nil, err
+	}
+	if len(restoredLogs) != logs {
+		diffs = append(diffs, fmt.Sprintf("logs: %d in backup, %d restored", logs, len(restoredLogs)))
+	}
+	sort.Strings(diffs)
+	return diffs, nil
+}
+
+//diffOrg compare the units, members and grants of a backup with the restored ones, units are matched by their paths
+func diffOrg(ctx context.Context, r Repositories, units []*models.OrgUnit, members []*models.OrgMember,
+	unitRoles map[int][]string, tenantNames map[int]string, userNames map[int64]named) ([]string, error) {
+	var diffs []string
+	restoredUnits, err := r.Org.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	want := unitPaths(units, func(id int) string { return tenantNames[id] })
+	got := unitPaths(restoredUnits, func(id int) string {
+		if tenant, err := r.Tenants.FindById(ctx, id); err == nil {
+			return tenant.Name
+		}
+		return ""
+	})
+	byPath := map[string]*models.OrgUnit{}
+	for _, unit := range restoredUnits {
+		byPath[got[unit.ID]] = unit
+	}
+
+	for _, unit := range units {
+		path := want[unit.ID]
+		restored, ok := byPath[path]
+		if !ok {
+			diffs = append(diffs, fmt.Sprintf("org unit %s: not restored", path))
+			continue
+		}
+		if err = compare(&diffs, "org unit "+path, unit, restored, nil); err != nil {
+			return nil, err
+		}
+		roleIDs, err := r.Org.Grants(ctx, restored.ID)
+		if err != nil {
+			return nil, err
+		}
+		var roles []string
+		for _, id := range roleIDs {
+			if role, err := r.Roles.FindById(ctx, int64(id)); err == nil {
+				roles = append(roles, role.Name)
+			}
+		}
+		compareNames(&diffs, "roles granted to org unit "+path, unitRoles[unit.ID], roles)
+	}
+
+	for _, member := range members {
+		n := userNames[member.UserID]
+		wantUnits := want[member.DeptID] + " " + want[member.PositionID]
+		var gotUnits string
+		if user, err := r.Users.FindByName(restoredIn(ctx, r, tenantNames, n), n.name); err == nil {
+			if restored, err := r.Org.MemberOf(ctx, user.ID); err == nil {
+				gotUnits = got[restored.DeptID] + " " + got[restored.PositionID]
+			}
+		}
+		if gotUnits != wantUnits {
+			diffs = append(diffs, fmt.Sprintf("org member %s: in %q in backup, %q restored", n.label(tenantNames), wantUnits, gotUnits))
+		}
+	}
+	return diffs, nil
+}
+
+//unitPaths of units by id, the path of a unit is the name of its tenant and the names of the units down to it
+func unitPaths(units []*models.OrgUnit, tenantName func(id int) string) map[int]string {
+	paths := map[int]string{}
+	for _, unit := range transfer.ParentsFirst(units) {
+		parent, ok := paths[unit.ParentID]
+		if !ok {
+			parent = tenantName(unit.TenantID)
+		}
+		paths[unit.ID] = parent + "/" + unit.Name
+	}
+	return paths
+}
+
+//compare the fields of an entity in the backup and the restored one, except the volatile ones
+func compare(diffs *[]string, name string, backup, restored interface{}, err error) error {
+	if err != nil {
+		*diffs = append(*diffs, fmt.Sprintf("%s: %v", name, err))
+		return nil
+	}
+	want, err := fields(backup)
+	if err != nil {
+		return err
+	}
+	got, err := fields(restored)
+	if err != nil {
+		return err
+	}
+	for field, value := range want {
+		if !reflect.DeepEqual(value, got[field]) {
+			*diffs = append(*diffs, fmt.Sprintf("%s: %s is %v in backup, %v restored", name, field, value, got[field]))
+		}
+	}
+	return nil
+}
+
+func fields(entity interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{}
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for _, field := range volatile {
+		delete(fields, field)
+	}
+	return fields, nil
+}
+
+func compareNames(diffs *[]string, name string, want, got []string) {
+	sort.Strings(want)
+	sort.Strings(got)
+	if len(want) == 0 && len(got) == 0 {
+		return
+	}
+	if !reflect.DeepEqual(want, got) {
+		*diffs = append(*diffs, fmt.Sprintf("%s: %v in backup, %v restored", name, want, got))
+	}
+}
@@ -0,0 +1,159 @@
+package conformance
+
+import (
+	"context"
+	"time"
+
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+)
+
+func userRepo(r repository.IUser) entityRepo {
+	return entityRepo{
+		add: func(ctx context.Context, name string) (int64, error) {
+			user := &models.User{Name: name, TenantID: repository.NameTenant(ctx)}
+			err := r.Add(ctx, user)
+			return user.ID, err
+		},
+		findByID: func(ctx context.Context, id int64) (string, time.Time, error) {
+			user, err := r.FindById(ctx, id)
+			if err != nil {
+				return "", time.Time{}, err
+			}
+			return user.Name, user.CreatedAt, nil
+		},
+		findByName: func(ctx context.Context, name string) (int64, error) {
+			user, err := r.FindByName(ctx, name)
+			if err != nil {
+				return 0, err
+			}
+			return user.ID, nil
+		},
+		version: func(ctx context.Context, id int64) (int64, error) {
+			user, err := r.FindById(repository.WithDeleted(ctx), id)
+			if err != nil {
+				return 0, err
+			}
+			return user.Version, nil
+		},
+		renameAt: func(ctx context.Context, id int64, name string, version int64) error {
+			user, err := r.FindById(ctx, id)
+			if err != nil {
+				user = &models.User{ID: id}
+			}
+			user.Name, user.Version = name, version
+			return r.Update(ctx, user)
+		},
+		deleteAt: r.Delete,
+		restore:  r.Restore,
+		purge:    r.Purge,
+		list: func(ctx context.Context, opts repository.ListOptions) ([]string, int64, error) {
+			users, total, err := r.List(ctx, opts)
+			names := make([]string, 0, len(users))
+			for _, user := range users {
+				names = append(names, user.Name)
+			}
+			return names, total, err
+		},
+	}
+}
+
+func roleRepo(r repository.IRole) entityRepo {
+	return entityRepo{
+		add: func(ctx context.Context, name string) (int64, error) {
+			role := &models.Role{Name: name, TenantID: repository.NameTenant(ctx)}
+			err := r.Add(ctx, role)
+			return int64(role.ID), err
+		},
+		findByID: func(ctx context.Context, id int64) (string, time.Time, error) {
+			role, err := r.FindById(ctx, id)
+			if err != nil {
+				return "", time.Time{}, err
+			}
+			return role.Name, role.CreatedAt, nil
+		},
+		findByName: func(ctx context.Context, name string) (int64, error) {
+			role, err := r.FindByName(ctx, name)
+			if err != nil {
+				return 0, err
+			}
+			return int64(role.ID), nil
+		},
+		version: func(ctx context.Context, id int64) (int64, error) {
+			role, err := r.FindById(repository.WithDeleted(ctx), id)
+			if err != nil {
+				return 0, err
+			}
+			return role.Version, nil
+		},
+		renameAt: func(ctx context.Context, id int64, name string, version int64) error {
+			role, err := r.FindById(ctx, id)
+			if err != nil {
+				role = &models.Role{ID: int(id)}
+			}
+			role.Name, role.Version = name, version
+			return r.Update(ctx, role)
+		},
+		deleteAt: r.Delete,
+		restore:  r.Restore,
+		purge:    r.Purge,
+		list: func(ctx context.Context, opts repository.ListOptions) ([]string, int64, error) {
+			roles, total, err := r.List(ctx, opts)
+			names := make([]string, 0, len(roles))
+			for _, role := range roles {
+				names = append(names, role.Name)
+			}
+			return names, total, err
+		},
+	}
+}
+
+func resourceRepo(r repository.IResource) entityRepo {
+	return entityRepo{
+		add: func(ctx context.Context, name string) (int64, error) {
+			resource := &models.Resource{Name: name, TenantID: repository.NameTenant(ctx)}
+			err := r.Add(ctx, resource)
+			return int64(resource.ID), err
+		},
+		findByID: func(ctx context.Context, id int64) (string, time.Time, error) {
+			resource, err := r.FindById(ctx, id)
+			if err != nil {
+				return "", time.Time{}, err
+			}
+			return resource.Name, resource.CreatedAt, nil
+		},
+		findByName: func(ctx context.Context, name string) (int64, error) {
+			resource, err := r.FindByName(ctx, name)
+			if err != nil {
+				return 0, err
+			}
+			return int64(resource.ID), nil
+		},
+		version: func(ctx context.Context, id int64) (int64, error) {
+			resource, err := r.FindById(repository.WithDeleted(ctx), id)
+			if err != nil {
+				return 0, err
+			}
+			return resource.Version, nil
+		},
+		renameAt: func(ctx context.Context, id int64, name string, version int64) error {
+			resource, err := r.FindById(ctx, id)
+			if err != nil {
+				resource = &models.Resource{ID: int(id)}
+			}
+			resource.Name, resource.Version = name, version
+			return r.Update(ctx, resource)
+		},
+		deleteAt: r.Delete,
+		restore:  r.Restore,
+		purge:    r.Purge,
+		list: func(ctx context.Context, opts repository.ListOptions) ([]string, int64, error) {
+			resources, total, err := r.List(ctx, opts)
+			names := make([]string, 0, len(resources))
+			for _, resource := range resources {
+				names = append(names, resource.Name)
+			}
+			return names, total, err
+		},
+	}
+}
@@ -0,0 +1,700 @@
+//Package conformance is the shared test suite of repository implementations,
+//every backend runs it to keep the semantics documented in package repository
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+)
+
+//missingID is an id no backend assigns in the suite
+const missingID = 1<<31 - 1
+
+//entityRepo adapt IUser, IRole and IResource to the cases of the suite
+type entityRepo struct {
+	add        func(ctx context.Context, name string) (int64, error)
+	findByID   func(ctx context.Context, id int64) (string, time.Time, error)
+	findByName func(ctx context.Context, name string) (int64, error)
+	version    func(ctx context.Context, id int64) (int64, error)
+	renameAt   func(ctx context.Context, id int64, name string, version int64) error
+	deleteAt   func(ctx context.Context, id, version int64) error
+	restore    func(ctx context.Context, id int64) error
+	purge      func(ctx context.Context, before time.Time) (int64, error)
+	list       func(ctx context.Context, opts repository.ListOptions) ([]string, int64, error)
+}
+
+//rename the entity of id at its current version, or at version 0 when it is missing
+func (r entityRepo) rename(ctx context.Context, id int64, name string) error {
+	version, _ := r.version(ctx, id)
+	return r.renameAt(ctx, id, name, version)
+}
+
+//delete the entity of id at its current version, or at version 0 when it is missing
+func (r entityRepo) delete(ctx context.Context, id int64) error {
+	version, _ := r.version(ctx, id)
+	return r.deleteAt(ctx, id, version)
+}
+
+type testCase struct {
+	name string
+	run  func(t *testing.T, r entityRepo, prefix string)
+}
+
+//cases of the suite, names of a case start with its prefix to isolate it in a shared store
+var cases = []testCase{
+	{"AddAndFind", testAddAndFind},
+	{"FindMissing", testFindMissing},
+	{"Duplicated", testDuplicated},
+	{"SameNameInTenants", testSameNameInTenants},
+	{"Update", testUpdate},
+	{"UpdateMissing", testUpdateMissing},
+	{"UpdateDuplicated", testUpdateDuplicated},
+	{"UpdateNameOfAnotherTenant", testUpdateNameOfAnotherTenant},
+	{"Delete", testDelete},
+	{"DeleteMissing", testDeleteMissing},
+	{"SoftDelete", testSoftDelete},
+	{"Restore", testRestore},
+	{"Purge", testPurge},
+	{"Version", testVersion},
+	{"StaleVersion", testStaleVersion},
+	{"ConcurrentUpdate", testConcurrentUpdate},
+	{"Pagination", testPagination},
+	{"Sort", testSort},
+	{"Keyset", testKeyset},
+	{"ConcurrentAdd", testConcurrentAdd},
+	{"ConcurrentDuplicated", testConcurrentDuplicated},
+}
+
+//RunUser run the suite against the IUser created by newRepo for every case
+func RunUser(t *testing.T, newRepo func(t *testing.T) repository.IUser) {
+	run(t, func(t *testing.T) entityRepo { return userRepo(newRepo(t)) })
+}
+
+//RunRole run the suite against the IRole created by newRepo for every case
+func RunRole(t *testing.T, newRepo func(t *testing.T) repository.IRole) {
+	run(t, func(t *testing.T) entityRepo { return roleRepo(newRepo(t)) })
+}
+
+//RunResource run the suite against the IResource created by newRepo for every case
+func RunResource(t *testing.T, newRepo func(t *testing.T) repository.IResource) {
+	run(t, func(t *testing.T) entityRepo { return resourceRepo(newRepo(t)) })
+	t.Run("Search", func(t *testing.T) { testSearch(t, newRepo(t), prefixOf(t)) })
+}
+
+func run(t *testing.T, newRepo func(t *testing.T) entityRepo) {
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			c.run(t, newRepo(t), prefixOf(t))
+		})
+	}
+}
+
+//prefixOf a test, unique in a run and between runs
+func prefixOf(t *testing.T) string {
+	return fmt.Sprintf("%s-%d-", t.Name(), time.Now().UnixNano())
+}
+
+func testAddAndFind(t *testing.T, r entityRepo, prefix string) {
+	ctx := context.Background()
+	id, err := r.add(ctx, prefix+"a")
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if id == 0 {
+		t.Fatal("add: no id assigned")
+	}
+
+	name, created, err := r.findByID(ctx, id)
+	if err != nil {
+		t.Fatalf("find by id: %v", err)
+	}
+	if name != prefix+"a" {
+		t.Errorf("find by id: name %q, want %q", name, prefix+"a")
+	}
+	if created.IsZero() {
+		t.Error("find by id: created time not set")
+	}
+
+	found, err := r.findByName(ctx, prefix+"a")
+	if err != nil {
+		t.Fatalf("find by name: %v", err)
+	}
+	if found != id {
+		t.Errorf("find by name: id %d, want %d", found, id)
+	}
+}
+
+func testFindMissing(t *testing.T, r entityRepo, prefix string) {
+	ctx := context.Background()
+	if _, _, err := r.findByID(ctx, missingID); errs.CodeOf(err) != errs.NotFound {
+		t.Errorf("find by id: %v, want NotFound", err)
+	}
+	if _, err := r.findByName(ctx, prefix+"missing"); errs.CodeOf(err) != errs.NotFound {
+		t.Errorf("find by name: %v, want NotFound", err)
+	}
+}
+
+func testDuplicated(t *testing.T, r entityRepo, prefix string) {
+	ctx := context.Background()
+	if _, err := r.add(ctx, prefix+"a"); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if _, err := r.add(ctx, prefix+"a"); errs.CodeOf(err) != errs.AlreadyExists {
+		t.Errorf("add duplicated: %v, want AlreadyExists", err)
+	}
+}
+
+func testSameNameInTenants(t *testing.T, r entityRepo, prefix string) {
+	tenant := int(time.Now().UnixNano() % 1000000)
+	first, second := repository.WithTenant(context.Background(), tenant), repository.WithTenant(context.Background(), tenant+1)
+	firstID, err := r.add(first, prefix+"a")
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	secondID, err := r.add(second, prefix+"a")
+	if err != nil {
+		t.Fatalf("add name of another tenant: %v", err)
+	}
+	if found, err := r.findByName(first, prefix+"a"); err != nil || found != firstID {
+		t.Errorf("find by name in the first tenant: %d, %v, want %d", found, err, firstID)
+	}
+	if found, err := r.findByName(second, prefix+"a"); err != nil || found != secondID {
+		t.Errorf("find by name in the second tenant: %d, %v, want %d", found, err, secondID)
+	}
+	if _, err = r.findByName(context.Background(), prefix+"a"); errs.CodeOf(err) != errs.NotFound {
+		t.Errorf("find by name in the super tenant: %v, want NotFound", err)
+	}
+	if _, err = r.add(second, prefix+"a"); errs.CodeOf(err) != errs.AlreadyExists {
+		t.Errorf("add duplicated in a tenant: %v, want AlreadyExists", err)
+	}
+}
+
+func testUpdate(t *testing.T, r entityRepo, prefix string) {
+	ctx := context.Background()
+	id, err := r.add(ctx, prefix+"a")
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	_, created, err := r.findByID(ctx, id)
+	if err != nil {
+		t.Fatalf("find by id: %v", err)
+	}
+
+	if err = r.rename(ctx, id, prefix+"b"); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	name, updatedCreated, err := r.findByID(ctx, id)
+	if err != nil {
+		t.Fatalf("find by id: %v", err)
+	}
+	if name != prefix+"b" {
+		t.Errorf("update: name %q, want %q", name, prefix+"b")
+	}
+	if !updatedCreated.Equal(created) {
+		t.Errorf("update: created time changed from %v to %v", created, updatedCreated)
+	}
+	if _, err = r.findByName(ctx, prefix+"a"); errs.CodeOf(err) != errs.NotFound {
+		t.Errorf("find old name: %v, want NotFound", err)
+	}
+}
+
+func testUpdateMissing(t *testing.T, r entityRepo, prefix string) {
+	if err := r.rename(context.Background(), missingID, prefix+"a"); errs.CodeOf(err) != errs.NotFound {
+		t.Errorf("update: %v, want NotFound", err)
+	}
+}
+
+func testUpdateDuplicated(t *testing.T, r entityRepo, prefix string) {
+	ctx := context.Background()
+	if _, err := r.add(ctx, prefix+"a"); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	id, err := r.add(ctx, prefix+"b")
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	deleted, err := r.add(ctx, prefix+"c")
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if err = r.delete(ctx, deleted); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	if err = r.rename(ctx, id, prefix+"a"); errs.CodeOf(err) != errs.AlreadyExists {
+		t.Errorf("update to a taken name: %v, want AlreadyExists", err)
+	}
+	if err = r.rename(ctx, id, prefix+"c"); errs.CodeOf(err) != errs.AlreadyExists {
+		t.Errorf("update to the name of a deleted one: %v, want AlreadyExists", err)
+	}
+	if name, _, err := r.findByID(ctx, id); err != nil || name != prefix+"b" {
+		t.Errorf("find after rejected updates: %q, %v, want %q", name, err, prefix+"b")
+	}
+	if err = r.rename(ctx, id, prefix+"b"); err != nil {
+		t.Errorf("update keeping its name: %v", err)
+	}
+}
+
+func testUpdateNameOfAnotherTenant(t *testing.T, r entityRepo, prefix string) {
+	tenant := int(time.Now().UnixNano() % 1000000)
+	first, second := repository.WithTenant(context.Background(), tenant), repository.WithTenant(context.Background(), tenant+1)
+	firstID, err := r.add(first, prefix+"a")
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	secondID, err := r.add(second, prefix+"b")
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+
+	if err = r.rename(second, secondID, prefix+"a"); err != nil {
+		t.Fatalf("update to a name of another tenant: %v", err)
+	}
+	if found, err := r.findByName(second, prefix+"a"); err != nil || found != secondID {
+		t.Errorf("find by name in the second tenant: %d, %v, want %d", found, err, secondID)
+	}
+	if found, err := r.findByName(first, prefix+"a"); err != nil || found != firstID {
+		t.Errorf("find by name in the first tenant: %d, %v, want %d", found, err, firstID)
+	}
+}
+
+func testDelete(t *testing.T, r entityRepo, prefix string) {
+	ctx := context.Background()
+	id, err := r.add(ctx, prefix+"a")
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if err = r.delete(ctx, id); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	if _, _, err = r.findByID(ctx, id); errs.CodeOf(err) != errs.NotFound {
+		t.Errorf("find deleted by id: %v, want NotFound", err)
+	}
+	if _, err = r.findByName(ctx, prefix+"a"); errs.CodeOf(err) != errs.NotFound {
+		t.Errorf("find deleted by name: %v, want NotFound", err)
+	}
+	names, total, err := r.list(ctx, repository.ListOptions{NamePrefix: prefix})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if total != 0 || len(names) != 0 {
+		t.Errorf("list deleted: %v of %d, want none", names, total)
+	}
+	if err = r.delete(ctx, id); errs.CodeOf(err) != errs.NotFound {
+		t.Errorf("delete again: %v, want NotFound", err)
+	}
+}
+
+func testDeleteMissing(t *testing.T, r entityRepo, prefix string) {
+	if err := r.delete(context.Background(), missingID); errs.CodeOf(err) != errs.NotFound {
+		t.Errorf("delete: %v, want NotFound", err)
+	}
+}
+
+func testSoftDelete(t *testing.T, r entityRepo, prefix string) {
+	ctx := context.Background()
+	id, err := r.add(ctx, prefix+"a")
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if err = r.delete(ctx, id); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	deleted := repository.WithDeleted(ctx)
+	if name, _, err := r.findByID(deleted, id); err != nil || name != prefix+"a" {
+		t.Errorf("find deleted by id with deleted: %q, %v", name, err)
+	}
+	if found, err := r.findByName(deleted, prefix+"a"); err != nil || found != id {
+		t.Errorf("find deleted by name with deleted: %d, %v, want %d", found, err, id)
+	}
+	if names, total, err := r.list(deleted, repository.ListOptions{NamePrefix: prefix}); err != nil || total != 1 || len(names) != 1 {
+		t.Errorf("list with deleted: %v of %d, %v, want 1", names, total, err)
+	}
+	if _, err = r.add(ctx, prefix+"a"); errs.CodeOf(err) != errs.AlreadyExists {
+		t.Errorf("add name of deleted: %v, want AlreadyExists", err)
+	}
+	if err = r.rename(ctx, id, prefix+"b"); errs.CodeOf(err) != errs.NotFound {
+		t.Errorf("update deleted: %v, want NotFound", err)
+	}
+}
+
+func testRestore(t *testing.T, r entityRepo, prefix string) {
+	ctx := context.Background()
+	id, err := r.add(ctx, prefix+"a")
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if err = r.restore(ctx, id); errs.CodeOf(err) != errs.NotFound {
+		t.Errorf("restore live: %v, want NotFound", err)
+	}
+	if err = r.restore(ctx, missingID); errs.CodeOf(err) != errs.NotFound {
+		t.Errorf("restore missing: %v, want NotFound", err)
+	}
+
+	if err = r.delete(ctx, id); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if err = r.restore(ctx, id); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if name, _, err := r.findByID(ctx, id); err != nil || name != prefix+"a" {
+		t.Errorf("find restored: %q, %v", name, err)
+	}
+	if err = r.rename(ctx, id, prefix+"b"); err != nil {
+		t.Errorf("update restored: %v", err)
+	}
+}
+
+func testPurge(t *testing.T, r entityRepo, prefix string) {
+	ctx := context.Background()
+	id, err := r.add(ctx, prefix+"a")
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	kept, err := r.add(ctx, prefix+"b")
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if err = r.delete(ctx, id); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	if n, err := r.purge(ctx, time.Now().Add(-time.Hour)); err != nil {
+		t.Fatalf("purge before deletion: %v", err)
+	} else if _, _, err = r.findByID(repository.WithDeleted(ctx), id); err != nil {
+		t.Errorf("purge before deletion removed %d, find: %v", n, err)
+	}
+
+	n, err := r.purge(ctx, time.Now().Add(time.Second))
+	if err != nil {
+		t.Fatalf("purge: %v", err)
+	}
+	if n < 1 {
+		t.Errorf("purge: %d purged, want at least 1", n)
+	}
+	if _, _, err = r.findByID(repository.WithDeleted(ctx), id); errs.CodeOf(err) != errs.NotFound {
+		t.Errorf("find purged with deleted: %v, want NotFound", err)
+	}
+	if _, _, err = r.findByID(ctx, kept); err != nil {
+		t.Errorf("find live after purge: %v", err)
+	}
+	if _, err = r.add(ctx, prefix+"a"); err != nil {
+		t.Errorf("add name of purged: %v", err)
+	}
+}
+
+func testVersion(t *testing.T, r entityRepo, prefix string) {
+	ctx := context.Background()
+	id, err := r.add(ctx, prefix+"a")
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	steps := []struct {
+		name string
+		run  func() error
+	}{
+		{"add", func() error { return nil }},
+		{"update", func() error { return r.rename(ctx, id, prefix+"b") }},
+		{"delete", func() error { return r.delete(ctx, id) }},
+		{"restore", func() error { return r.restore(ctx, id) }},
+	}
+	for i, step := range steps {
+		if err = step.run(); err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		if version, err := r.version(ctx, id); err != nil || version != int64(i+1) {
+			t.Errorf("version after %s: %d, %v, want %d", step.name, version, err, i+1)
+		}
+	}
+}
+
+func testStaleVersion(t *testing.T, r entityRepo, prefix string) {
+	ctx := context.Background()
+	id, err := r.add(ctx, prefix+"a")
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if err = r.renameAt(ctx, id, prefix+"b", 1); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+
+	if err = r.renameAt(ctx, id, prefix+"c", 1); errs.CodeOf(err) != errs.Conflict {
+		t.Errorf("update at stale version: %v, want Conflict", err)
+	}
+	if err = r.deleteAt(ctx, id, 1); errs.CodeOf(err) != errs.Conflict {
+		t.Errorf("delete at stale version: %v, want Conflict", err)
+	}
+	if name, _, err := r.findByID(ctx, id); err != nil || name != prefix+"b" {
+		t.Errorf("find after stale writes: %q, %v, want %q", name, err, prefix+"b")
+	}
+	if err = r.renameAt(ctx, missingID, prefix+"c", 1); errs.CodeOf(err) != errs.NotFound {
+		t.Errorf("update missing: %v, want NotFound", err)
+	}
+	if err = r.deleteAt(ctx, missingID, 1); errs.CodeOf(err) != errs.NotFound {
+		t.Errorf("delete missing: %v, want NotFound", err)
+	}
+	if err = r.deleteAt(ctx, id, 2); err != nil {
+		t.Errorf("delete at version: %v", err)
+	}
+}
+
+func testConcurrentUpdate(t *testing.T, r entityRepo, prefix string) {
+	const n = 10
+	ctx := context.Background()
+	id, err := r.add(ctx, prefix+"a")
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	errors := make([]error, n)
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errors[i] = r.renameAt(ctx, id, fmt.Sprintf("%s%d", prefix, i), 1)
+		}(i)
+	}
+	wg.Wait()
+
+	updated := 0
+	for _, err := range errors {
+		switch {
+		case err == nil:
+			updated++
+		case errs.CodeOf(err) != errs.Conflict:
+			t.Errorf("update at the same version: %v, want Conflict", err)
+		}
+	}
+	if updated != 1 {
+		t.Errorf("update at the same version: %d updated, want 1", updated)
+	}
+	if version, err := r.version(ctx, id); err != nil || version != 2 {
+		t.Errorf("version: %d, %v, want 2", version, err)
+	}
+}
+
+func testPagination(t *testing.T, r entityRepo, prefix string) {
+	ctx := context.Background()
+	var want []string
+	for i := 0; i < 5; i++ {
+		name := fmt.Sprintf("%s%d", prefix, i)
+		if _, err := r.add(ctx, name); err != nil {
+			t.Fatalf("add: %v", err)
+		}
+		want = append(want, name)
+	}
+
+	var got []string
+	for offset := 0; offset < len(want); offset += 2 {
+		names, total, err := r.list(ctx, repository.ListOptions{NamePrefix: prefix, Offset: offset, Limit: 2})
+		if err != nil {
+			t.Fatalf("list: %v", err)
+		}
+		if total != int64(len(want)) {
+			t.Errorf("list from %d: total %d, want %d", offset, total, len(want))
+		}
+		got = append(got, names...)
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("pages: %v, want %v", got, want)
+	}
+
+	names, _, err := r.list(ctx, repository.ListOptions{NamePrefix: prefix, Offset: len(want)})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(names) != 0 {
+		t.Errorf("list after the end: %v, want none", names)
+	}
+}
+
+func testKeyset(t *testing.T, r entityRepo, prefix string) {
+	ctx := context.Background()
+	for _, name := range []string{"b", "c", "a", "d"} {
+		if _, err := r.add(ctx, prefix+name); err != nil {
+			t.Fatalf("add: %v", err)
+		}
+	}
+	keyOf := func(name string) *repository.ListKey {
+		id, err := r.findByName(ctx, name)
+		if err != nil {
+			t.Fatalf("find by name: %v", err)
+		}
+		_, created, err := r.findByID(ctx, id)
+		if err != nil {
+			t.Fatalf("find by id: %v", err)
+		}
+		return &repository.ListKey{ID: id, Name: name, Created: created}
+	}
+
+	tests := []struct {
+		sortBy repository.SortField
+		desc   bool
+		want   []string
+	}{
+		{repository.SortByID, false, []string{"b", "c", "a", "d"}},
+		{repository.SortByID, true, []string{"d", "a", "c", "b"}},
+		{repository.SortByName, false, []string{"a", "b", "c", "d"}},
+		{repository.SortByName, true, []string{"d", "c", "b", "a"}},
+		{repository.SortByCreatedAt, false, []string{"b", "c", "a", "d"}},
+	}
+	for _, tt := range tests {
+		var got []string
+		opts := repository.ListOptions{NamePrefix: prefix, SortBy: tt.sortBy, Desc: tt.desc, Limit: 3}
+		for {
+			names, total, err := r.list(ctx, opts)
+			if err != nil {
+				t.Fatalf("list: %v", err)
+			}
+			if total != 4 {
+				t.Errorf("sort by %v desc %v: total %d, want 4", tt.sortBy, tt.desc, total)
+			}
+			got = append(got, names...)
+			if len(names) < opts.Limit {
+				break
+			}
+			opts.After = keyOf(names[len(names)-1])
+		}
+		want := make([]string, 0, len(tt.want))
+		for _, name := range tt.want {
+			want = append(want, prefix+name)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("sort by %v desc %v: pages %v, want %v", tt.sortBy, tt.desc, got, want)
+		}
+	}
+}
+
+func testSort(t *testing.T, r entityRepo, prefix string) {
+	ctx := context.Background()
+	for _, name := range []string{"b", "c", "a"} {
+		if _, err := r.add(ctx, prefix+name); err != nil {
+			t.Fatalf("add: %v", err)
+		}
+	}
+
+	tests := []struct {
+		sortBy repository.SortField
+		desc   bool
+		want   []string
+	}{
+		{repository.SortByID, false, []string{"b", "c", "a"}},
+		{repository.SortByID, true, []string{"a", "c", "b"}},
+		{repository.SortByName, false, []string{"a", "b", "c"}},
+		{repository.SortByName, true, []string{"c", "b", "a"}},
+	}
+	for _, tt := range tests {
+		names, _, err := r.list(ctx, repository.ListOptions{NamePrefix: prefix, SortBy: tt.sortBy, Desc: tt.desc})
+		if err != nil {
+			t.Fatalf("list: %v", err)
+		}
+		want := make([]string, 0, len(tt.want))
+		for _, name := range tt.want {
+			want = append(want, prefix+name)
+		}
+		if fmt.Sprint(names) != fmt.Sprint(want) {
+			t.Errorf("sort by %v desc %v: %v, want %v", tt.sortBy, tt.desc, names, want)
+		}
+	}
+}
+
+func testConcurrentAdd(t *testing.T, r entityRepo, prefix string) {
+	const n = 10
+	ctx := context.Background()
+	ids := make([]int64, n)
+	errors := make([]error, n)
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ids[i], errors[i] = r.add(ctx, fmt.Sprintf("%s%d", prefix, i))
+		}(i)
+	}
+	wg.Wait()
+
+	seen := map[int64]bool{}
+	for i := 0; i < n; i++ {
+		if errors[i] != nil {
+			t.Fatalf("add: %v", errors[i])
+		}
+		if seen[ids[i]] {
+			t.Errorf("add: id %d assigned twice", ids[i])
+		}
+		seen[ids[i]] = true
+	}
+	if _, total, err := r.list(ctx, repository.ListOptions{NamePrefix: prefix}); err != nil || total != n {
+		t.Errorf("list: %d, %v, want %d", total, err, n)
+	}
+}
+
+func testConcurrentDuplicated(t *testing.T, r entityRepo, prefix string) {
+	const n = 10
+	ctx := context.Background()
+	errors := make([]error, n)
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errors[i] = r.add(ctx, prefix+"a")
+		}(i)
+	}
+	wg.Wait()
+
+	added := 0
+	for _, err := range errors {
+		switch {
+		case err == nil:
+			added++
+		case errs.CodeOf(err) != errs.AlreadyExists:
+			t.Errorf("add duplicated: %v, want AlreadyExists", err)
+		}
+	}
+	if added != 1 {
+		t.Errorf("add duplicated: %d added, want 1", added)
+	}
+}
+
+func testSearch(t *testing.T, r repository.IResource, prefix string) {
+	ctx := context.Background()
+	tenant := int(time.Now().UnixNano() % 1000000)
+	for i, typ := range []models.ResourceCatalog{models.UIMenu, models.Device, models.UIMenu} {
+		resource := &models.Resource{Name: fmt.Sprintf("%s%d", prefix, i), TenantID: tenant, Type: int(typ)}
+		if err := r.Add(ctx, resource); err != nil {
+			t.Fatalf("add: %v", err)
+		}
+	}
+
+	tests := []struct {
+		types []models.ResourceCatalog
+		want  int
+	}{
+		{nil, 3},
+		{[]models.ResourceCatalog{models.UIMenu}, 2},
+		{[]models.ResourceCatalog{models.UIMenu, models.Device}, 3},
+		{[]models.ResourceCatalog{models.System}, 0},
+	}
+	for _, tt := range tests {
+		resources, err := r.Search(ctx, tenant, tt.types...)
+		if err != nil {
+			t.Fatalf("search: %v", err)
+		}
+		if len(resources) != tt.want {
+			t.Errorf("search %v: %d resources, want %d", tt.types, len(resources), tt.want)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+)
+
+//Repositories of a backend, links are checked against entities of the same backend
+type Repositories struct {
+	Users     repository.IUser
+	Roles     repository.IRole
+	Resources repository.IResource
+	Links     repository.ILink
+	Work      repository.UnitOfWork
+}
+
+//linkFixture is a user with roles a (resources x, y) and b (resource y), entities are named by the prefix
+type linkFixture struct {
+	user         *models.User
+	roleA, roleB *models.Role
+	unlinkedRole *models.Role // a role of nobody, linked to resource z
+	resX, resY   *models.Resource
+	resZ         *models.Resource
+}
+
+func newLinkFixture(t *testing.T, r Repositories, prefix string) linkFixture {
+	ctx := context.Background()
+	f := linkFixture{
+		user:         &models.User{Name: prefix + "user"},
+		roleA:        &models.Role{Name: prefix + "a"},
+		roleB:        &models.Role{Name: prefix + "b"},
+		unlinkedRole: &models.Role{Name: prefix + "c"},
+		resX:         &models.Resource{Name: prefix + "x"},
+		resY:         &models.Resource{Name: prefix + "y"},
+		resZ:         &models.Resource{Name: prefix + "z"},
+	}
+	must := func(err error) {
+		if err != nil {
+			t.Fatalf("fixture: %v", err)
+		}
+	}
+	must(r.Users.Add(ctx, f.user))
+	for _, role := range []*models.Role{f.roleA, f.roleB, f.unlinkedRole} {
+		must(r.Roles.Add(ctx, role))
+	}
+	for _, resource := range []*models.Resource{f.resX, f.resY, f.resZ} {
+		must(r.Resources.Add(ctx, resource))
+	}
+
+	must(r.Links.LinkUserRole(ctx, f.user.ID, f.roleB.ID))
+	must(r.Links.LinkUserRole(ctx, f.user.ID, f.roleA.ID))
+	must(r.Links.LinkRoleResource(ctx, f.roleA.ID, f.resY.ID))
+	must(r.Links.LinkRoleResource(ctx, f.roleA.ID, f.resX.ID))
+	must(r.Links.LinkRoleResource(ctx, f.roleB.ID, f.resY.ID))
+	must(r.Links.LinkRoleResource(ctx, f.unlinkedRole.ID, f.resZ.ID))
+	return f
+}
+
+func roleNames(roles []*models.Role) string {
+	names := make([]string, 0, len(roles))
+	for _, role := range roles {
+		names = append(names, role.Name)
+	}
+	return fmt.Sprint(names)
+}
+
+func resourceNames(resources []*models.Resource) string {
+	names := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		names = append(names, resource.Name)
+	}
+	return fmt.Sprint(names)
+}
+
+var linkCases = []struct {
+	name string
+	run  func(t *testing.T, r Repositories, f linkFixture)
+}{
+	{"Query", testLinkQuery},
+	{"Duplicated", testLinkDuplicated},
+	{"MissingEnd", testLinkMissingEnd},
+	{"Unlink", testUnlink},
+	{"DeletedEnd", testLinkDeletedEnd},
+	{"RestoredEnd", testLinkRestoredEnd},
+	{"PurgedEnd", testLinkPurgedEnd},
+}
+
+//RunLink run the link suite against the repositories created by newRepos for every case
+func RunLink(t *testing.T, newRepos func(t *testing.T) Repositories) {
+	for _, c := range linkCases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			r := newRepos(t)
+			c.run(t, r, newLinkFixture(t, r, prefixOf(t)))
+		})
+	}
+}
+
+func testLinkQuery(t *testing.T, r Repositories, f linkFixture) {
+	ctx := context.Background()
+	roles, err := r.Links.UserRoles(ctx, f.user.ID)
+	if err != nil {
+		t.Fatalf("user roles: %v", err)
+	}
+	if got, want := roleNames(roles), roleNames([]*models.Role{f.roleA, f.roleB}); got != want {
+		t.Errorf("user roles: %s, want %s", got, want)
+	}
+
+	resources, err := r.Links.RoleResources(ctx, f.roleA.ID)
+	if err != nil {
+		t.Fatalf("role resources: %v", err)
+	}
+	if got, want := resourceNames(resources), resourceNames([]*models.Resource{f.resX, f.resY}); got != want {
+		t.Errorf("role resources: %s, want %s", got, want)
+	}
+
+	resources, err = r.Links.UserResources(ctx, f.user.ID)
+	if err != nil {
+		t.Fatalf("user resources: %v", err)
+	}
+	if got, want := resourceNames(resources), resourceNames([]*models.Resource{f.resX, f.resY}); got != want {
+		t.Errorf("user resources: %s, want %s", got, want)
+	}
+}
+
+func testLinkDuplicated(t *testing.T, r Repositories, f linkFixture) {
+	ctx := context.Background()
+	if err := r.Links.LinkUserRole(ctx, f.user.ID, f.roleA.ID); errs.CodeOf(err) != errs.AlreadyExists {
+		t.Errorf("link user role again: %v, want AlreadyExists", err)
+	}
+	if err := r.Links.LinkRoleResource(ctx, f.roleA.ID, f.resX.ID); errs.CodeOf(err) != errs.AlreadyExists {
+		t.Errorf("link role resource again: %v, want AlreadyExists", err)
+	}
+}
+
+func testLinkMissingEnd(t *testing.T, r Repositories, f linkFixture) {
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"link missing user", r.Links.LinkUserRole(ctx, missingID, f.roleA.ID)},
+		{"link missing role", r.Links.LinkUserRole(ctx, f.user.ID, missingID)},
+		{"link missing resource", r.Links.LinkRoleResource(ctx, f.roleA.ID, missingID)},
+	}
+	for _, tt := range tests {
+		if errs.CodeOf(tt.err) != errs.NotFound {
+			t.Errorf("%s: %v, want NotFound", tt.name, tt.err)
+		}
+	}
+	if _, err := r.Links.UserRoles(ctx, missingID); errs.CodeOf(err) != errs.NotFound {
+		t.Errorf("roles of missing user: %v, want NotFound", err)
+	}
+	if _, err := r.Links.RoleResources(ctx, missingID); errs.CodeOf(err) != errs.NotFound {
+		t.Errorf("resources of missing role: %v, want NotFound", err)
+	}
+}
+
+func testUnlink(t *testing.T, r Repositories, f linkFixture) {
+	ctx := context.Background()
+	if err := r.Links.UnlinkUserRole(ctx, f.user.ID, f.roleA.ID); err != nil {
+		t.Fatalf("unlink user role: %v", err)
+	}
+	if err := r.Links.UnlinkUserRole(ctx, f.user.ID, f.roleA.ID); errs.CodeOf(err) != errs.NotFound {
+		t.Errorf("unlink user role again: %v, want NotFound", err)
+	}
+	if err := r.Links.UnlinkRoleResource(ctx, f.roleB.ID, f.resY.ID); err != nil {
+		t.Fatalf("unlink role resource: %v", err)
+	}
+	if err := r.Links.UnlinkRoleResource(ctx, f.roleB.ID, f.resY.ID); errs.CodeOf(err) != errs.NotFound {
+		t.Errorf("unlink role resource again: %v, want NotFound", err)
+	}
+
+	roles, err := r.Links.UserRoles(ctx, f.user.ID)
+	if err != nil {
+		t.Fatalf("user roles: %v", err)
+	}
+	if got, want := roleNames(roles), roleNames([]*models.Role{f.roleB}); got != want {
+		t.Errorf("user roles: %s, want %s", got, want)
+	}
+	resources, err := r.Links.UserResources(ctx, f.user.ID)
+	if err != nil {
+		t.Fatalf("user resources: %v", err)
+	}
+	if len(resources) != 0 {
+		t.Errorf("user resources: %s, want none", resourceNames(resources))
+	}
+}
+
+func testLinkDeletedEnd(t *testing.T, r Repositories, f linkFixture) {
+	ctx := context.Background()
+	if err := r.Roles.Delete(ctx, int64(f.roleA.ID), f.roleA.Version); err != nil {
+		t.Fatalf("delete role: %v", err)
+	}
+	if err := r.Resources.Delete(ctx, int64(f.resY.ID), f.resY.Version); err != nil {
+		t.Fatalf("delete resource: %v", err)
+	}
+
+	roles, err := r.Links.UserRoles(ctx, f.user.ID)
+	if err != nil {
+		t.Fatalf("user roles: %v", err)
+	}
+	if got, want := roleNames(roles), roleNames([]*models.Role{f.roleB}); got != want {
+		t.Errorf("user roles: %s, want %s", got, want)
+	}
+	resources, err := r.Links.UserResources(ctx, f.user.ID)
+	if err != nil {
+		t.Fatalf("user resources: %v", err)
+	}
+	if len(resources) != 0 {
+		t.Errorf("user resources: %s, want none", resourceNames(resources))
+	}
+}
+
+func testLinkRestoredEnd(t *testing.T, r Repositories, f linkFixture) {
+	ctx := context.Background()
+	if err := r.Roles.Delete(ctx, int64(f.roleA.ID), f.roleA.Version); err != nil {
+		t.Fatalf("delete role: %v", err)
+	}
+	if err := r.Links.LinkRoleResource(ctx, f.roleA.ID, f.resZ.ID); errs.CodeOf(err) != errs.NotFound {
+		t.Errorf("link deleted role: %v, want NotFound", err)
+	}
+	roles, err := r.Links.UserRoles(repository.WithDeleted(ctx), f.user.ID)
+	if err != nil {
+		t.Fatalf("user roles with deleted: %v", err)
+	}
+	if got, want := roleNames(roles), roleNames([]*models.Role{f.roleA, f.roleB}); got != want {
+		t.Errorf("user roles with deleted: %s, want %s", got, want)
+	}
+
+	if err = r.Roles.Restore(ctx, int64(f.roleA.ID)); err != nil {
+		t.Fatalf("restore role: %v", err)
+	}
+	resources, err := r.Links.UserResources(ctx, f.user.ID)
+	if err != nil {
+		t.Fatalf("user resources: %v", err)
+	}
+	if got, want := resourceNames(resources), resourceNames([]*models.Resource{f.resX, f.resY}); got != want {
+		t.Errorf("user resources after restore: %s, want %s", got, want)
+	}
+}
+
+func testLinkPurgedEnd(t *testing.T, r Repositories, f linkFixture) {
+	ctx := context.Background()
+	if err := r.Roles.Delete(ctx, int64(f.roleA.ID), f.roleA.Version); err != nil {
+		t.Fatalf("delete role: %v", err)
+	}
+	if _, err := r.Roles.Purge(ctx, time.Now().Add(time.Second)); err != nil {
+		t.Fatalf("purge roles: %v", err)
+	}
+
+	roles, err := r.Links.UserRoles(repository.WithDeleted(ctx), f.user.ID)
+	if err != nil {
+		t.Fatalf("user roles with deleted: %v", err)
+	}
+	if got, want := roleNames(roles), roleNames([]*models.Role{f.roleB}); got != want {
+		t.Errorf("user roles after purge: %s, want %s", got, want)
+	}
+	if _, err = r.Links.RoleResources(repository.WithDeleted(ctx), f.roleA.ID); errs.CodeOf(err) != errs.NotFound {
+		t.Errorf("resources of purged role: %v, want NotFound", err)
+	}
+}
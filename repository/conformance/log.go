@@ -0,0 +1,150 @@
+package conformance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+)
+
+var logCases = []struct {
+	name string
+	run  func(t *testing.T, r repository.ILog, prefix string)
+}{
+	{"Append", testLogAppend},
+	{"Filter", testLogFilter},
+	{"Page", testLogPage},
+}
+
+//RunLog run the log suite against the ILog created by newRepo for every case,
+//logs of a case are kept apart from others by their actor
+func RunLog(t *testing.T, newRepo func(t *testing.T) repository.ILog) {
+	for _, c := range logCases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			c.run(t, newRepo(t), prefixOf(t))
+		})
+	}
+}
+
+//appendLogs append a log of the actor for every entity id, in order of ids
+func appendLogs(t *testing.T, r repository.ILog, actor string, kind models.ChangeKind, entityIDs ...string) []*models.Log {
+	logs := make([]*models.Log, 0, len(entityIDs))
+	for _, id := range entityIDs {
+		log := &models.Log{Actor: actor, Kind: kind, Action: models.Updated, EntityID: id}
+		if err := r.Append(context.Background(), log); err != nil {
+			t.Fatalf("append: %v", err)
+		}
+		logs = append(logs, log)
+	}
+	return logs
+}
+
+//logIDs of logs in order
+func logIDs(logs []*models.Log) []int64 {
+	ids := make([]int64, 0, len(logs))
+	for _, log := range logs {
+		ids = append(ids, log.ID)
+	}
+	return ids
+}
+
+func testLogAppend(t *testing.T, r repository.ILog, prefix string) {
+	ctx := context.Background()
+	log := &models.Log{
+		Actor:     prefix,
+		Kind:      models.RoleChange,
+		Action:    models.Linked,
+		EntityID:  prefix + "role",
+		TargetID:  prefix + "resource",
+		Diff:      []models.FieldDiff{{Field: "name", Before: `"a"`, After: `"b"`}},
+		RequestID: prefix + "request",
+	}
+	if err := r.Append(ctx, log); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	if log.ID == 0 || log.Time.IsZero() {
+		t.Fatalf("append should assign id and time, got %d %v", log.ID, log.Time)
+	}
+	next := appendLogs(t, r, prefix, models.RoleChange, prefix+"next")[0]
+	if next.ID <= log.ID {
+		t.Fatalf("id %d should be greater than %d", next.ID, log.ID)
+	}
+
+	logs, err := r.Query(ctx, repository.LogQuery{Actor: prefix})
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	if len(logs) != 2 || logs[0].ID != next.ID {
+		t.Fatalf("query should return the newest first, got %v", logIDs(logs))
+	}
+	got := logs[1]
+	if got.ID != log.ID || got.Kind != log.Kind || got.Action != log.Action || got.EntityID != log.EntityID ||
+		got.TargetID != log.TargetID || got.RequestID != log.RequestID || !got.Time.Round(time.Millisecond).Equal(log.Time.Round(time.Millisecond)) {
+		t.Fatalf("query got %+v, want %+v", got, log)
+	}
+	if len(got.Diff) != 1 || got.Diff[0] != log.Diff[0] {
+		t.Fatalf("query got diff %+v, want %+v", got.Diff, log.Diff)
+	}
+}
+
+func testLogFilter(t *testing.T, r repository.ILog, prefix string) {
+	ctx := context.Background()
+	users := appendLogs(t, r, prefix, models.UserChange, prefix+"1", prefix+"2")
+	roles := appendLogs(t, r, prefix, models.RoleChange, prefix+"1")
+	others := appendLogs(t, r, prefix+"other", models.UserChange, prefix+"1")
+	tenant := &models.Log{Actor: prefix + "tenant", TenantID: int(time.Now().UnixNano() % 1000000), Kind: models.UserChange, EntityID: prefix + "1"}
+	if err := r.Append(ctx, tenant); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+
+	query := func(q repository.LogQuery) []int64 {
+		logs, err := r.Query(ctx, q)
+		if err != nil {
+			t.Fatalf("query %+v: %v", q, err)
+		}
+		return logIDs(logs)
+	}
+	expect := func(name string, got []int64, want ...*models.Log) {
+		if len(got) != len(want) {
+			t.Fatalf("%s got %v, want %v", name, got, logIDs(want))
+		}
+		for i := range want {
+			if got[i] != want[i].ID {
+				t.Fatalf("%s got %v, want %v", name, got, logIDs(want))
+			}
+		}
+	}
+	expect("actor", query(repository.LogQuery{Actor: prefix + "other"}), others[0])
+	expect("kind", query(repository.LogQuery{Actor: prefix, Kinds: []models.ChangeKind{models.RoleChange}}), roles[0])
+	expect("kinds", query(repository.LogQuery{Actor: prefix, Kinds: []models.ChangeKind{models.RoleChange, models.UserChange}}), roles[0], users[1], users[0])
+	expect("entity", query(repository.LogQuery{Actor: prefix, EntityID: prefix + "1"}), roles[0], users[0])
+	expect("entity of any actor", query(repository.LogQuery{EntityID: prefix + "1"}), tenant, others[0], roles[0], users[0])
+	expect("tenant", query(repository.LogQuery{TenantID: tenant.TenantID}), tenant)
+
+	since := users[0].Time.Add(-time.Second)
+	expect("since", query(repository.LogQuery{Actor: prefix, Since: since}), roles[0], users[1], users[0])
+	expect("until", query(repository.LogQuery{Actor: prefix, Until: since}))
+}
+
+func testLogPage(t *testing.T, r repository.ILog, prefix string) {
+	ctx := context.Background()
+	logs := appendLogs(t, r, prefix, models.ResourceChange, prefix+"1", prefix+"2", prefix+"3")
+
+	page, err := r.Query(ctx, repository.LogQuery{Actor: prefix, Limit: 2})
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	if len(page) != 2 || page[0].ID != logs[2].ID || page[1].ID != logs[1].ID {
+		t.Fatalf("first page got %v, want %v", logIDs(page), logIDs(logs[1:]))
+	}
+	page, err = r.Query(ctx, repository.LogQuery{Actor: prefix, Limit: 2, BeforeID: page[1].ID})
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	if len(page) != 1 || page[0].ID != logs[0].ID {
+		t.Fatalf("last page got %v, want %v", logIDs(page), logs[0].ID)
+	}
+}
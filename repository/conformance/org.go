@@ -0,0 +1,228 @@
+package conformance
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+)
+
+var orgCases = []struct {
+	name string
+	run  func(t *testing.T, r repository.IOrg, prefix string)
+}{
+	{"AddAndFind", testOrgAddAndFind},
+	{"Update", testOrgUpdate},
+	{"Delete", testOrgDelete},
+	{"List", testOrgList},
+	{"Members", testOrgMembers},
+	{"Grants", testOrgGrants},
+}
+
+//RunOrg run the org suite against the IOrg created by newRepo for every case,
+//units of a case are kept apart from others by their names
+func RunOrg(t *testing.T, newRepo func(t *testing.T) repository.IOrg) {
+	for _, c := range orgCases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			c.run(t, newRepo(t), prefixOf(t))
+		})
+	}
+}
+
+func addOrgUnit(t *testing.T, r repository.IOrg, kind models.OrgUnitKind, name string, parentID int) *models.OrgUnit {
+	unit := &models.OrgUnit{Kind: kind, Name: name, ParentID: parentID, TenantID: 1}
+	if err := r.Add(context.Background(), unit); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	return unit
+}
+
+func testOrgAddAndFind(t *testing.T, r repository.IOrg, prefix string) {
+	ctx := context.Background()
+	org := addOrgUnit(t, r, models.OrgOrganization, prefix+"org", 0)
+	dept := addOrgUnit(t, r, models.OrgDepartment, prefix+"dept", org.ID)
+	if dept.ID <= 0 || dept.ID == org.ID || dept.Version != 1 {
+		t.Fatalf("add should assign a new id and version 1, got %d %d", dept.ID, dept.Version)
+	}
+
+	got, err := r.FindById(ctx, dept.ID)
+	if err != nil {
+		t.Fatalf("find by id: %v", err)
+	}
+	if got.Kind != models.OrgDepartment || got.Name != dept.Name || got.ParentID != org.ID || got.TenantID != 1 {
+		t.Fatalf("find by id got %+v, want %+v", got, dept)
+	}
+
+	if err = r.Add(ctx, &models.OrgUnit{Kind: models.OrgPosition, Name: dept.Name, ParentID: org.ID, TenantID: 1}); errs.CodeOf(err) != errs.AlreadyExists {
+		t.Fatalf("add a name taken under the parent should be AlreadyExists, got %v", err)
+	}
+	//names are unique among the units of a parent only
+	addOrgUnit(t, r, models.OrgDepartment, dept.Name, dept.ID)
+	if err = r.Add(ctx, &models.OrgUnit{Kind: models.OrgOrganization, Name: org.Name, TenantID: 2}); err != nil {
+		t.Fatalf("add an organization of a name taken in another tenant: %v", err)
+	}
+	if _, err = r.FindById(ctx, missingID); errs.CodeOf(err) != errs.NotFound {
+		t.Fatalf("find missing should be NotFound, got %v", err)
+	}
+}
+
+func testOrgUpdate(t *testing.T, r repository.IOrg, prefix string) {
+	ctx := context.Background()
+	org := addOrgUnit(t, r, models.OrgOrganization, prefix+"org", 0)
+	sales := addOrgUnit(t, r, models.OrgDepartment, prefix+"sales", org.ID)
+	dept := addOrgUnit(t, r, models.OrgDepartment, prefix+"dept", org.ID)
+
+	dept.Name, dept.ParentID = prefix+"east", sales.ID
+	if err := r.Update(ctx, dept); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if dept.Version != 2 {
+		t.Fatalf("update should step the version to 2, got %d", dept.Version)
+	}
+	got, err := r.FindById(ctx, dept.ID)
+	if err != nil || got.Name != prefix+"east" || got.ParentID != sales.ID || got.Version != 2 {
+		t.Fatalf("find updated got %+v %v", got, err)
+	}
+
+	stale := *got
+	stale.Version = 1
+	if err = r.Update(ctx, &stale); errs.CodeOf(err) != errs.Conflict {
+		t.Fatalf("update at a stale version should be Conflict, got %v", err)
+	}
+	got.ParentID = org.ID
+	got.Name = sales.Name
+	if err = r.Update(ctx, got); errs.CodeOf(err) != errs.AlreadyExists {
+		t.Fatalf("update to a name taken under the parent should be AlreadyExists, got %v", err)
+	}
+	missing := &models.OrgUnit{ID: missingID, Name: prefix + "missing", ModelExtension: models.ModelExtension{Version: 1}}
+	if err = r.Update(ctx, missing); errs.CodeOf(err) != errs.NotFound {
+		t.Fatalf("update missing should be NotFound, got %v", err)
+	}
+}
+
+func testOrgDelete(t *testing.T, r repository.IOrg, prefix string) {
+	ctx := context.Background()
+	org := addOrgUnit(t, r, models.OrgOrganization, prefix+"org", 0)
+	dept := addOrgUnit(t, r, models.OrgDepartment, prefix+"dept", org.ID)
+	if err := r.Grant(ctx, dept.ID, missingID); err != nil {
+		t.Fatalf("grant: %v", err)
+	}
+
+	if err := r.Delete(ctx, dept.ID, 2); errs.CodeOf(err) != errs.Conflict {
+		t.Fatalf("delete at a stale version should be Conflict, got %v", err)
+	}
+	if err := r.Delete(ctx, dept.ID, 1); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err := r.FindById(ctx, dept.ID); errs.CodeOf(err) != errs.NotFound {
+		t.Fatalf("find deleted should be NotFound, got %v", err)
+	}
+	if grants, err := r.Grants(ctx, dept.ID); err != nil || len(grants) != 0 {
+		t.Fatalf("grants of a deleted unit should be removed, got %v %v", grants, err)
+	}
+	if err := r.Delete(ctx, dept.ID, 1); errs.CodeOf(err) != errs.NotFound {
+		t.Fatalf("delete again should be NotFound, got %v", err)
+	}
+	addOrgUnit(t, r, models.OrgDepartment, dept.Name, org.ID)
+}
+
+func testOrgList(t *testing.T, r repository.IOrg, prefix string) {
+	org := addOrgUnit(t, r, models.OrgOrganization, prefix+"org", 0)
+	added := []*models.OrgUnit{org, addOrgUnit(t, r, models.OrgPosition, prefix+"clerk", org.ID)}
+	units, err := r.List(context.Background())
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	var found []int
+	for i, unit := range units {
+		if i > 0 && unit.ID <= units[i-1].ID {
+			t.Fatalf("list should be in order of id, got %d after %d", unit.ID, units[i-1].ID)
+		}
+		for _, a := range added {
+			if unit.ID == a.ID && unit.Kind == a.Kind && unit.ParentID == a.ParentID {
+				found = append(found, unit.ID)
+			}
+		}
+	}
+	if len(found) != len(added) {
+		t.Fatalf("list should have the added units, found %v", found)
+	}
+}
+
+func testOrgMembers(t *testing.T, r repository.IOrg, prefix string) {
+	ctx := context.Background()
+	org := addOrgUnit(t, r, models.OrgOrganization, prefix+"org", 0)
+	dept := addOrgUnit(t, r, models.OrgDepartment, prefix+"dept", org.ID)
+	other := addOrgUnit(t, r, models.OrgDepartment, prefix+"other", org.ID)
+	clerk := addOrgUnit(t, r, models.OrgPosition, prefix+"clerk", org.ID)
+	alice, bob := int64(missingID-1), int64(missingID-2)
+	for _, member := range []*models.OrgMember{
+		{UserID: alice, DeptID: other.ID, TenantID: 1},
+		{UserID: bob, DeptID: dept.ID, PositionID: clerk.ID, TenantID: 1},
+	} {
+		if err := r.SetMember(ctx, member); err != nil {
+			t.Fatalf("set member: %v", err)
+		}
+	}
+	//setting the member of the user again replaces it
+	if err := r.SetMember(ctx, &models.OrgMember{UserID: alice, DeptID: dept.ID, PositionID: clerk.ID, TenantID: 1}); err != nil {
+		t.Fatalf("set member again: %v", err)
+	}
+
+	got, err := r.MemberOf(ctx, alice)
+	if err != nil || got.DeptID != dept.ID || got.PositionID != clerk.ID || got.TenantID != 1 {
+		t.Fatalf("member of got %+v %v", got, err)
+	}
+	for _, unitID := range []int{dept.ID, clerk.ID} {
+		members, err := r.Members(ctx, unitID)
+		if err != nil {
+			t.Fatalf("members: %v", err)
+		}
+		if len(members) != 2 || members[0].UserID != bob || members[1].UserID != alice {
+			t.Fatalf("members of %d should be in order of user id, got %+v", unitID, members)
+		}
+	}
+	if members, err := r.Members(ctx, other.ID); err != nil || len(members) != 0 {
+		t.Fatalf("members of a unit left should be none, got %+v %v", members, err)
+	}
+
+	if err = r.RemoveMember(ctx, alice); err != nil {
+		t.Fatalf("remove member: %v", err)
+	}
+	if _, err = r.MemberOf(ctx, alice); errs.CodeOf(err) != errs.NotFound {
+		t.Fatalf("member of a removed user should be NotFound, got %v", err)
+	}
+	if err = r.RemoveMember(ctx, alice); errs.CodeOf(err) != errs.NotFound {
+		t.Fatalf("remove again should be NotFound, got %v", err)
+	}
+	if err = r.RemoveMember(ctx, bob); err != nil {
+		t.Fatalf("remove member: %v", err)
+	}
+}
+
+func testOrgGrants(t *testing.T, r repository.IOrg, prefix string) {
+	ctx := context.Background()
+	org := addOrgUnit(t, r, models.OrgOrganization, prefix+"org", 0)
+	for _, roleID := range []int{3, 1, 2} {
+		if err := r.Grant(ctx, org.ID, roleID); err != nil {
+			t.Fatalf("grant: %v", err)
+		}
+	}
+	if err := r.Grant(ctx, org.ID, 1); errs.CodeOf(err) != errs.AlreadyExists {
+		t.Fatalf("grant again should be AlreadyExists, got %v", err)
+	}
+	if err := r.Revoke(ctx, org.ID, 2); err != nil {
+		t.Fatalf("revoke: %v", err)
+	}
+	if err := r.Revoke(ctx, org.ID, 2); errs.CodeOf(err) != errs.NotFound {
+		t.Fatalf("revoke again should be NotFound, got %v", err)
+	}
+	grants, err := r.Grants(ctx, org.ID)
+	if err != nil || !reflect.DeepEqual(grants, []int{1, 3}) {
+		t.Fatalf("grants should be in order, got %v %v", grants, err)
+	}
+}
@@ -0,0 +1,185 @@
+package conformance
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+)
+
+var roleTemplateCases = []struct {
+	name string
+	run  func(t *testing.T, r repository.IRoleTemplate, prefix string)
+}{
+	{"AddAndFind", testRoleTemplateAddAndFind},
+	{"Update", testRoleTemplateUpdate},
+	{"Delete", testRoleTemplateDelete},
+	{"List", testRoleTemplateList},
+	{"Instances", testRoleTemplateInstances},
+}
+
+//RunRoleTemplate run the role template suite against the IRoleTemplate created by newRepo for every case,
+//templates of a case are kept apart from others by their names
+func RunRoleTemplate(t *testing.T, newRepo func(t *testing.T) repository.IRoleTemplate) {
+	for _, c := range roleTemplateCases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			c.run(t, newRepo(t), prefixOf(t))
+		})
+	}
+}
+
+func addRoleTemplate(t *testing.T, r repository.IRoleTemplate, name string) *models.RoleTemplate {
+	template := &models.RoleTemplate{
+		Name:    name,
+		Key:     "editor",
+		Grants:  models.Keys{"docs", "reports"},
+		Default: true,
+	}
+	if err := r.Add(context.Background(), template); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	return template
+}
+
+func testRoleTemplateAddAndFind(t *testing.T, r repository.IRoleTemplate, prefix string) {
+	ctx := context.Background()
+	template := addRoleTemplate(t, r, prefix+"a")
+	if template.ID <= 0 || template.Version != 1 {
+		t.Fatalf("add should assign an id and version 1, got %d %d", template.ID, template.Version)
+	}
+
+	got, err := r.FindById(ctx, template.ID)
+	if err != nil {
+		t.Fatalf("find by id: %v", err)
+	}
+	if got.Name != template.Name || got.Key != template.Key || !got.Default || !reflect.DeepEqual(got.Grants, template.Grants) {
+		t.Fatalf("find by id got %+v, want %+v", got, template)
+	}
+	if got, err = r.FindByName(ctx, template.Name); err != nil || got.ID != template.ID {
+		t.Fatalf("find by name got %v %v", got, err)
+	}
+
+	if err = r.Add(ctx, &models.RoleTemplate{Name: template.Name}); errs.CodeOf(err) != errs.AlreadyExists {
+		t.Fatalf("add duplicated should be AlreadyExists, got %v", err)
+	}
+	if _, err = r.FindById(ctx, missingID); errs.CodeOf(err) != errs.NotFound {
+		t.Fatalf("find missing should be NotFound, got %v", err)
+	}
+	if _, err = r.FindByName(ctx, prefix+"missing"); errs.CodeOf(err) != errs.NotFound {
+		t.Fatalf("find missing name should be NotFound, got %v", err)
+	}
+}
+
+func testRoleTemplateUpdate(t *testing.T, r repository.IRoleTemplate, prefix string) {
+	ctx := context.Background()
+	template := addRoleTemplate(t, r, prefix+"a")
+	other := addRoleTemplate(t, r, prefix+"b")
+
+	template.Grants = models.Keys{"docs"}
+	template.Default = false
+	if err := r.Update(ctx, template); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if template.Version != 2 {
+		t.Fatalf("update should step the version to 2, got %d", template.Version)
+	}
+	got, err := r.FindById(ctx, template.ID)
+	if err != nil || got.Default || !reflect.DeepEqual(got.Grants, models.Keys{"docs"}) || got.Version != 2 {
+		t.Fatalf("find updated got %+v %v", got, err)
+	}
+
+	stale := *got
+	stale.Version = 1
+	if err = r.Update(ctx, &stale); errs.CodeOf(err) != errs.Conflict {
+		t.Fatalf("update at a stale version should be Conflict, got %v", err)
+	}
+	got.Name = other.Name
+	if err = r.Update(ctx, got); errs.CodeOf(err) != errs.AlreadyExists {
+		t.Fatalf("update to a taken name should be AlreadyExists, got %v", err)
+	}
+	missing := &models.RoleTemplate{ID: missingID, Name: prefix + "missing", ModelExtension: models.ModelExtension{Version: 1}}
+	if err = r.Update(ctx, missing); errs.CodeOf(err) != errs.NotFound {
+		t.Fatalf("update missing should be NotFound, got %v", err)
+	}
+}
+
+func testRoleTemplateDelete(t *testing.T, r repository.IRoleTemplate, prefix string) {
+	ctx := context.Background()
+	template := addRoleTemplate(t, r, prefix+"a")
+	if err := r.SaveInstance(ctx, &models.RoleInstance{RoleID: missingID, TemplateID: template.ID, TemplateVersion: 1}); err != nil {
+		t.Fatalf("save instance: %v", err)
+	}
+
+	if err := r.Delete(ctx, template.ID, 2); errs.CodeOf(err) != errs.Conflict {
+		t.Fatalf("delete at a stale version should be Conflict, got %v", err)
+	}
+	if err := r.Delete(ctx, template.ID, 1); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err := r.FindById(ctx, template.ID); errs.CodeOf(err) != errs.NotFound {
+		t.Fatalf("find deleted should be NotFound, got %v", err)
+	}
+	if instances, err := r.Instances(ctx, template.ID); err != nil || len(instances) != 0 {
+		t.Fatalf("instances of a deleted template should be removed, got %v %v", instances, err)
+	}
+	if err := r.Delete(ctx, template.ID, 1); errs.CodeOf(err) != errs.NotFound {
+		t.Fatalf("delete again should be NotFound, got %v", err)
+	}
+	addRoleTemplate(t, r, template.Name)
+}
+
+func testRoleTemplateList(t *testing.T, r repository.IRoleTemplate, prefix string) {
+	added := []*models.RoleTemplate{addRoleTemplate(t, r, prefix+"b"), addRoleTemplate(t, r, prefix+"a")}
+	templates, err := r.List(context.Background())
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	var found []int
+	for i, template := range templates {
+		if i > 0 && template.ID <= templates[i-1].ID {
+			t.Fatalf("list should be in order of id, got %d after %d", template.ID, templates[i-1].ID)
+		}
+		for _, a := range added {
+			if template.ID == a.ID {
+				found = append(found, template.ID)
+			}
+		}
+	}
+	if len(found) != len(added) {
+		t.Fatalf("list should have the added templates, found %v", found)
+	}
+}
+
+func testRoleTemplateInstances(t *testing.T, r repository.IRoleTemplate, prefix string) {
+	ctx := context.Background()
+	template := addRoleTemplate(t, r, prefix+"a")
+	other := addRoleTemplate(t, r, prefix+"b")
+	for _, instance := range []*models.RoleInstance{
+		{RoleID: missingID - 1, TemplateID: template.ID, TemplateVersion: 1, TenantID: 2, ScopeID: 3},
+		{RoleID: missingID - 2, TemplateID: template.ID, TemplateVersion: 1, TenantID: 2},
+		{RoleID: missingID - 3, TemplateID: other.ID, TemplateVersion: 1},
+	} {
+		if err := r.SaveInstance(ctx, instance); err != nil {
+			t.Fatalf("save instance: %v", err)
+		}
+	}
+	//saving an instance of the role again replaces it
+	if err := r.SaveInstance(ctx, &models.RoleInstance{RoleID: missingID - 1, TemplateID: template.ID, TemplateVersion: 2, TenantID: 2, ScopeID: 3}); err != nil {
+		t.Fatalf("save instance again: %v", err)
+	}
+
+	instances, err := r.Instances(ctx, template.ID)
+	if err != nil {
+		t.Fatalf("instances: %v", err)
+	}
+	if len(instances) != 2 || instances[0].RoleID != missingID-2 || instances[1].RoleID != missingID-1 {
+		t.Fatalf("instances should be those of the template in order of role id, got %+v", instances)
+	}
+	if got := instances[1]; got.TemplateVersion != 2 || got.TenantID != 2 || got.ScopeID != 3 {
+		t.Fatalf("instance should be replaced, got %+v", got)
+	}
+}
@@ -0,0 +1,172 @@
+package conformance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+)
+
+var tenantCases = []struct {
+	name string
+	run  func(t *testing.T, r repository.ITenant, prefix string)
+}{
+	{"AddAndFind", testTenantAddAndFind},
+	{"Update", testTenantUpdate},
+	{"Delete", testTenantDelete},
+	{"List", testTenantList},
+}
+
+//RunTenant run the tenant suite against the ITenant created by newRepo for every case,
+//tenants of a case are kept apart from others by their names
+func RunTenant(t *testing.T, newRepo func(t *testing.T) repository.ITenant) {
+	for _, c := range tenantCases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			c.run(t, newRepo(t), prefixOf(t))
+		})
+	}
+}
+
+func addTenant(t *testing.T, r repository.ITenant, name string) *models.Tenant {
+	tenant := &models.Tenant{
+		Name:  name,
+		State: models.TenantActive,
+		Settings: models.TenantSettings{
+			PasswordPolicy:  models.PasswordPolicy{MinLength: 8, RequireDigit: true},
+			MFARequired:     true,
+			SessionLifetime: time.Hour,
+		},
+	}
+	if err := r.Add(context.Background(), tenant); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	return tenant
+}
+
+func testTenantAddAndFind(t *testing.T, r repository.ITenant, prefix string) {
+	ctx := context.Background()
+	tenant := addTenant(t, r, prefix+"a")
+	if tenant.ID <= models.SuperTenant || tenant.Version != 1 {
+		t.Fatalf("add should assign an id after the super tenant and version 1, got %d %d", tenant.ID, tenant.Version)
+	}
+
+	got, err := r.FindById(ctx, tenant.ID)
+	if err != nil {
+		t.Fatalf("find by id: %v", err)
+	}
+	if got.Name != tenant.Name || got.State != models.TenantActive || got.Settings != tenant.Settings {
+		t.Fatalf("find by id got %+v, want %+v", got, tenant)
+	}
+	if got, err = r.FindByName(ctx, tenant.Name); err != nil || got.ID != tenant.ID {
+		t.Fatalf("find by name got %v %v", got, err)
+	}
+
+	if err = r.Add(ctx, &models.Tenant{Name: tenant.Name}); errs.CodeOf(err) != errs.AlreadyExists {
+		t.Fatalf("add duplicated should be AlreadyExists, got %v", err)
+	}
+	if _, err = r.FindById(ctx, missingID); errs.CodeOf(err) != errs.NotFound {
+		t.Fatalf("find missing should be NotFound, got %v", err)
+	}
+	if _, err = r.FindByName(ctx, prefix+"missing"); errs.CodeOf(err) != errs.NotFound {
+		t.Fatalf("find missing name should be NotFound, got %v", err)
+	}
+}
+
+func testTenantUpdate(t *testing.T, r repository.ITenant, prefix string) {
+	ctx := context.Background()
+	tenant := addTenant(t, r, prefix+"a")
+	other := addTenant(t, r, prefix+"b")
+
+	tenant.State = models.TenantSuspended
+	tenant.Settings.SessionLifetime = time.Minute
+	if err := r.Update(ctx, tenant); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if tenant.Version != 2 {
+		t.Fatalf("update should step the version to 2, got %d", tenant.Version)
+	}
+	got, err := r.FindById(ctx, tenant.ID)
+	if err != nil || got.State != models.TenantSuspended || got.Settings.SessionLifetime != time.Minute || got.Version != 2 {
+		t.Fatalf("find updated got %+v %v", got, err)
+	}
+
+	stale := *got
+	stale.Version = 1
+	if err = r.Update(ctx, &stale); errs.CodeOf(err) != errs.Conflict {
+		t.Fatalf("update at a stale version should be Conflict, got %v", err)
+	}
+	got.Name = other.Name
+	if err = r.Update(ctx, got); errs.CodeOf(err) != errs.AlreadyExists {
+		t.Fatalf("update to a taken name should be AlreadyExists, got %v", err)
+	}
+	if err = r.Update(ctx, &models.Tenant{ID: missingID, Name: prefix + "missing", ModelExtension: models.ModelExtension{Version: 1}}); errs.CodeOf(err) != errs.NotFound {
+		t.Fatalf("update missing should be NotFound, got %v", err)
+	}
+}
+
+func testTenantDelete(t *testing.T, r repository.ITenant, prefix string) {
+	ctx := context.Background()
+	tenant := addTenant(t, r, prefix+"a")
+	if err := r.Delete(ctx, tenant.ID, 2); errs.CodeOf(err) != errs.Conflict {
+		t.Fatalf("delete at a stale version should be Conflict, got %v", err)
+	}
+	if err := r.Delete(ctx, tenant.ID, tenant.Version); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	//a deleted tenant is hidden even to a ctx showing deleted entities
+	if _, err := r.FindById(repository.WithDeleted(ctx), tenant.ID); errs.CodeOf(err) != errs.NotFound {
+		t.Fatalf("find deleted should be NotFound, got %v", err)
+	}
+	if _, err := r.FindByName(ctx, tenant.Name); errs.CodeOf(err) != errs.NotFound {
+		t.Fatalf("find deleted name should be NotFound, got %v", err)
+	}
+	tenants, err := r.List(ctx)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	for _, listed := range tenants {
+		if listed.ID == tenant.ID {
+			t.Fatalf("list should hide deleted tenant %d", tenant.ID)
+		}
+	}
+	if err = r.Update(ctx, tenant); errs.CodeOf(err) != errs.NotFound {
+		t.Fatalf("update deleted should be NotFound, got %v", err)
+	}
+	if err = r.Delete(ctx, tenant.ID, tenant.Version+1); errs.CodeOf(err) != errs.NotFound {
+		t.Fatalf("delete again should be NotFound, got %v", err)
+	}
+	//the name is free for a new tenant of another id
+	again := addTenant(t, r, tenant.Name)
+	if again.ID == tenant.ID {
+		t.Fatalf("id %d of a deleted tenant should not be reused", tenant.ID)
+	}
+	if err = r.Delete(ctx, again.ID, again.Version); err != nil {
+		t.Fatalf("delete a name deleted before: %v", err)
+	}
+}
+
+func testTenantList(t *testing.T, r repository.ITenant, prefix string) {
+	added := []*models.Tenant{addTenant(t, r, prefix+"b"), addTenant(t, r, prefix+"a")}
+	tenants, err := r.List(context.Background())
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	var found []int
+	for i, tenant := range tenants {
+		if i > 0 && tenant.ID <= tenants[i-1].ID {
+			t.Fatalf("list should be in order of id, got %d after %d", tenant.ID, tenants[i-1].ID)
+		}
+		for _, a := range added {
+			if tenant.ID == a.ID {
+				found = append(found, tenant.ID)
+			}
+		}
+	}
+	if len(found) != len(added) {
+		t.Fatalf("list should have the added tenants, found %v", found)
+	}
+}
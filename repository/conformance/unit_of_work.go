@@ -0,0 +1,123 @@
+package conformance
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+)
+
+//errAbort is returned by a unit of work to roll it back
+var errAbort = errors.New("abort")
+
+//createUser add a user named name linked to roleIDs in a unit of work, fail returns errAbort at last
+func createUser(ctx context.Context, r Repositories, name string, roleIDs []int, fail bool) error {
+	return r.Work.Do(ctx, func(ctx context.Context) error {
+		user := &models.User{Name: name}
+		if err := r.Users.Add(ctx, user); err != nil {
+			return err
+		}
+		for _, roleID := range roleIDs {
+			if err := r.Links.LinkUserRole(ctx, user.ID, roleID); err != nil {
+				return err
+			}
+		}
+		if fail {
+			return errAbort
+		}
+		return nil
+	})
+}
+
+var workCases = []struct {
+	name   string
+	atomic bool
+	run    func(t *testing.T, r Repositories, f linkFixture, prefix string)
+}{
+	{"Commit", false, testWorkCommit},
+	{"Rollback", true, testWorkRollback},
+	{"FailedStep", true, testWorkFailedStep},
+	{"Nested", true, testWorkNested},
+}
+
+//RunUnitOfWork run the unit of work suite against the repositories created by newRepos,
+//cases of rolling back are skipped when the backend is not atomic
+func RunUnitOfWork(t *testing.T, newRepos func(t *testing.T) Repositories, atomic bool) {
+	for _, c := range workCases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			if c.atomic && !atomic {
+				t.Skip("the unit of work is not atomic")
+			}
+			r := newRepos(t)
+			prefix := prefixOf(t)
+			c.run(t, r, newLinkFixture(t, r, prefix), prefix)
+		})
+	}
+}
+
+//mustBeGone check the user of name is not kept
+func mustBeGone(t *testing.T, r Repositories, name string) {
+	if _, err := r.Users.FindByName(context.Background(), name); errs.CodeOf(err) != errs.NotFound {
+		t.Errorf("find rolled back user: %v, want NotFound", err)
+	}
+}
+
+func testWorkCommit(t *testing.T, r Repositories, f linkFixture, prefix string) {
+	ctx := context.Background()
+	if err := createUser(ctx, r, prefix+"new", []int{f.roleA.ID, f.roleB.ID}, false); err != nil {
+		t.Fatalf("do: %v", err)
+	}
+	user, err := r.Users.FindByName(ctx, prefix+"new")
+	if err != nil {
+		t.Fatalf("find committed user: %v", err)
+	}
+	roles, err := r.Links.UserRoles(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("user roles: %v", err)
+	}
+	if got, want := roleNames(roles), roleNames([]*models.Role{f.roleA, f.roleB}); got != want {
+		t.Errorf("user roles: %s, want %s", got, want)
+	}
+}
+
+func testWorkRollback(t *testing.T, r Repositories, f linkFixture, prefix string) {
+	ctx := context.Background()
+	if err := createUser(ctx, r, prefix+"new", []int{f.roleA.ID}, true); err != errAbort {
+		t.Fatalf("do: %v, want %v", err, errAbort)
+	}
+	mustBeGone(t, r, prefix+"new")
+
+	roles, err := r.Links.UserRoles(ctx, f.user.ID)
+	if err != nil {
+		t.Fatalf("user roles: %v", err)
+	}
+	if got, want := roleNames(roles), roleNames([]*models.Role{f.roleA, f.roleB}); got != want {
+		t.Errorf("roles of the fixture user: %s, want %s", got, want)
+	}
+}
+
+func testWorkFailedStep(t *testing.T, r Repositories, f linkFixture, prefix string) {
+	ctx := context.Background()
+	err := createUser(ctx, r, prefix+"new", []int{f.roleA.ID, missingID}, false)
+	if errs.CodeOf(err) != errs.NotFound {
+		t.Fatalf("do with a missing role: %v, want NotFound", err)
+	}
+	mustBeGone(t, r, prefix+"new")
+}
+
+func testWorkNested(t *testing.T, r Repositories, f linkFixture, prefix string) {
+	ctx := context.Background()
+	err := r.Work.Do(ctx, func(ctx context.Context) error {
+		if err := createUser(ctx, r, prefix+"inner", []int{f.roleA.ID}, false); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	if err != errAbort {
+		t.Fatalf("do: %v, want %v", err, errAbort)
+	}
+	mustBeGone(t, r, prefix+"inner")
+}
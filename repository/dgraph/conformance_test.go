@@ -0,0 +1,72 @@
+// +build dgraph
+
+package dgraph
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/micro-community/auth/config"
+	"github.com/micro-community/auth/db"
+	"github.com/micro-community/auth/repository"
+	"github.com/micro-community/auth/repository/conformance"
+)
+
+func TestMain(m *testing.M) {
+	if url := os.Getenv("DGRAPH_URL"); url != "" {
+		config.Default.Dgraph.Url = url
+	}
+	db.BuildDBContext("dgraph")
+	if err := db.Migrate(context.Background()); err != nil {
+		log.Fatal(err)
+	}
+	os.Exit(m.Run())
+}
+
+func TestUserRepository(t *testing.T) {
+	conformance.RunUser(t, func(t *testing.T) repository.IUser { return NewUserRepository() })
+}
+
+func TestRoleRepository(t *testing.T) {
+	conformance.RunRole(t, func(t *testing.T) repository.IRole { return NewRoleRepository() })
+}
+
+func TestResourceRepository(t *testing.T) {
+	conformance.RunResource(t, func(t *testing.T) repository.IResource { return NewResourceRepository() })
+}
+
+func newRepositories(t *testing.T) conformance.Repositories {
+	return conformance.Repositories{
+		Users:     NewUserRepository(),
+		Roles:     NewRoleRepository(),
+		Resources: NewResourceRepository(),
+		Links:     NewLinkRepository(),
+		Work:      NewUnitOfWork(),
+	}
+}
+
+func TestLinkRepository(t *testing.T) {
+	conformance.RunLink(t, newRepositories)
+}
+
+func TestUnitOfWork(t *testing.T) {
+	conformance.RunUnitOfWork(t, newRepositories, true)
+}
+
+func TestLogRepository(t *testing.T) {
+	conformance.RunLog(t, func(t *testing.T) repository.ILog { return NewLogRepository() })
+}
+
+func TestTenantRepository(t *testing.T) {
+	conformance.RunTenant(t, func(t *testing.T) repository.ITenant { return NewTenantRepository() })
+}
+
+func TestRoleTemplateRepository(t *testing.T) {
+	conformance.RunRoleTemplate(t, func(t *testing.T) repository.IRoleTemplate { return NewRoleTemplateRepository() })
+}
+
+func TestOrgRepository(t *testing.T) {
+	conformance.RunOrg(t, func(t *testing.T) repository.IOrg { return NewOrgRepository() })
+}
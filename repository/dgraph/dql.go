@@ -1,8 +1,6 @@
 package dgraph
 
 import (
-	"fmt"
-
 	"github.com/micro-community/auth/models"
 )
 
@@ -14,40 +12,6 @@ type UID struct {
 	UID string `json:"uid"`
 }
 
-type Root struct {
-	Count []Count  `json:"counts"`
-	UID   []string `json:"uids"`
-}
-
 type RoleResult struct {
 	Roles []models.Role `json:"roles"`
 }
-
-// GetUserExistQuery return
-func GetUserExistQuery(id string) string {
-	return fmt.Sprintf(`
-	{
-		find(func: type(User)) @filter(eq(person.id, %s)) {
-			uid
-		}
-	}
-	`, id)
-
-}
-
-// GetUserResourceQuery return
-func GetUserResourceQuery(id string) string {
-	return fmt.Sprintf(`
-	{
-		find(func: type(User)) @filter(eq(person.id, %s)) @normalize {
-			role {
-				resource {
-					resource.id
-					resource.name
-				}
-			}
-		}
-	}
-	`, id)
-
-}
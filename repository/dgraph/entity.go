@@ -0,0 +1,227 @@
+package dgraph
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/dgo/v200/protos/api"
+	"github.com/micro-community/auth/db"
+	"github.com/micro-community/auth/db/nosql"
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+)
+
+//predicates of the deletion and the version of nodes, the json names of models.ModelExtension
+const (
+	deletedPredicate   = "isSoftDelete"
+	deletedAtPredicate = "deletedAt"
+	versionPredicate   = "version"
+)
+
+//lowerNamePredicate keep the lowercased names of users, roles and resources for prefix filters
+const lowerNamePredicate = "lowerName"
+
+//live return the filters hiding deleted nodes unless ctx shows them
+func live(ctx context.Context, q *nosql.DQL) []nosql.Func {
+	if repository.ShowDeleted(ctx) {
+		return nil
+	}
+	return []nosql.Func{nosql.Not(nosql.Eq(deletedPredicate, q.Bool(true)))}
+}
+
+//findOne query the first node of type p.typ matched by the root function of match and filters into item,
+//return false when nothing matched
+func findOne(ctx context.Context, p listPredicates, match func(q *nosql.DQL) nosql.Func, item interface{}, filters ...func(q *nosql.DQL) nosql.Func) (bool, error) {
+	q := nosql.NewDQL("find")
+	block := q.Block("find", match(q)).Filter(nosql.OfType(p.typ)).Filter(live(ctx, q)...)
+	for _, filter := range filters {
+		block.Filter(filter(q))
+	}
+	block.First(q.Int(1)).Fields("uid").ExpandAll()
+	drsp, err := db.DDB().Run(ctx, q)
+	if err != nil {
+		return false, errs.NewUnavailable(err, "query %s err", p.typ)
+	}
+
+	var r struct {
+		Find []json.RawMessage `json:"find"`
+	}
+	if err = json.Unmarshal(drsp.Json, &r); err != nil {
+		return false, errs.Wrap(err, errs.Unknown, "json unmarshal %s error", p.typ)
+	}
+	if len(r.Find) == 0 {
+		return false, nil
+	}
+	if err = json.Unmarshal(r.Find[0], item); err != nil {
+		return false, errs.Wrap(err, errs.Unknown, "json unmarshal %s error", p.typ)
+	}
+	return true, nil
+}
+
+//findByID query the node of type p.typ with id into item
+func findByID(ctx context.Context, p listPredicates, id int64, item interface{}) (bool, error) {
+	return findOne(ctx, p, func(q *nosql.DQL) nosql.Func { return nosql.Eq(p.id, q.Int(id)) }, item)
+}
+
+//findByName query the node of type p.typ with name into item
+func findByName(ctx context.Context, p listPredicates, name string, item interface{}) (bool, error) {
+	return findOne(ctx, p, func(q *nosql.DQL) nosql.Func { return nosql.Eq(p.name, q.Str(name)) }, item)
+}
+
+//findByNameIn query the node of type p.typ with name in the tenant into item,
+//nodes of the super tenant may have no tenant predicate
+func findByNameIn(ctx context.Context, p listPredicates, tenant int, name string, item interface{}) (bool, error) {
+	inTenant := func(q *nosql.DQL) nosql.Func {
+		f := nosql.Eq(p.tenant, q.Int(int64(tenant)))
+		if tenant == models.SuperTenant {
+			f = nosql.Or(f, nosql.Not(nosql.Has(p.tenant)))
+		}
+		return f
+	}
+	return findOne(ctx, p, func(q *nosql.DQL) nosql.Func { return nosql.Eq(p.name, q.Str(name)) }, item, inTenant)
+}
+
+//findAll query all nodes of type p.typ matched by filters in order of the predicate order into items
+func findAll(ctx context.Context, p listPredicates, order string, filters func(q *nosql.DQL) []nosql.Func, items interface{}) error {
+	q := nosql.NewDQL("all")
+	block := q.Block("all", nosql.OfType(p.typ)).OrderAsc(order)
+	if filters != nil {
+		block.Filter(filters(q)...)
+	}
+	block.Fields("uid").ExpandAll()
+	drsp, err := db.DDB().Run(ctx, q)
+	if err != nil {
+		return errs.NewUnavailable(err, "query %s err", p.typ)
+	}
+
+	var r struct {
+		All json.RawMessage `json:"all"`
+	}
+	if err = json.Unmarshal(drsp.Json, &r); err != nil {
+		return errs.Wrap(err, errs.Unknown, "json unmarshal %s error", p.typ)
+	}
+	if len(r.All) == 0 {
+		return nil
+	}
+	if err = json.Unmarshal(r.All, items); err != nil {
+		return errs.Wrap(err, errs.Unknown, "json unmarshal %s error", p.typ)
+	}
+	return nil
+}
+
+//nextID return the max id of type p.typ plus one
+func nextID(ctx context.Context, p listPredicates) (int64, error) {
+	q := nosql.NewDQL("next")
+	q.VarBlock(nosql.OfType(p.typ)).ValueAs("ids", p.id)
+	q.Block("next", nosql.Func{}).Max("max", "ids")
+	drsp, err := db.DDB().Run(ctx, q)
+	if err != nil {
+		return 0, errs.NewUnavailable(err, "query %s id err", p.typ)
+	}
+
+	var r struct {
+		Next []struct {
+			Max int64 `json:"max"`
+		} `json:"next"`
+	}
+	if err = json.Unmarshal(drsp.Json, &r); err != nil {
+		return 0, errs.Wrap(err, errs.Unknown, "json unmarshal %s id error", p.typ)
+	}
+	if len(r.Next) == 0 {
+		return 1, nil
+	}
+	return r.Next[0].Max + 1, nil
+}
+
+//save set all predicates of item to the node uid, a blank uid like "_:new" creates a node,
+//the lowercased name is set along with the name for types listed by name prefix
+func save(ctx context.Context, p listPredicates, uid string, item interface{}) error {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return errs.Wrap(err, errs.Unknown, "json marshal %s error", p.typ)
+	}
+	node := map[string]interface{}{}
+	if err = json.Unmarshal(data, &node); err != nil {
+		return errs.Wrap(err, errs.Unknown, "json marshal %s error", p.typ)
+	}
+	node["uid"] = uid
+	node["dgraph.type"] = p.typ
+	if name, ok := node[p.name].(string); ok && p.lower != "" {
+		node[p.lower] = strings.ToLower(name)
+	}
+
+	if _, err = db.DDB().MutateObject(ctx, node); err != nil {
+		return errs.NewUnavailable(err, "dgraph Mutate error")
+	}
+	return nil
+}
+
+//markDeleted mark the node uid deleted now, or restore it, as the version
+func markDeleted(ctx context.Context, p listPredicates, uid string, version int64, deleted bool) error {
+	var deletedAt time.Time
+	if deleted {
+		deletedAt = time.Now()
+	}
+	node := map[string]interface{}{"uid": uid, deletedPredicate: deleted, deletedAtPredicate: deletedAt, versionPredicate: version}
+	if _, err := db.DDB().MutateObject(ctx, node); err != nil {
+		return errs.NewUnavailable(err, "dgraph mark %s deleted error", p.typ)
+	}
+	return nil
+}
+
+//purge remove the nodes of type p.typ deleted before the time with the edges linking to them
+func purge(ctx context.Context, p listPredicates, before time.Time) (int64, error) {
+	q := nosql.NewDQL("purge")
+	q.Block("purge", nosql.OfType(p.typ)).
+		Filter(nosql.Eq(deletedPredicate, q.Bool(true)), nosql.Lt(deletedAtPredicate, q.Time(before))).Fields("uid")
+
+	var purged int64
+	err := inTxn(ctx, func(ctx context.Context) error {
+		drsp, err := db.DDB().Run(ctx, q)
+		if err != nil {
+			return errs.NewUnavailable(err, "query deleted %s err", p.typ)
+		}
+		var r struct {
+			Purge []UID `json:"purge"`
+		}
+		if err = json.Unmarshal(drsp.Json, &r); err != nil {
+			return errs.Wrap(err, errs.Unknown, "json unmarshal drsp error")
+		}
+		for _, node := range r.Purge {
+			if err = remove(ctx, p, node.UID); err != nil {
+				return err
+			}
+		}
+		purged = int64(len(r.Purge))
+		return nil
+	})
+	return purged, err
+}
+
+//removeWhere delete the nodes of type p.typ matched by filters with all their predicates in one upsert
+func removeWhere(ctx context.Context, p listPredicates, filters func(q *nosql.DQL) []nosql.Func) error {
+	q := nosql.NewDQL("remove")
+	q.VarBlock(nosql.OfType(p.typ)).Filter(filters(q)...).As("nodes")
+	if _, err := db.DDB().RunUpsert(ctx, q, &api.Mutation{DelNquads: []byte("uid(nodes) * * .")}); err != nil {
+		return errs.NewUnavailable(err, "dgraph delete %s error", p.typ)
+	}
+	return nil
+}
+
+//remove delete the node uid with all its predicates and the edges linking to it in one upsert
+func remove(ctx context.Context, p listPredicates, uid string) error {
+	q := nosql.NewDQL("remove")
+	node := q.VarBlock(nosql.UIDOf(q.Str(uid))).As("node")
+	del := "uid(node) * * ."
+	if p.in != "" {
+		node.Edge("~" + p.in).As("from").Fields("uid")
+		del += "\nuid(from) <" + p.in + "> uid(node) ."
+	}
+	if _, err := db.DDB().RunUpsert(ctx, q, &api.Mutation{DelNquads: []byte(del)}); err != nil {
+		return errs.NewUnavailable(err, "dgraph delete %s error", p.typ)
+	}
+	return nil
+}
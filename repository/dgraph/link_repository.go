@@ -0,0 +1,151 @@
+package dgraph
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/micro-community/auth/db"
+	"github.com/micro-community/auth/db/nosql"
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+)
+
+//edges of links, from users to roles and from roles to resources
+const (
+	roleEdge     = "role"
+	resourceEdge = "resource"
+)
+
+//linkRepository store links as edges between nodes
+type linkRepository struct {
+}
+
+func NewLinkRepository() repository.ILink {
+	return &linkRepository{}
+}
+
+//linkedOf query the nodes linked to the node of p with id by edge into items in order of id
+func linkedOf(ctx context.Context, p, to listPredicates, id int64, edge string, items interface{}) error {
+	q := nosql.NewDQL("links")
+	q.Block("find", nosql.Eq(p.id, q.Int(id))).Filter(nosql.OfType(p.typ)).Filter(live(ctx, q)...).First(q.Int(1)).Fields("uid").
+		EdgeAs("linked", edge).Filter(live(ctx, q)...).OrderAsc(to.id).Fields("uid").ExpandAll()
+	drsp, err := db.DDB().Run(ctx, q)
+	if err != nil {
+		return errs.NewUnavailable(err, "query %s links err", p.typ)
+	}
+
+	var r struct {
+		Find []struct {
+			Linked json.RawMessage `json:"linked"`
+		} `json:"find"`
+	}
+	if err = json.Unmarshal(drsp.Json, &r); err != nil {
+		return errs.Wrap(err, errs.Unknown, "json unmarshal %s links error", p.typ)
+	}
+	if len(r.Find) == 0 {
+		return errs.NewNotFound("%s %d not found", p.typ, id)
+	}
+	if len(r.Find[0].Linked) == 0 {
+		return nil
+	}
+	if err = json.Unmarshal(r.Find[0].Linked, items); err != nil {
+		return errs.Wrap(err, errs.Unknown, "json unmarshal %s links error", p.typ)
+	}
+	return nil
+}
+
+//setEdge add or remove the edge between the nodes of from and to
+func setEdge(ctx context.Context, from, to listPredicates, fromID, toID int64, edge string, add bool) error {
+	var fromNode, toNode struct {
+		Uid     string `json:"uid"`
+		Deleted bool   `json:"isSoftDelete"`
+	}
+	found, err := findByID(ctx, from, fromID, &fromNode)
+	if err != nil {
+		return err
+	}
+	if !found || fromNode.Deleted {
+		return errs.NewNotFound("%s %d not found", from.typ, fromID)
+	}
+	if found, err = findByID(ctx, to, toID, &toNode); err != nil {
+		return err
+	} else if !found || toNode.Deleted {
+		return errs.NewNotFound("%s %d not found", to.typ, toID)
+	}
+
+	var linked []struct {
+		ID int64 `json:"id"`
+	}
+	if err = linkedOf(ctx, from, to, fromID, edge, &linked); err != nil {
+		return err
+	}
+	exists := false
+	for _, item := range linked {
+		exists = exists || item.ID == toID
+	}
+	switch {
+	case add && exists:
+		return errs.NewAlreadyExists("%s %d already linked to %s %d", from.typ, fromID, to.typ, toID)
+	case !add && !exists:
+		return errs.NewNotFound("%s %d not linked to %s %d", from.typ, fromID, to.typ, toID)
+	}
+
+	if _, err = db.DDB().UpdateRelationShip(ctx, fromNode.Uid, edge, toNode.Uid, add); err != nil {
+		return errs.NewUnavailable(err, "dgraph link error")
+	}
+	return nil
+}
+
+func (r *linkRepository) LinkUserRole(ctx context.Context, userID int64, roleID int) error {
+	return setEdge(ctx, userPredicates, rolePredicates, userID, int64(roleID), roleEdge, true)
+}
+
+func (r *linkRepository) UnlinkUserRole(ctx context.Context, userID int64, roleID int) error {
+	return setEdge(ctx, userPredicates, rolePredicates, userID, int64(roleID), roleEdge, false)
+}
+
+func (r *linkRepository) LinkRoleResource(ctx context.Context, roleID, resourceID int) error {
+	return setEdge(ctx, rolePredicates, resourcePredicates, int64(roleID), int64(resourceID), resourceEdge, true)
+}
+
+func (r *linkRepository) UnlinkRoleResource(ctx context.Context, roleID, resourceID int) error {
+	return setEdge(ctx, rolePredicates, resourcePredicates, int64(roleID), int64(resourceID), resourceEdge, false)
+}
+
+func (r *linkRepository) UserRoles(ctx context.Context, userID int64) ([]*models.Role, error) {
+	roles := []*models.Role{}
+	err := linkedOf(ctx, userPredicates, rolePredicates, userID, roleEdge, &roles)
+	return roles, err
+}
+
+func (r *linkRepository) RoleResources(ctx context.Context, roleID int) ([]*models.Resource, error) {
+	resources := []*models.Resource{}
+	err := linkedOf(ctx, rolePredicates, resourcePredicates, int64(roleID), resourceEdge, &resources)
+	return resources, err
+}
+
+func (r *linkRepository) UserResources(ctx context.Context, userID int64) ([]*models.Resource, error) {
+	roles, err := r.UserRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[int]bool{}
+	resources := []*models.Resource{}
+	for _, role := range roles {
+		linked, err := r.RoleResources(ctx, role.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, resource := range linked {
+			if !seen[resource.ID] {
+				seen[resource.ID] = true
+				resources = append(resources, resource)
+			}
+		}
+	}
+	sort.Slice(resources, func(i, j int) bool { return resources[i].ID < resources[j].ID })
+	return resources, nil
+}
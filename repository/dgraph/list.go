@@ -0,0 +1,148 @@
+package dgraph
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/micro-community/auth/db"
+	"github.com/micro-community/auth/db/nosql"
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+)
+
+//listPredicates of a dgraph type, predicates are the json names of the models,
+//empty predicate means the type does not have the filter, in is the edge linking other nodes to the type,
+//lower is the lowercased name kept by save for the case insensitive prefix filter
+type listPredicates struct {
+	typ     string
+	id      string
+	name    string
+	lower   string
+	tenant  string
+	status  string
+	catalog string
+	created string
+	in      string
+}
+
+var (
+	userPredicates     = listPredicates{typ: "User", id: "id", name: "name", lower: lowerNamePredicate, tenant: "tenantId", status: "Stated", created: "createdAt"}
+	rolePredicates     = listPredicates{typ: "Role", id: "id", name: "Name", lower: lowerNamePredicate, tenant: "TenantId", created: "createdAt", in: roleEdge}
+	resourcePredicates = listPredicates{typ: "Resource", id: "id", name: "Name", lower: lowerNamePredicate, tenant: "TenantId", catalog: "Type", created: "createdAt", in: resourceEdge}
+)
+
+type listResult struct {
+	Total []Count         `json:"total"`
+	Items json.RawMessage `json:"items"`
+}
+
+//listQuery build the query of opts, deleted nodes are hidden unless ctx shows them
+func listQuery(ctx context.Context, p listPredicates, opts repository.ListOptions) *nosql.DQL {
+	q := nosql.NewDQL("list")
+
+	var filters []nosql.Func
+	if opts.NamePrefix != "" {
+		filters = append(filters, nosql.HasPrefix(q, p.lower, strings.ToLower(opts.NamePrefix)))
+	}
+	if opts.TenantID != 0 {
+		filters = append(filters, nosql.Eq(p.tenant, q.Int(int64(opts.TenantID))))
+	}
+	if opts.Status != 0 && p.status != "" {
+		filters = append(filters, nosql.Eq(p.status, q.Int(int64(opts.Status))))
+	}
+	if len(opts.Types) > 0 && p.catalog != "" {
+		types := make([]nosql.Var, 0, len(opts.Types))
+		for _, t := range opts.Types {
+			types = append(types, q.Int(int64(t)))
+		}
+		filters = append(filters, nosql.In(p.catalog, types...))
+	}
+	if !opts.CreatedAfter.IsZero() {
+		filters = append(filters, nosql.Ge(p.created, q.Time(opts.CreatedAfter)))
+	}
+	if !opts.CreatedBefore.IsZero() {
+		filters = append(filters, nosql.Lt(p.created, q.Time(opts.CreatedBefore)))
+	}
+	q.VarBlock(nosql.OfType(p.typ)).Filter(filters...).Filter(live(ctx, q)...).ValueAs("matched", "uid")
+	q.Block("total", nosql.UID("matched")).Count("count", "uid")
+
+	items := q.Block("items", nosql.UID("matched"))
+	order, after := (*nosql.Block).OrderAsc, nosql.Gt
+	if opts.Desc {
+		order, after = (*nosql.Block).OrderDesc, nosql.Lt
+	}
+	switch opts.SortBy {
+	case repository.SortByName:
+		if opts.After != nil {
+			name := q.Str(opts.After.Name)
+			items.Filter(nosql.Or(after(p.name, name), nosql.And(nosql.Eq(p.name, name), after(p.id, q.Int(opts.After.ID)))))
+		}
+		order(items, p.name)
+	case repository.SortByCreatedAt:
+		if opts.After != nil {
+			created := q.Time(opts.After.Created)
+			items.Filter(nosql.Or(after(p.created, created), nosql.And(nosql.Eq(p.created, created), after(p.id, q.Int(opts.After.ID)))))
+		}
+		order(items, p.created)
+	default:
+		if opts.After != nil {
+			items.Filter(after(p.id, q.Int(opts.After.ID)))
+		}
+	}
+	order(items, p.id)
+	if opts.Offset > 0 {
+		items.Offset(q.Int(int64(opts.Offset)))
+	}
+	if opts.Limit > 0 {
+		items.First(q.Int(int64(opts.Limit)))
+	}
+	items.Fields("uid").ExpandAll()
+	return q
+}
+
+//list query the items matched opts into items, return the total count of them
+func list(ctx context.Context, p listPredicates, opts repository.ListOptions, items interface{}) (int64, error) {
+	drsp, err := db.DDB().Run(ctx, listQuery(ctx, p, opts))
+	if err != nil {
+		return 0, errs.NewUnavailable(err, "query %s list err", p.typ)
+	}
+
+	var r listResult
+	if err = json.Unmarshal(drsp.Json, &r); err != nil {
+		return 0, errs.Wrap(err, errs.Unknown, "json unmarshal list error")
+	}
+	if len(r.Items) > 0 {
+		if err = json.Unmarshal(r.Items, items); err != nil {
+			return 0, errs.Wrap(err, errs.Unknown, "json unmarshal %s error", p.typ)
+		}
+	}
+
+	var total int64
+	if len(r.Total) > 0 {
+		total = int64(r.Total[0].Count)
+	}
+	return total, nil
+}
+
+//ListUsers return users matched opts and the total count of them
+func (e *RbacRepository) ListUsers(ctx context.Context, opts repository.ListOptions) ([]*models.User, int64, error) {
+	users := []*models.User{}
+	total, err := list(ctx, userPredicates, opts, &users)
+	return users, total, err
+}
+
+//ListRoles return roles matched opts and the total count of them
+func (e *RbacRepository) ListRoles(ctx context.Context, opts repository.ListOptions) ([]*models.Role, int64, error) {
+	roles := []*models.Role{}
+	total, err := list(ctx, rolePredicates, opts, &roles)
+	return roles, total, err
+}
+
+//ListResources return resources matched opts and the total count of them
+func (e *RbacRepository) ListResources(ctx context.Context, opts repository.ListOptions) ([]*models.Resource, int64, error) {
+	resources := []*models.Resource{}
+	total, err := list(ctx, resourcePredicates, opts, &resources)
+	return resources, total, err
+}
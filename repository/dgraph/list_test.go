@@ -0,0 +1,19 @@
+package dgraph
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/micro-community/auth/repository"
+)
+
+func TestListQueryFiltersShortPrefix(t *testing.T) {
+	query, values := listQuery(context.Background(), userPredicates, repository.ListOptions{NamePrefix: "A"}).Build()
+	if !strings.Contains(query, "(ge(lowerName, $v1) AND lt(lowerName, $v2))") {
+		t.Fatalf("list by a short prefix should filter by a range, got:\n%s", query)
+	}
+	if values["$v1"] != "a" || values["$v2"] != "b" {
+		t.Fatalf("values %v", values)
+	}
+}
@@ -0,0 +1,125 @@
+package dgraph
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/micro-community/auth/db"
+	"github.com/micro-community/auth/db/nosql"
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+)
+
+var logPredicates = listPredicates{typ: "Log", id: "id", tenant: "tenantId"}
+
+//logNode is a log as a node of type Log, the diff is kept as a json string rather than child nodes
+type logNode struct {
+	ID        int64               `json:"id"`
+	Actor     string              `json:"actor"`
+	TenantID  int                 `json:"tenantId"`
+	Kind      models.ChangeKind   `json:"kind"`
+	Action    models.ChangeAction `json:"action"`
+	EntityID  string              `json:"entityId"`
+	TargetID  string              `json:"targetId,omitempty"`
+	Diff      string              `json:"diff,omitempty"`
+	RequestID string              `json:"requestId,omitempty"`
+	Time      time.Time           `json:"time"`
+}
+
+//logRepository append logs as nodes of type Log
+type logRepository struct {
+}
+
+func NewLogRepository() repository.ILog {
+	return &logRepository{}
+}
+
+//Append take the next id in the transaction, a concurrent append of the same id aborts it
+func (r *logRepository) Append(ctx context.Context, log *models.Log) error {
+	node := logNode{Actor: log.Actor, TenantID: log.TenantID, Kind: log.Kind, Action: log.Action,
+		EntityID: log.EntityID, TargetID: log.TargetID, RequestID: log.RequestID, Time: log.Time}
+	if node.Time.IsZero() {
+		node.Time = time.Now()
+	}
+	if len(log.Diff) > 0 {
+		data, err := json.Marshal(log.Diff)
+		if err != nil {
+			return errs.Wrap(err, errs.Unknown, "json marshal log diff error")
+		}
+		node.Diff = string(data)
+	}
+
+	return inTxn(ctx, func(ctx context.Context) error {
+		id, err := nextID(ctx, logPredicates)
+		if err != nil {
+			return err
+		}
+		node.ID = id
+		if err = save(ctx, logPredicates, "_:log", node); err != nil {
+			return err
+		}
+		log.ID, log.Time = node.ID, node.Time
+		return nil
+	})
+}
+
+func (r *logRepository) Query(ctx context.Context, opts repository.LogQuery) ([]*models.Log, error) {
+	q := nosql.NewDQL("logs")
+	filters := []nosql.Func{}
+	if opts.Actor != "" {
+		filters = append(filters, nosql.Eq("actor", q.Str(opts.Actor)))
+	}
+	if opts.TenantID != 0 {
+		filters = append(filters, nosql.Eq(logPredicates.tenant, q.Int(int64(opts.TenantID))))
+	}
+	if len(opts.Kinds) > 0 {
+		kinds := make([]nosql.Var, 0, len(opts.Kinds))
+		for _, kind := range opts.Kinds {
+			kinds = append(kinds, q.Int(int64(kind)))
+		}
+		filters = append(filters, nosql.In("kind", kinds...))
+	}
+	if opts.EntityID != "" {
+		filters = append(filters, nosql.Eq("entityId", q.Str(opts.EntityID)))
+	}
+	if !opts.Since.IsZero() {
+		filters = append(filters, nosql.Ge("time", q.Time(opts.Since)))
+	}
+	if !opts.Until.IsZero() {
+		filters = append(filters, nosql.Lt("time", q.Time(opts.Until)))
+	}
+	if opts.BeforeID > 0 {
+		filters = append(filters, nosql.Lt(logPredicates.id, q.Int(opts.BeforeID)))
+	}
+	logs := q.Block("logs", nosql.OfType(logPredicates.typ)).Filter(filters...).OrderDesc(logPredicates.id)
+	if opts.Limit > 0 {
+		logs.First(q.Int(int64(opts.Limit)))
+	}
+	logs.Fields("uid").ExpandAll()
+
+	drsp, err := db.DDB().Run(ctx, q)
+	if err != nil {
+		return nil, errs.NewUnavailable(err, "query logs err")
+	}
+	var res struct {
+		Logs []logNode `json:"logs"`
+	}
+	if err = json.Unmarshal(drsp.Json, &res); err != nil {
+		return nil, errs.Wrap(err, errs.Unknown, "json unmarshal logs error")
+	}
+
+	result := make([]*models.Log, 0, len(res.Logs))
+	for _, node := range res.Logs {
+		log := &models.Log{ID: node.ID, Actor: node.Actor, TenantID: node.TenantID, Kind: node.Kind, Action: node.Action,
+			EntityID: node.EntityID, TargetID: node.TargetID, RequestID: node.RequestID, Time: node.Time}
+		if node.Diff != "" {
+			if err = json.Unmarshal([]byte(node.Diff), &log.Diff); err != nil {
+				return nil, errs.Wrap(err, errs.Unknown, "json unmarshal log diff error")
+			}
+		}
+		result = append(result, log)
+	}
+	return result, nil
+}
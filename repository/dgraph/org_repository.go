@@ -0,0 +1,286 @@
+package dgraph
+
+import (
+	"context"
+	"time"
+
+	"github.com/micro-community/auth/db/nosql"
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+)
+
+var (
+	orgUnitPredicates   = listPredicates{typ: "OrgUnit", id: "id", name: "name", tenant: "tenantId"}
+	orgMemberPredicates = listPredicates{typ: "OrgMember", id: "userId", tenant: "tenantId"}
+	orgGrantPredicates  = listPredicates{typ: "OrgGrant", id: "roleId"}
+)
+
+//unitNode is a unit as a node of type OrgUnit
+type unitNode struct {
+	Uid string `json:"uid,omitempty"`
+	models.OrgUnit
+}
+
+//memberNode is a member as a node of type OrgMember
+type memberNode struct {
+	Uid string `json:"uid,omitempty"`
+	models.OrgMember
+}
+
+//grantNode is a grant as a node of type OrgGrant
+type grantNode struct {
+	Uid string `json:"uid,omitempty"`
+	models.OrgGrant
+}
+
+//orgRepository store units, members and grants as nodes of types OrgUnit, OrgMember and OrgGrant
+type orgRepository struct {
+}
+
+func NewOrgRepository() repository.IOrg {
+	return &orgRepository{}
+}
+
+func (r *orgRepository) findByID(ctx context.Context, id int) (*unitNode, error) {
+	var node unitNode
+	found, err := findByID(ctx, orgUnitPredicates, int64(id), &node)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errs.NewNotFound("org unit %d not found", id)
+	}
+	return &node, nil
+}
+
+//nameTaken report whether another unit of the parent of unit in its tenant has its name
+func (r *orgRepository) nameTaken(ctx context.Context, unit *models.OrgUnit) (bool, error) {
+	others := []*unitNode{}
+	err := findAll(ctx, orgUnitPredicates, orgUnitPredicates.id, func(q *nosql.DQL) []nosql.Func {
+		return []nosql.Func{
+			nosql.Eq(orgUnitPredicates.name, q.Str(unit.Name)),
+			nosql.Eq("parentId", q.Int(int64(unit.ParentID))),
+			nosql.Eq(orgUnitPredicates.tenant, q.Int(int64(unit.TenantID))),
+			nosql.Not(nosql.Eq(orgUnitPredicates.id, q.Int(int64(unit.ID)))),
+		}
+	}, &others)
+	return len(others) > 0, err
+}
+
+func (r *orgRepository) FindById(ctx context.Context, id int) (*models.OrgUnit, error) {
+	node, err := r.findByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &node.OrgUnit, nil
+}
+
+func (r *orgRepository) Add(ctx context.Context, unit *models.OrgUnit) error {
+	return inTxn(ctx, func(ctx context.Context) error {
+		unit.ID = 0
+		taken, err := r.nameTaken(ctx, unit)
+		if err != nil {
+			return err
+		}
+		if taken {
+			return errs.NewAlreadyExists("org unit %s already exists", unit.Name)
+		}
+
+		id, err := nextID(ctx, orgUnitPredicates)
+		if err != nil {
+			return err
+		}
+		node := unitNode{OrgUnit: *unit}
+		node.ID, node.Version = int(id), 1
+		if node.CreatedAt.IsZero() {
+			node.CreatedAt = time.Now()
+		}
+		if err = save(ctx, orgUnitPredicates, "_:unit", node); err != nil {
+			return err
+		}
+		unit.ID, unit.Version, unit.CreatedAt = node.ID, node.Version, node.CreatedAt
+		return nil
+	})
+}
+
+func (r *orgRepository) Update(ctx context.Context, unit *models.OrgUnit) error {
+	return inTxn(ctx, func(ctx context.Context) error {
+		target, err := r.findByID(ctx, unit.ID)
+		if err != nil {
+			return err
+		}
+		if target.Version != unit.Version {
+			return repository.StaleVersion("org unit", int64(unit.ID), unit.Version)
+		}
+		taken, err := r.nameTaken(ctx, unit)
+		if err != nil {
+			return err
+		}
+		if taken {
+			return errs.NewAlreadyExists("org unit %s already exists", unit.Name)
+		}
+
+		node := unitNode{OrgUnit: *unit}
+		node.UpdatedAt = time.Now()
+		node.Version = unit.Version + 1
+		if err = save(ctx, orgUnitPredicates, target.Uid, node); err != nil {
+			return err
+		}
+		unit.UpdatedAt, unit.Version = node.UpdatedAt, node.Version
+		return nil
+	})
+}
+
+//Delete remove the unit with the roles granted to it
+func (r *orgRepository) Delete(ctx context.Context, id int, version int64) error {
+	return inTxn(ctx, func(ctx context.Context) error {
+		target, err := r.findByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if target.Version != version {
+			return repository.StaleVersion("org unit", int64(id), version)
+		}
+		if err = remove(ctx, orgUnitPredicates, target.Uid); err != nil {
+			return err
+		}
+		return removeWhere(ctx, orgGrantPredicates, func(q *nosql.DQL) []nosql.Func {
+			return []nosql.Func{nosql.Eq("unitId", q.Int(int64(id)))}
+		})
+	})
+}
+
+func (r *orgRepository) List(ctx context.Context) ([]*models.OrgUnit, error) {
+	nodes := []*unitNode{}
+	if err := findAll(ctx, orgUnitPredicates, orgUnitPredicates.id, nil, &nodes); err != nil {
+		return nil, err
+	}
+	units := make([]*models.OrgUnit, 0, len(nodes))
+	for _, node := range nodes {
+		unit := node.OrgUnit
+		units = append(units, &unit)
+	}
+	return units, nil
+}
+
+//memberOf query the node of the member of a user, nil when the user is not a member
+func (r *orgRepository) memberOf(ctx context.Context, userID int64) (*memberNode, error) {
+	nodes := []*memberNode{}
+	err := findAll(ctx, orgMemberPredicates, orgMemberPredicates.id, func(q *nosql.DQL) []nosql.Func {
+		return []nosql.Func{nosql.Eq(orgMemberPredicates.id, q.Int(userID))}
+	}, &nodes)
+	if err != nil || len(nodes) == 0 {
+		return nil, err
+	}
+	return nodes[0], nil
+}
+
+func (r *orgRepository) SetMember(ctx context.Context, member *models.OrgMember) error {
+	return inTxn(ctx, func(ctx context.Context) error {
+		target, err := r.memberOf(ctx, member.UserID)
+		if err != nil {
+			return err
+		}
+		uid := "_:member"
+		if target != nil {
+			uid = target.Uid
+		}
+		if member.CreatedAt.IsZero() {
+			member.CreatedAt = time.Now()
+		}
+		return save(ctx, orgMemberPredicates, uid, memberNode{OrgMember: *member})
+	})
+}
+
+func (r *orgRepository) RemoveMember(ctx context.Context, userID int64) error {
+	return inTxn(ctx, func(ctx context.Context) error {
+		target, err := r.memberOf(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if target == nil {
+			return errs.NewNotFound("org member %d not found", userID)
+		}
+		return remove(ctx, orgMemberPredicates, target.Uid)
+	})
+}
+
+func (r *orgRepository) MemberOf(ctx context.Context, userID int64) (*models.OrgMember, error) {
+	target, err := r.memberOf(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if target == nil {
+		return nil, errs.NewNotFound("org member %d not found", userID)
+	}
+	return &target.OrgMember, nil
+}
+
+func (r *orgRepository) Members(ctx context.Context, unitID int) ([]*models.OrgMember, error) {
+	nodes := []*memberNode{}
+	err := findAll(ctx, orgMemberPredicates, orgMemberPredicates.id, func(q *nosql.DQL) []nosql.Func {
+		return []nosql.Func{nosql.Or(nosql.Eq("deptId", q.Int(int64(unitID))), nosql.Eq("positionId", q.Int(int64(unitID))))}
+	}, &nodes)
+	if err != nil {
+		return nil, err
+	}
+	members := make([]*models.OrgMember, 0, len(nodes))
+	for _, node := range nodes {
+		member := node.OrgMember
+		members = append(members, &member)
+	}
+	return members, nil
+}
+
+//grants query the nodes of the grants of a unit, of the role only when roleID is not 0
+func (r *orgRepository) grants(ctx context.Context, unitID, roleID int) ([]*grantNode, error) {
+	nodes := []*grantNode{}
+	err := findAll(ctx, orgGrantPredicates, orgGrantPredicates.id, func(q *nosql.DQL) []nosql.Func {
+		filters := []nosql.Func{nosql.Eq("unitId", q.Int(int64(unitID)))}
+		if roleID != 0 {
+			filters = append(filters, nosql.Eq(orgGrantPredicates.id, q.Int(int64(roleID))))
+		}
+		return filters
+	}, &nodes)
+	return nodes, err
+}
+
+func (r *orgRepository) Grant(ctx context.Context, unitID, roleID int) error {
+	return inTxn(ctx, func(ctx context.Context) error {
+		targets, err := r.grants(ctx, unitID, roleID)
+		if err != nil {
+			return err
+		}
+		if len(targets) > 0 {
+			return errs.NewAlreadyExists("role %d is granted to org unit %d already", roleID, unitID)
+		}
+		grant := models.OrgGrant{UnitID: unitID, RoleID: roleID, CreatedAt: time.Now()}
+		return save(ctx, orgGrantPredicates, "_:grant", grantNode{OrgGrant: grant})
+	})
+}
+
+func (r *orgRepository) Revoke(ctx context.Context, unitID, roleID int) error {
+	return inTxn(ctx, func(ctx context.Context) error {
+		targets, err := r.grants(ctx, unitID, roleID)
+		if err != nil {
+			return err
+		}
+		if len(targets) == 0 {
+			return errs.NewNotFound("role %d is not granted to org unit %d", roleID, unitID)
+		}
+		return remove(ctx, orgGrantPredicates, targets[0].Uid)
+	})
+}
+
+func (r *orgRepository) Grants(ctx context.Context, unitID int) ([]int, error) {
+	targets, err := r.grants(ctx, unitID, 0)
+	if err != nil {
+		return nil, err
+	}
+	roleIDs := make([]int, 0, len(targets))
+	for _, target := range targets {
+		roleIDs = append(roleIDs, target.RoleID)
+	}
+	return roleIDs, nil
+}
@@ -0,0 +1,143 @@
+package dgraph
+
+import (
+	"context"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+)
+
+//resourceRepository store resources as nodes of type Resource
+type resourceRepository struct {
+}
+
+func NewResourceRepository() repository.IResource {
+	return &resourceRepository{}
+}
+
+func (r *resourceRepository) FindById(ctx context.Context, id int64) (*models.Resource, error) {
+	var resource models.Resource
+	found, err := findByID(ctx, resourcePredicates, id, &resource)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errs.NewNotFound("resource %d not found", id)
+	}
+	return &resource, nil
+}
+
+func (r *resourceRepository) FindByName(ctx context.Context, name string) (*models.Resource, error) {
+	var resource models.Resource
+	found, err := findByNameIn(ctx, resourcePredicates, repository.NameTenant(ctx), name, &resource)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errs.NewNotFound("resource %s not found", name)
+	}
+	return &resource, nil
+}
+
+func (r *resourceRepository) Add(ctx context.Context, resource *models.Resource) error {
+	//names of deleted resources are kept until purged
+	found, err := findByNameIn(repository.WithDeleted(ctx), resourcePredicates, resource.TenantID, resource.Name, &models.Resource{})
+	if err != nil {
+		return err
+	}
+	if found {
+		return errs.NewAlreadyExists("resource %s already exists", resource.Name)
+	}
+
+	id, err := nextID(ctx, resourcePredicates)
+	if err != nil {
+		return err
+	}
+	resource.ID = int(id)
+	if resource.CreatedAt.IsZero() {
+		resource.CreatedAt = time.Now()
+	}
+	resource.Version = 1
+	return save(ctx, resourcePredicates, "_:resource", resource)
+}
+
+func (r *resourceRepository) Update(ctx context.Context, resource *models.Resource) error {
+	version := resource.Version
+	err := inTxn(ctx, func(ctx context.Context) error {
+		target, err := r.FindById(ctx, int64(resource.ID))
+		if err != nil {
+			return err
+		}
+		if target.IsSoftDel {
+			return errs.NewNotFound("resource %d not found", resource.ID)
+		}
+		if target.Version != version {
+			return repository.StaleVersion("resource", int64(resource.ID), version)
+		}
+		var other models.Resource
+		found, err := findByNameIn(repository.WithDeleted(ctx), resourcePredicates, resource.TenantID, resource.Name, &other)
+		if err != nil {
+			return err
+		}
+		if found && other.ID != resource.ID {
+			return errs.NewAlreadyExists("resource %s already exists", resource.Name)
+		}
+		resource.UpdatedAt = time.Now()
+		resource.IsSoftDel, resource.DeletedAt = false, time.Time{}
+		resource.Version = version + 1
+		return save(ctx, resourcePredicates, target.Uid, resource)
+	})
+	if err != nil {
+		resource.Version = version
+	}
+	return err
+}
+
+//Delete mark the resource deleted, its edges are kept for a restore
+func (r *resourceRepository) Delete(ctx context.Context, id, version int64) error {
+	return inTxn(ctx, func(ctx context.Context) error {
+		target, err := r.FindById(ctx, id)
+		if err != nil {
+			return err
+		}
+		if target.IsSoftDel {
+			return errs.NewNotFound("resource %d not found", id)
+		}
+		if target.Version != version {
+			return repository.StaleVersion("resource", id, version)
+		}
+		return markDeleted(ctx, resourcePredicates, target.Uid, version+1, true)
+	})
+}
+
+func (r *resourceRepository) Restore(ctx context.Context, id int64) error {
+	return inTxn(ctx, func(ctx context.Context) error {
+		target, err := r.FindById(repository.WithDeleted(ctx), id)
+		if errs.CodeOf(err) == errs.NotFound || err == nil && !target.IsSoftDel {
+			return errs.NewNotFound("deleted resource %d not found", id)
+		} else if err != nil {
+			return err
+		}
+		return markDeleted(ctx, resourcePredicates, target.Uid, target.Version+1, false)
+	})
+}
+
+//Purge resources deleted before the time with their edges
+func (r *resourceRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	return purge(ctx, resourcePredicates, before)
+}
+
+func (r *resourceRepository) List(ctx context.Context, opts repository.ListOptions) ([]*models.Resource, int64, error) {
+	resources := []*models.Resource{}
+	total, err := list(ctx, resourcePredicates, opts, &resources)
+	return resources, total, err
+}
+
+//Search resources of a tenant (0 for any tenant) by catalog types (none for any type)
+func (r *resourceRepository) Search(ctx context.Context, tenantID int, types ...models.ResourceCatalog) ([]*models.Resource, error) {
+	resources := []*models.Resource{}
+	_, err := list(ctx, resourcePredicates, repository.ListOptions{TenantID: tenantID, Types: types}, &resources)
+	return resources, err
+}
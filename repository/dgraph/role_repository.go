@@ -0,0 +1,140 @@
+package dgraph
+
+import (
+	"context"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+)
+
+//roleRepository store roles as nodes of type Role
+type roleRepository struct {
+}
+
+func NewRoleRepository() repository.IRole {
+	return &roleRepository{}
+}
+
+func (r *roleRepository) FindById(ctx context.Context, id int64) (*models.Role, error) {
+	var role models.Role
+	found, err := findByID(ctx, rolePredicates, id, &role)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errs.NewNotFound("role %d not found", id)
+	}
+	return &role, nil
+}
+
+func (r *roleRepository) FindByName(ctx context.Context, name string) (*models.Role, error) {
+	var role models.Role
+	found, err := findByNameIn(ctx, rolePredicates, repository.NameTenant(ctx), name, &role)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errs.NewNotFound("role %s not found", name)
+	}
+	return &role, nil
+}
+
+func (r *roleRepository) Add(ctx context.Context, role *models.Role) error {
+	//names of deleted roles are kept until purged
+	found, err := findByNameIn(repository.WithDeleted(ctx), rolePredicates, role.TenantID, role.Name, &models.Role{})
+	if err != nil {
+		return err
+	}
+	if found {
+		return errs.NewAlreadyExists("role %s already exists", role.Name)
+	}
+
+	id, err := nextID(ctx, rolePredicates)
+	if err != nil {
+		return err
+	}
+	role.ID = int(id)
+	if role.CreatedAt.IsZero() {
+		role.CreatedAt = time.Now()
+	}
+	role.Version = 1
+	return save(ctx, rolePredicates, "_:role", role)
+}
+
+//Update role, the key of a role can not be modified
+func (r *roleRepository) Update(ctx context.Context, role *models.Role) error {
+	version := role.Version
+	err := inTxn(ctx, func(ctx context.Context) error {
+		target, err := r.FindById(ctx, int64(role.ID))
+		if err != nil {
+			return err
+		}
+		if target.IsSoftDel {
+			return errs.NewNotFound("role %d not found", role.ID)
+		}
+		if target.Version != version {
+			return repository.StaleVersion("role", int64(role.ID), version)
+		}
+		if role.Key != "" && target.Key != role.Key {
+			return errs.NewConflict("role key modify forbidden")
+		}
+		var other models.Role
+		found, err := findByNameIn(repository.WithDeleted(ctx), rolePredicates, role.TenantID, role.Name, &other)
+		if err != nil {
+			return err
+		}
+		if found && other.ID != role.ID {
+			return errs.NewAlreadyExists("role %s already exists", role.Name)
+		}
+		role.UpdatedAt = time.Now()
+		role.IsSoftDel, role.DeletedAt = false, time.Time{}
+		role.Version = version + 1
+		return save(ctx, rolePredicates, target.Uid, role)
+	})
+	if err != nil {
+		role.Version = version
+	}
+	return err
+}
+
+//Delete mark the role deleted, its edges are kept for a restore
+func (r *roleRepository) Delete(ctx context.Context, id, version int64) error {
+	return inTxn(ctx, func(ctx context.Context) error {
+		target, err := r.FindById(ctx, id)
+		if err != nil {
+			return err
+		}
+		if target.IsSoftDel {
+			return errs.NewNotFound("role %d not found", id)
+		}
+		if target.Version != version {
+			return repository.StaleVersion("role", id, version)
+		}
+		return markDeleted(ctx, rolePredicates, target.Uid, version+1, true)
+	})
+}
+
+func (r *roleRepository) Restore(ctx context.Context, id int64) error {
+	return inTxn(ctx, func(ctx context.Context) error {
+		target, err := r.FindById(repository.WithDeleted(ctx), id)
+		if errs.CodeOf(err) == errs.NotFound || err == nil && !target.IsSoftDel {
+			return errs.NewNotFound("deleted role %d not found", id)
+		} else if err != nil {
+			return err
+		}
+		return markDeleted(ctx, rolePredicates, target.Uid, target.Version+1, false)
+	})
+}
+
+//Purge roles deleted before the time with their edges
+func (r *roleRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	return purge(ctx, rolePredicates, before)
+}
+
+func (r *roleRepository) List(ctx context.Context, opts repository.ListOptions) ([]*models.Role, int64, error) {
+	roles := []*models.Role{}
+	total, err := list(ctx, rolePredicates, opts, &roles)
+	return roles, total, err
+}
@@ -0,0 +1,223 @@
+package dgraph
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/micro-community/auth/db/nosql"
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+)
+
+var (
+	roleTemplatePredicates = listPredicates{typ: "RoleTemplate", id: "id", name: "name"}
+	roleInstancePredicates = listPredicates{typ: "RoleInstance", id: "roleId"}
+)
+
+//templateNode is a template as a node of type RoleTemplate,
+//the grants are kept as a json string, a list predicate would add to the grants on updates rather than replace them
+type templateNode struct {
+	Uid string `json:"uid,omitempty"`
+	models.RoleTemplate
+	Grants string `json:"grants"`
+}
+
+func toTemplateNode(template *models.RoleTemplate) (*templateNode, error) {
+	grants, err := json.Marshal(template.Grants)
+	if err != nil {
+		return nil, errs.Wrap(err, errs.Unknown, "json marshal role template grants error")
+	}
+	return &templateNode{RoleTemplate: *template, Grants: string(grants)}, nil
+}
+
+func (n *templateNode) template() (*models.RoleTemplate, error) {
+	template := n.RoleTemplate
+	template.Grants = nil
+	if n.Grants != "" {
+		if err := json.Unmarshal([]byte(n.Grants), &template.Grants); err != nil {
+			return nil, errs.Wrap(err, errs.Unknown, "json unmarshal role template grants error")
+		}
+	}
+	return &template, nil
+}
+
+//instanceNode is an instance as a node of type RoleInstance
+type instanceNode struct {
+	Uid string `json:"uid,omitempty"`
+	models.RoleInstance
+}
+
+//roleTemplateRepository store templates as nodes of type RoleTemplate and their instances as nodes of type RoleInstance
+type roleTemplateRepository struct {
+}
+
+func NewRoleTemplateRepository() repository.IRoleTemplate {
+	return &roleTemplateRepository{}
+}
+
+func (r *roleTemplateRepository) findByID(ctx context.Context, id int) (*templateNode, error) {
+	var node templateNode
+	found, err := findByID(ctx, roleTemplatePredicates, int64(id), &node)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errs.NewNotFound("role template %d not found", id)
+	}
+	return &node, nil
+}
+
+func (r *roleTemplateRepository) FindById(ctx context.Context, id int) (*models.RoleTemplate, error) {
+	node, err := r.findByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return node.template()
+}
+
+func (r *roleTemplateRepository) FindByName(ctx context.Context, name string) (*models.RoleTemplate, error) {
+	var node templateNode
+	found, err := findByName(ctx, roleTemplatePredicates, name, &node)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errs.NewNotFound("role template %s not found", name)
+	}
+	return node.template()
+}
+
+func (r *roleTemplateRepository) Add(ctx context.Context, template *models.RoleTemplate) error {
+	return inTxn(ctx, func(ctx context.Context) error {
+		found, err := findByName(ctx, roleTemplatePredicates, template.Name, &templateNode{})
+		if err != nil {
+			return err
+		}
+		if found {
+			return errs.NewAlreadyExists("role template %s already exists", template.Name)
+		}
+
+		id, err := nextID(ctx, roleTemplatePredicates)
+		if err != nil {
+			return err
+		}
+		node, err := toTemplateNode(template)
+		if err != nil {
+			return err
+		}
+		node.ID, node.Version = int(id), 1
+		if node.CreatedAt.IsZero() {
+			node.CreatedAt = time.Now()
+		}
+		if err = save(ctx, roleTemplatePredicates, "_:template", node); err != nil {
+			return err
+		}
+		template.ID, template.Version, template.CreatedAt = node.ID, node.Version, node.CreatedAt
+		return nil
+	})
+}
+
+func (r *roleTemplateRepository) Update(ctx context.Context, template *models.RoleTemplate) error {
+	return inTxn(ctx, func(ctx context.Context) error {
+		target, err := r.findByID(ctx, template.ID)
+		if err != nil {
+			return err
+		}
+		if target.Version != template.Version {
+			return repository.StaleVersion("role template", int64(template.ID), template.Version)
+		}
+		var other templateNode
+		found, err := findByName(ctx, roleTemplatePredicates, template.Name, &other)
+		if err != nil {
+			return err
+		}
+		if found && other.ID != template.ID {
+			return errs.NewAlreadyExists("role template %s already exists", template.Name)
+		}
+
+		node, err := toTemplateNode(template)
+		if err != nil {
+			return err
+		}
+		node.UpdatedAt = time.Now()
+		node.Version = template.Version + 1
+		if err = save(ctx, roleTemplatePredicates, target.Uid, node); err != nil {
+			return err
+		}
+		template.UpdatedAt, template.Version = node.UpdatedAt, node.Version
+		return nil
+	})
+}
+
+//Delete remove the template with its instances, the roles are kept
+func (r *roleTemplateRepository) Delete(ctx context.Context, id int, version int64) error {
+	return inTxn(ctx, func(ctx context.Context) error {
+		target, err := r.findByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if target.Version != version {
+			return repository.StaleVersion("role template", int64(id), version)
+		}
+		if err = remove(ctx, roleTemplatePredicates, target.Uid); err != nil {
+			return err
+		}
+		return removeWhere(ctx, roleInstancePredicates, func(q *nosql.DQL) []nosql.Func {
+			return []nosql.Func{nosql.Eq("templateId", q.Int(int64(id)))}
+		})
+	})
+}
+
+func (r *roleTemplateRepository) List(ctx context.Context) ([]*models.RoleTemplate, error) {
+	nodes := []*templateNode{}
+	if err := findAll(ctx, roleTemplatePredicates, roleTemplatePredicates.id, nil, &nodes); err != nil {
+		return nil, err
+	}
+	templates := make([]*models.RoleTemplate, 0, len(nodes))
+	for _, node := range nodes {
+		template, err := node.template()
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+	return templates, nil
+}
+
+func (r *roleTemplateRepository) SaveInstance(ctx context.Context, instance *models.RoleInstance) error {
+	return inTxn(ctx, func(ctx context.Context) error {
+		targets := []*instanceNode{}
+		err := findAll(ctx, roleInstancePredicates, roleInstancePredicates.id, func(q *nosql.DQL) []nosql.Func {
+			return []nosql.Func{nosql.Eq(roleInstancePredicates.id, q.Int(int64(instance.RoleID)))}
+		}, &targets)
+		if err != nil {
+			return err
+		}
+		uid := "_:instance"
+		if len(targets) > 0 {
+			uid = targets[0].Uid
+		}
+		if instance.CreatedAt.IsZero() {
+			instance.CreatedAt = time.Now()
+		}
+		return save(ctx, roleInstancePredicates, uid, instanceNode{RoleInstance: *instance})
+	})
+}
+
+func (r *roleTemplateRepository) Instances(ctx context.Context, templateID int) ([]*models.RoleInstance, error) {
+	nodes := []*instanceNode{}
+	err := findAll(ctx, roleInstancePredicates, roleInstancePredicates.id, func(q *nosql.DQL) []nosql.Func {
+		return []nosql.Func{nosql.Eq("templateId", q.Int(int64(templateID)))}
+	}, &nodes)
+	if err != nil {
+		return nil, err
+	}
+	instances := make([]*models.RoleInstance, 0, len(nodes))
+	for _, node := range nodes {
+		instance := node.RoleInstance
+		instances = append(instances, &instance)
+	}
+	return instances, nil
+}
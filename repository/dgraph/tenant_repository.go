@@ -0,0 +1,190 @@
+package dgraph
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/micro-community/auth/db/nosql"
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+)
+
+var tenantPredicates = listPredicates{typ: "Tenant", id: "id", name: "name"}
+
+//tenantNode is a tenant as a node of type Tenant, the settings are kept as a json string rather than a child node
+type tenantNode struct {
+	Uid string `json:"uid,omitempty"`
+	models.Tenant
+	Settings string `json:"settings"`
+}
+
+func toTenantNode(tenant *models.Tenant) (*tenantNode, error) {
+	settings, err := json.Marshal(tenant.Settings)
+	if err != nil {
+		return nil, errs.Wrap(err, errs.Unknown, "json marshal tenant settings error")
+	}
+	return &tenantNode{Tenant: *tenant, Settings: string(settings)}, nil
+}
+
+func (n *tenantNode) tenant() (*models.Tenant, error) {
+	tenant := n.Tenant
+	if n.Settings != "" {
+		if err := json.Unmarshal([]byte(n.Settings), &tenant.Settings); err != nil {
+			return nil, errs.Wrap(err, errs.Unknown, "json unmarshal tenant settings error")
+		}
+	}
+	return &tenant, nil
+}
+
+//tenantRepository store tenants as nodes of type Tenant.
+//A deleted tenant is kept as a hidden node, so its id is never taken by a new tenant
+type tenantRepository struct {
+}
+
+func NewTenantRepository() repository.ITenant {
+	return &tenantRepository{}
+}
+
+//findLive query the node of a tenant matched by match, deleted ones are hidden even to a ctx showing deleted entities
+func (r *tenantRepository) findLive(ctx context.Context, match func(q *nosql.DQL) nosql.Func) (*tenantNode, bool, error) {
+	nodes := []*tenantNode{}
+	err := findAll(ctx, tenantPredicates, tenantPredicates.id, func(q *nosql.DQL) []nosql.Func {
+		return []nosql.Func{match(q), nosql.Not(nosql.Eq(deletedPredicate, q.Bool(true)))}
+	}, &nodes)
+	if err != nil || len(nodes) == 0 {
+		return nil, false, err
+	}
+	return nodes[0], true, nil
+}
+
+func (r *tenantRepository) findByID(ctx context.Context, id int) (*tenantNode, bool, error) {
+	return r.findLive(ctx, func(q *nosql.DQL) nosql.Func { return nosql.Eq(tenantPredicates.id, q.Int(int64(id))) })
+}
+
+func (r *tenantRepository) findByName(ctx context.Context, name string) (*tenantNode, bool, error) {
+	return r.findLive(ctx, func(q *nosql.DQL) nosql.Func { return nosql.Eq(tenantPredicates.name, q.Str(name)) })
+}
+
+func (r *tenantRepository) FindById(ctx context.Context, id int) (*models.Tenant, error) {
+	node, found, err := r.findByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errs.NewNotFound("tenant %d not found", id)
+	}
+	return node.tenant()
+}
+
+func (r *tenantRepository) FindByName(ctx context.Context, name string) (*models.Tenant, error) {
+	node, found, err := r.findByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errs.NewNotFound("tenant %s not found", name)
+	}
+	return node.tenant()
+}
+
+func (r *tenantRepository) Add(ctx context.Context, tenant *models.Tenant) error {
+	return inTxn(ctx, func(ctx context.Context) error {
+		_, found, err := r.findByName(ctx, tenant.Name)
+		if err != nil {
+			return err
+		}
+		if found {
+			return errs.NewAlreadyExists("tenant %s already exists", tenant.Name)
+		}
+
+		id, err := nextID(ctx, tenantPredicates)
+		if err != nil {
+			return err
+		}
+		node, err := toTenantNode(tenant)
+		if err != nil {
+			return err
+		}
+		node.ID, node.Version = int(id), 1
+		if node.CreatedAt.IsZero() {
+			node.CreatedAt = time.Now()
+		}
+		if err = save(ctx, tenantPredicates, "_:tenant", node); err != nil {
+			return err
+		}
+		tenant.ID, tenant.Version, tenant.CreatedAt = node.ID, node.Version, node.CreatedAt
+		return nil
+	})
+}
+
+func (r *tenantRepository) Update(ctx context.Context, tenant *models.Tenant) error {
+	return inTxn(ctx, func(ctx context.Context) error {
+		target, found, err := r.findByID(ctx, tenant.ID)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return errs.NewNotFound("tenant %d not found", tenant.ID)
+		}
+		if target.Version != tenant.Version {
+			return repository.StaleVersion("tenant", int64(tenant.ID), tenant.Version)
+		}
+		other, found, err := r.findByName(ctx, tenant.Name)
+		if err != nil {
+			return err
+		}
+		if found && other.ID != tenant.ID {
+			return errs.NewAlreadyExists("tenant %s already exists", tenant.Name)
+		}
+
+		node, err := toTenantNode(tenant)
+		if err != nil {
+			return err
+		}
+		node.UpdatedAt = time.Now()
+		node.IsSoftDel, node.DeletedAt = false, time.Time{}
+		node.Version = tenant.Version + 1
+		if err = save(ctx, tenantPredicates, target.Uid, node); err != nil {
+			return err
+		}
+		tenant.UpdatedAt, tenant.Version = node.UpdatedAt, node.Version
+		return nil
+	})
+}
+
+func (r *tenantRepository) Delete(ctx context.Context, id int, version int64) error {
+	return inTxn(ctx, func(ctx context.Context) error {
+		target, found, err := r.findByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return errs.NewNotFound("tenant %d not found", id)
+		}
+		if target.Version != version {
+			return repository.StaleVersion("tenant", int64(id), version)
+		}
+		return markDeleted(ctx, tenantPredicates, target.Uid, target.Version+1, true)
+	})
+}
+
+func (r *tenantRepository) List(ctx context.Context) ([]*models.Tenant, error) {
+	nodes := []*tenantNode{}
+	err := findAll(ctx, tenantPredicates, tenantPredicates.id, func(q *nosql.DQL) []nosql.Func {
+		return []nosql.Func{nosql.Not(nosql.Eq(deletedPredicate, q.Bool(true)))}
+	}, &nodes)
+	if err != nil {
+		return nil, err
+	}
+	tenants := make([]*models.Tenant, 0, len(nodes))
+	for _, node := range nodes {
+		tenant, err := node.tenant()
+		if err != nil {
+			return nil, err
+		}
+		tenants = append(tenants, tenant)
+	}
+	return tenants, nil
+}
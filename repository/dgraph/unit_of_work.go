@@ -0,0 +1,48 @@
+package dgraph
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dgraph-io/dgo/v200"
+	"github.com/micro-community/auth/db"
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/repository"
+)
+
+//unitOfWork run operations in a dgraph transaction, a conflict with a concurrent transaction aborts it
+type unitOfWork struct {
+}
+
+func NewUnitOfWork() repository.UnitOfWork {
+	return unitOfWork{}
+}
+
+func (unitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	var fnErr error
+	err := db.DDB().WithTxn(ctx, func(ctx context.Context) error {
+		fnErr = fn(ctx)
+		return fnErr
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	return commitError(err)
+}
+
+//commitError translate the error of committing a transaction to domain errors
+func commitError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, dgo.ErrAborted):
+		return errs.NewConflict("transaction aborted by a concurrent one, try again")
+	default:
+		return errs.NewUnavailable(err, "dgraph commit error")
+	}
+}
+
+//inTxn run fn of domain errors in a transaction
+func inTxn(ctx context.Context, fn func(ctx context.Context) error) error {
+	return unitOfWork{}.Do(ctx, fn)
+}
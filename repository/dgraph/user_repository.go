@@ -0,0 +1,136 @@
+package dgraph
+
+import (
+	"context"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+)
+
+//userRepository store users as nodes of type User
+type userRepository struct {
+}
+
+func NewUserRepository() repository.IUser {
+	return &userRepository{}
+}
+
+func (r *userRepository) FindById(ctx context.Context, id int64) (*models.User, error) {
+	var user models.User
+	found, err := findByID(ctx, userPredicates, id, &user)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errs.NewNotFound("user %d not found", id)
+	}
+	return &user, nil
+}
+
+func (r *userRepository) FindByName(ctx context.Context, name string) (*models.User, error) {
+	var user models.User
+	found, err := findByNameIn(ctx, userPredicates, repository.NameTenant(ctx), name, &user)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errs.NewNotFound("user %s not found", name)
+	}
+	return &user, nil
+}
+
+func (r *userRepository) Add(ctx context.Context, user *models.User) error {
+	//names of deleted users are kept until purged
+	found, err := findByNameIn(repository.WithDeleted(ctx), userPredicates, user.TenantID, user.Name, &models.User{})
+	if err != nil {
+		return err
+	}
+	if found {
+		return errs.NewAlreadyExists("user %s already exists", user.Name)
+	}
+
+	id, err := nextID(ctx, userPredicates)
+	if err != nil {
+		return err
+	}
+	user.ID = id
+	if user.CreatedAt.IsZero() {
+		user.CreatedAt = time.Now()
+	}
+	user.Version = 1
+	return save(ctx, userPredicates, "_:user", user)
+}
+
+func (r *userRepository) Update(ctx context.Context, user *models.User) error {
+	version := user.Version
+	err := inTxn(ctx, func(ctx context.Context) error {
+		target, err := r.FindById(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+		if target.IsSoftDel {
+			return errs.NewNotFound("user %d not found", user.ID)
+		}
+		if target.Version != version {
+			return repository.StaleVersion("user", user.ID, version)
+		}
+		var other models.User
+		found, err := findByNameIn(repository.WithDeleted(ctx), userPredicates, user.TenantID, user.Name, &other)
+		if err != nil {
+			return err
+		}
+		if found && other.ID != user.ID {
+			return errs.NewAlreadyExists("user %s already exists", user.Name)
+		}
+		user.UpdatedAt = time.Now()
+		user.IsSoftDel, user.DeletedAt = false, time.Time{}
+		user.Version = version + 1
+		return save(ctx, userPredicates, target.Uid, user)
+	})
+	if err != nil {
+		user.Version = version
+	}
+	return err
+}
+
+//Delete mark the user deleted, its edges are kept for a restore
+func (r *userRepository) Delete(ctx context.Context, id, version int64) error {
+	return inTxn(ctx, func(ctx context.Context) error {
+		target, err := r.FindById(ctx, id)
+		if err != nil {
+			return err
+		}
+		if target.IsSoftDel {
+			return errs.NewNotFound("user %d not found", id)
+		}
+		if target.Version != version {
+			return repository.StaleVersion("user", id, version)
+		}
+		return markDeleted(ctx, userPredicates, target.Uid, version+1, true)
+	})
+}
+
+func (r *userRepository) Restore(ctx context.Context, id int64) error {
+	return inTxn(ctx, func(ctx context.Context) error {
+		target, err := r.FindById(repository.WithDeleted(ctx), id)
+		if errs.CodeOf(err) == errs.NotFound || err == nil && !target.IsSoftDel {
+			return errs.NewNotFound("deleted user %d not found", id)
+		} else if err != nil {
+			return err
+		}
+		return markDeleted(ctx, userPredicates, target.Uid, target.Version+1, false)
+	})
+}
+
+//Purge users deleted before the time with their edges
+func (r *userRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	return purge(ctx, userPredicates, before)
+}
+
+func (r *userRepository) List(ctx context.Context, opts repository.ListOptions) ([]*models.User, int64, error) {
+	users := []*models.User{}
+	total, err := list(ctx, userPredicates, opts, &users)
+	return users, total, err
+}
@@ -0,0 +1,66 @@
+package file
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/micro-community/auth/db/nosql"
+	"github.com/micro-community/auth/repository"
+	"github.com/micro-community/auth/repository/conformance"
+)
+
+func openDB(t *testing.T) *nosql.BoltDB {
+	db, err := nosql.OpenBolt(&nosql.BoltOptions{Path: filepath.Join(t.TempDir(), "auth.bolt")}, nosql.RbacBuckets...)
+	if err != nil {
+		t.Fatalf("open file database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestUserRepository(t *testing.T) {
+	conformance.RunUser(t, func(t *testing.T) repository.IUser { return NewUserRepository(openDB(t)) })
+}
+
+func TestRoleRepository(t *testing.T) {
+	conformance.RunRole(t, func(t *testing.T) repository.IRole { return NewRoleRepository(openDB(t)) })
+}
+
+func TestResourceRepository(t *testing.T) {
+	conformance.RunResource(t, func(t *testing.T) repository.IResource { return NewResourceRepository(openDB(t)) })
+}
+
+func newRepositories(t *testing.T) conformance.Repositories {
+	db := openDB(t)
+	return conformance.Repositories{
+		Users:     NewUserRepository(db),
+		Roles:     NewRoleRepository(db),
+		Resources: NewResourceRepository(db),
+		Links:     NewLinkRepository(db),
+		Work:      NewUnitOfWork(db),
+	}
+}
+
+func TestLinkRepository(t *testing.T) {
+	conformance.RunLink(t, newRepositories)
+}
+
+func TestUnitOfWork(t *testing.T) {
+	conformance.RunUnitOfWork(t, newRepositories, true)
+}
+
+func TestLogRepository(t *testing.T) {
+	conformance.RunLog(t, func(t *testing.T) repository.ILog { return NewLogRepository(openDB(t)) })
+}
+
+func TestTenantRepository(t *testing.T) {
+	conformance.RunTenant(t, func(t *testing.T) repository.ITenant { return NewTenantRepository(openDB(t)) })
+}
+
+func TestRoleTemplateRepository(t *testing.T) {
+	conformance.RunRoleTemplate(t, func(t *testing.T) repository.IRoleTemplate { return NewRoleTemplateRepository(openDB(t)) })
+}
+
+func TestOrgRepository(t *testing.T) {
+	conformance.RunOrg(t, func(t *testing.T) repository.IOrg { return NewOrgRepository(openDB(t)) })
+}
@@ -0,0 +1,197 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	bolt "go.etcd.io/bbolt"
+)
+
+//tenantName index the name of an entity in its tenant, names of users, roles and resources are unique in a tenant
+func tenantName(tenant int, name string) string {
+	return strconv.Itoa(tenant) + "/" + name
+}
+
+//collection of entities of a kind, entities are kept by id in a bucket and indexed by name in another
+type collection struct {
+	bucket []byte
+	names  []byte
+	kind   string
+}
+
+var (
+	users     = collection{[]byte("users"), []byte("users_names"), "user"}
+	roles     = collection{[]byte("roles"), []byte("roles_names"), "role"}
+	resources = collection{[]byte("resources"), []byte("resources_names"), "resource"}
+)
+
+//nextID of the collection, it is taken back when the transaction rolls back
+func (c collection) nextID(tx *bolt.Tx) (int64, error) {
+	id, err := tx.Bucket(c.bucket).NextSequence()
+	return int64(id), err
+}
+
+//load decode the entity of id into v, deleted or not, found is false when it is missing
+func (c collection) load(tx *bolt.Tx, id int64, v interface{}) (bool, error) {
+	return get(tx.Bucket(c.bucket), itob(id), v)
+}
+
+//find decode the entity of id into v, ext is the extension of v, found is false when it is missing or hidden from ctx
+func (c collection) find(ctx context.Context, tx *bolt.Tx, id int64, v interface{}, ext *models.ModelExtension) (bool, error) {
+	found, err := c.load(tx, id, v)
+	if err != nil || !found {
+		return false, err
+	}
+	return visible(ctx, *ext), nil
+}
+
+//findByName decode the entity named name into v like find
+func (c collection) findByName(ctx context.Context, tx *bolt.Tx, name string, v interface{}, ext *models.ModelExtension) (bool, error) {
+	id := tx.Bucket(c.names).Get([]byte(name))
+	if id == nil {
+		return false, nil
+	}
+	return c.find(ctx, tx, btoi(id), v, ext)
+}
+
+//taken report whether the name is kept by an entity, deleted or not
+func (c collection) taken(tx *bolt.Tx, name string) bool {
+	return tx.Bucket(c.names).Get([]byte(name)) != nil
+}
+
+//put the entity of id named name, the index of its old name is moved
+func (c collection) put(tx *bolt.Tx, id int64, name, oldName string, v interface{}) error {
+	if err := put(tx.Bucket(c.bucket), itob(id), v); err != nil {
+		return err
+	}
+	if name == oldName {
+		return nil
+	}
+	names := tx.Bucket(c.names)
+	if err := names.Put([]byte(name), itob(id)); err != nil {
+		return err
+	}
+	if oldName == "" {
+		return nil
+	}
+	return names.Delete([]byte(oldName))
+}
+
+//checkLive return an error when the stored entity of ext is not live at the version
+func (c collection) checkLive(found bool, ext models.ModelExtension, id, version int64) error {
+	if !found || ext.IsSoftDel {
+		return errs.NewNotFound("%s %d not found", c.kind, id)
+	}
+	if ext.Version != version {
+		return repository.StaleVersion(c.kind, id, version)
+	}
+	return nil
+}
+
+//setDeleted mark the entity of id at the version deleted now, or restore it at any version, as a new version
+func (c collection) setDeleted(tx *bolt.Tx, id, version int64, deleted bool) error {
+	var fields map[string]json.RawMessage
+	var ext models.ModelExtension
+	found, err := c.load(tx, id, &fields)
+	if err != nil {
+		return err
+	}
+	if found {
+		if _, err = c.load(tx, id, &ext); err != nil {
+			return err
+		}
+	}
+	if deleted {
+		if err = c.checkLive(found, ext, id, version); err != nil {
+			return err
+		}
+	} else if !found || !ext.IsSoftDel {
+		return errs.NewNotFound("deleted %s %d not found", c.kind, id)
+	}
+
+	markDeleted(&ext, deleted)
+	//fields of the extension are inlined in the entity
+	data, err := json.Marshal(ext)
+	if err != nil {
+		return fileError(err)
+	}
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return fileError(err)
+	}
+	return put(tx.Bucket(c.bucket), itob(id), fields)
+}
+
+//purge the entities deleted before the time with their names and links
+func (c collection) purge(tx *bolt.Tx, before time.Time, links ...func(tx *bolt.Tx, id int64) error) (int64, error) {
+	purged := map[int64]bool{}
+	err := tx.Bucket(c.bucket).ForEach(func(k, v []byte) error {
+		var ext models.ModelExtension
+		if err := json.Unmarshal(v, &ext); err != nil {
+			return fileError(err)
+		}
+		if expired(ext, before) {
+			purged[btoi(k)] = true
+		}
+		return nil
+	})
+	if err != nil || len(purged) == 0 {
+		return 0, err
+	}
+
+	var names [][]byte
+	err = tx.Bucket(c.names).ForEach(func(k, v []byte) error {
+		if purged[btoi(v)] {
+			names = append(names, append([]byte(nil), k...))
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, name := range names {
+		if err = tx.Bucket(c.names).Delete(name); err != nil {
+			return 0, err
+		}
+	}
+	for id := range purged {
+		for _, unlink := range links {
+			if err = unlink(tx, id); err != nil {
+				return 0, err
+			}
+		}
+		if err = tx.Bucket(c.bucket).Delete(itob(id)); err != nil {
+			return 0, err
+		}
+	}
+	return int64(len(purged)), nil
+}
+
+//scan the entities in order of id
+func (c collection) scan(tx *bolt.Tx, fn func(data []byte) error) error {
+	return tx.Bucket(c.bucket).ForEach(func(k, v []byte) error { return fn(v) })
+}
+
+//visible report whether reads with ctx see the entity of ext
+func visible(ctx context.Context, ext models.ModelExtension) bool {
+	return !ext.IsSoftDel || repository.ShowDeleted(ctx)
+}
+
+//expired report whether the entity of ext is deleted before the time
+func expired(ext models.ModelExtension, before time.Time) bool {
+	return ext.IsSoftDel && ext.DeletedAt.Before(before)
+}
+
+//markDeleted mark the entity of ext deleted now, or restore it, as a new version
+func markDeleted(ext *models.ModelExtension, deleted bool) {
+	ext.Version++
+	ext.IsSoftDel = deleted
+	ext.DeletedAt = time.Time{}
+	if deleted {
+		ext.DeletedAt = time.Now()
+	}
+}
@@ -0,0 +1,84 @@
+//Package file keep users, roles, resources, their links and logs in an embedded database file,
+//so the service runs standalone. Every operation is an atomic transaction of the file,
+//a unit of work joins operations into one transaction.
+package file
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/micro-community/auth/db/nosql"
+	"github.com/micro-community/auth/errs"
+	bolt "go.etcd.io/bbolt"
+)
+
+//txKey of the transaction of a unit of work in ctx
+type txKey struct{}
+
+//update run fn in the transaction of the unit of work in ctx, or in a new writable one
+func update(ctx context.Context, db *nosql.BoltDB, fn func(tx *bolt.Tx) error) error {
+	if tx, ok := ctx.Value(txKey{}).(*bolt.Tx); ok {
+		return fn(tx)
+	}
+	return fileError(db.Update(fn))
+}
+
+//view run fn in the transaction of the unit of work in ctx, or in a new read-only one
+func view(ctx context.Context, db *nosql.BoltDB, fn func(tx *bolt.Tx) error) error {
+	if tx, ok := ctx.Value(txKey{}).(*bolt.Tx); ok {
+		return fn(tx)
+	}
+	return fileError(db.View(fn))
+}
+
+//fileError translate errors of the file database to domain errors, domain errors are kept
+func fileError(err error) error {
+	if err == nil || errs.CodeOf(err) != errs.Unknown {
+		return err
+	}
+	return errs.NewUnavailable(err, "file database error")
+}
+
+//itob encode an id as a key in order of ids
+func itob(id int64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(id))
+	return b
+}
+
+func btoi(b []byte) int64 {
+	return int64(binary.BigEndian.Uint64(b))
+}
+
+//get decode the value of key in bucket into v, found is false when it is missing
+func get(bucket *bolt.Bucket, key []byte, v interface{}) (bool, error) {
+	data := bucket.Get(key)
+	if data == nil {
+		return false, nil
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return false, fileError(err)
+	}
+	return true, nil
+}
+
+//put v json encoded as the value of key in bucket
+func put(bucket *bolt.Bucket, key []byte, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fileError(err)
+	}
+	return bucket.Put(key, data)
+}
+
+//keysWithPrefix of bucket in order
+func keysWithPrefix(bucket *bolt.Bucket, prefix []byte) [][]byte {
+	var keys [][]byte
+	c := bucket.Cursor()
+	for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+		keys = append(keys, append([]byte(nil), k...))
+	}
+	return keys
+}
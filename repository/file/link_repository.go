@@ -0,0 +1,242 @@
+package file
+
+import (
+	"context"
+	"sort"
+
+	"github.com/micro-community/auth/db/nosql"
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	bolt "go.etcd.io/bbolt"
+)
+
+//linkTable keep a link at key <from><to> of the bucket forward and at key <to><from> of the bucket backward,
+//to find the ends linked to either end
+type linkTable struct {
+	forward  []byte
+	backward []byte
+}
+
+var (
+	userRoles     = linkTable{[]byte("user_roles"), []byte("role_users")}
+	roleResources = linkTable{[]byte("role_resources"), []byte("resource_roles")}
+)
+
+func linkKey(from, to int64) []byte {
+	return append(itob(from), itob(to)...)
+}
+
+func (t linkTable) exists(tx *bolt.Tx, from, to int64) bool {
+	return tx.Bucket(t.forward).Get(linkKey(from, to)) != nil
+}
+
+func (t linkTable) link(tx *bolt.Tx, from, to int64) error {
+	if err := tx.Bucket(t.forward).Put(linkKey(from, to), []byte{1}); err != nil {
+		return err
+	}
+	return tx.Bucket(t.backward).Put(linkKey(to, from), []byte{1})
+}
+
+func (t linkTable) unlink(tx *bolt.Tx, from, to int64) error {
+	if err := tx.Bucket(t.backward).Delete(linkKey(to, from)); err != nil {
+		return err
+	}
+	return tx.Bucket(t.forward).Delete(linkKey(from, to))
+}
+
+//targets linked from the end in order of id
+func (t linkTable) targets(tx *bolt.Tx, from int64) []int64 {
+	return ends(tx.Bucket(t.forward), from)
+}
+
+//sources linking to the end in order of id
+func (t linkTable) sources(tx *bolt.Tx, to int64) []int64 {
+	return ends(tx.Bucket(t.backward), to)
+}
+
+//unlinkFrom remove links from the end
+func (t linkTable) unlinkFrom(tx *bolt.Tx, from int64) error {
+	for _, to := range t.targets(tx, from) {
+		if err := t.unlink(tx, from, to); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//unlinkTo remove links to the end
+func (t linkTable) unlinkTo(tx *bolt.Tx, to int64) error {
+	for _, from := range t.sources(tx, to) {
+		if err := t.unlink(tx, from, to); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//ends linked to the end in bucket, which are the second half of the keys prefixed by the end
+func ends(bucket *bolt.Bucket, end int64) []int64 {
+	keys := keysWithPrefix(bucket, itob(end))
+	ids := make([]int64, 0, len(keys))
+	for _, k := range keys {
+		ids = append(ids, btoi(k[8:]))
+	}
+	return ids
+}
+
+//linkRepository keep links of users to roles and roles to resources, links of a hidden end are skipped in queries
+type linkRepository struct {
+	db *nosql.BoltDB
+}
+
+func NewLinkRepository(db *nosql.BoltDB) repository.ILink {
+	return &linkRepository{db: db}
+}
+
+//mustBeLive return an errs.NotFound error when the entity of id in c is missing or deleted
+func mustBeLive(tx *bolt.Tx, c collection, id int64) error {
+	var ext models.ModelExtension
+	found, err := c.load(tx, id, &ext)
+	if err != nil {
+		return err
+	}
+	if !found || ext.IsSoftDel {
+		return errs.NewNotFound("%s %d not found", c.kind, id)
+	}
+	return nil
+}
+
+//add a link of live ends in the table
+func (r *linkRepository) add(ctx context.Context, t linkTable, fromC, toC collection, from, to int64) error {
+	return update(ctx, r.db, func(tx *bolt.Tx) error {
+		if err := mustBeLive(tx, fromC, from); err != nil {
+			return err
+		}
+		if err := mustBeLive(tx, toC, to); err != nil {
+			return err
+		}
+		if t.exists(tx, from, to) {
+			return errs.NewAlreadyExists("%s %d already has %s %d", fromC.kind, from, toC.kind, to)
+		}
+		return t.link(tx, from, to)
+	})
+}
+
+//del a link in the table
+func (r *linkRepository) del(ctx context.Context, t linkTable, fromC, toC collection, from, to int64) error {
+	return update(ctx, r.db, func(tx *bolt.Tx) error {
+		if !t.exists(tx, from, to) {
+			return errs.NewNotFound("%s %d does not have %s %d", fromC.kind, from, toC.kind, to)
+		}
+		return t.unlink(tx, from, to)
+	})
+}
+
+func (r *linkRepository) LinkUserRole(ctx context.Context, userID int64, roleID int) error {
+	return r.add(ctx, userRoles, users, roles, userID, int64(roleID))
+}
+
+func (r *linkRepository) UnlinkUserRole(ctx context.Context, userID int64, roleID int) error {
+	return r.del(ctx, userRoles, users, roles, userID, int64(roleID))
+}
+
+func (r *linkRepository) LinkRoleResource(ctx context.Context, roleID, resourceID int) error {
+	return r.add(ctx, roleResources, roles, resources, int64(roleID), int64(resourceID))
+}
+
+func (r *linkRepository) UnlinkRoleResource(ctx context.Context, roleID, resourceID int) error {
+	return r.del(ctx, roleResources, roles, resources, int64(roleID), int64(resourceID))
+}
+
+func (r *linkRepository) UserRoles(ctx context.Context, userID int64) ([]*models.Role, error) {
+	var result []*models.Role
+	err := view(ctx, r.db, func(tx *bolt.Tx) (err error) {
+		result, err = userRolesOf(ctx, tx, userID)
+		return err
+	})
+	return result, err
+}
+
+func (r *linkRepository) RoleResources(ctx context.Context, roleID int) ([]*models.Resource, error) {
+	var result []*models.Resource
+	err := view(ctx, r.db, func(tx *bolt.Tx) error {
+		var role models.Role
+		found, err := roles.find(ctx, tx, int64(roleID), &role, &role.ModelExtension)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return errs.NewNotFound("role %d not found", roleID)
+		}
+		result, err = findResources(ctx, tx, roleResources.targets(tx, int64(roleID)))
+		return err
+	})
+	return result, err
+}
+
+func (r *linkRepository) UserResources(ctx context.Context, userID int64) ([]*models.Resource, error) {
+	var result []*models.Resource
+	err := view(ctx, r.db, func(tx *bolt.Tx) error {
+		roles, err := userRolesOf(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+		linked := map[int64]bool{}
+		var ids []int64
+		for _, role := range roles {
+			for _, id := range roleResources.targets(tx, int64(role.ID)) {
+				if !linked[id] {
+					linked[id] = true
+					ids = append(ids, id)
+				}
+			}
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		result, err = findResources(ctx, tx, ids)
+		return err
+	})
+	return result, err
+}
+
+//userRolesOf the user visible to ctx
+func userRolesOf(ctx context.Context, tx *bolt.Tx, userID int64) ([]*models.Role, error) {
+	var user models.User
+	found, err := users.find(ctx, tx, userID, &user, &user.ModelExtension)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errs.NewNotFound("user %d not found", userID)
+	}
+
+	ids := userRoles.targets(tx, userID)
+	result := make([]*models.Role, 0, len(ids))
+	for _, id := range ids {
+		role := &models.Role{}
+		found, err = roles.find(ctx, tx, id, role, &role.ModelExtension)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			result = append(result, role)
+		}
+	}
+	return result, nil
+}
+
+//findResources of ids visible to ctx
+func findResources(ctx context.Context, tx *bolt.Tx, ids []int64) ([]*models.Resource, error) {
+	result := make([]*models.Resource, 0, len(ids))
+	for _, id := range ids {
+		resource := &models.Resource{}
+		found, err := resources.find(ctx, tx, id, resource, &resource.ModelExtension)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			result = append(result, resource)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,65 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/micro-community/auth/db/nosql"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	bolt "go.etcd.io/bbolt"
+)
+
+var logs = []byte("logs")
+
+//logRepository keep logs in the bucket logs by id, ids are taken from its sequence
+type logRepository struct {
+	db *nosql.BoltDB
+}
+
+func NewLogRepository(db *nosql.BoltDB) repository.ILog {
+	return &logRepository{db: db}
+}
+
+func (r *logRepository) Append(ctx context.Context, log *models.Log) error {
+	return update(ctx, r.db, func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(logs)
+		id, err := bucket.NextSequence()
+		if err != nil {
+			return err
+		}
+		log.ID = int64(id)
+		if log.Time.IsZero() {
+			log.Time = time.Now()
+		}
+		return put(bucket, itob(log.ID), log)
+	})
+}
+
+func (r *logRepository) Query(ctx context.Context, opts repository.LogQuery) ([]*models.Log, error) {
+	result := make([]*models.Log, 0)
+	err := view(ctx, r.db, func(tx *bolt.Tx) error {
+		c := tx.Bucket(logs).Cursor()
+		k, v := c.Last()
+		if opts.BeforeID > 0 {
+			//seek the first log not before the cursor, then step back from it
+			if k, _ = c.Seek(itob(opts.BeforeID)); k == nil {
+				k, v = c.Last()
+			} else {
+				k, v = c.Prev()
+			}
+		}
+		for ; k != nil && (opts.Limit == 0 || len(result) < opts.Limit); k, v = c.Prev() {
+			log := &models.Log{}
+			if err := json.Unmarshal(v, log); err != nil {
+				return fileError(err)
+			}
+			if opts.Match(log) {
+				result = append(result, log)
+			}
+		}
+		return nil
+	})
+	return result, err
+}
@@ -0,0 +1,220 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/micro-community/auth/db/nosql"
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	orgUnits   = collection{[]byte("org_units"), []byte("org_units_names"), "org unit"}
+	orgMembers = []byte("org_members")
+	orgGrants  = []byte("org_grants")
+)
+
+//orgRepository keep org units in the bucket org_units, ids are taken from its sequence, names are indexed by tenant
+//and parent; their members in the bucket org_members by user id and grants in org_grants at key <unit id><role id>
+type orgRepository struct {
+	db *nosql.BoltDB
+}
+
+func NewOrgRepository(db *nosql.BoltDB) repository.IOrg {
+	return &orgRepository{db: db}
+}
+
+//unitName index the name of unit among the units of its parent in its tenant
+func unitName(unit *models.OrgUnit) string {
+	return strconv.Itoa(unit.TenantID) + "/" + strconv.Itoa(unit.ParentID) + "/" + unit.Name
+}
+
+func (r *orgRepository) FindById(ctx context.Context, id int) (*models.OrgUnit, error) {
+	var unit models.OrgUnit
+	var found bool
+	err := view(ctx, r.db, func(tx *bolt.Tx) (err error) {
+		found, err = orgUnits.load(tx, int64(id), &unit)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errs.NewNotFound("org unit %d not found", id)
+	}
+	return &unit, nil
+}
+
+func (r *orgRepository) Add(ctx context.Context, unit *models.OrgUnit) error {
+	return update(ctx, r.db, func(tx *bolt.Tx) error {
+		if orgUnits.taken(tx, unitName(unit)) {
+			return errs.NewAlreadyExists("org unit %s already exists", unit.Name)
+		}
+		id, err := orgUnits.nextID(tx)
+		if err != nil {
+			return err
+		}
+		unit.ID = int(id)
+		if unit.CreatedAt.IsZero() {
+			unit.CreatedAt = time.Now()
+		}
+		unit.Version = 1
+		return orgUnits.put(tx, id, unitName(unit), "", unit)
+	})
+}
+
+func (r *orgRepository) Update(ctx context.Context, unit *models.OrgUnit) error {
+	return update(ctx, r.db, func(tx *bolt.Tx) error {
+		id := int64(unit.ID)
+		var stored models.OrgUnit
+		found, err := orgUnits.load(tx, id, &stored)
+		if err != nil {
+			return err
+		}
+		if err = orgUnits.checkLive(found, stored.ModelExtension, id, unit.Version); err != nil {
+			return err
+		}
+		if unitName(&stored) != unitName(unit) && orgUnits.taken(tx, unitName(unit)) {
+			return errs.NewAlreadyExists("org unit %s already exists", unit.Name)
+		}
+
+		unit.UpdatedAt = time.Now()
+		unit.Version++
+		if err = orgUnits.put(tx, id, unitName(unit), unitName(&stored), unit); err != nil {
+			unit.Version--
+			return err
+		}
+		return nil
+	})
+}
+
+//Delete the unit at the version with its name and grants
+func (r *orgRepository) Delete(ctx context.Context, id int, version int64) error {
+	return update(ctx, r.db, func(tx *bolt.Tx) error {
+		var stored models.OrgUnit
+		found, err := orgUnits.load(tx, int64(id), &stored)
+		if err != nil {
+			return err
+		}
+		if err = orgUnits.checkLive(found, stored.ModelExtension, int64(id), version); err != nil {
+			return err
+		}
+		if err = tx.Bucket(orgUnits.bucket).Delete(itob(int64(id))); err != nil {
+			return err
+		}
+		if err = tx.Bucket(orgUnits.names).Delete([]byte(unitName(&stored))); err != nil {
+			return err
+		}
+		bucket := tx.Bucket(orgGrants)
+		for _, key := range keysWithPrefix(bucket, itob(int64(id))) {
+			if err = bucket.Delete(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *orgRepository) List(ctx context.Context) ([]*models.OrgUnit, error) {
+	result := make([]*models.OrgUnit, 0)
+	err := view(ctx, r.db, func(tx *bolt.Tx) error {
+		return orgUnits.scan(tx, func(data []byte) error {
+			unit := &models.OrgUnit{}
+			if err := json.Unmarshal(data, unit); err != nil {
+				return fileError(err)
+			}
+			result = append(result, unit)
+			return nil
+		})
+	})
+	return result, err
+}
+
+func (r *orgRepository) SetMember(ctx context.Context, member *models.OrgMember) error {
+	if member.CreatedAt.IsZero() {
+		member.CreatedAt = time.Now()
+	}
+	return update(ctx, r.db, func(tx *bolt.Tx) error {
+		return put(tx.Bucket(orgMembers), itob(member.UserID), member)
+	})
+}
+
+func (r *orgRepository) RemoveMember(ctx context.Context, userID int64) error {
+	return update(ctx, r.db, func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(orgMembers)
+		if bucket.Get(itob(userID)) == nil {
+			return errs.NewNotFound("org member %d not found", userID)
+		}
+		return bucket.Delete(itob(userID))
+	})
+}
+
+func (r *orgRepository) MemberOf(ctx context.Context, userID int64) (*models.OrgMember, error) {
+	var member models.OrgMember
+	var found bool
+	err := view(ctx, r.db, func(tx *bolt.Tx) (err error) {
+		found, err = get(tx.Bucket(orgMembers), itob(userID), &member)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errs.NewNotFound("org member %d not found", userID)
+	}
+	return &member, nil
+}
+
+//Members scan all members in order of user id
+func (r *orgRepository) Members(ctx context.Context, unitID int) ([]*models.OrgMember, error) {
+	result := make([]*models.OrgMember, 0)
+	err := view(ctx, r.db, func(tx *bolt.Tx) error {
+		return tx.Bucket(orgMembers).ForEach(func(k, v []byte) error {
+			member := &models.OrgMember{}
+			if err := json.Unmarshal(v, member); err != nil {
+				return fileError(err)
+			}
+			if member.DeptID == unitID || member.PositionID == unitID {
+				result = append(result, member)
+			}
+			return nil
+		})
+	})
+	return result, err
+}
+
+func (r *orgRepository) Grant(ctx context.Context, unitID, roleID int) error {
+	return update(ctx, r.db, func(tx *bolt.Tx) error {
+		bucket, key := tx.Bucket(orgGrants), linkKey(int64(unitID), int64(roleID))
+		if bucket.Get(key) != nil {
+			return errs.NewAlreadyExists("role %d is granted to org unit %d already", roleID, unitID)
+		}
+		return bucket.Put(key, []byte{1})
+	})
+}
+
+func (r *orgRepository) Revoke(ctx context.Context, unitID, roleID int) error {
+	return update(ctx, r.db, func(tx *bolt.Tx) error {
+		bucket, key := tx.Bucket(orgGrants), linkKey(int64(unitID), int64(roleID))
+		if bucket.Get(key) == nil {
+			return errs.NewNotFound("role %d is not granted to org unit %d", roleID, unitID)
+		}
+		return bucket.Delete(key)
+	})
+}
+
+func (r *orgRepository) Grants(ctx context.Context, unitID int) ([]int, error) {
+	roleIDs := make([]int, 0)
+	err := view(ctx, r.db, func(tx *bolt.Tx) error {
+		for _, id := range ends(tx.Bucket(orgGrants), int64(unitID)) {
+			roleIDs = append(roleIDs, int(id))
+		}
+		return nil
+	})
+	return roleIDs, err
+}
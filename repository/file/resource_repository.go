@@ -0,0 +1,178 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/micro-community/auth/db/nosql"
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	bolt "go.etcd.io/bbolt"
+)
+
+//resourceRepository keep resources in the bucket resources, ids are taken from its sequence
+type resourceRepository struct {
+	db *nosql.BoltDB
+}
+
+func NewResourceRepository(db *nosql.BoltDB) repository.IResource {
+	return &resourceRepository{db: db}
+}
+
+func (r *resourceRepository) FindById(ctx context.Context, id int64) (*models.Resource, error) {
+	var resource models.Resource
+	var found bool
+	err := view(ctx, r.db, func(tx *bolt.Tx) (err error) {
+		found, err = resources.find(ctx, tx, id, &resource, &resource.ModelExtension)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errs.NewNotFound("resource %d not found", id)
+	}
+	return &resource, nil
+}
+
+func (r *resourceRepository) FindByName(ctx context.Context, name string) (*models.Resource, error) {
+	var resource models.Resource
+	var found bool
+	err := view(ctx, r.db, func(tx *bolt.Tx) (err error) {
+		found, err = resources.findByName(ctx, tx, tenantName(repository.NameTenant(ctx), name), &resource, &resource.ModelExtension)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errs.NewNotFound("resource %s not found", name)
+	}
+	return &resource, nil
+}
+
+func (r *resourceRepository) Add(ctx context.Context, resource *models.Resource) error {
+	return update(ctx, r.db, func(tx *bolt.Tx) error {
+		if resources.taken(tx, tenantName(resource.TenantID, resource.Name)) {
+			return errs.NewAlreadyExists("resource %s already exists", resource.Name)
+		}
+		id, err := resources.nextID(tx)
+		if err != nil {
+			return err
+		}
+		resource.ID = int(id)
+		if resource.CreatedAt.IsZero() {
+			resource.CreatedAt = time.Now()
+		}
+		resource.Version = 1
+		return resources.put(tx, id, tenantName(resource.TenantID, resource.Name), "", resource)
+	})
+}
+
+func (r *resourceRepository) Update(ctx context.Context, resource *models.Resource) error {
+	return update(ctx, r.db, func(tx *bolt.Tx) error {
+		id := int64(resource.ID)
+		var stored models.Resource
+		found, err := resources.load(tx, id, &stored)
+		if err != nil {
+			return err
+		}
+		if err = resources.checkLive(found, stored.ModelExtension, id, resource.Version); err != nil {
+			return err
+		}
+		if tenantName(stored.TenantID, stored.Name) != tenantName(resource.TenantID, resource.Name) && resources.taken(tx, tenantName(resource.TenantID, resource.Name)) {
+			return errs.NewAlreadyExists("resource %s already exists", resource.Name)
+		}
+
+		resource.UpdatedAt = time.Now()
+		resource.Version++
+		if err = resources.put(tx, id, tenantName(resource.TenantID, resource.Name), tenantName(stored.TenantID, stored.Name), resource); err != nil {
+			resource.Version--
+			return err
+		}
+		return nil
+	})
+}
+
+//Delete mark the resource deleted, links of roles to it are kept for a restore
+func (r *resourceRepository) Delete(ctx context.Context, id, version int64) error {
+	return update(ctx, r.db, func(tx *bolt.Tx) error { return resources.setDeleted(tx, id, version, true) })
+}
+
+func (r *resourceRepository) Restore(ctx context.Context, id int64) error {
+	return update(ctx, r.db, func(tx *bolt.Tx) error { return resources.setDeleted(tx, id, 0, false) })
+}
+
+//Purge resources deleted before the time with the links of roles to them
+func (r *resourceRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+	err := update(ctx, r.db, func(tx *bolt.Tx) (err error) {
+		purged, err = resources.purge(tx, before, roleResources.unlinkTo)
+		return err
+	})
+	return purged, err
+}
+
+func (r *resourceRepository) Search(ctx context.Context, tenantID int, types ...models.ResourceCatalog) ([]*models.Resource, error) {
+	all, err := r.all(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*models.Resource, 0)
+	for _, resource := range all {
+		item := repository.ListItem{Tenant: resource.TenantID}
+		typ := models.ResourceCatalog(resource.Type)
+		item.Type = &typ
+		if item.Match(repository.ListOptions{TenantID: tenantID, Types: types}) {
+			result = append(result, resource)
+		}
+	}
+	return result, nil
+}
+
+func (r *resourceRepository) List(ctx context.Context, opts repository.ListOptions) ([]*models.Resource, int64, error) {
+	all, err := r.all(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	items := make([]repository.ListItem, 0, len(all))
+	for index, resource := range all {
+		typ := models.ResourceCatalog(resource.Type)
+		items = append(items, repository.ListItem{
+			Index:   index,
+			ID:      int64(resource.ID),
+			Name:    resource.Name,
+			Tenant:  resource.TenantID,
+			Type:    &typ,
+			Created: resource.CreatedAt,
+		})
+	}
+
+	indexes, total := repository.ListPage(items, opts)
+	result := make([]*models.Resource, 0, len(indexes))
+	for _, index := range indexes {
+		result = append(result, all[index])
+	}
+	return result, total, nil
+}
+
+//all resources visible to ctx in order of id
+func (r *resourceRepository) all(ctx context.Context) ([]*models.Resource, error) {
+	all := make([]*models.Resource, 0)
+	err := view(ctx, r.db, func(tx *bolt.Tx) error {
+		return resources.scan(tx, func(data []byte) error {
+			resource := &models.Resource{}
+			if err := json.Unmarshal(data, resource); err != nil {
+				return fileError(err)
+			}
+			if visible(ctx, resource.ModelExtension) {
+				all = append(all, resource)
+			}
+			return nil
+		})
+	})
+	return all, err
+}
@@ -0,0 +1,156 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/micro-community/auth/db/nosql"
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	bolt "go.etcd.io/bbolt"
+)
+
+//roleRepository keep roles in the bucket roles, ids are taken from its sequence
+type roleRepository struct {
+	db *nosql.BoltDB
+}
+
+func NewRoleRepository(db *nosql.BoltDB) repository.IRole {
+	return &roleRepository{db: db}
+}
+
+func (r *roleRepository) FindById(ctx context.Context, id int64) (*models.Role, error) {
+	var role models.Role
+	var found bool
+	err := view(ctx, r.db, func(tx *bolt.Tx) (err error) {
+		found, err = roles.find(ctx, tx, id, &role, &role.ModelExtension)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errs.NewNotFound("role %d not found", id)
+	}
+	return &role, nil
+}
+
+func (r *roleRepository) FindByName(ctx context.Context, name string) (*models.Role, error) {
+	var role models.Role
+	var found bool
+	err := view(ctx, r.db, func(tx *bolt.Tx) (err error) {
+		found, err = roles.findByName(ctx, tx, tenantName(repository.NameTenant(ctx), name), &role, &role.ModelExtension)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errs.NewNotFound("role %s not found", name)
+	}
+	return &role, nil
+}
+
+func (r *roleRepository) Add(ctx context.Context, role *models.Role) error {
+	return update(ctx, r.db, func(tx *bolt.Tx) error {
+		if roles.taken(tx, tenantName(role.TenantID, role.Name)) {
+			return errs.NewAlreadyExists("role %s already exists", role.Name)
+		}
+		id, err := roles.nextID(tx)
+		if err != nil {
+			return err
+		}
+		role.ID = int(id)
+		if role.CreatedAt.IsZero() {
+			role.CreatedAt = time.Now()
+		}
+		role.Version = 1
+		return roles.put(tx, id, tenantName(role.TenantID, role.Name), "", role)
+	})
+}
+
+//Update role, the key of a role can not be modified
+func (r *roleRepository) Update(ctx context.Context, role *models.Role) error {
+	return update(ctx, r.db, func(tx *bolt.Tx) error {
+		id := int64(role.ID)
+		var stored models.Role
+		found, err := roles.load(tx, id, &stored)
+		if err != nil {
+			return err
+		}
+		if err = roles.checkLive(found, stored.ModelExtension, id, role.Version); err != nil {
+			return err
+		}
+		if role.Key != "" && stored.Key != role.Key {
+			return errs.NewConflict("role key modify forbidden")
+		}
+		if tenantName(stored.TenantID, stored.Name) != tenantName(role.TenantID, role.Name) && roles.taken(tx, tenantName(role.TenantID, role.Name)) {
+			return errs.NewAlreadyExists("role %s already exists", role.Name)
+		}
+
+		role.UpdatedAt = time.Now()
+		role.Version++
+		if err = roles.put(tx, id, tenantName(role.TenantID, role.Name), tenantName(stored.TenantID, stored.Name), role); err != nil {
+			role.Version--
+			return err
+		}
+		return nil
+	})
+}
+
+//Delete mark the role deleted, its links are kept for a restore
+func (r *roleRepository) Delete(ctx context.Context, id, version int64) error {
+	return update(ctx, r.db, func(tx *bolt.Tx) error { return roles.setDeleted(tx, id, version, true) })
+}
+
+func (r *roleRepository) Restore(ctx context.Context, id int64) error {
+	return update(ctx, r.db, func(tx *bolt.Tx) error { return roles.setDeleted(tx, id, 0, false) })
+}
+
+//Purge roles deleted before the time with the links of users to them and their links to resources
+func (r *roleRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+	err := update(ctx, r.db, func(tx *bolt.Tx) (err error) {
+		purged, err = roles.purge(tx, before, userRoles.unlinkTo, roleResources.unlinkFrom)
+		return err
+	})
+	return purged, err
+}
+
+//List roles matched opts
+func (r *roleRepository) List(ctx context.Context, opts repository.ListOptions) ([]*models.Role, int64, error) {
+	all := make([]*models.Role, 0)
+	items := make([]repository.ListItem, 0)
+	err := view(ctx, r.db, func(tx *bolt.Tx) error {
+		return roles.scan(tx, func(data []byte) error {
+			role := &models.Role{}
+			if err := json.Unmarshal(data, role); err != nil {
+				return fileError(err)
+			}
+			if !visible(ctx, role.ModelExtension) {
+				return nil
+			}
+			items = append(items, repository.ListItem{
+				Index:   len(all),
+				ID:      int64(role.ID),
+				Name:    role.Name,
+				Tenant:  role.TenantID,
+				Created: role.CreatedAt,
+			})
+			all = append(all, role)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	indexes, total := repository.ListPage(items, opts)
+	result := make([]*models.Role, 0, len(indexes))
+	for _, index := range indexes {
+		result = append(result, all[index])
+	}
+	return result, total, nil
+}
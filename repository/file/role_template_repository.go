@@ -0,0 +1,182 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/micro-community/auth/db/nosql"
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	roleTemplates = collection{[]byte("role_templates"), []byte("role_templates_names"), "role template"}
+	roleInstances = []byte("role_instances")
+)
+
+//roleTemplateRepository keep role templates in the bucket role_templates, ids are taken from its sequence,
+//and their instances in the bucket role_instances by role id
+type roleTemplateRepository struct {
+	db *nosql.BoltDB
+}
+
+func NewRoleTemplateRepository(db *nosql.BoltDB) repository.IRoleTemplate {
+	return &roleTemplateRepository{db: db}
+}
+
+func (r *roleTemplateRepository) FindById(ctx context.Context, id int) (*models.RoleTemplate, error) {
+	var template models.RoleTemplate
+	var found bool
+	err := view(ctx, r.db, func(tx *bolt.Tx) (err error) {
+		found, err = roleTemplates.load(tx, int64(id), &template)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errs.NewNotFound("role template %d not found", id)
+	}
+	return &template, nil
+}
+
+func (r *roleTemplateRepository) FindByName(ctx context.Context, name string) (*models.RoleTemplate, error) {
+	var template models.RoleTemplate
+	var found bool
+	err := view(ctx, r.db, func(tx *bolt.Tx) (err error) {
+		found, err = roleTemplates.findByName(ctx, tx, name, &template, &template.ModelExtension)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errs.NewNotFound("role template %s not found", name)
+	}
+	return &template, nil
+}
+
+func (r *roleTemplateRepository) Add(ctx context.Context, template *models.RoleTemplate) error {
+	return update(ctx, r.db, func(tx *bolt.Tx) error {
+		if roleTemplates.taken(tx, template.Name) {
+			return errs.NewAlreadyExists("role template %s already exists", template.Name)
+		}
+		id, err := roleTemplates.nextID(tx)
+		if err != nil {
+			return err
+		}
+		template.ID = int(id)
+		if template.CreatedAt.IsZero() {
+			template.CreatedAt = time.Now()
+		}
+		template.Version = 1
+		return roleTemplates.put(tx, id, template.Name, "", template)
+	})
+}
+
+func (r *roleTemplateRepository) Update(ctx context.Context, template *models.RoleTemplate) error {
+	return update(ctx, r.db, func(tx *bolt.Tx) error {
+		id := int64(template.ID)
+		var stored models.RoleTemplate
+		found, err := roleTemplates.load(tx, id, &stored)
+		if err != nil {
+			return err
+		}
+		if err = roleTemplates.checkLive(found, stored.ModelExtension, id, template.Version); err != nil {
+			return err
+		}
+		if stored.Name != template.Name && roleTemplates.taken(tx, template.Name) {
+			return errs.NewAlreadyExists("role template %s already exists", template.Name)
+		}
+
+		template.UpdatedAt = time.Now()
+		template.Version++
+		if err = roleTemplates.put(tx, id, template.Name, stored.Name, template); err != nil {
+			template.Version--
+			return err
+		}
+		return nil
+	})
+}
+
+//Delete the template at the version with its name and instances
+func (r *roleTemplateRepository) Delete(ctx context.Context, id int, version int64) error {
+	return update(ctx, r.db, func(tx *bolt.Tx) error {
+		var stored models.RoleTemplate
+		found, err := roleTemplates.load(tx, int64(id), &stored)
+		if err != nil {
+			return err
+		}
+		if err = roleTemplates.checkLive(found, stored.ModelExtension, int64(id), version); err != nil {
+			return err
+		}
+		if err = tx.Bucket(roleTemplates.bucket).Delete(itob(int64(id))); err != nil {
+			return err
+		}
+		if err = tx.Bucket(roleTemplates.names).Delete([]byte(stored.Name)); err != nil {
+			return err
+		}
+		instances, err := instancesOf(tx, id)
+		if err != nil {
+			return err
+		}
+		bucket := tx.Bucket(roleInstances)
+		for _, instance := range instances {
+			if err = bucket.Delete(itob(int64(instance.RoleID))); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *roleTemplateRepository) List(ctx context.Context) ([]*models.RoleTemplate, error) {
+	result := make([]*models.RoleTemplate, 0)
+	err := view(ctx, r.db, func(tx *bolt.Tx) error {
+		return roleTemplates.scan(tx, func(data []byte) error {
+			template := &models.RoleTemplate{}
+			if err := json.Unmarshal(data, template); err != nil {
+				return fileError(err)
+			}
+			result = append(result, template)
+			return nil
+		})
+	})
+	return result, err
+}
+
+func (r *roleTemplateRepository) SaveInstance(ctx context.Context, instance *models.RoleInstance) error {
+	if instance.CreatedAt.IsZero() {
+		instance.CreatedAt = time.Now()
+	}
+	return update(ctx, r.db, func(tx *bolt.Tx) error {
+		return put(tx.Bucket(roleInstances), itob(int64(instance.RoleID)), instance)
+	})
+}
+
+func (r *roleTemplateRepository) Instances(ctx context.Context, templateID int) (instances []*models.RoleInstance, err error) {
+	err = view(ctx, r.db, func(tx *bolt.Tx) (err error) {
+		instances, err = instancesOf(tx, templateID)
+		return err
+	})
+	return
+}
+
+//instancesOf the template in order of role id
+func instancesOf(tx *bolt.Tx, templateID int) ([]*models.RoleInstance, error) {
+	result := make([]*models.RoleInstance, 0)
+	err := tx.Bucket(roleInstances).ForEach(func(k, v []byte) error {
+		instance := &models.RoleInstance{}
+		if err := json.Unmarshal(v, instance); err != nil {
+			return fileError(err)
+		}
+		if instance.TemplateID == templateID {
+			result = append(result, instance)
+		}
+		return nil
+	})
+	return result, err
+}
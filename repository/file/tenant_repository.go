@@ -0,0 +1,135 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/micro-community/auth/db/nosql"
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	bolt "go.etcd.io/bbolt"
+)
+
+var tenants = collection{[]byte("tenants"), []byte("tenants_names"), "tenant"}
+
+//tenantRepository keep tenants in the bucket tenants, ids are taken from its sequence and never reused.
+//A deleted tenant is kept hidden without its name
+type tenantRepository struct {
+	db *nosql.BoltDB
+}
+
+func NewTenantRepository(db *nosql.BoltDB) repository.ITenant {
+	return &tenantRepository{db: db}
+}
+
+func (r *tenantRepository) FindById(ctx context.Context, id int) (*models.Tenant, error) {
+	var tenant models.Tenant
+	var found bool
+	err := view(ctx, r.db, func(tx *bolt.Tx) (err error) {
+		found, err = tenants.load(tx, int64(id), &tenant)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !found || tenant.IsSoftDel {
+		return nil, errs.NewNotFound("tenant %d not found", id)
+	}
+	return &tenant, nil
+}
+
+func (r *tenantRepository) FindByName(ctx context.Context, name string) (*models.Tenant, error) {
+	var tenant models.Tenant
+	var found bool
+	err := view(ctx, r.db, func(tx *bolt.Tx) (err error) {
+		id := tx.Bucket(tenants.names).Get([]byte(name))
+		if id == nil {
+			return nil
+		}
+		found, err = tenants.load(tx, btoi(id), &tenant)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errs.NewNotFound("tenant %s not found", name)
+	}
+	return &tenant, nil
+}
+
+func (r *tenantRepository) Add(ctx context.Context, tenant *models.Tenant) error {
+	return update(ctx, r.db, func(tx *bolt.Tx) error {
+		if tenants.taken(tx, tenant.Name) {
+			return errs.NewAlreadyExists("tenant %s already exists", tenant.Name)
+		}
+		id, err := tenants.nextID(tx)
+		if err != nil {
+			return err
+		}
+		tenant.ID = int(id)
+		if tenant.CreatedAt.IsZero() {
+			tenant.CreatedAt = time.Now()
+		}
+		tenant.Version = 1
+		return tenants.put(tx, id, tenant.Name, "", tenant)
+	})
+}
+
+func (r *tenantRepository) Update(ctx context.Context, tenant *models.Tenant) error {
+	return update(ctx, r.db, func(tx *bolt.Tx) error {
+		id := int64(tenant.ID)
+		var stored models.Tenant
+		found, err := tenants.load(tx, id, &stored)
+		if err != nil {
+			return err
+		}
+		if err = tenants.checkLive(found, stored.ModelExtension, id, tenant.Version); err != nil {
+			return err
+		}
+		if stored.Name != tenant.Name && tenants.taken(tx, tenant.Name) {
+			return errs.NewAlreadyExists("tenant %s already exists", tenant.Name)
+		}
+
+		tenant.UpdatedAt = time.Now()
+		tenant.Version++
+		if err = tenants.put(tx, id, tenant.Name, stored.Name, tenant); err != nil {
+			tenant.Version--
+			return err
+		}
+		return nil
+	})
+}
+
+//Delete the tenant at the version, its name is freed
+func (r *tenantRepository) Delete(ctx context.Context, id int, version int64) error {
+	return update(ctx, r.db, func(tx *bolt.Tx) error {
+		var stored models.Tenant
+		if _, err := tenants.load(tx, int64(id), &stored); err != nil {
+			return err
+		}
+		if err := tenants.setDeleted(tx, int64(id), version, true); err != nil {
+			return err
+		}
+		return tx.Bucket(tenants.names).Delete([]byte(stored.Name))
+	})
+}
+
+func (r *tenantRepository) List(ctx context.Context) ([]*models.Tenant, error) {
+	result := make([]*models.Tenant, 0)
+	err := view(ctx, r.db, func(tx *bolt.Tx) error {
+		return tenants.scan(tx, func(data []byte) error {
+			tenant := &models.Tenant{}
+			if err := json.Unmarshal(data, tenant); err != nil {
+				return fileError(err)
+			}
+			if !tenant.IsSoftDel {
+				result = append(result, tenant)
+			}
+			return nil
+		})
+	})
+	return result, err
+}
@@ -0,0 +1,52 @@
+package file
+
+import (
+	"context"
+
+	"github.com/micro-community/auth/db/nosql"
+	"github.com/micro-community/auth/repository"
+	bolt "go.etcd.io/bbolt"
+)
+
+//unitOfWork run operations in one writable transaction of the file, writes of the file are serialized,
+//so fn should be short and must not wait for other writes of the file
+type unitOfWork struct {
+	db *nosql.BoltDB
+}
+
+func NewUnitOfWork(db *nosql.BoltDB) repository.UnitOfWork {
+	return &unitOfWork{db: db}
+}
+
+func (u *unitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*bolt.Tx); ok {
+		return fn(ctx)
+	}
+
+	var fnErr error
+	err := u.db.Update(func(tx *bolt.Tx) error {
+		fnErr = fn(context.WithValue(ctx, txKey{}, tx))
+		return fnErr
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	return fileError(err)
+}
+
+//View run fn in one read-only transaction of the file, it does not hold writes of the file
+func (u *unitOfWork) View(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*bolt.Tx); ok {
+		return fn(ctx)
+	}
+
+	var fnErr error
+	err := u.db.View(func(tx *bolt.Tx) error {
+		fnErr = fn(context.WithValue(ctx, txKey{}, tx))
+		return fnErr
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	return fileError(err)
+}
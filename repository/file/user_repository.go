@@ -0,0 +1,151 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/micro-community/auth/db/nosql"
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	bolt "go.etcd.io/bbolt"
+)
+
+//userRepository keep users in the bucket users, ids are taken from its sequence
+type userRepository struct {
+	db *nosql.BoltDB
+}
+
+func NewUserRepository(db *nosql.BoltDB) repository.IUser {
+	return &userRepository{db: db}
+}
+
+func (r *userRepository) FindById(ctx context.Context, id int64) (*models.User, error) {
+	var user models.User
+	var found bool
+	err := view(ctx, r.db, func(tx *bolt.Tx) (err error) {
+		found, err = users.find(ctx, tx, id, &user, &user.ModelExtension)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errs.NewNotFound("user %d not found", id)
+	}
+	return &user, nil
+}
+
+func (r *userRepository) FindByName(ctx context.Context, name string) (*models.User, error) {
+	var user models.User
+	var found bool
+	err := view(ctx, r.db, func(tx *bolt.Tx) (err error) {
+		found, err = users.findByName(ctx, tx, tenantName(repository.NameTenant(ctx), name), &user, &user.ModelExtension)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errs.NewNotFound("user %s not found", name)
+	}
+	return &user, nil
+}
+
+func (r *userRepository) Add(ctx context.Context, user *models.User) error {
+	return update(ctx, r.db, func(tx *bolt.Tx) error {
+		if users.taken(tx, tenantName(user.TenantID, user.Name)) {
+			return errs.NewAlreadyExists("user %s already exists", user.Name)
+		}
+		id, err := users.nextID(tx)
+		if err != nil {
+			return err
+		}
+		user.ID = id
+		if user.CreatedAt.IsZero() {
+			user.CreatedAt = time.Now()
+		}
+		user.Version = 1
+		return users.put(tx, id, tenantName(user.TenantID, user.Name), "", user)
+	})
+}
+
+func (r *userRepository) Update(ctx context.Context, user *models.User) error {
+	return update(ctx, r.db, func(tx *bolt.Tx) error {
+		var stored models.User
+		found, err := users.load(tx, user.ID, &stored)
+		if err != nil {
+			return err
+		}
+		if err = users.checkLive(found, stored.ModelExtension, user.ID, user.Version); err != nil {
+			return err
+		}
+		if tenantName(stored.TenantID, stored.Name) != tenantName(user.TenantID, user.Name) && users.taken(tx, tenantName(user.TenantID, user.Name)) {
+			return errs.NewAlreadyExists("user %s already exists", user.Name)
+		}
+
+		user.UpdatedAt = time.Now()
+		user.Version++
+		if err = users.put(tx, user.ID, tenantName(user.TenantID, user.Name), tenantName(stored.TenantID, stored.Name), user); err != nil {
+			user.Version--
+			return err
+		}
+		return nil
+	})
+}
+
+//Delete mark the user deleted, links to its roles are kept for a restore
+func (r *userRepository) Delete(ctx context.Context, id, version int64) error {
+	return update(ctx, r.db, func(tx *bolt.Tx) error { return users.setDeleted(tx, id, version, true) })
+}
+
+func (r *userRepository) Restore(ctx context.Context, id int64) error {
+	return update(ctx, r.db, func(tx *bolt.Tx) error { return users.setDeleted(tx, id, 0, false) })
+}
+
+//Purge users deleted before the time with the links to their roles
+func (r *userRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+	err := update(ctx, r.db, func(tx *bolt.Tx) (err error) {
+		purged, err = users.purge(tx, before, userRoles.unlinkFrom)
+		return err
+	})
+	return purged, err
+}
+
+func (r *userRepository) List(ctx context.Context, opts repository.ListOptions) ([]*models.User, int64, error) {
+	all := make([]*models.User, 0)
+	items := make([]repository.ListItem, 0)
+	err := view(ctx, r.db, func(tx *bolt.Tx) error {
+		return users.scan(tx, func(data []byte) error {
+			user := &models.User{}
+			if err := json.Unmarshal(data, user); err != nil {
+				return fileError(err)
+			}
+			if !visible(ctx, user.ModelExtension) {
+				return nil
+			}
+			items = append(items, repository.ListItem{
+				Index:   len(all),
+				ID:      user.ID,
+				Name:    user.Name,
+				Tenant:  user.TenantID,
+				Status:  user.Stated,
+				Created: user.CreatedAt,
+			})
+			all = append(all, user)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	indexes, total := repository.ListPage(items, opts)
+	result := make([]*models.User, 0, len(indexes))
+	for _, index := range indexes {
+		result = append(result, all[index])
+	}
+	return result, total, nil
+}
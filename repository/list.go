@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+)
+
+//SortField of list query, results are always ordered by id after the sort field,
+//both of them are reversed when Desc is set
+type SortField int
+
+const (
+	SortByID SortField = iota
+	SortByName
+	SortByCreatedAt
+)
+
+//ParseSortField from its name, empty name sort by id
+func ParseSortField(name string) (SortField, error) {
+	switch name {
+	case "", "id":
+		return SortByID, nil
+	case "name":
+		return SortByName, nil
+	case "created_at":
+		return SortByCreatedAt, nil
+	}
+	return SortByID, errs.NewInvalidArgument("unknown sort field %q", name)
+}
+
+//ListOptions of List, zero value of a filter means no filtering on it.
+//Every backend must follow the same semantics:
+//  - NamePrefix matches the beginning of name, case insensitive, dgraph needs at least 3 characters
+//  - TenantID and Status match exactly, Status only applies to users
+//  - Types matches any of the catalogs, only applies to resources
+//  - CreatedAfter is inclusive and CreatedBefore is exclusive
+//  - After skips the items up to and including the key in the sort order, a page of a keyset
+//  - Total counts all matched items regardless of After, Offset and Limit
+type ListOptions struct {
+	NamePrefix    string
+	TenantID      int
+	Status        int
+	Types         []models.ResourceCatalog
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+
+	SortBy SortField
+	Desc   bool
+
+	After  *ListKey
+	Offset int
+	Limit  int // 0 for no limit
+}
+
+//ListKey is the position of an item in the sort order, only the sort field and id of it are compared
+type ListKey struct {
+	ID      int64
+	Name    string
+	Created time.Time
+}
+
+//ListItem is the view of an entity to filter, sort and page by ListOptions in process,
+//for backends without a query engine, Index is the position of the entity kept by the caller
+type ListItem struct {
+	Index   int
+	ID      int64
+	Name    string
+	Tenant  int
+	Status  int
+	Type    *models.ResourceCatalog // nil for entities other than resources
+	Created time.Time
+}
+
+//Match report whether the item matches the filters of opts
+func (i ListItem) Match(opts ListOptions) bool {
+	if opts.NamePrefix != "" && !strings.HasPrefix(strings.ToLower(i.Name), strings.ToLower(opts.NamePrefix)) {
+		return false
+	}
+	if opts.TenantID != 0 && i.Tenant != opts.TenantID {
+		return false
+	}
+	if opts.Status != 0 && i.Status != opts.Status {
+		return false
+	}
+	if len(opts.Types) > 0 && i.Type != nil && !containsCatalog(opts.Types, *i.Type) {
+		return false
+	}
+	if !opts.CreatedAfter.IsZero() && i.Created.Before(opts.CreatedAfter) {
+		return false
+	}
+	if !opts.CreatedBefore.IsZero() && !i.Created.Before(opts.CreatedBefore) {
+		return false
+	}
+	return true
+}
+
+func (i ListItem) less(o ListItem, field SortField) bool {
+	switch field {
+	case SortByName:
+		if i.Name != o.Name {
+			return i.Name < o.Name
+		}
+	case SortByCreatedAt:
+		if !i.Created.Equal(o.Created) {
+			return i.Created.Before(o.Created)
+		}
+	}
+	return i.ID < o.ID
+}
+
+//ListPage filter, sort and page items, return the indexes of the page and the total count
+func ListPage(items []ListItem, opts ListOptions) ([]int, int64) {
+	matched := make([]ListItem, 0, len(items))
+	for _, item := range items {
+		if item.Match(opts) {
+			matched = append(matched, item)
+		}
+	}
+
+	sort.SliceStable(matched, func(a, b int) bool {
+		if opts.Desc {
+			return matched[b].less(matched[a], opts.SortBy)
+		}
+		return matched[a].less(matched[b], opts.SortBy)
+	})
+
+	total := int64(len(matched))
+	if opts.After != nil {
+		after := ListItem{ID: opts.After.ID, Name: opts.After.Name, Created: opts.After.Created}
+		skipped := sort.Search(len(matched), func(i int) bool {
+			if opts.Desc {
+				return matched[i].less(after, opts.SortBy)
+			}
+			return after.less(matched[i], opts.SortBy)
+		})
+		matched = matched[skipped:]
+	}
+	start := opts.Offset
+	if start > len(matched) {
+		start = len(matched)
+	}
+	end := len(matched)
+	if opts.Limit > 0 && start+opts.Limit < end {
+		end = start + opts.Limit
+	}
+
+	indexes := make([]int, 0, end-start)
+	for _, item := range matched[start:end] {
+		indexes = append(indexes, item.Index)
+	}
+	return indexes, total
+}
+
+func containsCatalog(types []models.ResourceCatalog, t models.ResourceCatalog) bool {
+	for _, v := range types {
+		if v == t {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/micro-community/auth/models"
+)
+
+//LogQuery of ILog, zero value of a filter means no filtering on it
+type LogQuery struct {
+	Actor    string
+	TenantID int
+	Kinds    []models.ChangeKind // any of the kinds
+	EntityID string
+	Since    time.Time // inclusive
+	Until    time.Time // exclusive
+	BeforeID int64     // logs older than the log of id, to page through logs
+	Limit    int       // 0 for no limit
+}
+
+//Match report whether the log matches the filters of q, regardless of BeforeID and Limit
+func (q LogQuery) Match(log *models.Log) bool {
+	if q.Actor != "" && log.Actor != q.Actor {
+		return false
+	}
+	if q.TenantID != 0 && log.TenantID != q.TenantID {
+		return false
+	}
+	if len(q.Kinds) > 0 && !containsKind(q.Kinds, log.Kind) {
+		return false
+	}
+	if q.EntityID != "" && log.EntityID != q.EntityID {
+		return false
+	}
+	if !q.Since.IsZero() && log.Time.Before(q.Since) {
+		return false
+	}
+	if !q.Until.IsZero() && !log.Time.Before(q.Until) {
+		return false
+	}
+	return true
+}
+
+func containsKind(kinds []models.ChangeKind, kind models.ChangeKind) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
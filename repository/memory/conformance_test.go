@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/micro-community/auth/repository"
+	"github.com/micro-community/auth/repository/conformance"
+)
+
+func TestUserRepository(t *testing.T) {
+	conformance.RunUser(t, func(t *testing.T) repository.IUser { return NewUserRepository() })
+}
+
+func TestRoleRepository(t *testing.T) {
+	conformance.RunRole(t, func(t *testing.T) repository.IRole { return NewRoleRepository() })
+}
+
+func TestResourceRepository(t *testing.T) {
+	conformance.RunResource(t, func(t *testing.T) repository.IResource { return NewResourceRepository() })
+}
+
+func newRepositories(t *testing.T) conformance.Repositories {
+	users, roles, resources := NewUserRepository(), NewRoleRepository(), NewResourceRepository()
+	return conformance.Repositories{
+		Users:     users,
+		Roles:     roles,
+		Resources: resources,
+		Links:     NewLinkRepository(users, roles, resources),
+		Work:      NewUnitOfWork(),
+	}
+}
+
+func TestLinkRepository(t *testing.T) {
+	conformance.RunLink(t, newRepositories)
+}
+
+func TestUnitOfWork(t *testing.T) {
+	conformance.RunUnitOfWork(t, newRepositories, false)
+}
+
+func TestLogRepository(t *testing.T) {
+	conformance.RunLog(t, func(t *testing.T) repository.ILog { return NewLogRepository() })
+}
+
+func TestTenantRepository(t *testing.T) {
+	conformance.RunTenant(t, func(t *testing.T) repository.ITenant { return NewTenantRepository() })
+}
+
+func TestRoleTemplateRepository(t *testing.T) {
+	conformance.RunRoleTemplate(t, func(t *testing.T) repository.IRoleTemplate { return NewRoleTemplateRepository() })
+}
+
+func TestOrgRepository(t *testing.T) {
+	conformance.RunOrg(t, func(t *testing.T) repository.IOrg { return NewOrgRepository() })
+}
@@ -0,0 +1,181 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+)
+
+type linkRepository struct {
+	mu            *sync.Mutex
+	users         repository.IUser
+	roles         repository.IRole
+	resources     repository.IResource
+	userRoles     map[int64]map[int]bool
+	roleResources map[int]map[int]bool
+}
+
+//NewLinkRepository link entities of the repositories, links of an entity not found are skipped in queries
+func NewLinkRepository(users repository.IUser, roles repository.IRole, resources repository.IResource) repository.ILink {
+	return &linkRepository{
+		mu:            &sync.Mutex{},
+		users:         users,
+		roles:         roles,
+		resources:     resources,
+		userRoles:     map[int64]map[int]bool{},
+		roleResources: map[int]map[int]bool{},
+	}
+}
+
+func (r *linkRepository) LinkUserRole(ctx context.Context, userID int64, roleID int) error {
+	user, err := r.users.FindById(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err = mustBeLive(user.ModelExtension, "user", userID); err != nil {
+		return err
+	}
+	role, err := r.roles.FindById(ctx, int64(roleID))
+	if err != nil {
+		return err
+	}
+	if err = mustBeLive(role.ModelExtension, "role", int64(roleID)); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.userRoles[userID][roleID] {
+		return errs.NewAlreadyExists("user %d already has role %d", userID, roleID)
+	}
+	if r.userRoles[userID] == nil {
+		r.userRoles[userID] = map[int]bool{}
+	}
+	r.userRoles[userID][roleID] = true
+	return nil
+}
+
+func (r *linkRepository) UnlinkUserRole(ctx context.Context, userID int64, roleID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.userRoles[userID][roleID] {
+		return errs.NewNotFound("user %d does not have role %d", userID, roleID)
+	}
+	delete(r.userRoles[userID], roleID)
+	return nil
+}
+
+func (r *linkRepository) LinkRoleResource(ctx context.Context, roleID, resourceID int) error {
+	role, err := r.roles.FindById(ctx, int64(roleID))
+	if err != nil {
+		return err
+	}
+	if err = mustBeLive(role.ModelExtension, "role", int64(roleID)); err != nil {
+		return err
+	}
+	resource, err := r.resources.FindById(ctx, int64(resourceID))
+	if err != nil {
+		return err
+	}
+	if err = mustBeLive(resource.ModelExtension, "resource", int64(resourceID)); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.roleResources[roleID][resourceID] {
+		return errs.NewAlreadyExists("role %d already has resource %d", roleID, resourceID)
+	}
+	if r.roleResources[roleID] == nil {
+		r.roleResources[roleID] = map[int]bool{}
+	}
+	r.roleResources[roleID][resourceID] = true
+	return nil
+}
+
+func (r *linkRepository) UnlinkRoleResource(ctx context.Context, roleID, resourceID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.roleResources[roleID][resourceID] {
+		return errs.NewNotFound("role %d does not have resource %d", roleID, resourceID)
+	}
+	delete(r.roleResources[roleID], resourceID)
+	return nil
+}
+
+func (r *linkRepository) UserRoles(ctx context.Context, userID int64) ([]*models.Role, error) {
+	if _, err := r.users.FindById(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	roles := make([]*models.Role, 0)
+	for _, id := range r.linked(func() map[int]bool { return r.userRoles[userID] }) {
+		role, err := r.roles.FindById(ctx, int64(id))
+		if errs.CodeOf(err) == errs.NotFound {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+	return roles, nil
+}
+
+func (r *linkRepository) RoleResources(ctx context.Context, roleID int) ([]*models.Resource, error) {
+	if _, err := r.roles.FindById(ctx, int64(roleID)); err != nil {
+		return nil, err
+	}
+	return r.findResources(ctx, r.linked(func() map[int]bool { return r.roleResources[roleID] }))
+}
+
+func (r *linkRepository) UserResources(ctx context.Context, userID int64) ([]*models.Resource, error) {
+	roles, err := r.UserRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	ids := r.linked(func() map[int]bool {
+		linked := map[int]bool{}
+		for _, role := range roles {
+			for id := range r.roleResources[role.ID] {
+				linked[id] = true
+			}
+		}
+		return linked
+	})
+	return r.findResources(ctx, ids)
+}
+
+//linked return the sorted ids of the set built by fn under the lock
+func (r *linkRepository) linked(fn func() map[int]bool) []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ids := make([]int, 0)
+	for id := range fn() {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+func (r *linkRepository) findResources(ctx context.Context, ids []int) ([]*models.Resource, error) {
+	resources := make([]*models.Resource, 0, len(ids))
+	for _, id := range ids {
+		resource, err := r.resources.FindById(ctx, int64(id))
+		if errs.CodeOf(err) == errs.NotFound {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
@@ -0,0 +1,54 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+)
+
+//logRepository keep logs in order of id, they are lost on restart
+type logRepository struct {
+	mu   *sync.Mutex
+	logs []*models.Log
+}
+
+func NewLogRepository() repository.ILog {
+	return &logRepository{
+		mu:   &sync.Mutex{},
+		logs: make([]*models.Log, 0),
+	}
+}
+
+func (r *logRepository) Append(ctx context.Context, log *models.Log) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	log.ID = int64(len(r.logs) + 1)
+	if log.Time.IsZero() {
+		log.Time = time.Now()
+	}
+	stored := *log
+	stored.Diff = append([]models.FieldDiff(nil), log.Diff...)
+	r.logs = append(r.logs, &stored)
+	return nil
+}
+
+func (r *logRepository) Query(ctx context.Context, opts repository.LogQuery) ([]*models.Log, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	logs := make([]*models.Log, 0)
+	for i := len(r.logs) - 1; i >= 0 && (opts.Limit == 0 || len(logs) < opts.Limit); i-- {
+		target := r.logs[i]
+		if opts.BeforeID > 0 && target.ID >= opts.BeforeID || !opts.Match(target) {
+			continue
+		}
+		log := *target
+		log.Diff = append([]models.FieldDiff(nil), target.Diff...)
+		logs = append(logs, &log)
+	}
+	return logs, nil
+}
@@ -0,0 +1,219 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+)
+
+type orgRepository struct {
+	mu      *sync.Mutex
+	lastID  int
+	units   []*models.OrgUnit
+	members map[int64]models.OrgMember // by user id
+	grants  map[models.OrgGrant]bool   // by unit and role, without the time
+}
+
+func NewOrgRepository() repository.IOrg {
+	return &orgRepository{
+		mu:      &sync.Mutex{},
+		members: map[int64]models.OrgMember{},
+		grants:  map[models.OrgGrant]bool{},
+	}
+}
+
+func (r *orgRepository) findTarget(id int) (int, *models.OrgUnit) {
+	for index, unit := range r.units {
+		if unit.ID == id {
+			return index, unit
+		}
+	}
+	return -1, nil
+}
+
+//nameTaken report whether another unit of the parent of unit in its tenant has its name
+func (r *orgRepository) nameTaken(unit *models.OrgUnit) bool {
+	for _, other := range r.units {
+		if other.Name == unit.Name && other.ParentID == unit.ParentID && other.TenantID == unit.TenantID && other.ID != unit.ID {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *orgRepository) FindById(ctx context.Context, id int) (*models.OrgUnit, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, target := r.findTarget(id)
+	if target == nil {
+		return nil, errs.NewNotFound("org unit %d not found", id)
+	}
+	unit := *target
+	return &unit, nil
+}
+
+func (r *orgRepository) Add(ctx context.Context, unit *models.OrgUnit) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	unit.ID = 0
+	if r.nameTaken(unit) {
+		return errs.NewAlreadyExists("org unit %s already exists", unit.Name)
+	}
+
+	r.lastID++
+	unit.ID = r.lastID
+	if unit.CreatedAt.IsZero() {
+		unit.CreatedAt = time.Now()
+	}
+	unit.Version = 1
+	stored := *unit
+	r.units = append(r.units, &stored)
+	return nil
+}
+
+func (r *orgRepository) Update(ctx context.Context, unit *models.OrgUnit) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	index, target := r.findTarget(unit.ID)
+	if index == -1 {
+		return errs.NewNotFound("org unit %d not found", unit.ID)
+	}
+	if target.Version != unit.Version {
+		return repository.StaleVersion("org unit", int64(unit.ID), unit.Version)
+	}
+	if r.nameTaken(unit) {
+		return errs.NewAlreadyExists("org unit %s already exists", unit.Name)
+	}
+
+	unit.UpdatedAt = time.Now()
+	unit.Version++
+	stored := *unit
+	r.units[index] = &stored
+	return nil
+}
+
+func (r *orgRepository) Delete(ctx context.Context, id int, version int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	index, target := r.findTarget(id)
+	if index == -1 {
+		return errs.NewNotFound("org unit %d not found", id)
+	}
+	if target.Version != version {
+		return repository.StaleVersion("org unit", int64(id), version)
+	}
+	r.units = append(r.units[:index], r.units[index+1:]...)
+	for grant := range r.grants {
+		if grant.UnitID == id {
+			delete(r.grants, grant)
+		}
+	}
+	return nil
+}
+
+func (r *orgRepository) List(ctx context.Context) ([]*models.OrgUnit, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	units := make([]*models.OrgUnit, 0, len(r.units))
+	for _, target := range r.units {
+		unit := *target
+		units = append(units, &unit)
+	}
+	return units, nil
+}
+
+func (r *orgRepository) SetMember(ctx context.Context, member *models.OrgMember) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if member.CreatedAt.IsZero() {
+		member.CreatedAt = time.Now()
+	}
+	r.members[member.UserID] = *member
+	return nil
+}
+
+func (r *orgRepository) RemoveMember(ctx context.Context, userID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.members[userID]; !ok {
+		return errs.NewNotFound("org member %d not found", userID)
+	}
+	delete(r.members, userID)
+	return nil
+}
+
+func (r *orgRepository) MemberOf(ctx context.Context, userID int64) (*models.OrgMember, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	member, ok := r.members[userID]
+	if !ok {
+		return nil, errs.NewNotFound("org member %d not found", userID)
+	}
+	return &member, nil
+}
+
+func (r *orgRepository) Members(ctx context.Context, unitID int) ([]*models.OrgMember, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	members := make([]*models.OrgMember, 0)
+	for _, target := range r.members {
+		if target.DeptID == unitID || target.PositionID == unitID {
+			member := target
+			members = append(members, &member)
+		}
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].UserID < members[j].UserID })
+	return members, nil
+}
+
+func (r *orgRepository) Grant(ctx context.Context, unitID, roleID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	grant := models.OrgGrant{UnitID: unitID, RoleID: roleID}
+	if r.grants[grant] {
+		return errs.NewAlreadyExists("role %d is granted to org unit %d already", roleID, unitID)
+	}
+	r.grants[grant] = true
+	return nil
+}
+
+func (r *orgRepository) Revoke(ctx context.Context, unitID, roleID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	grant := models.OrgGrant{UnitID: unitID, RoleID: roleID}
+	if !r.grants[grant] {
+		return errs.NewNotFound("role %d is not granted to org unit %d", roleID, unitID)
+	}
+	delete(r.grants, grant)
+	return nil
+}
+
+func (r *orgRepository) Grants(ctx context.Context, unitID int) ([]int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	roleIDs := make([]int, 0)
+	for grant := range r.grants {
+		if grant.UnitID == unitID {
+			roleIDs = append(roleIDs, grant.RoleID)
+		}
+	}
+	sort.Ints(roleIDs)
+	return roleIDs, nil
+}
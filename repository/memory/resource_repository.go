@@ -0,0 +1,207 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+)
+
+type resourceRepository struct {
+	mu        *sync.Mutex
+	lastID    int
+	resources []*models.Resource
+}
+
+func NewResourceRepository() repository.IResource {
+	return &resourceRepository{
+		mu:        &sync.Mutex{},
+		resources: make([]*models.Resource, 0),
+	}
+}
+
+func (r *resourceRepository) findTarget(id int) (int, *models.Resource) {
+	for index, resource := range r.resources {
+		if resource.ID == id {
+			return index, resource
+		}
+	}
+	return -1, nil
+}
+
+func (r *resourceRepository) FindById(ctx context.Context, id int64) (*models.Resource, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, target := r.findTarget(int(id))
+	if target == nil || !visible(ctx, target.ModelExtension) {
+		return nil, errs.NewNotFound("resource %d not found", id)
+	}
+	resource := *target
+	return &resource, nil
+}
+
+func (r *resourceRepository) FindByName(ctx context.Context, name string) (*models.Resource, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tenant := repository.NameTenant(ctx)
+	for _, target := range r.resources {
+		if target.Name == name && target.TenantID == tenant && visible(ctx, target.ModelExtension) {
+			resource := *target
+			return &resource, nil
+		}
+	}
+	return nil, errs.NewNotFound("resource %s not found", name)
+}
+
+func (r *resourceRepository) Add(ctx context.Context, resource *models.Resource) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, target := range r.resources {
+		if target.Name == resource.Name && target.TenantID == resource.TenantID {
+			return errs.NewAlreadyExists("resource %s already exists", resource.Name)
+		}
+	}
+
+	r.lastID++
+	resource.ID = r.lastID
+	if resource.CreatedAt.IsZero() {
+		resource.CreatedAt = time.Now()
+	}
+	resource.Version = 1
+	stored := *resource
+	r.resources = append(r.resources, &stored)
+
+	return nil
+}
+
+func (r *resourceRepository) Update(ctx context.Context, resource *models.Resource) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	index, target := r.findTarget(resource.ID)
+	if index == -1 || target.IsSoftDel {
+		return errs.NewNotFound("resource %d not found", resource.ID)
+	}
+	if target.Version != resource.Version {
+		return repository.StaleVersion("resource", int64(resource.ID), resource.Version)
+	}
+	//names of deleted resources are kept until purged
+	for _, other := range r.resources {
+		if other.ID != resource.ID && other.Name == resource.Name && other.TenantID == resource.TenantID {
+			return errs.NewAlreadyExists("resource %s already exists", resource.Name)
+		}
+	}
+	resource.UpdatedAt = time.Now()
+	resource.Version++
+	stored := *resource
+	r.resources[index] = &stored
+	return nil
+}
+
+//Delete mark the resource deleted
+func (r *resourceRepository) Delete(ctx context.Context, id, version int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, target := r.findTarget(int(id))
+	if target == nil || target.IsSoftDel {
+		return errs.NewNotFound("resource %d not found", id)
+	}
+	if target.Version != version {
+		return repository.StaleVersion("resource", id, version)
+	}
+	markDeleted(&target.ModelExtension, true)
+	return nil
+}
+
+func (r *resourceRepository) Restore(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, target := r.findTarget(int(id))
+	if target == nil || !target.IsSoftDel {
+		return errs.NewNotFound("deleted resource %d not found", id)
+	}
+	markDeleted(&target.ModelExtension, false)
+	return nil
+}
+
+func (r *resourceRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := make([]*models.Resource, 0, len(r.resources))
+	for _, resource := range r.resources {
+		if !expired(resource.ModelExtension, before) {
+			kept = append(kept, resource)
+		}
+	}
+	purged := int64(len(r.resources) - len(kept))
+	r.resources = kept
+	return purged, nil
+}
+
+func (r *resourceRepository) Search(ctx context.Context, tenantID int, types ...models.ResourceCatalog) ([]*models.Resource, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]*models.Resource, 0)
+	for _, resource := range r.resources {
+		if !visible(ctx, resource.ModelExtension) {
+			continue
+		}
+		if tenantID != 0 && resource.TenantID != tenantID {
+			continue
+		}
+		if len(types) > 0 && !containsCatalog(types, models.ResourceCatalog(resource.Type)) {
+			continue
+		}
+		found := *resource
+		result = append(result, &found)
+	}
+	return result, nil
+}
+
+func (r *resourceRepository) List(ctx context.Context, opts repository.ListOptions) ([]*models.Resource, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	items := make([]repository.ListItem, 0, len(r.resources))
+	for index, resource := range r.resources {
+		if !visible(ctx, resource.ModelExtension) {
+			continue
+		}
+		typ := models.ResourceCatalog(resource.Type)
+		items = append(items, repository.ListItem{
+			Index:   index,
+			ID:      int64(resource.ID),
+			Name:    resource.Name,
+			Tenant:  resource.TenantID,
+			Type:    &typ,
+			Created: resource.CreatedAt,
+		})
+	}
+
+	indexes, total := repository.ListPage(items, opts)
+	result := make([]*models.Resource, 0, len(indexes))
+	for _, index := range indexes {
+		resource := *r.resources[index]
+		result = append(result, &resource)
+	}
+	return result, total, nil
+}
+
+func containsCatalog(types []models.ResourceCatalog, t models.ResourceCatalog) bool {
+	for _, v := range types {
+		if v == t {
+			return true
+		}
+	}
+	return false
+}
@@ -1,110 +1,192 @@
 package memory
 
 import (
-	"errors"
+	"context"
 	"sync"
 	"time"
 
+	"github.com/micro-community/auth/errs"
 	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
 )
 
-//UserModel data
-type RoleRepository struct {
-	mu    *sync.Mutex
-	roles []*models.Role
+type roleRepository struct {
+	mu     *sync.Mutex
+	lastID int
+	roles  []*models.Role
 }
 
-func NewRoleRepository() *RoleRepository {
+func NewRoleRepository() repository.IRole {
 	roles := make([]*models.Role, 0)
 	roles = append(roles, &models.Role{
 		ID:   1,
 		Name: "boss",
 		ModelExtension: models.ModelExtension{
 			CreatedAt: time.Now(),
+			Version:   1,
 		},
 	})
 
-	return &RoleRepository{
-		mu:    &sync.Mutex{},
-		roles: roles,
+	return &roleRepository{
+		mu:     &sync.Mutex{},
+		lastID: 1,
+		roles:  roles,
 	}
 }
 
-func (r *RoleRepository) findTarget(roleID int) (int, *models.Role) {
-
+func (r *roleRepository) findTarget(id int) (int, *models.Role) {
 	for index, role := range r.roles {
-		if role.ID == roleID {
-			return index, r.roles[index]
+		if role.ID == id {
+			return index, role
 		}
 	}
 	return -1, nil
 }
 
-func (r *RoleRepository) Get(id int) (*models.Role, error) {
-
+func (r *roleRepository) FindById(ctx context.Context, id int64) (*models.Role, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	_, target := r.findTarget(id)
-
-	return target, nil
+	_, target := r.findTarget(int(id))
+	if target == nil || !visible(ctx, target.ModelExtension) {
+		return nil, errs.NewNotFound("role %d not found", id)
+	}
+	role := *target
+	return &role, nil
 }
 
-func (r *RoleRepository) Insert(role *models.Role) (id int, err error) {
+func (r *roleRepository) FindByName(ctx context.Context, name string) (*models.Role, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	id = int(len(r.roles) + 1)
-	role.ID = id
-	r.roles = append(r.roles, role)
-	return
+	tenant := repository.NameTenant(ctx)
+	for _, target := range r.roles {
+		if target.Name == name && target.TenantID == tenant && visible(ctx, target.ModelExtension) {
+			role := *target
+			return &role, nil
+		}
+	}
+	return nil, errs.NewNotFound("role %s not found", name)
 }
 
-//Update 修改
-func (r *RoleRepository) Update(update models.Role) (id int, err error) {
-
+func (r *roleRepository) Add(ctx context.Context, role *models.Role) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	targetID, targetRole := r.findTarget(update.ID)
-
-	if targetID == -1 {
-		return -1, errors.New("target role not exist")
+	for _, target := range r.roles {
+		if target.Name == role.Name && target.TenantID == role.TenantID {
+			return errs.NewAlreadyExists("role %s already exists", role.Name)
+		}
 	}
 
-	if update.Key != "" && targetRole.Key != update.Key {
-		return -1, errors.New("role key modify forbiden")
+	r.lastID++
+	role.ID = r.lastID
+	if role.CreatedAt.IsZero() {
+		role.CreatedAt = time.Now()
 	}
+	role.Version = 1
+	stored := *role
+	r.roles = append(r.roles, &stored)
 
-	targetRole = &update
-
-	return targetRole.ID, nil
+	return nil
 }
 
-func (r *RoleRepository) Del(id int) bool {
+//Update role, the key of a role can not be modified
+func (r *roleRepository) Update(ctx context.Context, role *models.Role) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	index, target := r.findTarget(role.ID)
+	if index == -1 || target.IsSoftDel {
+		return errs.NewNotFound("role %d not found", role.ID)
+	}
+	if target.Version != role.Version {
+		return repository.StaleVersion("role", int64(role.ID), role.Version)
+	}
+	if role.Key != "" && target.Key != role.Key {
+		return errs.NewConflict("role key modify forbidden")
+	}
+	//names of deleted roles are kept until purged
+	for _, other := range r.roles {
+		if other.ID != role.ID && other.Name == role.Name && other.TenantID == role.TenantID {
+			return errs.NewAlreadyExists("role %s already exists", role.Name)
+		}
+	}
+
+	role.UpdatedAt = time.Now()
+	role.Version++
+	stored := *role
+	r.roles[index] = &stored
+	return nil
+}
 
+//Delete mark the role deleted
+func (r *roleRepository) Delete(ctx context.Context, id, version int64) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	index, _ := r.findTarget(id)
-	if index != -1 {
-		r.roles = append(r.roles[:index], r.roles[index+1:]...)
-		return true
+	_, target := r.findTarget(int(id))
+	if target == nil || target.IsSoftDel {
+		return errs.NewNotFound("role %d not found", id)
+	}
+	if target.Version != version {
+		return repository.StaleVersion("role", id, version)
 	}
+	markDeleted(&target.ModelExtension, true)
+	return nil
+}
 
-	return false
+func (r *roleRepository) Restore(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
+	_, target := r.findTarget(int(id))
+	if target == nil || !target.IsSoftDel {
+		return errs.NewNotFound("deleted role %d not found", id)
+	}
+	markDeleted(&target.ModelExtension, false)
+	return nil
 }
 
-//BatchDelete 批量删除
-func (r *RoleRepository) BatchDelete(ids []int) []bool {
+func (r *roleRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := make([]*models.Role, 0, len(r.roles))
+	for _, role := range r.roles {
+		if !expired(role.ModelExtension, before) {
+			kept = append(kept, role)
+		}
+	}
+	purged := int64(len(r.roles) - len(kept))
+	r.roles = kept
+	return purged, nil
+}
 
-	cnt := len(ids)
+//List roles matched opts
+func (r *roleRepository) List(ctx context.Context, opts repository.ListOptions) ([]*models.Role, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
-	res := make([]bool, cnt)
+	items := make([]repository.ListItem, 0, len(r.roles))
+	for index, role := range r.roles {
+		if !visible(ctx, role.ModelExtension) {
+			continue
+		}
+		items = append(items, repository.ListItem{
+			Index:   index,
+			ID:      int64(role.ID),
+			Name:    role.Name,
+			Tenant:  role.TenantID,
+			Created: role.CreatedAt,
+		})
+	}
 
-	for idx, id := range ids {
-		res[idx] = r.Del(id)
+	indexes, total := repository.ListPage(items, opts)
+	result := make([]*models.Role, 0, len(indexes))
+	for _, index := range indexes {
+		role := *r.roles[index]
+		result = append(result, &role)
 	}
-	return res
+	return result, total, nil
 }
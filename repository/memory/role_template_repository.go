@@ -0,0 +1,165 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+)
+
+type roleTemplateRepository struct {
+	mu        *sync.Mutex
+	lastID    int
+	templates []*models.RoleTemplate
+	instances map[int]models.RoleInstance // by role id
+}
+
+func NewRoleTemplateRepository() repository.IRoleTemplate {
+	return &roleTemplateRepository{
+		mu:        &sync.Mutex{},
+		instances: map[int]models.RoleInstance{},
+	}
+}
+
+//copyTemplate return a copy of the template, its grants are not shared
+func copyTemplate(template *models.RoleTemplate) *models.RoleTemplate {
+	copied := *template
+	copied.Grants = append(models.Keys(nil), template.Grants...)
+	return &copied
+}
+
+func (r *roleTemplateRepository) findTarget(id int) (int, *models.RoleTemplate) {
+	for index, template := range r.templates {
+		if template.ID == id {
+			return index, template
+		}
+	}
+	return -1, nil
+}
+
+func (r *roleTemplateRepository) FindById(ctx context.Context, id int) (*models.RoleTemplate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, target := r.findTarget(id)
+	if target == nil {
+		return nil, errs.NewNotFound("role template %d not found", id)
+	}
+	return copyTemplate(target), nil
+}
+
+func (r *roleTemplateRepository) FindByName(ctx context.Context, name string) (*models.RoleTemplate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, target := range r.templates {
+		if target.Name == name {
+			return copyTemplate(target), nil
+		}
+	}
+	return nil, errs.NewNotFound("role template %s not found", name)
+}
+
+func (r *roleTemplateRepository) Add(ctx context.Context, template *models.RoleTemplate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, target := range r.templates {
+		if target.Name == template.Name {
+			return errs.NewAlreadyExists("role template %s already exists", template.Name)
+		}
+	}
+
+	r.lastID++
+	template.ID = r.lastID
+	if template.CreatedAt.IsZero() {
+		template.CreatedAt = time.Now()
+	}
+	template.Version = 1
+	r.templates = append(r.templates, copyTemplate(template))
+	return nil
+}
+
+func (r *roleTemplateRepository) Update(ctx context.Context, template *models.RoleTemplate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	index, target := r.findTarget(template.ID)
+	if index == -1 {
+		return errs.NewNotFound("role template %d not found", template.ID)
+	}
+	if target.Version != template.Version {
+		return repository.StaleVersion("role template", int64(template.ID), template.Version)
+	}
+	for _, other := range r.templates {
+		if other.Name == template.Name && other.ID != template.ID {
+			return errs.NewAlreadyExists("role template %s already exists", template.Name)
+		}
+	}
+
+	template.UpdatedAt = time.Now()
+	template.Version++
+	r.templates[index] = copyTemplate(template)
+	return nil
+}
+
+func (r *roleTemplateRepository) Delete(ctx context.Context, id int, version int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	index, target := r.findTarget(id)
+	if index == -1 {
+		return errs.NewNotFound("role template %d not found", id)
+	}
+	if target.Version != version {
+		return repository.StaleVersion("role template", int64(id), version)
+	}
+	r.templates = append(r.templates[:index], r.templates[index+1:]...)
+	for roleID, instance := range r.instances {
+		if instance.TemplateID == id {
+			delete(r.instances, roleID)
+		}
+	}
+	return nil
+}
+
+func (r *roleTemplateRepository) List(ctx context.Context) ([]*models.RoleTemplate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	templates := make([]*models.RoleTemplate, 0, len(r.templates))
+	for _, target := range r.templates {
+		templates = append(templates, copyTemplate(target))
+	}
+	return templates, nil
+}
+
+func (r *roleTemplateRepository) SaveInstance(ctx context.Context, instance *models.RoleInstance) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if instance.CreatedAt.IsZero() {
+		instance.CreatedAt = time.Now()
+	}
+	r.instances[instance.RoleID] = *instance
+	return nil
+}
+
+func (r *roleTemplateRepository) Instances(ctx context.Context, templateID int) ([]*models.RoleInstance, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	instances := make([]*models.RoleInstance, 0)
+	for _, target := range r.instances {
+		if target.TemplateID == templateID {
+			instance := target
+			instances = append(instances, &instance)
+		}
+	}
+	sort.Slice(instances, func(i, j int) bool { return instances[i].RoleID < instances[j].RoleID })
+	return instances, nil
+}
@@ -0,0 +1,38 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+)
+
+//visible report whether reads with ctx see the entity of ext
+func visible(ctx context.Context, ext models.ModelExtension) bool {
+	return !ext.IsSoftDel || repository.ShowDeleted(ctx)
+}
+
+//expired report whether the entity of ext is deleted before the time
+func expired(ext models.ModelExtension, before time.Time) bool {
+	return ext.IsSoftDel && ext.DeletedAt.Before(before)
+}
+
+//markDeleted mark the entity of ext deleted now, or restore it, as a new version
+func markDeleted(ext *models.ModelExtension, deleted bool) {
+	ext.Version++
+	ext.IsSoftDel = deleted
+	ext.DeletedAt = time.Time{}
+	if deleted {
+		ext.DeletedAt = time.Now()
+	}
+}
+
+//mustBeLive return an errs.NotFound error for an end of a link found WithDeleted
+func mustBeLive(ext models.ModelExtension, kind string, id int64) error {
+	if ext.IsSoftDel {
+		return errs.NewNotFound("%s %d not found", kind, id)
+	}
+	return nil
+}
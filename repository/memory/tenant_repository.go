@@ -0,0 +1,135 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+)
+
+//tenantRepository keep deleted tenants hidden, so their ids are never reused
+type tenantRepository struct {
+	mu      *sync.Mutex
+	lastID  int
+	tenants []*models.Tenant
+}
+
+func NewTenantRepository() repository.ITenant {
+	return &tenantRepository{mu: &sync.Mutex{}}
+}
+
+//findTarget return the live tenant of id
+func (r *tenantRepository) findTarget(id int) (int, *models.Tenant) {
+	for index, tenant := range r.tenants {
+		if tenant.ID == id && !tenant.IsSoftDel {
+			return index, tenant
+		}
+	}
+	return -1, nil
+}
+
+func (r *tenantRepository) FindById(ctx context.Context, id int) (*models.Tenant, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, target := r.findTarget(id)
+	if target == nil {
+		return nil, errs.NewNotFound("tenant %d not found", id)
+	}
+	tenant := *target
+	return &tenant, nil
+}
+
+func (r *tenantRepository) FindByName(ctx context.Context, name string) (*models.Tenant, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, target := range r.tenants {
+		if target.Name == name && !target.IsSoftDel {
+			tenant := *target
+			return &tenant, nil
+		}
+	}
+	return nil, errs.NewNotFound("tenant %s not found", name)
+}
+
+func (r *tenantRepository) Add(ctx context.Context, tenant *models.Tenant) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, target := range r.tenants {
+		if target.Name == tenant.Name && !target.IsSoftDel {
+			return errs.NewAlreadyExists("tenant %s already exists", tenant.Name)
+		}
+	}
+
+	r.lastID++
+	tenant.ID = r.lastID
+	if tenant.CreatedAt.IsZero() {
+		tenant.CreatedAt = time.Now()
+	}
+	tenant.Version = 1
+	stored := *tenant
+	r.tenants = append(r.tenants, &stored)
+	return nil
+}
+
+func (r *tenantRepository) Update(ctx context.Context, tenant *models.Tenant) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	index, target := r.findTarget(tenant.ID)
+	if index == -1 {
+		return errs.NewNotFound("tenant %d not found", tenant.ID)
+	}
+	if target.Version != tenant.Version {
+		return repository.StaleVersion("tenant", int64(tenant.ID), tenant.Version)
+	}
+	for _, other := range r.tenants {
+		if other.Name == tenant.Name && other.ID != tenant.ID && !other.IsSoftDel {
+			return errs.NewAlreadyExists("tenant %s already exists", tenant.Name)
+		}
+	}
+
+	tenant.UpdatedAt = time.Now()
+	tenant.Version++
+	stored := *tenant
+	r.tenants[index] = &stored
+	return nil
+}
+
+func (r *tenantRepository) Delete(ctx context.Context, id int, version int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	index, target := r.findTarget(id)
+	if index == -1 {
+		return errs.NewNotFound("tenant %d not found", id)
+	}
+	if target.Version != version {
+		return repository.StaleVersion("tenant", int64(id), version)
+	}
+	deleted := *target
+	deleted.IsSoftDel, deleted.DeletedAt = true, time.Now()
+	deleted.Version++
+	r.tenants[index] = &deleted
+	return nil
+}
+
+func (r *tenantRepository) List(ctx context.Context) ([]*models.Tenant, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tenants := make([]*models.Tenant, 0, len(r.tenants))
+	for _, target := range r.tenants {
+		if target.IsSoftDel {
+			continue
+		}
+		tenant := *target
+		tenants = append(tenants, &tenant)
+	}
+	return tenants, nil
+}
@@ -0,0 +1,19 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/micro-community/auth/repository"
+)
+
+//unitOfWork of memory repositories is not atomic, each operation applies at once and nothing rolls back
+type unitOfWork struct {
+}
+
+func NewUnitOfWork() repository.UnitOfWork {
+	return unitOfWork{}
+}
+
+func (unitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
@@ -1,15 +1,19 @@
 package memory
 
 import (
+	"context"
 	"sync"
+	"time"
 
+	"github.com/micro-community/auth/errs"
 	"github.com/micro-community/auth/models"
 	"github.com/micro-community/auth/repository"
 )
 
 type userRepository struct {
-	mu    *sync.Mutex
-	users []*models.User
+	mu     *sync.Mutex
+	lastID int64
+	users  []*models.User
 }
 
 func NewUserRepository() repository.IUser {
@@ -18,50 +22,170 @@ func NewUserRepository() repository.IUser {
 		ID:       1,
 		Name:     "admin",
 		Password: "123456",
+		UserDetails: models.UserDetails{
+			Stated: models.UserPublished,
+		},
+		ModelExtension: models.ModelExtension{
+			CreatedAt: time.Now(),
+			Version:   1,
+		},
 	})
 
 	return &userRepository{
-		mu:    &sync.Mutex{},
-		users: users,
+		mu:     &sync.Mutex{},
+		lastID: 1,
+		users:  users,
 	}
 }
 
-func (r *userRepository) FindById(id int64) (*models.User, error) {
+func (r *userRepository) findTarget(id int64) (int, *models.User) {
+	for index, user := range r.users {
+		if user.ID == id {
+			return index, user
+		}
+	}
+	return -1, nil
+}
+
+func (r *userRepository) FindById(ctx context.Context, id int64) (*models.User, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	for _, user := range r.users {
-		if user.ID == id {
-			return user, nil
+	_, target := r.findTarget(id)
+	if target == nil || !visible(ctx, target.ModelExtension) {
+		return nil, errs.NewNotFound("user %d not found", id)
+	}
+	user := *target
+	return &user, nil
+}
+
+func (r *userRepository) FindByName(ctx context.Context, name string) (*models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tenant := repository.NameTenant(ctx)
+	for _, target := range r.users {
+		if target.Name == name && target.TenantID == tenant && visible(ctx, target.ModelExtension) {
+			user := *target
+			return &user, nil
 		}
 	}
-	return nil, nil
+	return nil, errs.NewNotFound("user %s not found", name)
 }
 
-func (r *userRepository) FindByName(name string) (*models.User, error) {
+func (r *userRepository) Add(ctx context.Context, user *models.User) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	for _, user := range r.users {
-		if user.Name == name {
-			return user, nil
+	for _, target := range r.users {
+		if target.Name == user.Name && target.TenantID == user.TenantID {
+			return errs.NewAlreadyExists("user %s already exists", user.Name)
+		}
+	}
+
+	r.lastID++
+	user.ID = r.lastID
+	if user.CreatedAt.IsZero() {
+		user.CreatedAt = time.Now()
+	}
+	user.Version = 1
+	stored := *user
+	r.users = append(r.users, &stored)
+
+	return nil
+}
+
+func (r *userRepository) Update(ctx context.Context, user *models.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	index, target := r.findTarget(user.ID)
+	if index == -1 || target.IsSoftDel {
+		return errs.NewNotFound("user %d not found", user.ID)
+	}
+	if target.Version != user.Version {
+		return repository.StaleVersion("user", user.ID, user.Version)
+	}
+	//names of deleted users are kept until purged
+	for _, other := range r.users {
+		if other.ID != user.ID && other.Name == user.Name && other.TenantID == user.TenantID {
+			return errs.NewAlreadyExists("user %s already exists", user.Name)
 		}
 	}
-	return nil, nil
+	user.UpdatedAt = time.Now()
+	user.Version++
+	stored := *user
+	r.users[index] = &stored
+	return nil
 }
 
-func (r *userRepository) Add(user *models.User) error {
+//Delete mark the user deleted
+func (r *userRepository) Delete(ctx context.Context, id, version int64) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	id := int64(len(r.users) + 1)
-	user.ID = id
+	_, target := r.findTarget(id)
+	if target == nil || target.IsSoftDel {
+		return errs.NewNotFound("user %d not found", id)
+	}
+	if target.Version != version {
+		return repository.StaleVersion("user", id, version)
+	}
+	markDeleted(&target.ModelExtension, true)
+	return nil
+}
 
-	r.users = append(r.users, user)
+func (r *userRepository) Restore(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
+	_, target := r.findTarget(id)
+	if target == nil || !target.IsSoftDel {
+		return errs.NewNotFound("deleted user %d not found", id)
+	}
+	markDeleted(&target.ModelExtension, false)
 	return nil
 }
 
-func (r *userRepository) List(page, size int) ([]*models.User, error) {
-	return nil, nil
+func (r *userRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := make([]*models.User, 0, len(r.users))
+	for _, user := range r.users {
+		if !expired(user.ModelExtension, before) {
+			kept = append(kept, user)
+		}
+	}
+	purged := int64(len(r.users) - len(kept))
+	r.users = kept
+	return purged, nil
+}
+
+func (r *userRepository) List(ctx context.Context, opts repository.ListOptions) ([]*models.User, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	items := make([]repository.ListItem, 0, len(r.users))
+	for index, user := range r.users {
+		if !visible(ctx, user.ModelExtension) {
+			continue
+		}
+		items = append(items, repository.ListItem{
+			Index:   index,
+			ID:      user.ID,
+			Name:    user.Name,
+			Tenant:  user.TenantID,
+			Status:  user.Stated,
+			Created: user.CreatedAt,
+		})
+	}
+
+	indexes, total := repository.ListPage(items, opts)
+	result := make([]*models.User, 0, len(indexes))
+	for _, index := range indexes {
+		user := *r.users[index]
+		result = append(result, &user)
+	}
+	return result, total, nil
 }
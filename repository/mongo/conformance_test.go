@@ -0,0 +1,85 @@
+// +build mongo
+
+package mongo
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/micro-community/auth/db/migration"
+	"github.com/micro-community/auth/repository"
+	"github.com/micro-community/auth/repository/conformance"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+//newDatabase connect to the mongodb of MONGO_URI and migrate it, names of the suite keep its data apart from others
+func newDatabase(t *testing.T) *mongo.Database {
+	uri := os.Getenv("MONGO_URI")
+	if uri == "" {
+		uri = "mongodb://localhost:27017"
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Disconnect(context.Background()) })
+	db := client.Database("auth_conformance")
+	if _, err = migration.Up(ctx, migration.NewMongo(db), 0); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestUserRepository(t *testing.T) {
+	conformance.RunUser(t, func(t *testing.T) repository.IUser { return NewUserRepository(newDatabase(t)) })
+}
+
+func TestRoleRepository(t *testing.T) {
+	conformance.RunRole(t, func(t *testing.T) repository.IRole { return NewRoleRepository(newDatabase(t)) })
+}
+
+func TestResourceRepository(t *testing.T) {
+	conformance.RunResource(t, func(t *testing.T) repository.IResource { return NewResourceRepository(newDatabase(t)) })
+}
+
+func newRepositories(t *testing.T) conformance.Repositories {
+	db := newDatabase(t)
+	return conformance.Repositories{
+		Users:     NewUserRepository(db),
+		Roles:     NewRoleRepository(db),
+		Resources: NewResourceRepository(db),
+		Links:     NewLinkRepository(db),
+		Work:      NewUnitOfWork(db),
+	}
+}
+
+func TestLinkRepository(t *testing.T) {
+	conformance.RunLink(t, newRepositories)
+}
+
+//TestUnitOfWork needs a replica set, transactions are not supported by a standalone server
+func TestUnitOfWork(t *testing.T) {
+	conformance.RunUnitOfWork(t, newRepositories, true)
+}
+
+func TestLogRepository(t *testing.T) {
+	conformance.RunLog(t, func(t *testing.T) repository.ILog { return NewLogRepository(newDatabase(t)) })
+}
+
+func TestTenantRepository(t *testing.T) {
+	conformance.RunTenant(t, func(t *testing.T) repository.ITenant { return NewTenantRepository(newDatabase(t)) })
+}
+
+func TestRoleTemplateRepository(t *testing.T) {
+	conformance.RunRoleTemplate(t, func(t *testing.T) repository.IRoleTemplate { return NewRoleTemplateRepository(newDatabase(t)) })
+}
+
+func TestOrgRepository(t *testing.T) {
+	conformance.RunOrg(t, func(t *testing.T) repository.IOrg { return NewOrgRepository(newDatabase(t)) })
+}
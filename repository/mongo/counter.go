@@ -0,0 +1,37 @@
+package mongo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+//countersCollection keep the last id of every collection
+const countersCollection = "counters"
+
+//nextID increase the counter of collection name and return it as the next id
+func nextID(ctx context.Context, db *mongo.Database, name string) (int64, error) {
+	var counter struct {
+		Seq int64 `bson:"seq"`
+	}
+	err := db.Collection(countersCollection).FindOneAndUpdate(ctx,
+		bson.M{"_id": name},
+		bson.M{"$inc": bson.M{"seq": 1}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&counter)
+	if err != nil {
+		return 0, dbError(err)
+	}
+	return counter.Seq, nil
+}
+
+//exists check whether any document of coll matches filter
+func exists(ctx context.Context, coll *mongo.Collection, filter interface{}) (bool, error) {
+	count, err := coll.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	if err != nil {
+		return false, dbError(err)
+	}
+	return count > 0, nil
+}
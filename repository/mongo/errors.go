@@ -0,0 +1,35 @@
+package mongo
+
+import (
+	"errors"
+
+	"github.com/micro-community/auth/errs"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+//dbError translate errors of mongo driver to domain errors
+func dbError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return errs.Wrap(err, errs.NotFound, "not found")
+	case isDuplicateKey(err):
+		return errs.Wrap(err, errs.AlreadyExists, "already exists")
+	default:
+		return errs.NewUnavailable(err, "database error")
+	}
+}
+
+//isDuplicateKey check the write error code of unique index violation
+func isDuplicateKey(err error) bool {
+	var we mongo.WriteException
+	if errors.As(err, &we) {
+		for _, e := range we.WriteErrors {
+			if e.Code == 11000 {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -22,7 +22,7 @@ func (e *EventRepository) Create(ctx context.Context, event models.Event) (inter
 
 	rsp, err := e.db.InsertOne(ctx, event)
 	if err != nil {
-		return nil, err
+		return nil, dbError(err)
 	}
 	return rsp.InsertedID, nil
 }
@@ -31,10 +31,10 @@ func (e *EventRepository) Find(ctx context.Context, query bson.M) ([]models.Even
 	var events []models.Event
 	cursor, err := e.db.Find(ctx, query)
 	if err != nil {
-		return nil, err
+		return nil, dbError(err)
 	}
 	if err = cursor.All(ctx, &events); err != nil {
-		return nil, err
+		return nil, dbError(err)
 	}
 	return events, nil
 }
@@ -42,7 +42,7 @@ func (e *EventRepository) Find(ctx context.Context, query bson.M) ([]models.Even
 func (e *EventRepository) FindOne(ctx context.Context, query bson.D) (*models.Event, error) {
 	var event models.Event
 	if err := e.db.FindOne(ctx, query).Decode(&event); err != nil {
-		return nil, err
+		return nil, dbError(err)
 	}
 	return &event, nil
 }
@@ -54,15 +54,15 @@ func (e *EventRepository) UpdateOne(ctx context.Context, query, update bson.D) (
 
 	rsp, err := e.db.UpdateOne(ctx, query, update)
 	if err != nil {
-		return nil, err
+		return nil, dbError(err)
 	}
 	return rsp, nil
 }
 
 func (e *EventRepository) Delete(ctx context.Context, event models.Event) (err error) {
 
-	if _, err = e.db.DeleteOne(context.TODO(), e); err != nil {
-		return
+	if _, err = e.db.DeleteOne(ctx, e); err != nil {
+		return dbError(err)
 	}
 	return
 }
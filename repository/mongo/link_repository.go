@@ -0,0 +1,172 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+//keys of link documents
+const (
+	keyUserID     = "userid"
+	keyRoleID     = "roleid"
+	keyResourceID = "resourceid"
+)
+
+//linkRepository store links in collections user_roles and role_resources
+type linkRepository struct {
+	users         *mongo.Collection
+	roles         *mongo.Collection
+	resources     *mongo.Collection
+	userRoles     *mongo.Collection
+	roleResources *mongo.Collection
+}
+
+func NewLinkRepository(db *mongo.Database) repository.ILink {
+	return &linkRepository{
+		users:         db.Collection("users"),
+		roles:         db.Collection("roles"),
+		resources:     db.Collection("resources"),
+		userRoles:     db.Collection("user_roles"),
+		roleResources: db.Collection("role_resources"),
+	}
+}
+
+//mustExist return an errs.NotFound error when the document with id does not exist,
+//a deleted document only exists when showDeleted is set
+func mustExist(ctx context.Context, coll *mongo.Collection, kind string, id int64, showDeleted bool) error {
+	filter := bson.M{keyID: id}
+	if !showDeleted {
+		filter[keyIsSoftDel] = notDeleted
+	}
+	found, err := exists(ctx, coll, filter)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errs.NewNotFound("%s %d not found", kind, id)
+	}
+	return nil
+}
+
+//insertLink insert a link, an existing one is reported as errs.AlreadyExists
+func insertLink(ctx context.Context, coll *mongo.Collection, link interface{}) error {
+	_, err := coll.InsertOne(ctx, link)
+	if isDuplicateKey(err) {
+		return errs.NewAlreadyExists("link already exists")
+	}
+	return dbError(err)
+}
+
+//deleteLink delete the link matched filter
+func deleteLink(ctx context.Context, coll *mongo.Collection, filter bson.M) error {
+	result, err := coll.DeleteOne(ctx, filter)
+	if err != nil {
+		return dbError(err)
+	}
+	if result.DeletedCount == 0 {
+		return errs.NewNotFound("link not found")
+	}
+	return nil
+}
+
+//linkedIDs return the distinct values of key in links matched filter
+func linkedIDs(ctx context.Context, coll *mongo.Collection, key string, filter bson.M) ([]interface{}, error) {
+	ids, err := coll.Distinct(ctx, key, filter)
+	return ids, dbError(err)
+}
+
+//findByIDs decode documents with ids into items in order of id
+func findByIDs(ctx context.Context, coll *mongo.Collection, ids []interface{}, items interface{}) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	cursor, err := coll.Find(ctx, live(ctx, bson.D{{Key: keyID, Value: bson.M{"$in": ids}}}), options.Find().SetSort(bson.D{{Key: keyID, Value: 1}}))
+	if err != nil {
+		return dbError(err)
+	}
+	defer cursor.Close(ctx)
+	return dbError(cursor.All(ctx, items))
+}
+
+func (r *linkRepository) LinkUserRole(ctx context.Context, userID int64, roleID int) error {
+	if err := mustExist(ctx, r.users, "user", userID, false); err != nil {
+		return err
+	}
+	if err := mustExist(ctx, r.roles, "role", int64(roleID), false); err != nil {
+		return err
+	}
+	return insertLink(ctx, r.userRoles, models.UserRole{UserID: userID, RoleID: roleID, CreatedAt: time.Now()})
+}
+
+func (r *linkRepository) UnlinkUserRole(ctx context.Context, userID int64, roleID int) error {
+	return deleteLink(ctx, r.userRoles, bson.M{keyUserID: userID, keyRoleID: roleID})
+}
+
+func (r *linkRepository) LinkRoleResource(ctx context.Context, roleID, resourceID int) error {
+	if err := mustExist(ctx, r.roles, "role", int64(roleID), false); err != nil {
+		return err
+	}
+	if err := mustExist(ctx, r.resources, "resource", int64(resourceID), false); err != nil {
+		return err
+	}
+	return insertLink(ctx, r.roleResources, models.RoleResource{RoleID: roleID, ResourceID: resourceID, CreatedAt: time.Now()})
+}
+
+func (r *linkRepository) UnlinkRoleResource(ctx context.Context, roleID, resourceID int) error {
+	return deleteLink(ctx, r.roleResources, bson.M{keyRoleID: roleID, keyResourceID: resourceID})
+}
+
+func (r *linkRepository) UserRoles(ctx context.Context, userID int64) ([]*models.Role, error) {
+	if err := mustExist(ctx, r.users, "user", userID, repository.ShowDeleted(ctx)); err != nil {
+		return nil, err
+	}
+	ids, err := linkedIDs(ctx, r.userRoles, keyRoleID, bson.M{keyUserID: userID})
+	if err != nil {
+		return nil, err
+	}
+	roles := make([]*models.Role, 0)
+	return roles, findByIDs(ctx, r.roles, ids, &roles)
+}
+
+func (r *linkRepository) RoleResources(ctx context.Context, roleID int) ([]*models.Resource, error) {
+	if err := mustExist(ctx, r.roles, "role", int64(roleID), repository.ShowDeleted(ctx)); err != nil {
+		return nil, err
+	}
+	ids, err := linkedIDs(ctx, r.roleResources, keyResourceID, bson.M{keyRoleID: roleID})
+	if err != nil {
+		return nil, err
+	}
+	resources := make([]*models.Resource, 0)
+	return resources, findByIDs(ctx, r.resources, ids, &resources)
+}
+
+func (r *linkRepository) UserResources(ctx context.Context, userID int64) ([]*models.Resource, error) {
+	if err := mustExist(ctx, r.users, "user", userID, repository.ShowDeleted(ctx)); err != nil {
+		return nil, err
+	}
+	roleIDs, err := linkedIDs(ctx, r.userRoles, keyRoleID, bson.M{keyUserID: userID})
+	if err != nil {
+		return nil, err
+	}
+	resources := make([]*models.Resource, 0)
+	if len(roleIDs) == 0 {
+		return resources, nil
+	}
+	//resources of deleted roles are not granted
+	roleIDs, err = r.roles.Distinct(ctx, keyID, live(ctx, bson.D{{Key: keyID, Value: bson.M{"$in": roleIDs}}}))
+	if err != nil {
+		return nil, dbError(err)
+	}
+	ids, err := linkedIDs(ctx, r.roleResources, keyResourceID, bson.M{keyRoleID: bson.M{"$in": roleIDs}})
+	if err != nil {
+		return nil, err
+	}
+	return resources, findByIDs(ctx, r.resources, ids, &resources)
+}
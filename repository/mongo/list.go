@@ -0,0 +1,99 @@
+package mongo
+
+import (
+	"regexp"
+
+	"github.com/micro-community/auth/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+//keys of documents, models are encoded with the default lowercased field names
+const (
+	keyID        = "id"
+	keyName      = "name"
+	keyTenantID  = "tenantid"
+	keyStatus    = "userdetails.stated"
+	keyType      = "type"
+	keyCreatedAt = "modelextension.createdat"
+)
+
+//listFilter translate the filters of opts to a query document,
+//callers must clear the filters their entity does not have
+func listFilter(opts repository.ListOptions) bson.D {
+	filter := bson.D{}
+	if opts.NamePrefix != "" {
+		filter = append(filter, bson.E{Key: keyName, Value: bson.M{
+			"$regex": "^" + regexp.QuoteMeta(opts.NamePrefix), "$options": "i",
+		}})
+	}
+	if opts.TenantID != 0 {
+		filter = append(filter, bson.E{Key: keyTenantID, Value: opts.TenantID})
+	}
+	if opts.Status != 0 {
+		filter = append(filter, bson.E{Key: keyStatus, Value: opts.Status})
+	}
+	if len(opts.Types) > 0 {
+		filter = append(filter, bson.E{Key: keyType, Value: bson.M{"$in": opts.Types}})
+	}
+	created := bson.M{}
+	if !opts.CreatedAfter.IsZero() {
+		created["$gte"] = opts.CreatedAfter
+	}
+	if !opts.CreatedBefore.IsZero() {
+		created["$lt"] = opts.CreatedBefore
+	}
+	if len(created) > 0 {
+		filter = append(filter, bson.E{Key: keyCreatedAt, Value: created})
+	}
+	return filter
+}
+
+//listAfter restrict filter to the documents after the key of opts in the sort order,
+//it only applies to finding the page, the total ignores it
+func listAfter(filter bson.D, opts repository.ListOptions) bson.D {
+	if opts.After == nil {
+		return filter
+	}
+	op := "$gt"
+	if opts.Desc {
+		op = "$lt"
+	}
+	var key string
+	var value interface{}
+	switch opts.SortBy {
+	case repository.SortByName:
+		key, value = keyName, opts.After.Name
+	case repository.SortByCreatedAt:
+		key, value = keyCreatedAt, opts.After.Created
+	default:
+		return bson.D{{Key: "$and", Value: bson.A{filter, bson.M{keyID: bson.M{op: opts.After.ID}}}}}
+	}
+	after := bson.M{"$or": bson.A{
+		bson.M{key: bson.M{op: value}},
+		bson.M{key: value, keyID: bson.M{op: opts.After.ID}},
+	}}
+	return bson.D{{Key: "$and", Value: bson.A{filter, after}}}
+}
+
+//listFindOptions translate the sort order, offset and limit of opts
+func listFindOptions(opts repository.ListOptions) *options.FindOptions {
+	direction := 1
+	if opts.Desc {
+		direction = -1
+	}
+	sort := bson.D{}
+	switch opts.SortBy {
+	case repository.SortByName:
+		sort = append(sort, bson.E{Key: keyName, Value: direction})
+	case repository.SortByCreatedAt:
+		sort = append(sort, bson.E{Key: keyCreatedAt, Value: direction})
+	}
+	sort = append(sort, bson.E{Key: keyID, Value: direction})
+
+	findOptions := options.Find().SetSort(sort).SetSkip(int64(opts.Offset))
+	if opts.Limit > 0 {
+		findOptions.SetLimit(int64(opts.Limit))
+	}
+	return findOptions
+}
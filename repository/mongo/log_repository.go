@@ -2,47 +2,82 @@ package mongo
 
 import (
 	"context"
-	"sync"
+	"time"
 
 	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+//LogRepository append logs in collection logs, ids are taken from the counters
 type LogRepository struct {
-	db *mongo.Collection
-	mu *sync.Mutex
+	db   *mongo.Database
+	coll *mongo.Collection
 }
 
-func (l *LogRepository) Create(ctx context.Context, event models.Log) (interface{}, error) {
-
-	l.mu.Lock()
-	defer l.mu.Unlock()
+func NewLogRepository(db *mongo.Database) repository.ILog {
+	return &LogRepository{
+		db:   db,
+		coll: db.Collection("logs"),
+	}
+}
 
-	rsp, err := l.db.InsertOne(ctx, event)
+func (l *LogRepository) Append(ctx context.Context, log *models.Log) error {
+	id, err := nextID(ctx, l.db, l.coll.Name())
 	if err != nil {
-		return nil, err
+		return err
+	}
+	log.ID = id
+	if log.Time.IsZero() {
+		log.Time = time.Now()
 	}
-	return rsp.InsertedID, nil
+	if _, err = l.coll.InsertOne(ctx, log); err != nil {
+		return dbError(err)
+	}
+	return nil
 }
 
-func (l *LogRepository) Find(ctx context.Context, query bson.M) ([]models.Log, error) {
+func (l *LogRepository) Query(ctx context.Context, opts repository.LogQuery) ([]*models.Log, error) {
+	filter := bson.M{}
+	if opts.Actor != "" {
+		filter["actor"] = opts.Actor
+	}
+	if opts.TenantID != 0 {
+		filter["tenantid"] = opts.TenantID
+	}
+	if len(opts.Kinds) > 0 {
+		filter["kind"] = bson.M{"$in": opts.Kinds}
+	}
+	if opts.EntityID != "" {
+		filter["entityid"] = opts.EntityID
+	}
+	if !opts.Since.IsZero() || !opts.Until.IsZero() {
+		span := bson.M{}
+		if !opts.Since.IsZero() {
+			span["$gte"] = opts.Since
+		}
+		if !opts.Until.IsZero() {
+			span["$lt"] = opts.Until
+		}
+		filter["time"] = span
+	}
+	if opts.BeforeID > 0 {
+		filter["_id"] = bson.M{"$lt": opts.BeforeID}
+	}
 
-	var logs []models.Log
-	cursor, err := l.db.Find(ctx, query)
+	findOptions := options.Find().SetSort(bson.D{{Key: "_id", Value: -1}})
+	if opts.Limit > 0 {
+		findOptions.SetLimit(int64(opts.Limit))
+	}
+	cursor, err := l.coll.Find(ctx, filter, findOptions)
 	if err != nil {
-		return nil, err
+		return nil, dbError(err)
 	}
+	logs := []*models.Log{}
 	if err = cursor.All(ctx, &logs); err != nil {
-		return nil, err
+		return nil, dbError(err)
 	}
 	return logs, nil
 }
-
-func (l *LogRepository) FindOne(ctx context.Context, query bson.M) (*models.Log, error) {
-	var log models.Log
-	if err := l.db.FindOne(ctx, query).Decode(&log); err != nil {
-		return nil, err
-	}
-	return &log, nil
-}
@@ -0,0 +1,187 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+//orgRepository store org units in collection org_units, ids are taken from the counters,
+//their members in collection org_members by user id and their grants in collection org_grants
+type orgRepository struct {
+	db      *mongo.Database
+	coll    *mongo.Collection
+	members *mongo.Collection
+	grants  *mongo.Collection
+}
+
+func NewOrgRepository(db *mongo.Database) repository.IOrg {
+	return &orgRepository{
+		db:      db,
+		coll:    db.Collection("org_units"),
+		members: db.Collection("org_members"),
+		grants:  db.Collection("org_grants"),
+	}
+}
+
+//nameTaken return an errs.AlreadyExists error when another unit of the parent of unit in its tenant has its name
+func (r *orgRepository) nameTaken(ctx context.Context, unit *models.OrgUnit) error {
+	filter := bson.M{keyName: unit.Name, "parentid": unit.ParentID, keyTenantID: unit.TenantID, keyID: bson.M{"$ne": unit.ID}}
+	found, err := exists(ctx, r.coll, filter)
+	if err != nil {
+		return err
+	}
+	if found {
+		return errs.NewAlreadyExists("org unit %s already exists", unit.Name)
+	}
+	return nil
+}
+
+func (r *orgRepository) FindById(ctx context.Context, id int) (*models.OrgUnit, error) {
+	var unit models.OrgUnit
+	if err := r.coll.FindOne(ctx, bson.M{keyID: id}).Decode(&unit); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errs.NewNotFound("org unit %d not found", id)
+		}
+		return nil, dbError(err)
+	}
+	return &unit, nil
+}
+
+func (r *orgRepository) Add(ctx context.Context, unit *models.OrgUnit) error {
+	unit.ID = 0
+	if err := r.nameTaken(ctx, unit); err != nil {
+		return err
+	}
+
+	id, err := nextID(ctx, r.db, r.coll.Name())
+	if err != nil {
+		return err
+	}
+	unit.ID = int(id)
+	if unit.CreatedAt.IsZero() {
+		unit.CreatedAt = time.Now()
+	}
+	unit.Version = 1
+	_, err = r.coll.InsertOne(ctx, unit)
+	return dbError(err)
+}
+
+func (r *orgRepository) Update(ctx context.Context, unit *models.OrgUnit) error {
+	if err := r.nameTaken(ctx, unit); err != nil {
+		return err
+	}
+	unit.UpdatedAt = time.Now()
+	return replace(ctx, r.coll, "org unit", int64(unit.ID), unit, &unit.ModelExtension)
+}
+
+//Delete the unit at the version, then its grants
+func (r *orgRepository) Delete(ctx context.Context, id int, version int64) error {
+	result, err := r.coll.DeleteOne(ctx, bson.M{keyID: id, keyVersion: version})
+	if err != nil {
+		return dbError(err)
+	}
+	if result.DeletedCount == 0 {
+		return staleError(ctx, r.coll, "org unit", int64(id), version)
+	}
+	_, err = r.grants.DeleteMany(ctx, bson.M{"unitid": id})
+	return dbError(err)
+}
+
+func (r *orgRepository) List(ctx context.Context) (units []*models.OrgUnit, err error) {
+	cursor, err := r.coll.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: keyID, Value: 1}}))
+	if err != nil {
+		return nil, dbError(err)
+	}
+	defer cursor.Close(ctx)
+	err = dbError(cursor.All(ctx, &units))
+	return
+}
+
+func (r *orgRepository) SetMember(ctx context.Context, member *models.OrgMember) error {
+	if member.CreatedAt.IsZero() {
+		member.CreatedAt = time.Now()
+	}
+	_, err := r.members.ReplaceOne(ctx, bson.M{"userid": member.UserID}, member, options.Replace().SetUpsert(true))
+	return dbError(err)
+}
+
+func (r *orgRepository) RemoveMember(ctx context.Context, userID int64) error {
+	result, err := r.members.DeleteOne(ctx, bson.M{"userid": userID})
+	if err != nil {
+		return dbError(err)
+	}
+	if result.DeletedCount == 0 {
+		return errs.NewNotFound("org member %d not found", userID)
+	}
+	return nil
+}
+
+func (r *orgRepository) MemberOf(ctx context.Context, userID int64) (*models.OrgMember, error) {
+	var member models.OrgMember
+	if err := r.members.FindOne(ctx, bson.M{"userid": userID}).Decode(&member); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errs.NewNotFound("org member %d not found", userID)
+		}
+		return nil, dbError(err)
+	}
+	return &member, nil
+}
+
+func (r *orgRepository) Members(ctx context.Context, unitID int) (members []*models.OrgMember, err error) {
+	filter := bson.M{"$or": bson.A{bson.M{"deptid": unitID}, bson.M{"positionid": unitID}}}
+	cursor, err := r.members.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "userid", Value: 1}}))
+	if err != nil {
+		return nil, dbError(err)
+	}
+	defer cursor.Close(ctx)
+	members = make([]*models.OrgMember, 0)
+	err = dbError(cursor.All(ctx, &members))
+	return
+}
+
+func (r *orgRepository) Grant(ctx context.Context, unitID, roleID int) error {
+	found, err := exists(ctx, r.grants, bson.M{"unitid": unitID, "roleid": roleID})
+	if err != nil {
+		return err
+	}
+	if found {
+		return errs.NewAlreadyExists("role %d is granted to org unit %d already", roleID, unitID)
+	}
+	_, err = r.grants.InsertOne(ctx, &models.OrgGrant{UnitID: unitID, RoleID: roleID, CreatedAt: time.Now()})
+	return dbError(err)
+}
+
+func (r *orgRepository) Revoke(ctx context.Context, unitID, roleID int) error {
+	result, err := r.grants.DeleteOne(ctx, bson.M{"unitid": unitID, "roleid": roleID})
+	if err != nil {
+		return dbError(err)
+	}
+	if result.DeletedCount == 0 {
+		return errs.NewNotFound("role %d is not granted to org unit %d", roleID, unitID)
+	}
+	return nil
+}
+
+func (r *orgRepository) Grants(ctx context.Context, unitID int) ([]int, error) {
+	cursor, err := r.grants.Find(ctx, bson.M{"unitid": unitID}, options.Find().SetSort(bson.D{{Key: "roleid", Value: 1}}))
+	if err != nil {
+		return nil, dbError(err)
+	}
+	defer cursor.Close(ctx)
+	var grants []*models.OrgGrant
+	if err = cursor.All(ctx, &grants); err != nil {
+		return nil, dbError(err)
+	}
+	roleIDs := make([]int, 0, len(grants))
+	for _, grant := range grants {
+		roleIDs = append(roleIDs, grant.RoleID)
+	}
+	return roleIDs, nil
+}
@@ -0,0 +1,118 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+//resourceRepository store resources in collection resources, ids are taken from the counters
+type resourceRepository struct {
+	db   *mongo.Database
+	coll *mongo.Collection
+}
+
+func NewResourceRepository(db *mongo.Database) repository.IResource {
+	coll := db.Collection("resources")
+	return &resourceRepository{
+		db:   db,
+		coll: coll,
+	}
+}
+
+func (r *resourceRepository) FindById(ctx context.Context, id int64) (*models.Resource, error) {
+	var resource models.Resource
+	if err := r.coll.FindOne(ctx, live(ctx, bson.D{{Key: keyID, Value: id}})).Decode(&resource); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errs.NewNotFound("resource %d not found", id)
+		}
+		return nil, dbError(err)
+	}
+	return &resource, nil
+}
+
+func (r *resourceRepository) FindByName(ctx context.Context, name string) (*models.Resource, error) {
+	var resource models.Resource
+	if err := r.coll.FindOne(ctx, live(ctx, bson.D{{Key: keyTenantID, Value: repository.NameTenant(ctx)}, {Key: keyName, Value: name}})).Decode(&resource); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errs.NewNotFound("resource %s not found", name)
+		}
+		return nil, dbError(err)
+	}
+	return &resource, nil
+}
+
+func (r *resourceRepository) Add(ctx context.Context, resource *models.Resource) error {
+	found, err := exists(ctx, r.coll, bson.M{keyTenantID: resource.TenantID, keyName: resource.Name})
+	if err != nil {
+		return err
+	}
+	if found {
+		return errs.NewAlreadyExists("resource %s already exists", resource.Name)
+	}
+
+	id, err := nextID(ctx, r.db, r.coll.Name())
+	if err != nil {
+		return err
+	}
+	resource.ID = int(id)
+	if resource.CreatedAt.IsZero() {
+		resource.CreatedAt = time.Now()
+	}
+	resource.Version = 1
+	_, err = r.coll.InsertOne(ctx, resource)
+	return dbError(err)
+}
+
+func (r *resourceRepository) Update(ctx context.Context, resource *models.Resource) error {
+	resource.UpdatedAt = time.Now()
+	return replace(ctx, r.coll, "resource", int64(resource.ID), resource, &resource.ModelExtension)
+}
+
+//Delete mark the resource deleted, its links are kept for a restore
+func (r *resourceRepository) Delete(ctx context.Context, id, version int64) error {
+	return markDeleted(ctx, r.coll, "resource", id, version, true)
+}
+
+func (r *resourceRepository) Restore(ctx context.Context, id int64) error {
+	return markDeleted(ctx, r.coll, "resource", id, 0, false)
+}
+
+//Purge resources deleted before the time with their links
+func (r *resourceRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	return purge(ctx, r.coll, before, linkKey{r.db.Collection("role_resources"), keyResourceID})
+}
+
+//List resources matched opts and the total count of them
+func (r *resourceRepository) List(ctx context.Context, opts repository.ListOptions) (resources []*models.Resource, total int64, err error) {
+	opts.Status = 0
+
+	filter := live(ctx, listFilter(opts))
+	if total, err = r.coll.CountDocuments(ctx, filter); err != nil {
+		return nil, 0, dbError(err)
+	}
+	cursor, err := r.coll.Find(ctx, listAfter(filter, opts), listFindOptions(opts))
+	if err != nil {
+		return nil, 0, dbError(err)
+	}
+	defer cursor.Close(ctx)
+	err = dbError(cursor.All(ctx, &resources))
+	return
+}
+
+//Search resources of a tenant (0 for any tenant) by catalog types (none for any type)
+func (r *resourceRepository) Search(ctx context.Context, tenantID int, types ...models.ResourceCatalog) (resources []*models.Resource, err error) {
+	filter := live(ctx, listFilter(repository.ListOptions{TenantID: tenantID, Types: types}))
+	cursor, err := r.coll.Find(ctx, filter, listFindOptions(repository.ListOptions{}))
+	if err != nil {
+		return nil, dbError(err)
+	}
+	defer cursor.Close(ctx)
+	err = dbError(cursor.All(ctx, &resources))
+	return
+}
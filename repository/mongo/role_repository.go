@@ -2,73 +2,120 @@ package mongo
 
 import (
 	"context"
+	"time"
 
+	"github.com/micro-community/auth/errs"
 	"github.com/micro-community/auth/models"
-	"github.com/micro/micro/v3/service/logger"
+	"github.com/micro-community/auth/repository"
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
-type RoleRepository struct {
-	db *mongo.Collection
+//roleRepository store roles in collection roles, ids are taken from the counters
+type roleRepository struct {
+	db   *mongo.Database
+	coll *mongo.Collection
 }
 
-// ListAllRole - gets all
-func (r *RoleRepository) ListAllRole(ctx context.Context) (roles []models.Role, err error) {
-
-	cursor, err := r.db.Find(ctx, bson.D{})
-	if err != nil {
-		logger.Infof("Find error: %v", err)
-		return
+func NewRoleRepository(db *mongo.Database) repository.IRole {
+	coll := db.Collection("roles")
+	return &roleRepository{
+		db:   db,
+		coll: coll,
 	}
-	defer cursor.Close(ctx)
-	if err = cursor.All(ctx, &roles); err != nil {
-		logger.Infof("Error getting data: ", err)
-		return
+}
+
+func (r *roleRepository) FindById(ctx context.Context, id int64) (*models.Role, error) {
+	var role models.Role
+	if err := r.coll.FindOne(ctx, live(ctx, bson.D{{Key: keyID, Value: id}})).Decode(&role); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errs.NewNotFound("role %d not found", id)
+		}
+		return nil, dbError(err)
 	}
+	return &role, nil
+}
 
-	return
+func (r *roleRepository) FindByName(ctx context.Context, name string) (*models.Role, error) {
+	var role models.Role
+	if err := r.coll.FindOne(ctx, live(ctx, bson.D{{Key: keyTenantID, Value: repository.NameTenant(ctx)}, {Key: keyName, Value: name}})).Decode(&role); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errs.NewNotFound("role %s not found", name)
+		}
+		return nil, dbError(err)
+	}
+	return &role, nil
 }
 
-// GetByID - gets role by id
-func (r *RoleRepository) GetByID(ctx context.Context, ID string) (role models.Role, err error) {
+func (r *roleRepository) Add(ctx context.Context, role *models.Role) error {
+	found, err := exists(ctx, r.coll, bson.M{keyTenantID: role.TenantID, keyName: role.Name})
+	if err != nil {
+		return err
+	}
+	if found {
+		return errs.NewAlreadyExists("role %s already exists", role.Name)
+	}
 
-	objID, err := primitive.ObjectIDFromHex(ID)
+	id, err := nextID(ctx, r.db, r.coll.Name())
 	if err != nil {
-		logger.Infof("Invalid id: %v", err)
-		return
+		return err
+	}
+	role.ID = int(id)
+	if role.CreatedAt.IsZero() {
+		role.CreatedAt = time.Now()
 	}
-	sr := r.db.FindOne(context.TODO(), bson.M{"_id": objID})
-	err = sr.Decode(&role)
+	role.Version = 1
+	_, err = r.coll.InsertOne(ctx, role)
+	return dbError(err)
+}
+
+//Update role, the key of a role can not be modified
+func (r *roleRepository) Update(ctx context.Context, role *models.Role) error {
+	target, err := r.FindById(ctx, int64(role.ID))
 	if err != nil {
-		logger.Infof("Error decoding data: ", err)
+		return err
+	}
+	if target.IsSoftDel {
+		return errs.NewNotFound("role %d not found", role.ID)
+	}
+	if target.Version != role.Version {
+		return repository.StaleVersion("role", int64(role.ID), role.Version)
+	}
+	if role.Key != "" && target.Key != role.Key {
+		return errs.NewConflict("role key modify forbidden")
 	}
 
-	return
+	role.UpdatedAt = time.Now()
+	return replace(ctx, r.coll, "role", int64(role.ID), role, &role.ModelExtension)
 }
 
-// Create - creates new role in collection
-func (r *RoleRepository) Create(ctx context.Context, roleName string) (err error) {
-	tempRoleID := primitive.NewObjectID()
+//Delete mark the role deleted, its links are kept for a restore
+func (r *roleRepository) Delete(ctx context.Context, id, version int64) error {
+	return markDeleted(ctx, r.coll, "role", id, version, true)
+}
 
-	if _, err = r.db.InsertOne(ctx, tempRoleID); err != nil {
-		logger.Infof("Error inserting role: %v", r)
-		return
-	}
+func (r *roleRepository) Restore(ctx context.Context, id int64) error {
+	return markDeleted(ctx, r.coll, "role", id, 0, false)
+}
 
-	logger.Info("Inserted role into collection")
-	return
+//Purge roles deleted before the time with their links
+func (r *roleRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	return purge(ctx, r.coll, before, linkKey{r.db.Collection("user_roles"), keyRoleID}, linkKey{r.db.Collection("role_resources"), keyRoleID})
 }
 
-// Delete - deletes role
-func (r *RoleRepository) Delete(ctx context.Context, roleID string) (err error) {
+//List roles matched opts and the total count of them
+func (r *roleRepository) List(ctx context.Context, opts repository.ListOptions) (roles []*models.Role, total int64, err error) {
+	opts.Status, opts.Types = 0, nil
 
-	if _, err = r.db.DeleteOne(context.TODO(), roleID); err != nil {
-		logger.Infof("Error deleting role: %v", roleID)
-		return
+	filter := live(ctx, listFilter(opts))
+	if total, err = r.coll.CountDocuments(ctx, filter); err != nil {
+		return nil, 0, dbError(err)
 	}
-
-	logger.Info("Deleted role from collection")
+	cursor, err := r.coll.Find(ctx, listAfter(filter, opts), listFindOptions(opts))
+	if err != nil {
+		return nil, 0, dbError(err)
+	}
+	defer cursor.Close(ctx)
+	err = dbError(cursor.All(ctx, &roles))
 	return
 }
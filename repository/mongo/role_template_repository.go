@@ -0,0 +1,124 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+//roleTemplateRepository store role templates in collection role_templates, ids are taken from the counters,
+//and their instances in collection role_instances by role id
+type roleTemplateRepository struct {
+	db        *mongo.Database
+	coll      *mongo.Collection
+	instances *mongo.Collection
+}
+
+func NewRoleTemplateRepository(db *mongo.Database) repository.IRoleTemplate {
+	return &roleTemplateRepository{
+		db:        db,
+		coll:      db.Collection("role_templates"),
+		instances: db.Collection("role_instances"),
+	}
+}
+
+func (r *roleTemplateRepository) find(ctx context.Context, filter bson.M, key interface{}) (*models.RoleTemplate, error) {
+	var template models.RoleTemplate
+	if err := r.coll.FindOne(ctx, filter).Decode(&template); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errs.NewNotFound("role template %v not found", key)
+		}
+		return nil, dbError(err)
+	}
+	return &template, nil
+}
+
+func (r *roleTemplateRepository) FindById(ctx context.Context, id int) (*models.RoleTemplate, error) {
+	return r.find(ctx, bson.M{keyID: id}, id)
+}
+
+func (r *roleTemplateRepository) FindByName(ctx context.Context, name string) (*models.RoleTemplate, error) {
+	return r.find(ctx, bson.M{keyName: name}, name)
+}
+
+func (r *roleTemplateRepository) Add(ctx context.Context, template *models.RoleTemplate) error {
+	found, err := exists(ctx, r.coll, bson.M{keyName: template.Name})
+	if err != nil {
+		return err
+	}
+	if found {
+		return errs.NewAlreadyExists("role template %s already exists", template.Name)
+	}
+
+	id, err := nextID(ctx, r.db, r.coll.Name())
+	if err != nil {
+		return err
+	}
+	template.ID = int(id)
+	if template.CreatedAt.IsZero() {
+		template.CreatedAt = time.Now()
+	}
+	template.Version = 1
+	_, err = r.coll.InsertOne(ctx, template)
+	return dbError(err)
+}
+
+func (r *roleTemplateRepository) Update(ctx context.Context, template *models.RoleTemplate) error {
+	found, err := exists(ctx, r.coll, bson.M{keyName: template.Name, keyID: bson.M{"$ne": template.ID}})
+	if err != nil {
+		return err
+	}
+	if found {
+		return errs.NewAlreadyExists("role template %s already exists", template.Name)
+	}
+	template.UpdatedAt = time.Now()
+	return replace(ctx, r.coll, "role template", int64(template.ID), template, &template.ModelExtension)
+}
+
+//Delete the template at the version, then its instances
+func (r *roleTemplateRepository) Delete(ctx context.Context, id int, version int64) error {
+	result, err := r.coll.DeleteOne(ctx, bson.M{keyID: id, keyVersion: version})
+	if err != nil {
+		return dbError(err)
+	}
+	if result.DeletedCount == 0 {
+		return staleError(ctx, r.coll, "role template", int64(id), version)
+	}
+	_, err = r.instances.DeleteMany(ctx, bson.M{"templateid": id})
+	return dbError(err)
+}
+
+func (r *roleTemplateRepository) List(ctx context.Context) (templates []*models.RoleTemplate, err error) {
+	cursor, err := r.coll.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: keyID, Value: 1}}))
+	if err != nil {
+		return nil, dbError(err)
+	}
+	defer cursor.Close(ctx)
+	err = dbError(cursor.All(ctx, &templates))
+	return
+}
+
+func (r *roleTemplateRepository) SaveInstance(ctx context.Context, instance *models.RoleInstance) error {
+	if instance.CreatedAt.IsZero() {
+		instance.CreatedAt = time.Now()
+	}
+	_, err := r.instances.ReplaceOne(ctx, bson.M{"roleid": instance.RoleID}, instance, options.Replace().SetUpsert(true))
+	return dbError(err)
+}
+
+func (r *roleTemplateRepository) Instances(ctx context.Context, templateID int) (instances []*models.RoleInstance, err error) {
+	cursor, err := r.instances.Find(ctx, bson.M{"templateid": templateID}, options.Find().SetSort(bson.D{{Key: "roleid", Value: 1}}))
+	if err != nil {
+		return nil, dbError(err)
+	}
+	defer cursor.Close(ctx)
+	instances = make([]*models.RoleInstance, 0)
+	err = dbError(cursor.All(ctx, &instances))
+	return
+}
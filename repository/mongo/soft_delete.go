@@ -0,0 +1,79 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+//keys of the deletion of documents
+const (
+	keyIsSoftDel = "modelextension.issoftdel"
+	keyDeletedAt = "modelextension.deletedat"
+)
+
+//notDeleted match documents not deleted, including the ones stored before soft delete
+var notDeleted = bson.M{"$ne": true}
+
+//linkKey of a link collection referring to the purged documents
+type linkKey struct {
+	coll *mongo.Collection
+	key  string
+}
+
+//live hide deleted documents from filter unless ctx shows them
+func live(ctx context.Context, filter bson.D) bson.D {
+	if repository.ShowDeleted(ctx) {
+		return filter
+	}
+	return append(filter, bson.E{Key: keyIsSoftDel, Value: notDeleted})
+}
+
+//markDeleted mark the document with id at the version deleted now, or restore it at any version
+func markDeleted(ctx context.Context, coll *mongo.Collection, kind string, id, version int64, deleted bool) error {
+	filter := bson.M{keyID: id, keyIsSoftDel: notDeleted}
+	var deletedAt time.Time
+	if deleted {
+		deletedAt = time.Now()
+		filter[keyVersion] = version
+	} else {
+		filter[keyIsSoftDel] = true
+	}
+	update := bson.M{"$set": bson.M{keyIsSoftDel: deleted, keyDeletedAt: deletedAt}, "$inc": bson.M{keyVersion: 1}}
+	result, err := coll.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return dbError(err)
+	}
+	if result.MatchedCount == 0 && deleted {
+		return staleError(ctx, coll, kind, id, version)
+	} else if result.MatchedCount == 0 {
+		return errs.NewNotFound("deleted %s %d not found", kind, id)
+	}
+	return nil
+}
+
+//purge delete the documents deleted before the time, links go first so a failed purge is safe to run again
+func purge(ctx context.Context, coll *mongo.Collection, before time.Time, links ...linkKey) (int64, error) {
+	filter := bson.M{keyIsSoftDel: true, keyDeletedAt: bson.M{"$lt": before}}
+	ids, err := coll.Distinct(ctx, keyID, filter)
+	if err != nil {
+		return 0, dbError(err)
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	for _, link := range links {
+		if _, err = link.coll.DeleteMany(ctx, bson.M{link.key: bson.M{"$in": ids}}); err != nil {
+			return 0, dbError(err)
+		}
+	}
+	result, err := coll.DeleteMany(ctx, bson.M{keyID: bson.M{"$in": ids}, keyIsSoftDel: true})
+	if err != nil {
+		return 0, dbError(err)
+	}
+	return result.DeletedCount, nil
+}
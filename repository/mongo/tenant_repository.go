@@ -0,0 +1,96 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+//tenantRepository store tenants in collection tenants, ids are taken from the counters and never reused.
+//A deleted tenant is kept as a hidden document, names are unique among the live ones by the deletion time
+type tenantRepository struct {
+	db   *mongo.Database
+	coll *mongo.Collection
+}
+
+func NewTenantRepository(db *mongo.Database) repository.ITenant {
+	return &tenantRepository{
+		db:   db,
+		coll: db.Collection("tenants"),
+	}
+}
+
+//find the live tenant matched by filter, deleted ones are hidden even to ctx showing deleted entities
+func (r *tenantRepository) find(ctx context.Context, filter bson.M, key interface{}) (*models.Tenant, error) {
+	filter[keyIsSoftDel] = notDeleted
+	var tenant models.Tenant
+	if err := r.coll.FindOne(ctx, filter).Decode(&tenant); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errs.NewNotFound("tenant %v not found", key)
+		}
+		return nil, dbError(err)
+	}
+	return &tenant, nil
+}
+
+func (r *tenantRepository) FindById(ctx context.Context, id int) (*models.Tenant, error) {
+	return r.find(ctx, bson.M{keyID: id}, id)
+}
+
+func (r *tenantRepository) FindByName(ctx context.Context, name string) (*models.Tenant, error) {
+	return r.find(ctx, bson.M{keyName: name}, name)
+}
+
+func (r *tenantRepository) Add(ctx context.Context, tenant *models.Tenant) error {
+	found, err := exists(ctx, r.coll, bson.M{keyName: tenant.Name, keyIsSoftDel: notDeleted})
+	if err != nil {
+		return err
+	}
+	if found {
+		return errs.NewAlreadyExists("tenant %s already exists", tenant.Name)
+	}
+
+	id, err := nextID(ctx, r.db, r.coll.Name())
+	if err != nil {
+		return err
+	}
+	tenant.ID = int(id)
+	if tenant.CreatedAt.IsZero() {
+		tenant.CreatedAt = time.Now()
+	}
+	tenant.Version = 1
+	_, err = r.coll.InsertOne(ctx, tenant)
+	return dbError(err)
+}
+
+func (r *tenantRepository) Update(ctx context.Context, tenant *models.Tenant) error {
+	found, err := exists(ctx, r.coll, bson.M{keyName: tenant.Name, keyID: bson.M{"$ne": tenant.ID}, keyIsSoftDel: notDeleted})
+	if err != nil {
+		return err
+	}
+	if found {
+		return errs.NewAlreadyExists("tenant %s already exists", tenant.Name)
+	}
+	tenant.UpdatedAt = time.Now()
+	return replace(ctx, r.coll, "tenant", int64(tenant.ID), tenant, &tenant.ModelExtension)
+}
+
+func (r *tenantRepository) Delete(ctx context.Context, id int, version int64) error {
+	return markDeleted(ctx, r.coll, "tenant", int64(id), version, true)
+}
+
+func (r *tenantRepository) List(ctx context.Context) (tenants []*models.Tenant, err error) {
+	cursor, err := r.coll.Find(ctx, bson.M{keyIsSoftDel: notDeleted}, options.Find().SetSort(bson.D{{Key: keyID, Value: 1}}))
+	if err != nil {
+		return nil, dbError(err)
+	}
+	defer cursor.Close(ctx)
+	err = dbError(cursor.All(ctx, &tenants))
+	return
+}
@@ -0,0 +1,37 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/micro-community/auth/repository"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+//unitOfWork run operations in a transaction of a session, which needs a replica set or a sharded cluster.
+//The driver retries fn on a transient transaction error, so fn must not have effects out of the database.
+type unitOfWork struct {
+	client *mongo.Client
+}
+
+func NewUnitOfWork(db *mongo.Database) repository.UnitOfWork {
+	return &unitOfWork{client: db.Client()}
+}
+
+func (u *unitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if mongo.SessionFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	var fnErr error
+	err := u.client.UseSession(ctx, func(sc mongo.SessionContext) error {
+		_, err := sc.WithTransaction(sc, func(sc mongo.SessionContext) (interface{}, error) {
+			fnErr = fn(sc)
+			return nil, fnErr
+		})
+		return err
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	return dbError(err)
+}
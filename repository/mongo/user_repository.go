@@ -0,0 +1,106 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+//userRepository store users in collection users, ids are taken from the counters
+type userRepository struct {
+	db   *mongo.Database
+	coll *mongo.Collection
+}
+
+func NewUserRepository(db *mongo.Database) repository.IUser {
+	coll := db.Collection("users")
+	return &userRepository{
+		db:   db,
+		coll: coll,
+	}
+}
+
+func (r *userRepository) FindById(ctx context.Context, id int64) (*models.User, error) {
+	var user models.User
+	if err := r.coll.FindOne(ctx, live(ctx, bson.D{{Key: keyID, Value: id}})).Decode(&user); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errs.NewNotFound("user %d not found", id)
+		}
+		return nil, dbError(err)
+	}
+	return &user, nil
+}
+
+func (r *userRepository) FindByName(ctx context.Context, name string) (*models.User, error) {
+	var user models.User
+	if err := r.coll.FindOne(ctx, live(ctx, bson.D{{Key: keyTenantID, Value: repository.NameTenant(ctx)}, {Key: keyName, Value: name}})).Decode(&user); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errs.NewNotFound("user %s not found", name)
+		}
+		return nil, dbError(err)
+	}
+	return &user, nil
+}
+
+func (r *userRepository) Add(ctx context.Context, user *models.User) error {
+	found, err := exists(ctx, r.coll, bson.M{keyTenantID: user.TenantID, keyName: user.Name})
+	if err != nil {
+		return err
+	}
+	if found {
+		return errs.NewAlreadyExists("user %s already exists", user.Name)
+	}
+
+	id, err := nextID(ctx, r.db, r.coll.Name())
+	if err != nil {
+		return err
+	}
+	user.ID = id
+	if user.CreatedAt.IsZero() {
+		user.CreatedAt = time.Now()
+	}
+	user.Version = 1
+	_, err = r.coll.InsertOne(ctx, user)
+	return dbError(err)
+}
+
+func (r *userRepository) Update(ctx context.Context, user *models.User) error {
+	user.UpdatedAt = time.Now()
+	return replace(ctx, r.coll, "user", user.ID, user, &user.ModelExtension)
+}
+
+//Delete mark the user deleted, its links are kept for a restore
+func (r *userRepository) Delete(ctx context.Context, id, version int64) error {
+	return markDeleted(ctx, r.coll, "user", id, version, true)
+}
+
+func (r *userRepository) Restore(ctx context.Context, id int64) error {
+	return markDeleted(ctx, r.coll, "user", id, 0, false)
+}
+
+//Purge users deleted before the time with their links
+func (r *userRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	return purge(ctx, r.coll, before, linkKey{r.db.Collection("user_roles"), keyUserID})
+}
+
+//List users matched opts and the total count of them
+func (r *userRepository) List(ctx context.Context, opts repository.ListOptions) (users []*models.User, total int64, err error) {
+	opts.Types = nil
+
+	filter := live(ctx, listFilter(opts))
+	if total, err = r.coll.CountDocuments(ctx, filter); err != nil {
+		return nil, 0, dbError(err)
+	}
+	cursor, err := r.coll.Find(ctx, listAfter(filter, opts), listFindOptions(opts))
+	if err != nil {
+		return nil, 0, dbError(err)
+	}
+	defer cursor.Close(ctx)
+	err = dbError(cursor.All(ctx, &users))
+	return
+}
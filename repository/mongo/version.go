@@ -0,0 +1,41 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+//keyVersion of documents
+const keyVersion = "modelextension.version"
+
+//replace the live document with id at the version of ext by item, the version of ext steps to the stored one
+func replace(ctx context.Context, coll *mongo.Collection, kind string, id int64, item interface{}, ext *models.ModelExtension) error {
+	version := ext.Version
+	ext.Version++
+	result, err := coll.ReplaceOne(ctx, bson.M{keyID: id, keyIsSoftDel: notDeleted, keyVersion: version}, item)
+	if err == nil && result.MatchedCount == 1 {
+		return nil
+	}
+	ext.Version = version
+	if err != nil {
+		return dbError(err)
+	}
+	return staleError(ctx, coll, kind, id, version)
+}
+
+//staleError tell why no live document with id is at the version
+func staleError(ctx context.Context, coll *mongo.Collection, kind string, id, version int64) error {
+	found, err := exists(ctx, coll, bson.M{keyID: id, keyIsSoftDel: notDeleted})
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errs.NewNotFound("%s %d not found", kind, id)
+	}
+	return repository.StaleVersion(kind, id, version)
+}
@@ -1,25 +1,159 @@
+//Package repository defines data access of every backend, they follow the same semantics:
+//  - FindById/FindByName return an errs.NotFound error when nothing matched
+//  - Add assign a new id, return an errs.AlreadyExists error for a duplicated name
+//  - Update/Delete return an errs.NotFound error for a missing id
+//  - Link returns an errs.NotFound error for a missing end, an errs.AlreadyExists error for an existing link,
+//    Unlink returns an errs.NotFound error for a missing link
+//  - Delete marks an entity deleted, reads hide it and its links unless the ctx is WithDeleted,
+//    its name is kept until it is purged; Update/Delete of a deleted entity return an errs.NotFound error
+//  - Restore returns an errs.NotFound error when the entity is not deleted,
+//    Purge removes entities deleted before a time with their links for good
+//  - Add sets the version of an entity to 1, every Update/Delete/Restore steps it by one;
+//    Update/Delete with a version other than the stored one return an errs.Conflict error,
+//    a successful Update sets the new version to the entity passed in
 package repository
 
-import "github.com/micro-community/auth/models"
+import (
+	"context"
+	"time"
+
+	"github.com/micro-community/auth/models"
+)
 
 //IUser for user
 type IUser interface {
-	FindById(id int64) (*models.User, error)
-	FindByName(name string) (*models.User, error)
-	Add(user *models.User) error
-	List(page, size int) ([]*models.User, error)
+	FindById(ctx context.Context, id int64) (*models.User, error)
+	FindByName(ctx context.Context, name string) (*models.User, error)
+	Add(ctx context.Context, user *models.User) error
+	Update(ctx context.Context, user *models.User) error
+	//Delete the user of id at the version
+	Delete(ctx context.Context, id, version int64) error
+	//Restore a deleted user
+	Restore(ctx context.Context, id int64) error
+	//Purge users deleted before the time, return the count of them
+	Purge(ctx context.Context, before time.Time) (int64, error)
+	//List users matched opts and the total count of them
+	List(ctx context.Context, opts ListOptions) ([]*models.User, int64, error)
 }
 
 type IRole interface {
-	FindById(id int64) (*models.Role, error)
-	FindByName(name string) (*models.Role, error)
-	Add(user *models.Role) error
-	List(page, size int) ([]*models.Role, error)
+	FindById(ctx context.Context, id int64) (*models.Role, error)
+	FindByName(ctx context.Context, name string) (*models.Role, error)
+	Add(ctx context.Context, role *models.Role) error
+	Update(ctx context.Context, role *models.Role) error
+	//Delete the role of id at the version
+	Delete(ctx context.Context, id, version int64) error
+	//Restore a deleted role
+	Restore(ctx context.Context, id int64) error
+	//Purge roles deleted before the time, return the count of them
+	Purge(ctx context.Context, before time.Time) (int64, error)
+	List(ctx context.Context, opts ListOptions) ([]*models.Role, int64, error)
 }
 
 type IResource interface {
-	FindById(id int64) (*models.Resource, error)
-	FindByName(name string) (*models.Resource, error)
-	Add(user *models.Resource) error
-	List(page, size int) ([]*models.Resource, error)
+	FindById(ctx context.Context, id int64) (*models.Resource, error)
+	FindByName(ctx context.Context, name string) (*models.Resource, error)
+	Add(ctx context.Context, resource *models.Resource) error
+	Update(ctx context.Context, resource *models.Resource) error
+	//Delete the resource of id at the version
+	Delete(ctx context.Context, id, version int64) error
+	//Restore a deleted resource
+	Restore(ctx context.Context, id int64) error
+	//Purge resources deleted before the time, return the count of them
+	Purge(ctx context.Context, before time.Time) (int64, error)
+	//Search resources of a tenant (0 for any tenant) by catalog types (none for any type)
+	Search(ctx context.Context, tenantID int, types ...models.ResourceCatalog) ([]*models.Resource, error)
+	List(ctx context.Context, opts ListOptions) ([]*models.Resource, int64, error)
+}
+
+//ILink for links of users to roles and roles to resources
+type ILink interface {
+	LinkUserRole(ctx context.Context, userID int64, roleID int) error
+	UnlinkUserRole(ctx context.Context, userID int64, roleID int) error
+	LinkRoleResource(ctx context.Context, roleID, resourceID int) error
+	UnlinkRoleResource(ctx context.Context, roleID, resourceID int) error
+	//UserRoles return roles linked to a user in order of id
+	UserRoles(ctx context.Context, userID int64) ([]*models.Role, error)
+	//RoleResources return resources linked to a role in order of id
+	RoleResources(ctx context.Context, roleID int) ([]*models.Resource, error)
+	//UserResources return resources linked to any role of a user in order of id, without duplicates
+	UserResources(ctx context.Context, userID int64) ([]*models.Resource, error)
+}
+
+//ITenant for tenants, it is not scoped to tenants, a deleted tenant is kept hidden even to ctx showing deleted entities.
+//Its id is never reused, its name is free for a new tenant. Ids of tenants start from 1, 0 is the super tenant.
+type ITenant interface {
+	FindById(ctx context.Context, id int) (*models.Tenant, error)
+	FindByName(ctx context.Context, name string) (*models.Tenant, error)
+	Add(ctx context.Context, tenant *models.Tenant) error
+	Update(ctx context.Context, tenant *models.Tenant) error
+	Delete(ctx context.Context, id int, version int64) error
+	//List all tenants in order of id
+	List(ctx context.Context) ([]*models.Tenant, error)
+}
+
+//IRoleTemplate for role templates and the instances of roles of them, it is not scoped to tenants.
+//A deleted template is removed for good with its instances, the roles are kept.
+type IRoleTemplate interface {
+	FindById(ctx context.Context, id int) (*models.RoleTemplate, error)
+	FindByName(ctx context.Context, name string) (*models.RoleTemplate, error)
+	Add(ctx context.Context, template *models.RoleTemplate) error
+	Update(ctx context.Context, template *models.RoleTemplate) error
+	Delete(ctx context.Context, id int, version int64) error
+	//List all templates in order of id
+	List(ctx context.Context) ([]*models.RoleTemplate, error)
+	//SaveInstance add the instance of its role, or replace it
+	SaveInstance(ctx context.Context, instance *models.RoleInstance) error
+	//Instances of a template in order of role id
+	Instances(ctx context.Context, templateID int) ([]*models.RoleInstance, error)
+}
+
+//IOrg for the org trees of tenants: organizations, departments and positions are units of them.
+//A deleted unit is removed for good with the roles granted to it.
+type IOrg interface {
+	FindById(ctx context.Context, id int) (*models.OrgUnit, error)
+	//Add a unit, return an errs.AlreadyExists error for a name of another unit of its parent
+	Add(ctx context.Context, unit *models.OrgUnit) error
+	//Update the name and parent of a unit at its version
+	Update(ctx context.Context, unit *models.OrgUnit) error
+	Delete(ctx context.Context, id int, version int64) error
+	//List all units in order of id
+	List(ctx context.Context) ([]*models.OrgUnit, error)
+	//SetMember add the member of its user, or replace it
+	SetMember(ctx context.Context, member *models.OrgMember) error
+	//RemoveMember return an errs.NotFound error when the user is not a member
+	RemoveMember(ctx context.Context, userID int64) error
+	//MemberOf return the member of a user, an errs.NotFound error when the user is not a member
+	MemberOf(ctx context.Context, userID int64) (*models.OrgMember, error)
+	//Members in the department or of the position of a unit in order of user id
+	Members(ctx context.Context, unitID int) ([]*models.OrgMember, error)
+	//Grant a role to a unit, return an errs.AlreadyExists error for a role granted already
+	Grant(ctx context.Context, unitID, roleID int) error
+	//Revoke a role from a unit, return an errs.NotFound error for a role not granted
+	Revoke(ctx context.Context, unitID, roleID int) error
+	//Grants return ids of the roles granted to a unit in order
+	Grants(ctx context.Context, unitID int) ([]int, error)
+}
+
+//UnitOfWork run operations of repositories atomically
+type UnitOfWork interface {
+	//Do run fn in a transaction, operations of repositories called with the ctx passed to fn join it.
+	//It commits when fn returns nil and rolls back otherwise, a Do in fn joins the outer transaction.
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+//ReadOnlyWork is a UnitOfWork of a backend whose writes wait for its transactions,
+//long reads run in a read-only one so they do not hold the writes
+type ReadOnlyWork interface {
+	UnitOfWork
+	//View run fn in a read-only transaction, reads of repositories called with the ctx passed to fn see one snapshot
+	View(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+//ILog keep logs of mutations, logs are only appended and never changed
+type ILog interface {
+	//Append a log, its id is greater than the ids of logs appended before
+	Append(ctx context.Context, log *models.Log) error
+	//Query logs matched opts in order of id from the newest
+	Query(ctx context.Context, opts LogQuery) ([]*models.Log, error)
 }
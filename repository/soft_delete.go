@@ -0,0 +1,16 @@
+package repository
+
+import "context"
+
+type withDeletedKey struct{}
+
+//WithDeleted return a ctx whose reads also see deleted entities, e.g. to find one to restore
+func WithDeleted(ctx context.Context) context.Context {
+	return context.WithValue(ctx, withDeletedKey{}, true)
+}
+
+//ShowDeleted report whether reads with ctx see deleted entities
+func ShowDeleted(ctx context.Context) bool {
+	show, _ := ctx.Value(withDeletedKey{}).(bool)
+	return show
+}
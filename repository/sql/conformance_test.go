@@ -0,0 +1,73 @@
+package sql
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/micro-community/auth/db/migration"
+	"github.com/micro-community/auth/repository"
+	"github.com/micro-community/auth/repository/conformance"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+//newSQLite open a migrated sqlite database in a temporary directory of the test
+func newSQLite(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "auth.db")+"?_busy_timeout=5000&_txlock=immediate"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = migration.Up(context.Background(), migration.NewSQL(db), 0); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestUserRepository(t *testing.T) {
+	conformance.RunUser(t, func(t *testing.T) repository.IUser { return NewUserRepository(newSQLite(t)) })
+}
+
+func TestRoleRepository(t *testing.T) {
+	conformance.RunRole(t, func(t *testing.T) repository.IRole { return NewRoleRepository(newSQLite(t)) })
+}
+
+func TestResourceRepository(t *testing.T) {
+	conformance.RunResource(t, func(t *testing.T) repository.IResource { return NewResourceRepository(newSQLite(t)) })
+}
+
+func newRepositories(t *testing.T) conformance.Repositories {
+	db := newSQLite(t)
+	return conformance.Repositories{
+		Users:     NewUserRepository(db),
+		Roles:     NewRoleRepository(db),
+		Resources: NewResourceRepository(db),
+		Links:     NewLinkRepository(db),
+		Work:      NewUnitOfWork(db),
+	}
+}
+
+func TestLinkRepository(t *testing.T) {
+	conformance.RunLink(t, newRepositories)
+}
+
+func TestUnitOfWork(t *testing.T) {
+	conformance.RunUnitOfWork(t, newRepositories, true)
+}
+
+func TestTenantRepository(t *testing.T) {
+	conformance.RunTenant(t, func(t *testing.T) repository.ITenant { return NewTenantRepository(newSQLite(t)) })
+}
+
+func TestRoleTemplateRepository(t *testing.T) {
+	conformance.RunRoleTemplate(t, func(t *testing.T) repository.IRoleTemplate { return NewRoleTemplateRepository(newSQLite(t)) })
+}
+
+func TestOrgRepository(t *testing.T) {
+	conformance.RunOrg(t, func(t *testing.T) repository.IOrg { return NewOrgRepository(newSQLite(t)) })
+}
+
+func TestLogRepository(t *testing.T) {
+	conformance.RunLog(t, func(t *testing.T) repository.ILog { return NewLogRepository(newSQLite(t)) })
+}
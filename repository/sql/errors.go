@@ -0,0 +1,37 @@
+package sql
+
+import (
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/mattn/go-sqlite3"
+	"github.com/micro-community/auth/errs"
+	"gorm.io/gorm"
+)
+
+//dbError translate errors of gorm to domain errors
+func dbError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return errs.Wrap(err, errs.NotFound, "not found")
+	case isDuplicateKey(err):
+		return errs.Wrap(err, errs.AlreadyExists, "already exists")
+	default:
+		return errs.NewUnavailable(err, "database error")
+	}
+}
+
+//isDuplicateKey check the unique index violation of mysql and sqlite
+func isDuplicateKey(err error) bool {
+	var myErr *mysql.MySQLError
+	if errors.As(err, &myErr) {
+		return myErr.Number == 1062
+	}
+	var liteErr sqlite3.Error
+	if errors.As(err, &liteErr) {
+		return liteErr.ExtendedCode == sqlite3.ErrConstraintUnique || liteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	"gorm.io/gorm"
+)
+
+//linkRepository store links in tables user_roles and role_resources
+type linkRepository struct {
+	db *gorm.DB
+}
+
+func NewLinkRepository(db *gorm.DB) repository.ILink {
+	return &linkRepository{db: db}
+}
+
+//mustExist return an errs.NotFound error when the row of model with id does not exist,
+//a deleted row only exists when showDeleted is set
+func mustExist(tx *gorm.DB, model interface{}, kind string, id int64, showDeleted bool) error {
+	query := tx.Model(model).Where("id = ?", id)
+	if !showDeleted {
+		query = query.Where("is_soft_del = ?", false)
+	}
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return dbError(err)
+	}
+	if count == 0 {
+		return errs.NewNotFound("%s %d not found", kind, id)
+	}
+	return nil
+}
+
+//link insert the link after both ends are checked in a transaction
+func (r *linkRepository) link(ctx context.Context, link interface{}, check func(tx *gorm.DB) error) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := check(tx); err != nil {
+			return err
+		}
+		return dbError(tx.Create(link).Error)
+	})
+}
+
+//unlink delete the link matched query
+func (r *linkRepository) unlink(ctx context.Context, model interface{}, query string, args ...interface{}) error {
+	result := conn(ctx, r.db).Where(query, args...).Delete(model)
+	if result.Error != nil {
+		return dbError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errs.NewNotFound("link not found")
+	}
+	return nil
+}
+
+func (r *linkRepository) LinkUserRole(ctx context.Context, userID int64, roleID int) error {
+	link := &models.UserRole{UserID: userID, RoleID: roleID, CreatedAt: time.Now()}
+	return r.link(ctx, link, func(tx *gorm.DB) error {
+		if err := mustExist(tx, &models.User{}, "user", userID, false); err != nil {
+			return err
+		}
+		return mustExist(tx, &models.Role{}, "role", int64(roleID), false)
+	})
+}
+
+func (r *linkRepository) UnlinkUserRole(ctx context.Context, userID int64, roleID int) error {
+	return r.unlink(ctx, &models.UserRole{}, "user_id = ? AND role_id = ?", userID, roleID)
+}
+
+func (r *linkRepository) LinkRoleResource(ctx context.Context, roleID, resourceID int) error {
+	link := &models.RoleResource{RoleID: roleID, ResourceID: resourceID, CreatedAt: time.Now()}
+	return r.link(ctx, link, func(tx *gorm.DB) error {
+		if err := mustExist(tx, &models.Role{}, "role", int64(roleID), false); err != nil {
+			return err
+		}
+		return mustExist(tx, &models.Resource{}, "resource", int64(resourceID), false)
+	})
+}
+
+func (r *linkRepository) UnlinkRoleResource(ctx context.Context, roleID, resourceID int) error {
+	return r.unlink(ctx, &models.RoleResource{}, "role_id = ? AND resource_id = ?", roleID, resourceID)
+}
+
+func (r *linkRepository) UserRoles(ctx context.Context, userID int64) (roles []*models.Role, err error) {
+	tx := conn(ctx, r.db)
+	if err = mustExist(tx, &models.User{}, "user", userID, repository.ShowDeleted(ctx)); err != nil {
+		return nil, err
+	}
+	err = dbError(tx.Model(&models.Role{}).
+		Joins("JOIN user_roles ON user_roles.role_id = roles.id").
+		Where("user_roles.user_id = ?", userID).Scopes(live(ctx, "roles")).
+		Order("roles.id").Find(&roles).Error)
+	return
+}
+
+func (r *linkRepository) RoleResources(ctx context.Context, roleID int) (resources []*models.Resource, err error) {
+	tx := conn(ctx, r.db)
+	if err = mustExist(tx, &models.Role{}, "role", int64(roleID), repository.ShowDeleted(ctx)); err != nil {
+		return nil, err
+	}
+	err = dbError(tx.Model(&models.Resource{}).
+		Joins("JOIN role_resources ON role_resources.resource_id = resources.id").
+		Where("role_resources.role_id = ?", roleID).Scopes(live(ctx, "resources")).
+		Order("resources.id").Find(&resources).Error)
+	return
+}
+
+func (r *linkRepository) UserResources(ctx context.Context, userID int64) (resources []*models.Resource, err error) {
+	tx := conn(ctx, r.db)
+	if err = mustExist(tx, &models.User{}, "user", userID, repository.ShowDeleted(ctx)); err != nil {
+		return nil, err
+	}
+	err = dbError(tx.Model(&models.Resource{}).
+		Where("resources.id IN (?)", tx.Model(&models.RoleResource{}).
+			Select("role_resources.resource_id").
+			Joins("JOIN user_roles ON user_roles.role_id = role_resources.role_id").
+			Joins("JOIN roles ON roles.id = role_resources.role_id").
+			Where("user_roles.user_id = ?", userID).Scopes(live(ctx, "roles"))).
+		Scopes(live(ctx, "resources")).
+		Order("resources.id").Find(&resources).Error)
+	return
+}
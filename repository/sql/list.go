@@ -0,0 +1,82 @@
+package sql
+
+import (
+	"math"
+	"strings"
+
+	"github.com/micro-community/auth/repository"
+	"gorm.io/gorm"
+)
+
+//likeEscaper escape the wildcards of LIKE with '!', which is the same in mysql and sqlite
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+//filterScope translate the filters of opts to where clauses,
+//callers must clear the filters their entity does not have
+func filterScope(opts repository.ListOptions) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if opts.NamePrefix != "" {
+			db = db.Where("LOWER(name) LIKE ? ESCAPE '!'", likeEscaper.Replace(strings.ToLower(opts.NamePrefix))+"%")
+		}
+		if opts.TenantID != 0 {
+			db = db.Where("tenant_id = ?", opts.TenantID)
+		}
+		if opts.Status != 0 {
+			db = db.Where("stated = ?", opts.Status)
+		}
+		if len(opts.Types) > 0 {
+			db = db.Where("type IN ?", opts.Types)
+		}
+		if !opts.CreatedAfter.IsZero() {
+			db = db.Where("created_at >= ?", opts.CreatedAfter)
+		}
+		if !opts.CreatedBefore.IsZero() {
+			db = db.Where("created_at < ?", opts.CreatedBefore)
+		}
+		return db
+	}
+}
+
+//pageScope translate the sort order, the key after, offset and limit of opts
+func pageScope(opts repository.ListOptions) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		direction, after := " ASC", " > ?"
+		if opts.Desc {
+			direction, after = " DESC", " < ?"
+		}
+		//names are ordered by bytes as other backends, mysql collations ignore case
+		name := "name"
+		if db.Dialector.Name() == "mysql" {
+			name = "CAST(name AS BINARY)"
+		}
+
+		switch opts.SortBy {
+		case repository.SortByName:
+			if opts.After != nil {
+				db = db.Where("("+name+after+" OR ("+name+" = ? AND id"+after+"))", opts.After.Name, opts.After.Name, opts.After.ID)
+			}
+			db = db.Order(name + direction)
+		case repository.SortByCreatedAt:
+			if opts.After != nil {
+				db = db.Where("(created_at"+after+" OR (created_at = ? AND id"+after+"))", opts.After.Created, opts.After.Created, opts.After.ID)
+			}
+			db = db.Order("created_at" + direction)
+		default:
+			if opts.After != nil {
+				db = db.Where("id"+after, opts.After.ID)
+			}
+		}
+		db = db.Order("id" + direction)
+
+		if opts.Offset > 0 {
+			db = db.Offset(opts.Offset)
+		}
+		if opts.Limit > 0 {
+			db = db.Limit(opts.Limit)
+		} else if opts.Offset > 0 {
+			//offset without limit is a syntax error of mysql and sqlite
+			db = db.Limit(math.MaxInt32)
+		}
+		return db
+	}
+}
@@ -0,0 +1,61 @@
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	"gorm.io/gorm"
+)
+
+//logRepository append logs in table logs, in the transaction of the unit of work if any
+type logRepository struct {
+	db *gorm.DB
+}
+
+func NewLogRepository(db *gorm.DB) repository.ILog {
+	return &logRepository{db: db}
+}
+
+func (r *logRepository) Append(ctx context.Context, log *models.Log) error {
+	log.ID = 0
+	if log.Time.IsZero() {
+		log.Time = time.Now()
+	}
+	return dbError(conn(ctx, r.db).Create(log).Error)
+}
+
+func (r *logRepository) Query(ctx context.Context, opts repository.LogQuery) ([]*models.Log, error) {
+	table := conn(ctx, r.db).Model(&models.Log{})
+	if opts.Actor != "" {
+		table = table.Where("actor = ?", opts.Actor)
+	}
+	if opts.TenantID != 0 {
+		table = table.Where("tenant_id = ?", opts.TenantID)
+	}
+	if len(opts.Kinds) > 0 {
+		table = table.Where("kind IN ?", opts.Kinds)
+	}
+	if opts.EntityID != "" {
+		table = table.Where("entity_id = ?", opts.EntityID)
+	}
+	if !opts.Since.IsZero() {
+		table = table.Where("time >= ?", opts.Since)
+	}
+	if !opts.Until.IsZero() {
+		table = table.Where("time < ?", opts.Until)
+	}
+	if opts.BeforeID > 0 {
+		table = table.Where("id < ?", opts.BeforeID)
+	}
+	if opts.Limit > 0 {
+		table = table.Limit(opts.Limit)
+	}
+
+	logs := []*models.Log{}
+	if err := table.Order("id DESC").Find(&logs).Error; err != nil {
+		return nil, dbError(err)
+	}
+	return logs, nil
+}
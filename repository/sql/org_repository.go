@@ -0,0 +1,151 @@
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//orgRepository store org units, their members and grants by gorm, in mysql or sqlite
+type orgRepository struct {
+	db *gorm.DB
+}
+
+func NewOrgRepository(db *gorm.DB) repository.IOrg {
+	return &orgRepository{db: db}
+}
+
+func (r *orgRepository) table(ctx context.Context) *gorm.DB {
+	return conn(ctx, r.db).Model(&models.OrgUnit{})
+}
+
+//nameTaken return an errs.AlreadyExists error when another unit of the parent of unit in its tenant has its name
+func (r *orgRepository) nameTaken(ctx context.Context, unit *models.OrgUnit) error {
+	var count int64
+	err := r.table(ctx).Where("name = ? AND parent_id = ? AND tenant_id = ? AND id <> ?", unit.Name, unit.ParentID, unit.TenantID, unit.ID).
+		Count(&count).Error
+	if err != nil {
+		return dbError(err)
+	}
+	if count > 0 {
+		return errs.NewAlreadyExists("org unit %s already exists", unit.Name)
+	}
+	return nil
+}
+
+func (r *orgRepository) FindById(ctx context.Context, id int) (*models.OrgUnit, error) {
+	var unit models.OrgUnit
+	if err := r.table(ctx).Where("id = ?", id).First(&unit).Error; err != nil {
+		return nil, dbError(err)
+	}
+	return &unit, nil
+}
+
+func (r *orgRepository) Add(ctx context.Context, unit *models.OrgUnit) error {
+	unit.ID = 0
+	if err := r.nameTaken(ctx, unit); err != nil {
+		return err
+	}
+
+	unit.Version = 1
+	return dbError(conn(ctx, r.db).Create(unit).Error)
+}
+
+func (r *orgRepository) Update(ctx context.Context, unit *models.OrgUnit) error {
+	if err := r.nameTaken(ctx, unit); err != nil {
+		return err
+	}
+	return update(ctx, r.db, &models.OrgUnit{}, "org unit", int64(unit.ID), unit, &unit.ModelExtension)
+}
+
+//Delete the unit at the version with its grants in a transaction
+func (r *orgRepository) Delete(ctx context.Context, id int, version int64) error {
+	var stale error
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ? AND version = ?", id, version).Delete(&models.OrgUnit{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			stale = staleError(ctx, tx, &models.OrgUnit{}, "org unit", int64(id), version)
+			return stale
+		}
+		return tx.Where("unit_id = ?", id).Delete(&models.OrgGrant{}).Error
+	})
+	if stale != nil {
+		return stale
+	}
+	return dbError(err)
+}
+
+func (r *orgRepository) List(ctx context.Context) (units []*models.OrgUnit, err error) {
+	err = dbError(r.table(ctx).Order("id").Find(&units).Error)
+	return
+}
+
+func (r *orgRepository) SetMember(ctx context.Context, member *models.OrgMember) error {
+	upsert := clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"dept_id", "position_id", "tenant_id"}),
+	}
+	return dbError(conn(ctx, r.db).Clauses(upsert).Create(member).Error)
+}
+
+func (r *orgRepository) RemoveMember(ctx context.Context, userID int64) error {
+	result := conn(ctx, r.db).Where("user_id = ?", userID).Delete(&models.OrgMember{})
+	if result.Error != nil {
+		return dbError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errs.NewNotFound("org member %d not found", userID)
+	}
+	return nil
+}
+
+func (r *orgRepository) MemberOf(ctx context.Context, userID int64) (*models.OrgMember, error) {
+	var member models.OrgMember
+	if err := conn(ctx, r.db).Where("user_id = ?", userID).First(&member).Error; err != nil {
+		return nil, dbError(err)
+	}
+	return &member, nil
+}
+
+func (r *orgRepository) Members(ctx context.Context, unitID int) (members []*models.OrgMember, err error) {
+	err = dbError(conn(ctx, r.db).Where("dept_id = ? OR position_id = ?", unitID, unitID).Order("user_id").Find(&members).Error)
+	return
+}
+
+func (r *orgRepository) Grant(ctx context.Context, unitID, roleID int) error {
+	var count int64
+	if err := conn(ctx, r.db).Model(&models.OrgGrant{}).Where("unit_id = ? AND role_id = ?", unitID, roleID).Count(&count).Error; err != nil {
+		return dbError(err)
+	}
+	if count > 0 {
+		return errs.NewAlreadyExists("role %d is granted to org unit %d already", roleID, unitID)
+	}
+	return dbError(conn(ctx, r.db).Create(&models.OrgGrant{UnitID: unitID, RoleID: roleID, CreatedAt: time.Now()}).Error)
+}
+
+func (r *orgRepository) Revoke(ctx context.Context, unitID, roleID int) error {
+	result := conn(ctx, r.db).Where("unit_id = ? AND role_id = ?", unitID, roleID).Delete(&models.OrgGrant{})
+	if result.Error != nil {
+		return dbError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errs.NewNotFound("role %d is not granted to org unit %d", roleID, unitID)
+	}
+	return nil
+}
+
+func (r *orgRepository) Grants(ctx context.Context, unitID int) (roleIDs []int, err error) {
+	err = dbError(conn(ctx, r.db).Model(&models.OrgGrant{}).Where("unit_id = ?", unitID).Order("role_id").Pluck("role_id", &roleIDs).Error)
+	if roleIDs == nil {
+		roleIDs = []int{}
+	}
+	return
+}
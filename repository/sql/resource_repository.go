@@ -0,0 +1,96 @@
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	"gorm.io/gorm"
+)
+
+//resourceRepository store resources by gorm, in mysql or sqlite
+type resourceRepository struct {
+	db *gorm.DB
+}
+
+func NewResourceRepository(db *gorm.DB) repository.IResource {
+	return &resourceRepository{db: db}
+}
+
+//table of resources, deleted ones are hidden unless ctx shows them
+func (r *resourceRepository) table(ctx context.Context) *gorm.DB {
+	return conn(ctx, r.db).Model(&models.Resource{}).Scopes(live(ctx, "resources"))
+}
+
+func (r *resourceRepository) FindById(ctx context.Context, id int64) (*models.Resource, error) {
+	var resource models.Resource
+	if err := r.table(ctx).Where("id = ?", id).First(&resource).Error; err != nil {
+		return nil, dbError(err)
+	}
+	return &resource, nil
+}
+
+func (r *resourceRepository) FindByName(ctx context.Context, name string) (*models.Resource, error) {
+	var resource models.Resource
+	if err := r.table(ctx).Where("name = ? AND tenant_id = ?", name, repository.NameTenant(ctx)).First(&resource).Error; err != nil {
+		return nil, dbError(err)
+	}
+	return &resource, nil
+}
+
+func (r *resourceRepository) Add(ctx context.Context, resource *models.Resource) error {
+	var count int64
+	if err := conn(ctx, r.db).Model(&models.Resource{}).Where("name = ? AND tenant_id = ?", resource.Name, resource.TenantID).Count(&count).Error; err != nil {
+		return dbError(err)
+	}
+	if count > 0 {
+		return errs.NewAlreadyExists("resource %s already exists", resource.Name)
+	}
+
+	resource.ID, resource.Version = 0, 1
+	return dbError(conn(ctx, r.db).Create(resource).Error)
+}
+
+func (r *resourceRepository) Update(ctx context.Context, resource *models.Resource) error {
+	return update(ctx, r.db, &models.Resource{}, "resource", int64(resource.ID), resource, &resource.ModelExtension)
+}
+
+//Delete mark the resource deleted, its links are kept for a restore
+func (r *resourceRepository) Delete(ctx context.Context, id, version int64) error {
+	return markDeleted(ctx, r.db, &models.Resource{}, "resource", id, version, true)
+}
+
+func (r *resourceRepository) Restore(ctx context.Context, id int64) error {
+	return markDeleted(ctx, r.db, &models.Resource{}, "resource", id, 0, false)
+}
+
+//Purge resources deleted before the time with their links
+func (r *resourceRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	return purge(ctx, r.db, &models.Resource{}, before, linkColumn{&models.RoleResource{}, "resource_id"})
+}
+
+//Search resources of a tenant (0 for any tenant) by catalog types (none for any type)
+func (r *resourceRepository) Search(ctx context.Context, tenantID int, types ...models.ResourceCatalog) (resources []*models.Resource, err error) {
+	table := r.table(ctx)
+	if tenantID != 0 {
+		table = table.Where("tenant_id = ?", tenantID)
+	}
+	if len(types) > 0 {
+		table = table.Where("type IN ?", types)
+	}
+	err = dbError(table.Order("id").Find(&resources).Error)
+	return
+}
+
+//List resources matched opts
+func (r *resourceRepository) List(ctx context.Context, opts repository.ListOptions) (resources []*models.Resource, total int64, err error) {
+	opts.Status = 0
+
+	if err = r.table(ctx).Scopes(filterScope(opts)).Count(&total).Error; err != nil {
+		return nil, 0, dbError(err)
+	}
+	err = dbError(r.table(ctx).Scopes(filterScope(opts), pageScope(opts)).Find(&resources).Error)
+	return
+}
@@ -0,0 +1,97 @@
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	"gorm.io/gorm"
+)
+
+//roleRepository store roles by gorm, in mysql or sqlite
+type roleRepository struct {
+	db *gorm.DB
+}
+
+func NewRoleRepository(db *gorm.DB) repository.IRole {
+	return &roleRepository{db: db}
+}
+
+//table of roles, deleted ones are hidden unless ctx shows them
+func (r *roleRepository) table(ctx context.Context) *gorm.DB {
+	return conn(ctx, r.db).Model(&models.Role{}).Scopes(live(ctx, "roles"))
+}
+
+func (r *roleRepository) FindById(ctx context.Context, id int64) (*models.Role, error) {
+	var role models.Role
+	if err := r.table(ctx).Where("id = ?", id).First(&role).Error; err != nil {
+		return nil, dbError(err)
+	}
+	return &role, nil
+}
+
+func (r *roleRepository) FindByName(ctx context.Context, name string) (*models.Role, error) {
+	var role models.Role
+	if err := r.table(ctx).Where("name = ? AND tenant_id = ?", name, repository.NameTenant(ctx)).First(&role).Error; err != nil {
+		return nil, dbError(err)
+	}
+	return &role, nil
+}
+
+func (r *roleRepository) Add(ctx context.Context, role *models.Role) error {
+	var count int64
+	if err := conn(ctx, r.db).Model(&models.Role{}).Where("name = ? AND tenant_id = ?", role.Name, role.TenantID).Count(&count).Error; err != nil {
+		return dbError(err)
+	}
+	if count > 0 {
+		return errs.NewAlreadyExists("role %s already exists", role.Name)
+	}
+
+	role.ID, role.Version = 0, 1
+	return dbError(conn(ctx, r.db).Create(role).Error)
+}
+
+//Update role, the key of a role can not be modified
+func (r *roleRepository) Update(ctx context.Context, role *models.Role) error {
+	target, err := r.FindById(ctx, int64(role.ID))
+	if err != nil {
+		return err
+	}
+	if target.IsSoftDel {
+		return errs.NewNotFound("role %d not found", role.ID)
+	}
+	if target.Version != role.Version {
+		return repository.StaleVersion("role", int64(role.ID), role.Version)
+	}
+	if role.Key != "" && target.Key != role.Key {
+		return errs.NewConflict("role key modify forbidden")
+	}
+	return update(ctx, r.db, &models.Role{}, "role", int64(role.ID), role, &role.ModelExtension)
+}
+
+//Delete mark the role deleted, its links are kept for a restore
+func (r *roleRepository) Delete(ctx context.Context, id, version int64) error {
+	return markDeleted(ctx, r.db, &models.Role{}, "role", id, version, true)
+}
+
+func (r *roleRepository) Restore(ctx context.Context, id int64) error {
+	return markDeleted(ctx, r.db, &models.Role{}, "role", id, 0, false)
+}
+
+//Purge roles deleted before the time with their links
+func (r *roleRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	return purge(ctx, r.db, &models.Role{}, before, linkColumn{&models.UserRole{}, "role_id"}, linkColumn{&models.RoleResource{}, "role_id"})
+}
+
+//List roles matched opts
+func (r *roleRepository) List(ctx context.Context, opts repository.ListOptions) (roles []*models.Role, total int64, err error) {
+	opts.Status, opts.Types = 0, nil
+
+	if err = r.table(ctx).Scopes(filterScope(opts)).Count(&total).Error; err != nil {
+		return nil, 0, dbError(err)
+	}
+	err = dbError(r.table(ctx).Scopes(filterScope(opts), pageScope(opts)).Find(&roles).Error)
+	return
+}
@@ -0,0 +1,102 @@
+package sql
+
+import (
+	"context"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//roleTemplateRepository store role templates and their instances by gorm, in mysql or sqlite
+type roleTemplateRepository struct {
+	db *gorm.DB
+}
+
+func NewRoleTemplateRepository(db *gorm.DB) repository.IRoleTemplate {
+	return &roleTemplateRepository{db: db}
+}
+
+func (r *roleTemplateRepository) table(ctx context.Context) *gorm.DB {
+	return conn(ctx, r.db).Model(&models.RoleTemplate{})
+}
+
+func (r *roleTemplateRepository) FindById(ctx context.Context, id int) (*models.RoleTemplate, error) {
+	var template models.RoleTemplate
+	if err := r.table(ctx).Where("id = ?", id).First(&template).Error; err != nil {
+		return nil, dbError(err)
+	}
+	return &template, nil
+}
+
+func (r *roleTemplateRepository) FindByName(ctx context.Context, name string) (*models.RoleTemplate, error) {
+	var template models.RoleTemplate
+	if err := r.table(ctx).Where("name = ?", name).First(&template).Error; err != nil {
+		return nil, dbError(err)
+	}
+	return &template, nil
+}
+
+func (r *roleTemplateRepository) Add(ctx context.Context, template *models.RoleTemplate) error {
+	var count int64
+	if err := r.table(ctx).Where("name = ?", template.Name).Count(&count).Error; err != nil {
+		return dbError(err)
+	}
+	if count > 0 {
+		return errs.NewAlreadyExists("role template %s already exists", template.Name)
+	}
+
+	template.ID, template.Version = 0, 1
+	return dbError(conn(ctx, r.db).Create(template).Error)
+}
+
+func (r *roleTemplateRepository) Update(ctx context.Context, template *models.RoleTemplate) error {
+	var count int64
+	if err := r.table(ctx).Where("name = ? AND id <> ?", template.Name, template.ID).Count(&count).Error; err != nil {
+		return dbError(err)
+	}
+	if count > 0 {
+		return errs.NewAlreadyExists("role template %s already exists", template.Name)
+	}
+	return update(ctx, r.db, &models.RoleTemplate{}, "role template", int64(template.ID), template, &template.ModelExtension)
+}
+
+//Delete the template at the version with its instances in a transaction
+func (r *roleTemplateRepository) Delete(ctx context.Context, id int, version int64) error {
+	var stale error
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ? AND version = ?", id, version).Delete(&models.RoleTemplate{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			stale = staleError(ctx, tx, &models.RoleTemplate{}, "role template", int64(id), version)
+			return stale
+		}
+		return tx.Where("template_id = ?", id).Delete(&models.RoleInstance{}).Error
+	})
+	if stale != nil {
+		return stale
+	}
+	return dbError(err)
+}
+
+func (r *roleTemplateRepository) List(ctx context.Context) (templates []*models.RoleTemplate, err error) {
+	err = dbError(r.table(ctx).Order("id").Find(&templates).Error)
+	return
+}
+
+func (r *roleTemplateRepository) SaveInstance(ctx context.Context, instance *models.RoleInstance) error {
+	upsert := clause.OnConflict{
+		Columns:   []clause.Column{{Name: "role_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"template_id", "template_version", "tenant_id", "scope_id"}),
+	}
+	return dbError(conn(ctx, r.db).Clauses(upsert).Create(instance).Error)
+}
+
+func (r *roleTemplateRepository) Instances(ctx context.Context, templateID int) (instances []*models.RoleInstance, err error) {
+	err = dbError(conn(ctx, r.db).Where("template_id = ?", templateID).Order("role_id").Find(&instances).Error)
+	return
+}
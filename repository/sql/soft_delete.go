@@ -0,0 +1,68 @@
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/repository"
+	"gorm.io/gorm"
+)
+
+//linkColumn of a link table referring to the purged entities
+type linkColumn struct {
+	model  interface{}
+	column string
+}
+
+//live hide deleted rows of table unless ctx shows them
+func live(ctx context.Context, table string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if repository.ShowDeleted(ctx) {
+			return db
+		}
+		return db.Where(table+".is_soft_del = ?", false)
+	}
+}
+
+//markDeleted mark the row of model with id at the version deleted now, or restore it at any version
+func markDeleted(ctx context.Context, db *gorm.DB, model interface{}, kind string, id, version int64, deleted bool) error {
+	var deletedAt time.Time
+	tx := conn(ctx, db).Model(model).Where("id = ? AND is_soft_del = ?", id, !deleted)
+	if deleted {
+		deletedAt = time.Now()
+		tx = tx.Where("version = ?", version)
+	}
+	result := tx.Updates(map[string]interface{}{"is_soft_del": deleted, "deleted_at": deletedAt, "version": gorm.Expr("version + 1")})
+	if result.Error != nil {
+		return dbError(result.Error)
+	}
+	if result.RowsAffected == 0 && deleted {
+		return staleError(ctx, db, model, kind, id, version)
+	} else if result.RowsAffected == 0 {
+		return errs.NewNotFound("deleted %s %d not found", kind, id)
+	}
+	return nil
+}
+
+//purge delete the rows of model deleted before the time with their links
+func purge(ctx context.Context, db *gorm.DB, model interface{}, before time.Time, links ...linkColumn) (purged int64, err error) {
+	err = conn(ctx, db).Transaction(func(tx *gorm.DB) error {
+		var ids []int64
+		if err := tx.Model(model).Where("is_soft_del = ? AND deleted_at < ?", true, before).Pluck("id", &ids).Error; err != nil {
+			return dbError(err)
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		for _, link := range links {
+			if err := tx.Where(link.column+" IN ?", ids).Delete(link.model).Error; err != nil {
+				return dbError(err)
+			}
+		}
+		result := tx.Where("id IN ?", ids).Delete(model)
+		purged = result.RowsAffected
+		return dbError(result.Error)
+	})
+	return
+}
@@ -0,0 +1,74 @@
+package sql
+
+import (
+	"context"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	"gorm.io/gorm"
+)
+
+//tenantRepository store tenants by gorm, in mysql or sqlite.
+//A deleted tenant is kept as a hidden row, names are unique among the live ones by the deletion time
+type tenantRepository struct {
+	db *gorm.DB
+}
+
+func NewTenantRepository(db *gorm.DB) repository.ITenant {
+	return &tenantRepository{db: db}
+}
+
+//table of the live tenants, deleted ones are hidden even to ctx showing deleted entities
+func (r *tenantRepository) table(ctx context.Context) *gorm.DB {
+	return conn(ctx, r.db).Model(&models.Tenant{}).Where("is_soft_del = ?", false)
+}
+
+func (r *tenantRepository) FindById(ctx context.Context, id int) (*models.Tenant, error) {
+	var tenant models.Tenant
+	if err := r.table(ctx).Where("id = ?", id).First(&tenant).Error; err != nil {
+		return nil, dbError(err)
+	}
+	return &tenant, nil
+}
+
+func (r *tenantRepository) FindByName(ctx context.Context, name string) (*models.Tenant, error) {
+	var tenant models.Tenant
+	if err := r.table(ctx).Where("name = ?", name).First(&tenant).Error; err != nil {
+		return nil, dbError(err)
+	}
+	return &tenant, nil
+}
+
+func (r *tenantRepository) Add(ctx context.Context, tenant *models.Tenant) error {
+	var count int64
+	if err := r.table(ctx).Where("name = ?", tenant.Name).Count(&count).Error; err != nil {
+		return dbError(err)
+	}
+	if count > 0 {
+		return errs.NewAlreadyExists("tenant %s already exists", tenant.Name)
+	}
+
+	tenant.ID, tenant.Version = 0, 1
+	return dbError(conn(ctx, r.db).Create(tenant).Error)
+}
+
+func (r *tenantRepository) Update(ctx context.Context, tenant *models.Tenant) error {
+	var count int64
+	if err := r.table(ctx).Where("name = ? AND id <> ?", tenant.Name, tenant.ID).Count(&count).Error; err != nil {
+		return dbError(err)
+	}
+	if count > 0 {
+		return errs.NewAlreadyExists("tenant %s already exists", tenant.Name)
+	}
+	return update(ctx, r.db, &models.Tenant{}, "tenant", int64(tenant.ID), tenant, &tenant.ModelExtension)
+}
+
+func (r *tenantRepository) Delete(ctx context.Context, id int, version int64) error {
+	return markDeleted(ctx, r.db, &models.Tenant{}, "tenant", int64(id), version, true)
+}
+
+func (r *tenantRepository) List(ctx context.Context) (tenants []*models.Tenant, err error) {
+	err = dbError(r.table(ctx).Order("id").Find(&tenants).Error)
+	return
+}
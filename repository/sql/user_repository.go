@@ -0,0 +1,83 @@
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	"gorm.io/gorm"
+)
+
+//userRepository store users by gorm, in mysql or sqlite
+type userRepository struct {
+	db *gorm.DB
+}
+
+func NewUserRepository(db *gorm.DB) repository.IUser {
+	return &userRepository{db: db}
+}
+
+//table of users, deleted ones are hidden unless ctx shows them
+func (r *userRepository) table(ctx context.Context) *gorm.DB {
+	return conn(ctx, r.db).Model(&models.User{}).Scopes(live(ctx, "users"))
+}
+
+func (r *userRepository) FindById(ctx context.Context, id int64) (*models.User, error) {
+	var user models.User
+	if err := r.table(ctx).Where("id = ?", id).First(&user).Error; err != nil {
+		return nil, dbError(err)
+	}
+	return &user, nil
+}
+
+func (r *userRepository) FindByName(ctx context.Context, name string) (*models.User, error) {
+	var user models.User
+	if err := r.table(ctx).Where("name = ? AND tenant_id = ?", name, repository.NameTenant(ctx)).First(&user).Error; err != nil {
+		return nil, dbError(err)
+	}
+	return &user, nil
+}
+
+func (r *userRepository) Add(ctx context.Context, user *models.User) error {
+	var count int64
+	if err := conn(ctx, r.db).Model(&models.User{}).Where("name = ? AND tenant_id = ?", user.Name, user.TenantID).Count(&count).Error; err != nil {
+		return dbError(err)
+	}
+	if count > 0 {
+		return errs.NewAlreadyExists("user %s already exists", user.Name)
+	}
+
+	user.ID, user.Version = 0, 1
+	return dbError(conn(ctx, r.db).Create(user).Error)
+}
+
+func (r *userRepository) Update(ctx context.Context, user *models.User) error {
+	return update(ctx, r.db, &models.User{}, "user", user.ID, user, &user.ModelExtension)
+}
+
+//Delete mark the user deleted, its links are kept for a restore
+func (r *userRepository) Delete(ctx context.Context, id, version int64) error {
+	return markDeleted(ctx, r.db, &models.User{}, "user", id, version, true)
+}
+
+func (r *userRepository) Restore(ctx context.Context, id int64) error {
+	return markDeleted(ctx, r.db, &models.User{}, "user", id, 0, false)
+}
+
+//Purge users deleted before the time with their links
+func (r *userRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	return purge(ctx, r.db, &models.User{}, before, linkColumn{&models.UserRole{}, "user_id"})
+}
+
+//List users matched opts
+func (r *userRepository) List(ctx context.Context, opts repository.ListOptions) (users []*models.User, total int64, err error) {
+	opts.Types = nil
+
+	if err = r.table(ctx).Scopes(filterScope(opts)).Count(&total).Error; err != nil {
+		return nil, 0, dbError(err)
+	}
+	err = dbError(r.table(ctx).Scopes(filterScope(opts), pageScope(opts)).Find(&users).Error)
+	return
+}
@@ -0,0 +1,40 @@
+package sql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	"gorm.io/gorm"
+)
+
+//update set the columns of item to the live row of model with id at the version of ext,
+//the version of ext steps to the stored one
+func update(ctx context.Context, db *gorm.DB, model interface{}, kind string, id int64, item interface{}, ext *models.ModelExtension) error {
+	version := ext.Version
+	ext.Version++
+	result := conn(ctx, db).Model(model).Where("id = ? AND is_soft_del = ? AND version = ?", id, false, version).
+		Select("*").Omit("id", "created_at", "deleted_at", "is_soft_del").Updates(item)
+	if result.Error == nil && result.RowsAffected == 1 {
+		return nil
+	}
+	ext.Version = version
+	if result.Error != nil {
+		return dbError(result.Error)
+	}
+	return staleError(ctx, db, model, kind, id, version)
+}
+
+//staleError tell why no live row of model with id is at the version
+func staleError(ctx context.Context, db *gorm.DB, model interface{}, kind string, id, version int64) error {
+	var current int64
+	err := conn(ctx, db).Model(model).Select("version").Where("id = ? AND is_soft_del = ?", id, false).Take(&current).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errs.NewNotFound("%s %d not found", kind, id)
+	} else if err != nil {
+		return dbError(err)
+	}
+	return repository.StaleVersion(kind, id, version)
+}
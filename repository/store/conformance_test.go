@@ -0,0 +1,56 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/micro-community/auth/repository"
+	"github.com/micro-community/auth/repository/conformance"
+	"github.com/micro/micro/v3/service/store/memory"
+)
+
+func TestUserRepository(t *testing.T) {
+	conformance.RunUser(t, func(t *testing.T) repository.IUser { return NewUserRepository(memory.NewStore()) })
+}
+
+func TestRoleRepository(t *testing.T) {
+	conformance.RunRole(t, func(t *testing.T) repository.IRole { return NewRoleRepository(memory.NewStore()) })
+}
+
+func TestResourceRepository(t *testing.T) {
+	conformance.RunResource(t, func(t *testing.T) repository.IResource { return NewResourceRepository(memory.NewStore()) })
+}
+
+func newRepositories(t *testing.T) conformance.Repositories {
+	s := memory.NewStore()
+	return conformance.Repositories{
+		Users:     NewUserRepository(s),
+		Roles:     NewRoleRepository(s),
+		Resources: NewResourceRepository(s),
+		Links:     NewLinkRepository(s),
+		Work:      NewUnitOfWork(),
+	}
+}
+
+func TestLinkRepository(t *testing.T) {
+	conformance.RunLink(t, newRepositories)
+}
+
+func TestUnitOfWork(t *testing.T) {
+	conformance.RunUnitOfWork(t, newRepositories, false)
+}
+
+func TestLogRepository(t *testing.T) {
+	conformance.RunLog(t, func(t *testing.T) repository.ILog { return NewLogRepository(memory.NewStore()) })
+}
+
+func TestTenantRepository(t *testing.T) {
+	conformance.RunTenant(t, func(t *testing.T) repository.ITenant { return NewTenantRepository(memory.NewStore()) })
+}
+
+func TestRoleTemplateRepository(t *testing.T) {
+	conformance.RunRoleTemplate(t, func(t *testing.T) repository.IRoleTemplate { return NewRoleTemplateRepository(memory.NewStore()) })
+}
+
+func TestOrgRepository(t *testing.T) {
+	conformance.RunOrg(t, func(t *testing.T) repository.IOrg { return NewOrgRepository(memory.NewStore()) })
+}
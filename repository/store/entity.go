@@ -0,0 +1,217 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	mstore "github.com/micro/micro/v3/service/store"
+)
+
+//tenantName index the name of an entity in its tenant, names of users, roles and resources are unique in a tenant
+func tenantName(tenant int, name string) string {
+	return strconv.Itoa(tenant) + "/" + name
+}
+
+//collection of entities of a kind, an entity is kept at <name>/id/<id> and indexed at <name>/name/<its name>,
+//the name of a user, role or resource is indexed in its tenant
+type collection struct {
+	name string
+	kind string
+}
+
+var (
+	users     = collection{"users", "user"}
+	roles     = collection{"roles", "role"}
+	resources = collection{"resources", "resource"}
+)
+
+func (c collection) idPrefix() string {
+	return c.name + "/id/"
+}
+
+func (c collection) idKey(id int64) string {
+	return c.idPrefix() + padID(id)
+}
+
+func (c collection) nameKey(name string) string {
+	return c.name + "/name/" + name
+}
+
+//load decode the entity of id into v, deleted or not, found is false when it is missing
+func (c collection) load(s mstore.Store, id int64, v interface{}) (bool, error) {
+	data, found, err := load(s, c.idKey(id))
+	if err != nil || !found {
+		return false, err
+	}
+	if err = json.Unmarshal(data, v); err != nil {
+		return false, storeError(err)
+	}
+	return true, nil
+}
+
+//find decode the entity of id into v, ext is the extension of v, found is false when it is missing or hidden from ctx
+func (c collection) find(ctx context.Context, s mstore.Store, id int64, v interface{}, ext *models.ModelExtension) (bool, error) {
+	found, err := c.load(s, id, v)
+	if err != nil || !found {
+		return false, err
+	}
+	return visible(ctx, *ext), nil
+}
+
+//findByName decode the entity named name into v like find
+func (c collection) findByName(ctx context.Context, s mstore.Store, name string, v interface{}, ext *models.ModelExtension) (bool, error) {
+	data, found, err := load(s, c.nameKey(name))
+	if err != nil || !found {
+		return false, err
+	}
+	var id int64
+	if err = json.Unmarshal(data, &id); err != nil {
+		return false, storeError(err)
+	}
+	return c.find(ctx, s, id, v, ext)
+}
+
+//taken report whether the name is kept by an entity, deleted or not
+func (c collection) taken(s mstore.Store, name string) (bool, error) {
+	_, found, err := load(s, c.nameKey(name))
+	return found, err
+}
+
+//put the entity of id named name, the index of its old name is moved
+func (c collection) put(s mstore.Store, id int64, name, oldName string, v interface{}) error {
+	if err := save(s, c.idKey(id), v); err != nil {
+		return err
+	}
+	if name == oldName {
+		return nil
+	}
+	if err := save(s, c.nameKey(name), id); err != nil {
+		return err
+	}
+	if oldName == "" {
+		return nil
+	}
+	return remove(s, c.nameKey(oldName))
+}
+
+//checkLive return an error when the stored entity of ext is not live at the version
+func (c collection) checkLive(found bool, ext models.ModelExtension, id, version int64) error {
+	if !found || ext.IsSoftDel {
+		return errs.NewNotFound("%s %d not found", c.kind, id)
+	}
+	if ext.Version != version {
+		return repository.StaleVersion(c.kind, id, version)
+	}
+	return nil
+}
+
+//setDeleted mark the entity of id at the version deleted now, or restore it at any version, as a new version
+func (c collection) setDeleted(s mstore.Store, id, version int64, deleted bool) error {
+	data, found, err := load(s, c.idKey(id))
+	if err != nil {
+		return err
+	}
+	var fields map[string]json.RawMessage
+	var ext models.ModelExtension
+	if found {
+		if err = json.Unmarshal(data, &fields); err == nil {
+			err = json.Unmarshal(data, &ext)
+		}
+		if err != nil {
+			return storeError(err)
+		}
+	}
+	if deleted {
+		if err = c.checkLive(found, ext, id, version); err != nil {
+			return err
+		}
+	} else if !found || !ext.IsSoftDel {
+		return errs.NewNotFound("deleted %s %d not found", c.kind, id)
+	}
+
+	markDeleted(&ext, deleted)
+	//fields of the extension are inlined in the entity
+	data, err = json.Marshal(ext)
+	if err != nil {
+		return storeError(err)
+	}
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return storeError(err)
+	}
+	return save(s, c.idKey(id), fields)
+}
+
+//purge the entities deleted before the time with their names, links go first so a failed purge is safe to run again
+func (c collection) purge(s mstore.Store, before time.Time, links ...func(id int64) error) (int64, error) {
+	records, err := scan(s, c.idPrefix())
+	if err != nil {
+		return 0, err
+	}
+	purged := map[int64]bool{}
+	for _, record := range records {
+		var entity struct {
+			ID int64 `json:"id"`
+			models.ModelExtension
+		}
+		if err = json.Unmarshal(record.Value, &entity); err != nil {
+			return 0, storeError(err)
+		}
+		if expired(entity.ModelExtension, before) {
+			purged[entity.ID] = true
+		}
+	}
+	if len(purged) == 0 {
+		return 0, nil
+	}
+
+	for id := range purged {
+		for _, unlink := range links {
+			if err = unlink(id); err != nil {
+				return 0, err
+			}
+		}
+	}
+	names, err := scan(s, c.nameKey(""))
+	if err != nil {
+		return 0, err
+	}
+	for _, record := range names {
+		var id int64
+		if json.Unmarshal(record.Value, &id) == nil && purged[id] {
+			if err = remove(s, record.Key); err != nil {
+				return 0, err
+			}
+		}
+	}
+	for id := range purged {
+		if err = remove(s, c.idKey(id)); err != nil {
+			return 0, err
+		}
+	}
+	return int64(len(purged)), nil
+}
+
+//visible report whether reads with ctx see the entity of ext
+func visible(ctx context.Context, ext models.ModelExtension) bool {
+	return !ext.IsSoftDel || repository.ShowDeleted(ctx)
+}
+
+//expired report whether the entity of ext is deleted before the time
+func expired(ext models.ModelExtension, before time.Time) bool {
+	return ext.IsSoftDel && ext.DeletedAt.Before(before)
+}
+
+//markDeleted mark the entity of ext deleted now, or restore it, as a new version
+func markDeleted(ext *models.ModelExtension, deleted bool) {
+	ext.Version++
+	ext.IsSoftDel = deleted
+	ext.DeletedAt = time.Time{}
+	if deleted {
+		ext.DeletedAt = time.Now()
+	}
+}
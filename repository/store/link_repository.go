@@ -0,0 +1,247 @@
+package store
+
+import (
+	"context"
+	"sync"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	mstore "github.com/micro/micro/v3/service/store"
+)
+
+//linkTable keep a link at <forward>/<from>/<to> and at <backward>/<to>/<from>,
+//to find the ends linked to either end
+type linkTable struct {
+	forward  string
+	backward string
+}
+
+var (
+	userRoles     = linkTable{"user_roles", "role_users"}
+	roleResources = linkTable{"role_resources", "resource_roles"}
+)
+
+func (t linkTable) key(from, to int64) string {
+	return t.forward + "/" + padID(from) + "/" + padID(to)
+}
+
+func (t linkTable) backwardKey(from, to int64) string {
+	return t.backward + "/" + padID(to) + "/" + padID(from)
+}
+
+func (t linkTable) exists(s mstore.Store, from, to int64) (bool, error) {
+	_, found, err := load(s, t.key(from, to))
+	return found, err
+}
+
+func (t linkTable) link(s mstore.Store, from, to int64) error {
+	if err := save(s, t.key(from, to), true); err != nil {
+		return err
+	}
+	return save(s, t.backwardKey(from, to), true)
+}
+
+func (t linkTable) unlink(s mstore.Store, from, to int64) error {
+	return remove(s, t.backwardKey(from, to), t.key(from, to))
+}
+
+//targets linked from the end in order of id
+func (t linkTable) targets(s mstore.Store, from int64) ([]int64, error) {
+	return ids(s, t.forward+"/"+padID(from)+"/")
+}
+
+//sources linking to the end in order of id
+func (t linkTable) sources(s mstore.Store, to int64) ([]int64, error) {
+	return ids(s, t.backward+"/"+padID(to)+"/")
+}
+
+//unlinkFrom remove links from the end
+func (t linkTable) unlinkFrom(s mstore.Store, from int64) error {
+	targets, err := t.targets(s, from)
+	if err != nil {
+		return err
+	}
+	for _, to := range targets {
+		if err = t.unlink(s, from, to); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//unlinkTo remove links to the end
+func (t linkTable) unlinkTo(s mstore.Store, to int64) error {
+	sources, err := t.sources(s, to)
+	if err != nil {
+		return err
+	}
+	for _, from := range sources {
+		if err = t.unlink(s, from, to); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//linkRepository keep links of users to roles and roles to resources, links of a hidden end are skipped in queries
+type linkRepository struct {
+	store mstore.Store
+	mu    *sync.Mutex
+}
+
+func NewLinkRepository(s mstore.Store) repository.ILink {
+	return &linkRepository{
+		store: s,
+		mu:    lockOf(s),
+	}
+}
+
+//mustBeLive return an errs.NotFound error when the entity of id in c is missing or deleted
+func (r *linkRepository) mustBeLive(c collection, id int64) error {
+	var ext models.ModelExtension
+	found, err := c.load(r.store, id, &ext)
+	if err != nil {
+		return err
+	}
+	if !found || ext.IsSoftDel {
+		return errs.NewNotFound("%s %d not found", c.kind, id)
+	}
+	return nil
+}
+
+//add a link of live ends in the table
+func (r *linkRepository) add(t linkTable, fromC, toC collection, from, to int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.mustBeLive(fromC, from); err != nil {
+		return err
+	}
+	if err := r.mustBeLive(toC, to); err != nil {
+		return err
+	}
+	found, err := t.exists(r.store, from, to)
+	if err != nil {
+		return err
+	}
+	if found {
+		return errs.NewAlreadyExists("%s %d already has %s %d", fromC.kind, from, toC.kind, to)
+	}
+	return t.link(r.store, from, to)
+}
+
+//del a link in the table
+func (r *linkRepository) del(t linkTable, fromC, toC collection, from, to int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	found, err := t.exists(r.store, from, to)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errs.NewNotFound("%s %d does not have %s %d", fromC.kind, from, toC.kind, to)
+	}
+	return t.unlink(r.store, from, to)
+}
+
+func (r *linkRepository) LinkUserRole(ctx context.Context, userID int64, roleID int) error {
+	return r.add(userRoles, users, roles, userID, int64(roleID))
+}
+
+func (r *linkRepository) UnlinkUserRole(ctx context.Context, userID int64, roleID int) error {
+	return r.del(userRoles, users, roles, userID, int64(roleID))
+}
+
+func (r *linkRepository) LinkRoleResource(ctx context.Context, roleID, resourceID int) error {
+	return r.add(roleResources, roles, resources, int64(roleID), int64(resourceID))
+}
+
+func (r *linkRepository) UnlinkRoleResource(ctx context.Context, roleID, resourceID int) error {
+	return r.del(roleResources, roles, resources, int64(roleID), int64(resourceID))
+}
+
+func (r *linkRepository) UserRoles(ctx context.Context, userID int64) ([]*models.Role, error) {
+	var user models.User
+	found, err := users.find(ctx, r.store, userID, &user, &user.ModelExtension)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errs.NewNotFound("user %d not found", userID)
+	}
+
+	ids, err := userRoles.targets(r.store, userID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*models.Role, 0, len(ids))
+	for _, id := range ids {
+		role := &models.Role{}
+		found, err = roles.find(ctx, r.store, id, role, &role.ModelExtension)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			result = append(result, role)
+		}
+	}
+	return result, nil
+}
+
+func (r *linkRepository) RoleResources(ctx context.Context, roleID int) ([]*models.Resource, error) {
+	var role models.Role
+	found, err := roles.find(ctx, r.store, int64(roleID), &role, &role.ModelExtension)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errs.NewNotFound("role %d not found", roleID)
+	}
+
+	ids, err := roleResources.targets(r.store, int64(roleID))
+	if err != nil {
+		return nil, err
+	}
+	return r.findResources(ctx, ids)
+}
+
+func (r *linkRepository) UserResources(ctx context.Context, userID int64) ([]*models.Resource, error) {
+	roles, err := r.UserRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	linked := map[int64]bool{}
+	var ids []int64
+	for _, role := range roles {
+		targets, err := roleResources.targets(r.store, int64(role.ID))
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range targets {
+			if !linked[id] {
+				linked[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	sortIDs(ids)
+	return r.findResources(ctx, ids)
+}
+
+//findResources of ids visible to ctx
+func (r *linkRepository) findResources(ctx context.Context, ids []int64) ([]*models.Resource, error) {
+	result := make([]*models.Resource, 0, len(ids))
+	for _, id := range ids {
+		resource := &models.Resource{}
+		found, err := resources.find(ctx, r.store, id, resource, &resource.ModelExtension)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			result = append(result, resource)
+		}
+	}
+	return result, nil
+}
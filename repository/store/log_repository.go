@@ -0,0 +1,62 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	mstore "github.com/micro/micro/v3/service/store"
+)
+
+//logsPrefix of the keys of logs, ids are taken from the counters
+const logsPrefix = "logs/"
+
+//logRepository keep logs at logs/<id>
+type logRepository struct {
+	store mstore.Store
+	mu    *sync.Mutex
+}
+
+func NewLogRepository(s mstore.Store) repository.ILog {
+	return &logRepository{
+		store: s,
+		mu:    lockOf(s),
+	}
+}
+
+func (r *logRepository) Append(ctx context.Context, log *models.Log) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id, err := nextID(r.store, "logs")
+	if err != nil {
+		return err
+	}
+	log.ID = id
+	if log.Time.IsZero() {
+		log.Time = time.Now()
+	}
+	return save(r.store, logsPrefix+padID(id), log)
+}
+
+func (r *logRepository) Query(ctx context.Context, opts repository.LogQuery) ([]*models.Log, error) {
+	records, err := scan(r.store, logsPrefix)
+	if err != nil {
+		return nil, err
+	}
+	logs := make([]*models.Log, 0)
+	for i := len(records) - 1; i >= 0 && (opts.Limit == 0 || len(logs) < opts.Limit); i-- {
+		log := &models.Log{}
+		if err = json.Unmarshal(records[i].Value, log); err != nil {
+			return nil, storeError(err)
+		}
+		if opts.BeforeID > 0 && log.ID >= opts.BeforeID || !opts.Match(log) {
+			continue
+		}
+		logs = append(logs, log)
+	}
+	return logs, nil
+}
@@ -0,0 +1,246 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	mstore "github.com/micro/micro/v3/service/store"
+)
+
+var orgUnits = collection{"org_units", "org unit"}
+
+//prefixes of the keys of members of org units by user id, and of grants by unit and role id
+const (
+	orgMembersPrefix = "org_members/"
+	orgGrantsPrefix  = "org_grants/"
+)
+
+//orgRepository store org units in the collection org_units, ids are taken from the counters, names are indexed
+//by tenant and parent; their members at org_members/<user id> and grants at org_grants/<unit id>/<role id>
+type orgRepository struct {
+	store mstore.Store
+	mu    *sync.Mutex
+}
+
+func NewOrgRepository(s mstore.Store) repository.IOrg {
+	return &orgRepository{
+		store: s,
+		mu:    lockOf(s),
+	}
+}
+
+//unitName index the name of unit among the units of its parent in its tenant
+func unitName(unit *models.OrgUnit) string {
+	return strconv.Itoa(unit.TenantID) + "/" + strconv.Itoa(unit.ParentID) + "/" + unit.Name
+}
+
+func grantsPrefix(unitID int) string {
+	return orgGrantsPrefix + padID(int64(unitID)) + "/"
+}
+
+func (r *orgRepository) FindById(ctx context.Context, id int) (*models.OrgUnit, error) {
+	var unit models.OrgUnit
+	found, err := orgUnits.load(r.store, int64(id), &unit)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errs.NewNotFound("org unit %d not found", id)
+	}
+	return &unit, nil
+}
+
+func (r *orgRepository) Add(ctx context.Context, unit *models.OrgUnit) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	taken, err := orgUnits.taken(r.store, unitName(unit))
+	if err != nil {
+		return err
+	}
+	if taken {
+		return errs.NewAlreadyExists("org unit %s already exists", unit.Name)
+	}
+
+	id, err := nextID(r.store, orgUnits.name)
+	if err != nil {
+		return err
+	}
+	unit.ID = int(id)
+	if unit.CreatedAt.IsZero() {
+		unit.CreatedAt = time.Now()
+	}
+	unit.Version = 1
+	return orgUnits.put(r.store, id, unitName(unit), "", unit)
+}
+
+func (r *orgRepository) Update(ctx context.Context, unit *models.OrgUnit) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := int64(unit.ID)
+	var stored models.OrgUnit
+	found, err := orgUnits.load(r.store, id, &stored)
+	if err != nil {
+		return err
+	}
+	if err = orgUnits.checkLive(found, stored.ModelExtension, id, unit.Version); err != nil {
+		return err
+	}
+	if unitName(&stored) != unitName(unit) {
+		if taken, err := orgUnits.taken(r.store, unitName(unit)); err != nil {
+			return err
+		} else if taken {
+			return errs.NewAlreadyExists("org unit %s already exists", unit.Name)
+		}
+	}
+
+	unit.UpdatedAt = time.Now()
+	unit.Version++
+	if err = orgUnits.put(r.store, id, unitName(unit), unitName(&stored), unit); err != nil {
+		unit.Version--
+		return err
+	}
+	return nil
+}
+
+//Delete the unit at the version with its name, then its grants
+func (r *orgRepository) Delete(ctx context.Context, id int, version int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var stored models.OrgUnit
+	found, err := orgUnits.load(r.store, int64(id), &stored)
+	if err != nil {
+		return err
+	}
+	if err = orgUnits.checkLive(found, stored.ModelExtension, int64(id), version); err != nil {
+		return err
+	}
+	if err = remove(r.store, orgUnits.nameKey(unitName(&stored)), orgUnits.idKey(int64(id))); err != nil {
+		return err
+	}
+	grants, err := keys(r.store, grantsPrefix(id))
+	if err != nil {
+		return err
+	}
+	return remove(r.store, grants...)
+}
+
+func (r *orgRepository) List(ctx context.Context) ([]*models.OrgUnit, error) {
+	records, err := scan(r.store, orgUnits.idPrefix())
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*models.OrgUnit, 0, len(records))
+	for _, record := range records {
+		unit := &models.OrgUnit{}
+		if err = json.Unmarshal(record.Value, unit); err != nil {
+			return nil, storeError(err)
+		}
+		result = append(result, unit)
+	}
+	return result, nil
+}
+
+func (r *orgRepository) SetMember(ctx context.Context, member *models.OrgMember) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if member.CreatedAt.IsZero() {
+		member.CreatedAt = time.Now()
+	}
+	return save(r.store, orgMembersPrefix+padID(member.UserID), member)
+}
+
+func (r *orgRepository) RemoveMember(ctx context.Context, userID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := r.MemberOf(ctx, userID); err != nil {
+		return err
+	}
+	return remove(r.store, orgMembersPrefix+padID(userID))
+}
+
+func (r *orgRepository) MemberOf(ctx context.Context, userID int64) (*models.OrgMember, error) {
+	data, found, err := load(r.store, orgMembersPrefix+padID(userID))
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errs.NewNotFound("org member %d not found", userID)
+	}
+	member := &models.OrgMember{}
+	if err = json.Unmarshal(data, member); err != nil {
+		return nil, storeError(err)
+	}
+	return member, nil
+}
+
+//Members scan all members, in order of user id by their keys
+func (r *orgRepository) Members(ctx context.Context, unitID int) ([]*models.OrgMember, error) {
+	records, err := scan(r.store, orgMembersPrefix)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*models.OrgMember, 0)
+	for _, record := range records {
+		member := &models.OrgMember{}
+		if err = json.Unmarshal(record.Value, member); err != nil {
+			return nil, storeError(err)
+		}
+		if member.DeptID == unitID || member.PositionID == unitID {
+			result = append(result, member)
+		}
+	}
+	return result, nil
+}
+
+func (r *orgRepository) Grant(ctx context.Context, unitID, roleID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := grantsPrefix(unitID) + padID(int64(roleID))
+	_, found, err := load(r.store, key)
+	if err != nil {
+		return err
+	}
+	if found {
+		return errs.NewAlreadyExists("role %d is granted to org unit %d already", roleID, unitID)
+	}
+	return save(r.store, key, &models.OrgGrant{UnitID: unitID, RoleID: roleID, CreatedAt: time.Now()})
+}
+
+func (r *orgRepository) Revoke(ctx context.Context, unitID, roleID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := grantsPrefix(unitID) + padID(int64(roleID))
+	_, found, err := load(r.store, key)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errs.NewNotFound("role %d is not granted to org unit %d", roleID, unitID)
+	}
+	return remove(r.store, key)
+}
+
+func (r *orgRepository) Grants(ctx context.Context, unitID int) ([]int, error) {
+	found, err := ids(r.store, grantsPrefix(unitID))
+	if err != nil {
+		return nil, err
+	}
+	roleIDs := make([]int, 0, len(found))
+	for _, id := range found {
+		roleIDs = append(roleIDs, int(id))
+	}
+	return roleIDs, nil
+}
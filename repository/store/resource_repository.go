@@ -0,0 +1,190 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	mstore "github.com/micro/micro/v3/service/store"
+)
+
+//resourceRepository store resources in the collection resources, ids are taken from the counters
+type resourceRepository struct {
+	store mstore.Store
+	mu    *sync.Mutex
+}
+
+func NewResourceRepository(s mstore.Store) repository.IResource {
+	return &resourceRepository{
+		store: s,
+		mu:    lockOf(s),
+	}
+}
+
+func (r *resourceRepository) FindById(ctx context.Context, id int64) (*models.Resource, error) {
+	var resource models.Resource
+	found, err := resources.find(ctx, r.store, id, &resource, &resource.ModelExtension)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errs.NewNotFound("resource %d not found", id)
+	}
+	return &resource, nil
+}
+
+func (r *resourceRepository) FindByName(ctx context.Context, name string) (*models.Resource, error) {
+	var resource models.Resource
+	found, err := resources.findByName(ctx, r.store, tenantName(repository.NameTenant(ctx), name), &resource, &resource.ModelExtension)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errs.NewNotFound("resource %s not found", name)
+	}
+	return &resource, nil
+}
+
+func (r *resourceRepository) Add(ctx context.Context, resource *models.Resource) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	taken, err := resources.taken(r.store, tenantName(resource.TenantID, resource.Name))
+	if err != nil {
+		return err
+	}
+	if taken {
+		return errs.NewAlreadyExists("resource %s already exists", resource.Name)
+	}
+
+	id, err := nextID(r.store, resources.name)
+	if err != nil {
+		return err
+	}
+	resource.ID = int(id)
+	if resource.CreatedAt.IsZero() {
+		resource.CreatedAt = time.Now()
+	}
+	resource.Version = 1
+	return resources.put(r.store, id, tenantName(resource.TenantID, resource.Name), "", resource)
+}
+
+func (r *resourceRepository) Update(ctx context.Context, resource *models.Resource) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := int64(resource.ID)
+	var stored models.Resource
+	found, err := resources.load(r.store, id, &stored)
+	if err != nil {
+		return err
+	}
+	if err = resources.checkLive(found, stored.ModelExtension, id, resource.Version); err != nil {
+		return err
+	}
+	if tenantName(stored.TenantID, stored.Name) != tenantName(resource.TenantID, resource.Name) {
+		if taken, err := resources.taken(r.store, tenantName(resource.TenantID, resource.Name)); err != nil {
+			return err
+		} else if taken {
+			return errs.NewAlreadyExists("resource %s already exists", resource.Name)
+		}
+	}
+
+	resource.UpdatedAt = time.Now()
+	resource.Version++
+	if err = resources.put(r.store, id, tenantName(resource.TenantID, resource.Name), tenantName(stored.TenantID, stored.Name), resource); err != nil {
+		resource.Version--
+		return err
+	}
+	return nil
+}
+
+//Delete mark the resource deleted, links of roles to it are kept for a restore
+func (r *resourceRepository) Delete(ctx context.Context, id, version int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return resources.setDeleted(r.store, id, version, true)
+}
+
+func (r *resourceRepository) Restore(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return resources.setDeleted(r.store, id, 0, false)
+}
+
+//Purge resources deleted before the time with the links of roles to them
+func (r *resourceRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return resources.purge(r.store, before, func(id int64) error { return roleResources.unlinkTo(r.store, id) })
+}
+
+func (r *resourceRepository) Search(ctx context.Context, tenantID int, types ...models.ResourceCatalog) ([]*models.Resource, error) {
+	all, err := r.all(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*models.Resource, 0)
+	for _, resource := range all {
+		item := repository.ListItem{Tenant: resource.TenantID}
+		typ := models.ResourceCatalog(resource.Type)
+		item.Type = &typ
+		if item.Match(repository.ListOptions{TenantID: tenantID, Types: types}) {
+			result = append(result, resource)
+		}
+	}
+	return result, nil
+}
+
+func (r *resourceRepository) List(ctx context.Context, opts repository.ListOptions) ([]*models.Resource, int64, error) {
+	all, err := r.all(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	items := make([]repository.ListItem, 0, len(all))
+	for index, resource := range all {
+		typ := models.ResourceCatalog(resource.Type)
+		items = append(items, repository.ListItem{
+			Index:   index,
+			ID:      int64(resource.ID),
+			Name:    resource.Name,
+			Tenant:  resource.TenantID,
+			Type:    &typ,
+			Created: resource.CreatedAt,
+		})
+	}
+
+	indexes, total := repository.ListPage(items, opts)
+	result := make([]*models.Resource, 0, len(indexes))
+	for _, index := range indexes {
+		result = append(result, all[index])
+	}
+	return result, total, nil
+}
+
+//all resources visible to ctx in order of id
+func (r *resourceRepository) all(ctx context.Context) ([]*models.Resource, error) {
+	records, err := scan(r.store, resources.idPrefix())
+	if err != nil {
+		return nil, err
+	}
+	all := make([]*models.Resource, 0, len(records))
+	for _, record := range records {
+		resource := &models.Resource{}
+		if err = json.Unmarshal(record.Value, resource); err != nil {
+			return nil, storeError(err)
+		}
+		if visible(ctx, resource.ModelExtension) {
+			all = append(all, resource)
+		}
+	}
+	return all, nil
+}
@@ -0,0 +1,168 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	mstore "github.com/micro/micro/v3/service/store"
+)
+
+//roleRepository store roles in the collection roles, ids are taken from the counters
+type roleRepository struct {
+	store mstore.Store
+	mu    *sync.Mutex
+}
+
+func NewRoleRepository(s mstore.Store) repository.IRole {
+	return &roleRepository{
+		store: s,
+		mu:    lockOf(s),
+	}
+}
+
+func (r *roleRepository) FindById(ctx context.Context, id int64) (*models.Role, error) {
+	var role models.Role
+	found, err := roles.find(ctx, r.store, id, &role, &role.ModelExtension)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errs.NewNotFound("role %d not found", id)
+	}
+	return &role, nil
+}
+
+func (r *roleRepository) FindByName(ctx context.Context, name string) (*models.Role, error) {
+	var role models.Role
+	found, err := roles.findByName(ctx, r.store, tenantName(repository.NameTenant(ctx), name), &role, &role.ModelExtension)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errs.NewNotFound("role %s not found", name)
+	}
+	return &role, nil
+}
+
+func (r *roleRepository) Add(ctx context.Context, role *models.Role) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	taken, err := roles.taken(r.store, tenantName(role.TenantID, role.Name))
+	if err != nil {
+		return err
+	}
+	if taken {
+		return errs.NewAlreadyExists("role %s already exists", role.Name)
+	}
+
+	id, err := nextID(r.store, roles.name)
+	if err != nil {
+		return err
+	}
+	role.ID = int(id)
+	if role.CreatedAt.IsZero() {
+		role.CreatedAt = time.Now()
+	}
+	role.Version = 1
+	return roles.put(r.store, id, tenantName(role.TenantID, role.Name), "", role)
+}
+
+//Update role, the key of a role can not be modified
+func (r *roleRepository) Update(ctx context.Context, role *models.Role) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := int64(role.ID)
+	var stored models.Role
+	found, err := roles.load(r.store, id, &stored)
+	if err != nil {
+		return err
+	}
+	if err = roles.checkLive(found, stored.ModelExtension, id, role.Version); err != nil {
+		return err
+	}
+	if role.Key != "" && stored.Key != role.Key {
+		return errs.NewConflict("role key modify forbidden")
+	}
+	if tenantName(stored.TenantID, stored.Name) != tenantName(role.TenantID, role.Name) {
+		if taken, err := roles.taken(r.store, tenantName(role.TenantID, role.Name)); err != nil {
+			return err
+		} else if taken {
+			return errs.NewAlreadyExists("role %s already exists", role.Name)
+		}
+	}
+
+	role.UpdatedAt = time.Now()
+	role.Version++
+	if err = roles.put(r.store, id, tenantName(role.TenantID, role.Name), tenantName(stored.TenantID, stored.Name), role); err != nil {
+		role.Version--
+		return err
+	}
+	return nil
+}
+
+//Delete mark the role deleted, its links are kept for a restore
+func (r *roleRepository) Delete(ctx context.Context, id, version int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return roles.setDeleted(r.store, id, version, true)
+}
+
+func (r *roleRepository) Restore(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return roles.setDeleted(r.store, id, 0, false)
+}
+
+//Purge roles deleted before the time with the links of users to them and their links to resources
+func (r *roleRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return roles.purge(r.store, before,
+		func(id int64) error { return userRoles.unlinkTo(r.store, id) },
+		func(id int64) error { return roleResources.unlinkFrom(r.store, id) })
+}
+
+//List roles matched opts
+func (r *roleRepository) List(ctx context.Context, opts repository.ListOptions) ([]*models.Role, int64, error) {
+	records, err := scan(r.store, roles.idPrefix())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	all := make([]*models.Role, 0, len(records))
+	items := make([]repository.ListItem, 0, len(records))
+	for _, record := range records {
+		role := &models.Role{}
+		if err = json.Unmarshal(record.Value, role); err != nil {
+			return nil, 0, storeError(err)
+		}
+		if !visible(ctx, role.ModelExtension) {
+			continue
+		}
+		items = append(items, repository.ListItem{
+			Index:   len(all),
+			ID:      int64(role.ID),
+			Name:    role.Name,
+			Tenant:  role.TenantID,
+			Created: role.CreatedAt,
+		})
+		all = append(all, role)
+	}
+
+	indexes, total := repository.ListPage(items, opts)
+	result := make([]*models.Role, 0, len(indexes))
+	for _, index := range indexes {
+		result = append(result, all[index])
+	}
+	return result, total, nil
+}
@@ -0,0 +1,187 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	mstore "github.com/micro/micro/v3/service/store"
+)
+
+var roleTemplates = collection{"role_templates", "role template"}
+
+//roleInstancesPrefix of the keys of instances of role templates by role id
+const roleInstancesPrefix = "role_instances/"
+
+//roleTemplateRepository store role templates in the collection role_templates, ids are taken from the counters,
+//and their instances at role_instances/<role id>
+type roleTemplateRepository struct {
+	store mstore.Store
+	mu    *sync.Mutex
+}
+
+func NewRoleTemplateRepository(s mstore.Store) repository.IRoleTemplate {
+	return &roleTemplateRepository{
+		store: s,
+		mu:    lockOf(s),
+	}
+}
+
+func (r *roleTemplateRepository) FindById(ctx context.Context, id int) (*models.RoleTemplate, error) {
+	var template models.RoleTemplate
+	found, err := roleTemplates.load(r.store, int64(id), &template)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errs.NewNotFound("role template %d not found", id)
+	}
+	return &template, nil
+}
+
+func (r *roleTemplateRepository) FindByName(ctx context.Context, name string) (*models.RoleTemplate, error) {
+	var template models.RoleTemplate
+	found, err := roleTemplates.findByName(ctx, r.store, name, &template, &template.ModelExtension)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errs.NewNotFound("role template %s not found", name)
+	}
+	return &template, nil
+}
+
+func (r *roleTemplateRepository) Add(ctx context.Context, template *models.RoleTemplate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	taken, err := roleTemplates.taken(r.store, template.Name)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return errs.NewAlreadyExists("role template %s already exists", template.Name)
+	}
+
+	id, err := nextID(r.store, roleTemplates.name)
+	if err != nil {
+		return err
+	}
+	template.ID = int(id)
+	if template.CreatedAt.IsZero() {
+		template.CreatedAt = time.Now()
+	}
+	template.Version = 1
+	return roleTemplates.put(r.store, id, template.Name, "", template)
+}
+
+func (r *roleTemplateRepository) Update(ctx context.Context, template *models.RoleTemplate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := int64(template.ID)
+	var stored models.RoleTemplate
+	found, err := roleTemplates.load(r.store, id, &stored)
+	if err != nil {
+		return err
+	}
+	if err = roleTemplates.checkLive(found, stored.ModelExtension, id, template.Version); err != nil {
+		return err
+	}
+	if stored.Name != template.Name {
+		if taken, err := roleTemplates.taken(r.store, template.Name); err != nil {
+			return err
+		} else if taken {
+			return errs.NewAlreadyExists("role template %s already exists", template.Name)
+		}
+	}
+
+	template.UpdatedAt = time.Now()
+	template.Version++
+	if err = roleTemplates.put(r.store, id, template.Name, stored.Name, template); err != nil {
+		template.Version--
+		return err
+	}
+	return nil
+}
+
+//Delete the template at the version with its name, then its instances
+func (r *roleTemplateRepository) Delete(ctx context.Context, id int, version int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var stored models.RoleTemplate
+	found, err := roleTemplates.load(r.store, int64(id), &stored)
+	if err != nil {
+		return err
+	}
+	if err = roleTemplates.checkLive(found, stored.ModelExtension, int64(id), version); err != nil {
+		return err
+	}
+	if err = remove(r.store, roleTemplates.nameKey(stored.Name), roleTemplates.idKey(int64(id))); err != nil {
+		return err
+	}
+	instances, err := r.instances(id)
+	if err != nil {
+		return err
+	}
+	for _, instance := range instances {
+		if err = remove(r.store, roleInstancesPrefix+padID(int64(instance.RoleID))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *roleTemplateRepository) List(ctx context.Context) ([]*models.RoleTemplate, error) {
+	records, err := scan(r.store, roleTemplates.idPrefix())
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*models.RoleTemplate, 0, len(records))
+	for _, record := range records {
+		template := &models.RoleTemplate{}
+		if err = json.Unmarshal(record.Value, template); err != nil {
+			return nil, storeError(err)
+		}
+		result = append(result, template)
+	}
+	return result, nil
+}
+
+func (r *roleTemplateRepository) SaveInstance(ctx context.Context, instance *models.RoleInstance) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if instance.CreatedAt.IsZero() {
+		instance.CreatedAt = time.Now()
+	}
+	return save(r.store, roleInstancesPrefix+padID(int64(instance.RoleID)), instance)
+}
+
+func (r *roleTemplateRepository) Instances(ctx context.Context, templateID int) ([]*models.RoleInstance, error) {
+	return r.instances(templateID)
+}
+
+//instances of the template in order of role id, by the keys of instances
+func (r *roleTemplateRepository) instances(templateID int) ([]*models.RoleInstance, error) {
+	records, err := scan(r.store, roleInstancesPrefix)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*models.RoleInstance, 0)
+	for _, record := range records {
+		instance := &models.RoleInstance{}
+		if err = json.Unmarshal(record.Value, instance); err != nil {
+			return nil, storeError(err)
+		}
+		if instance.TemplateID == templateID {
+			result = append(result, instance)
+		}
+	}
+	return result, nil
+}
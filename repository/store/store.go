@@ -0,0 +1,136 @@
+//Package store keep users, roles, resources and their links in a micro store.Store, so the service persists
+//through whatever store the runtime provides. Entities are json encoded by their id keys, with an index of names;
+//a link is kept in both directions. Writes are serialized per store in the process, but not across processes.
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/micro-community/auth/errs"
+	mstore "github.com/micro/micro/v3/service/store"
+)
+
+//countersPrefix of the keys keeping the last id of every collection
+const countersPrefix = "counters/"
+
+//locks of writes by store
+var locks sync.Map
+
+//lockOf return the lock shared by the repositories of a store
+func lockOf(s mstore.Store) *sync.Mutex {
+	lock, _ := locks.LoadOrStore(s, &sync.Mutex{})
+	return lock.(*sync.Mutex)
+}
+
+//storeError translate errors of the store to domain errors
+func storeError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return errs.NewUnavailable(err, "store error")
+}
+
+//padID format an id to keep keys in order of id
+func padID(id int64) string {
+	return fmt.Sprintf("%020d", id)
+}
+
+//load the value of key, found is false when it is missing
+func load(s mstore.Store, key string) ([]byte, bool, error) {
+	records, err := s.Read(key)
+	if err == mstore.ErrNotFound {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, storeError(err)
+	}
+	if len(records) == 0 {
+		return nil, false, nil
+	}
+	return records[0].Value, true, nil
+}
+
+//save v json encoded as the value of key
+func save(s mstore.Store, key string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return storeError(err)
+	}
+	return storeError(s.Write(&mstore.Record{Key: key, Value: data}))
+}
+
+//remove the keys, missing ones are skipped
+func remove(s mstore.Store, keys ...string) error {
+	for _, key := range keys {
+		if err := s.Delete(key); err != nil && err != mstore.ErrNotFound {
+			return storeError(err)
+		}
+	}
+	return nil
+}
+
+//keys starting with prefix in order
+func keys(s mstore.Store, prefix string) ([]string, error) {
+	found, err := s.List(mstore.ListPrefix(prefix))
+	if err != nil {
+		return nil, storeError(err)
+	}
+	sort.Strings(found)
+	return found, nil
+}
+
+//scan the records of keys starting with prefix in order of keys
+func scan(s mstore.Store, prefix string) ([]*mstore.Record, error) {
+	records, err := s.Read(prefix, mstore.ReadPrefix())
+	if err == mstore.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, storeError(err)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].Key < records[j].Key })
+	return records, nil
+}
+
+//ids at the end of the keys starting with prefix, in order
+func ids(s mstore.Store, prefix string) ([]int64, error) {
+	found, err := keys(s, prefix)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]int64, 0, len(found))
+	for _, key := range found {
+		id, err := strconv.ParseInt(strings.TrimPrefix(key, prefix), 10, 64)
+		if err != nil {
+			return nil, errs.NewUnavailable(err, "malformed key %s", key)
+		}
+		result = append(result, id)
+	}
+	return result, nil
+}
+
+func sortIDs(ids []int64) {
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+}
+
+//nextID increase the counter of collection name and return it as the next id, under the lock of the store
+func nextID(s mstore.Store, name string) (int64, error) {
+	var last int64
+	data, found, err := load(s, countersPrefix+name)
+	if err != nil {
+		return 0, err
+	}
+	if found {
+		if err = json.Unmarshal(data, &last); err != nil {
+			return 0, storeError(err)
+		}
+	}
+	last++
+	if err = save(s, countersPrefix+name, last); err != nil {
+		return 0, err
+	}
+	return last, nil
+}
@@ -0,0 +1,141 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	mstore "github.com/micro/micro/v3/service/store"
+)
+
+var tenants = collection{"tenants", "tenant"}
+
+//tenantRepository store tenants in the collection tenants, ids are taken from the counters and never reused.
+//A deleted tenant is kept hidden without its name
+type tenantRepository struct {
+	store mstore.Store
+	mu    *sync.Mutex
+}
+
+func NewTenantRepository(s mstore.Store) repository.ITenant {
+	return &tenantRepository{
+		store: s,
+		mu:    lockOf(s),
+	}
+}
+
+func (r *tenantRepository) FindById(ctx context.Context, id int) (*models.Tenant, error) {
+	var tenant models.Tenant
+	found, err := tenants.load(r.store, int64(id), &tenant)
+	if err != nil {
+		return nil, err
+	}
+	if !found || tenant.IsSoftDel {
+		return nil, errs.NewNotFound("tenant %d not found", id)
+	}
+	return &tenant, nil
+}
+
+func (r *tenantRepository) FindByName(ctx context.Context, name string) (*models.Tenant, error) {
+	var tenant models.Tenant
+	found, err := tenants.findByName(ctx, r.store, name, &tenant, &tenant.ModelExtension)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errs.NewNotFound("tenant %s not found", name)
+	}
+	return &tenant, nil
+}
+
+func (r *tenantRepository) Add(ctx context.Context, tenant *models.Tenant) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	taken, err := tenants.taken(r.store, tenant.Name)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return errs.NewAlreadyExists("tenant %s already exists", tenant.Name)
+	}
+
+	id, err := nextID(r.store, tenants.name)
+	if err != nil {
+		return err
+	}
+	tenant.ID = int(id)
+	if tenant.CreatedAt.IsZero() {
+		tenant.CreatedAt = time.Now()
+	}
+	tenant.Version = 1
+	return tenants.put(r.store, id, tenant.Name, "", tenant)
+}
+
+func (r *tenantRepository) Update(ctx context.Context, tenant *models.Tenant) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := int64(tenant.ID)
+	var stored models.Tenant
+	found, err := tenants.load(r.store, id, &stored)
+	if err != nil {
+		return err
+	}
+	if err = tenants.checkLive(found, stored.ModelExtension, id, tenant.Version); err != nil {
+		return err
+	}
+	if stored.Name != tenant.Name {
+		if taken, err := tenants.taken(r.store, tenant.Name); err != nil {
+			return err
+		} else if taken {
+			return errs.NewAlreadyExists("tenant %s already exists", tenant.Name)
+		}
+	}
+
+	tenant.UpdatedAt = time.Now()
+	tenant.Version++
+	if err = tenants.put(r.store, id, tenant.Name, stored.Name, tenant); err != nil {
+		tenant.Version--
+		return err
+	}
+	return nil
+}
+
+//Delete the tenant at the version, its name is freed
+func (r *tenantRepository) Delete(ctx context.Context, id int, version int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var stored models.Tenant
+	if _, err := tenants.load(r.store, int64(id), &stored); err != nil {
+		return err
+	}
+	if err := tenants.setDeleted(r.store, int64(id), version, true); err != nil {
+		return err
+	}
+	return remove(r.store, tenants.nameKey(stored.Name))
+}
+
+func (r *tenantRepository) List(ctx context.Context) ([]*models.Tenant, error) {
+	records, err := scan(r.store, tenants.idPrefix())
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*models.Tenant, 0, len(records))
+	for _, record := range records {
+		tenant := &models.Tenant{}
+		if err = json.Unmarshal(record.Value, tenant); err != nil {
+			return nil, storeError(err)
+		}
+		if tenant.IsSoftDel {
+			continue
+		}
+		result = append(result, tenant)
+	}
+	return result, nil
+}
@@ -0,0 +1,20 @@
+package store
+
+import (
+	"context"
+
+	"github.com/micro-community/auth/repository"
+)
+
+//unitOfWork of store repositories is not atomic, the store has no transactions,
+//each operation applies at once and nothing rolls back
+type unitOfWork struct {
+}
+
+func NewUnitOfWork() repository.UnitOfWork {
+	return unitOfWork{}
+}
+
+func (unitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
@@ -0,0 +1,161 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	mstore "github.com/micro/micro/v3/service/store"
+)
+
+//userRepository store users in the collection users, ids are taken from the counters
+type userRepository struct {
+	store mstore.Store
+	mu    *sync.Mutex
+}
+
+func NewUserRepository(s mstore.Store) repository.IUser {
+	return &userRepository{
+		store: s,
+		mu:    lockOf(s),
+	}
+}
+
+func (r *userRepository) FindById(ctx context.Context, id int64) (*models.User, error) {
+	var user models.User
+	found, err := users.find(ctx, r.store, id, &user, &user.ModelExtension)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errs.NewNotFound("user %d not found", id)
+	}
+	return &user, nil
+}
+
+func (r *userRepository) FindByName(ctx context.Context, name string) (*models.User, error) {
+	var user models.User
+	found, err := users.findByName(ctx, r.store, tenantName(repository.NameTenant(ctx), name), &user, &user.ModelExtension)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errs.NewNotFound("user %s not found", name)
+	}
+	return &user, nil
+}
+
+func (r *userRepository) Add(ctx context.Context, user *models.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	taken, err := users.taken(r.store, tenantName(user.TenantID, user.Name))
+	if err != nil {
+		return err
+	}
+	if taken {
+		return errs.NewAlreadyExists("user %s already exists", user.Name)
+	}
+
+	id, err := nextID(r.store, users.name)
+	if err != nil {
+		return err
+	}
+	user.ID = id
+	if user.CreatedAt.IsZero() {
+		user.CreatedAt = time.Now()
+	}
+	user.Version = 1
+	return users.put(r.store, id, tenantName(user.TenantID, user.Name), "", user)
+}
+
+func (r *userRepository) Update(ctx context.Context, user *models.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var stored models.User
+	found, err := users.load(r.store, user.ID, &stored)
+	if err != nil {
+		return err
+	}
+	if err = users.checkLive(found, stored.ModelExtension, user.ID, user.Version); err != nil {
+		return err
+	}
+	if tenantName(stored.TenantID, stored.Name) != tenantName(user.TenantID, user.Name) {
+		if taken, err := users.taken(r.store, tenantName(user.TenantID, user.Name)); err != nil {
+			return err
+		} else if taken {
+			return errs.NewAlreadyExists("user %s already exists", user.Name)
+		}
+	}
+
+	user.UpdatedAt = time.Now()
+	user.Version++
+	if err = users.put(r.store, user.ID, tenantName(user.TenantID, user.Name), tenantName(stored.TenantID, stored.Name), user); err != nil {
+		user.Version--
+		return err
+	}
+	return nil
+}
+
+//Delete mark the user deleted, links to its roles are kept for a restore
+func (r *userRepository) Delete(ctx context.Context, id, version int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return users.setDeleted(r.store, id, version, true)
+}
+
+func (r *userRepository) Restore(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return users.setDeleted(r.store, id, 0, false)
+}
+
+//Purge users deleted before the time with the links to their roles
+func (r *userRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return users.purge(r.store, before, func(id int64) error { return userRoles.unlinkFrom(r.store, id) })
+}
+
+func (r *userRepository) List(ctx context.Context, opts repository.ListOptions) ([]*models.User, int64, error) {
+	records, err := scan(r.store, users.idPrefix())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	all := make([]*models.User, 0, len(records))
+	items := make([]repository.ListItem, 0, len(records))
+	for _, record := range records {
+		user := &models.User{}
+		if err = json.Unmarshal(record.Value, user); err != nil {
+			return nil, 0, storeError(err)
+		}
+		if !visible(ctx, user.ModelExtension) {
+			continue
+		}
+		items = append(items, repository.ListItem{
+			Index:   len(all),
+			ID:      user.ID,
+			Name:    user.Name,
+			Tenant:  user.TenantID,
+			Status:  user.Stated,
+			Created: user.CreatedAt,
+		})
+		all = append(all, user)
+	}
+
+	indexes, total := repository.ListPage(items, opts)
+	result := make([]*models.User, 0, len(indexes))
+	for _, index := range indexes {
+		result = append(result, all[index])
+	}
+	return result, total, nil
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/micro-community/auth/models"
+)
+
+type tenantKey struct{}
+
+//WithTenant return a ctx of the tenant, repositories scoped by tenant only see and write its entities
+func WithTenant(ctx context.Context, tenant int) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+//TenantOf return the tenant of ctx, false for a ctx of no tenant, e.g. of internal calls
+func TenantOf(ctx context.Context) (int, bool) {
+	tenant, ok := ctx.Value(tenantKey{}).(int)
+	return tenant, ok
+}
+
+//ScopedTenant return the tenant reads and writes with ctx are limited to,
+//false for a ctx of no tenant or of the super tenant
+func ScopedTenant(ctx context.Context) (int, bool) {
+	tenant, ok := TenantOf(ctx)
+	return tenant, ok && tenant != models.SuperTenant
+}
+
+//NameTenant return the tenant FindByName looks a name up in, names of users, roles and resources are unique in a tenant.
+//A ctx of no tenant looks up in the super tenant
+func NameTenant(ctx context.Context) int {
+	tenant, _ := TenantOf(ctx)
+	return tenant
+}
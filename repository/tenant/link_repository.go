@@ -0,0 +1,145 @@
+package tenant
+
+import (
+	"context"
+
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+)
+
+type linkRepository struct {
+	links     repository.ILink
+	users     repository.IUser
+	roles     repository.IRole
+	resources repository.IResource
+}
+
+//NewLinkRepository scope links to the tenant of ctx, both ends of a link are found in the scoped
+//users, roles and resources, so a tenant can only link and read its own entities
+func NewLinkRepository(links repository.ILink, users repository.IUser, roles repository.IRole, resources repository.IResource) repository.ILink {
+	return &linkRepository{
+		links:     links,
+		users:     NewUserRepository(users),
+		roles:     NewRoleRepository(roles),
+		resources: NewResourceRepository(resources),
+	}
+}
+
+//ownUserRole check both ends of a link of a user to a role, deleted ones are left for the links to report
+func (r *linkRepository) ownUserRole(ctx context.Context, userID int64, roleID int) error {
+	if _, ok := repository.ScopedTenant(ctx); !ok {
+		return nil
+	}
+	ctx = repository.WithDeleted(ctx)
+	if _, err := r.users.FindById(ctx, userID); err != nil {
+		return err
+	}
+	_, err := r.roles.FindById(ctx, int64(roleID))
+	return err
+}
+
+func (r *linkRepository) ownRoleResource(ctx context.Context, roleID, resourceID int) error {
+	if _, ok := repository.ScopedTenant(ctx); !ok {
+		return nil
+	}
+	ctx = repository.WithDeleted(ctx)
+	if _, err := r.roles.FindById(ctx, int64(roleID)); err != nil {
+		return err
+	}
+	_, err := r.resources.FindById(ctx, int64(resourceID))
+	return err
+}
+
+func (r *linkRepository) LinkUserRole(ctx context.Context, userID int64, roleID int) error {
+	if err := r.ownUserRole(ctx, userID, roleID); err != nil {
+		return err
+	}
+	return r.links.LinkUserRole(ctx, userID, roleID)
+}
+
+func (r *linkRepository) UnlinkUserRole(ctx context.Context, userID int64, roleID int) error {
+	if err := r.ownUserRole(ctx, userID, roleID); err != nil {
+		return err
+	}
+	return r.links.UnlinkUserRole(ctx, userID, roleID)
+}
+
+func (r *linkRepository) LinkRoleResource(ctx context.Context, roleID, resourceID int) error {
+	if err := r.ownRoleResource(ctx, roleID, resourceID); err != nil {
+		return err
+	}
+	return r.links.LinkRoleResource(ctx, roleID, resourceID)
+}
+
+func (r *linkRepository) UnlinkRoleResource(ctx context.Context, roleID, resourceID int) error {
+	if err := r.ownRoleResource(ctx, roleID, resourceID); err != nil {
+		return err
+	}
+	return r.links.UnlinkRoleResource(ctx, roleID, resourceID)
+}
+
+func (r *linkRepository) UserRoles(ctx context.Context, userID int64) ([]*models.Role, error) {
+	if _, ok := repository.ScopedTenant(ctx); !ok {
+		return r.links.UserRoles(ctx, userID)
+	}
+	if _, err := r.users.FindById(repository.WithDeleted(ctx), userID); err != nil {
+		return nil, err
+	}
+	roles, err := r.links.UserRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return ownedRoles(ctx, roles), nil
+}
+
+func (r *linkRepository) RoleResources(ctx context.Context, roleID int) ([]*models.Resource, error) {
+	if _, ok := repository.ScopedTenant(ctx); !ok {
+		return r.links.RoleResources(ctx, roleID)
+	}
+	if _, err := r.roles.FindById(repository.WithDeleted(ctx), int64(roleID)); err != nil {
+		return nil, err
+	}
+	resources, err := r.links.RoleResources(ctx, roleID)
+	if err != nil {
+		return nil, err
+	}
+	return ownedResources(ctx, resources), nil
+}
+
+func (r *linkRepository) UserResources(ctx context.Context, userID int64) ([]*models.Resource, error) {
+	if _, ok := repository.ScopedTenant(ctx); !ok {
+		return r.links.UserResources(ctx, userID)
+	}
+	if _, err := r.users.FindById(repository.WithDeleted(ctx), userID); err != nil {
+		return nil, err
+	}
+	resources, err := r.links.UserResources(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return ownedResources(ctx, resources), nil
+}
+
+//ownedRoles keep the roles of the tenant of ctx, links made before tenancy may cross tenants
+func ownedRoles(ctx context.Context, roles []*models.Role) []*models.Role {
+	tenant, _ := repository.ScopedTenant(ctx)
+	owned := roles[:0]
+	for _, role := range roles {
+		if role.TenantID == tenant {
+			owned = append(owned, role)
+		}
+	}
+	return owned
+}
+
+//ownedResources keep the resources of the tenant of ctx
+func ownedResources(ctx context.Context, resources []*models.Resource) []*models.Resource {
+	tenant, _ := repository.ScopedTenant(ctx)
+	owned := resources[:0]
+	for _, resource := range resources {
+		if resource.TenantID == tenant {
+			owned = append(owned, resource)
+		}
+	}
+	return owned
+}
@@ -0,0 +1,31 @@
+package tenant
+
+import (
+	"context"
+
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+)
+
+type logRepository struct {
+	logs repository.ILog
+}
+
+//NewLogRepository scope logs to the tenant of ctx, a log appended in a tenant belongs to it
+func NewLogRepository(logs repository.ILog) repository.ILog {
+	return &logRepository{logs: logs}
+}
+
+func (r *logRepository) Append(ctx context.Context, log *models.Log) error {
+	if tenant, ok := repository.ScopedTenant(ctx); ok {
+		log.TenantID = tenant
+	}
+	return r.logs.Append(ctx, log)
+}
+
+func (r *logRepository) Query(ctx context.Context, opts repository.LogQuery) ([]*models.Log, error) {
+	if tenant, ok := repository.ScopedTenant(ctx); ok {
+		opts.TenantID = tenant
+	}
+	return r.logs.Query(ctx, opts)
+}
@@ -0,0 +1,172 @@
+package tenant
+
+import (
+	"context"
+
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+)
+
+type orgRepository struct {
+	org   repository.IOrg
+	users repository.IUser
+	roles repository.IRole
+}
+
+//NewOrgRepository scope org units to the tenant of ctx, members and grants are scoped by their units,
+//users and roles, so a tenant can only assign and grant its own entities
+func NewOrgRepository(org repository.IOrg, users repository.IUser, roles repository.IRole) repository.IOrg {
+	return &orgRepository{
+		org:   org,
+		users: NewUserRepository(users),
+		roles: NewRoleRepository(roles),
+	}
+}
+
+func (r *orgRepository) FindById(ctx context.Context, id int) (*models.OrgUnit, error) {
+	unit, err := r.org.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err = own(ctx, "org unit", id, unit.TenantID); err != nil {
+		return nil, err
+	}
+	return unit, nil
+}
+
+//ownParent check the parent of a unit written
+func (r *orgRepository) ownParent(ctx context.Context, unit *models.OrgUnit) error {
+	if unit.ParentID == 0 {
+		return nil
+	}
+	_, err := r.FindById(ctx, unit.ParentID)
+	return err
+}
+
+func (r *orgRepository) Add(ctx context.Context, unit *models.OrgUnit) error {
+	if err := assign(ctx, &unit.TenantID); err != nil {
+		return err
+	}
+	if err := r.ownParent(ctx, unit); err != nil {
+		return err
+	}
+	return r.org.Add(ctx, unit)
+}
+
+func (r *orgRepository) Update(ctx context.Context, unit *models.OrgUnit) error {
+	if _, err := r.FindById(ctx, unit.ID); err != nil {
+		return err
+	}
+	if err := assign(ctx, &unit.TenantID); err != nil {
+		return err
+	}
+	if err := r.ownParent(ctx, unit); err != nil {
+		return err
+	}
+	return r.org.Update(ctx, unit)
+}
+
+func (r *orgRepository) Delete(ctx context.Context, id int, version int64) error {
+	if _, err := r.FindById(ctx, id); err != nil {
+		return err
+	}
+	return r.org.Delete(ctx, id, version)
+}
+
+func (r *orgRepository) List(ctx context.Context) ([]*models.OrgUnit, error) {
+	units, err := r.org.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenant, ok := repository.ScopedTenant(ctx)
+	if !ok {
+		return units, nil
+	}
+	owned := units[:0]
+	for _, unit := range units {
+		if unit.TenantID == tenant {
+			owned = append(owned, unit)
+		}
+	}
+	return owned, nil
+}
+
+//SetMember check the user, department and position of the member, deleted users are left for the service
+func (r *orgRepository) SetMember(ctx context.Context, member *models.OrgMember) error {
+	if err := assign(ctx, &member.TenantID); err != nil {
+		return err
+	}
+	if _, ok := repository.ScopedTenant(ctx); ok {
+		if _, err := r.users.FindById(repository.WithDeleted(ctx), member.UserID); err != nil {
+			return err
+		}
+		for _, unitID := range []int{member.DeptID, member.PositionID} {
+			if unitID == 0 {
+				continue
+			}
+			if _, err := r.FindById(ctx, unitID); err != nil {
+				return err
+			}
+		}
+	}
+	return r.org.SetMember(ctx, member)
+}
+
+func (r *orgRepository) RemoveMember(ctx context.Context, userID int64) error {
+	if _, err := r.MemberOf(ctx, userID); err != nil {
+		return err
+	}
+	return r.org.RemoveMember(ctx, userID)
+}
+
+func (r *orgRepository) MemberOf(ctx context.Context, userID int64) (*models.OrgMember, error) {
+	member, err := r.org.MemberOf(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err = own(ctx, "org member", userID, member.TenantID); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+func (r *orgRepository) Members(ctx context.Context, unitID int) ([]*models.OrgMember, error) {
+	if _, err := r.FindById(ctx, unitID); err != nil {
+		return nil, err
+	}
+	return r.org.Members(ctx, unitID)
+}
+
+//ownGrant check both ends of a grant, deleted roles are left for the service
+func (r *orgRepository) ownGrant(ctx context.Context, unitID, roleID int) error {
+	if _, ok := repository.ScopedTenant(ctx); !ok {
+		return nil
+	}
+	if _, err := r.FindById(ctx, unitID); err != nil {
+		return err
+	}
+	_, err := r.roles.FindById(repository.WithDeleted(ctx), int64(roleID))
+	return err
+}
+
+func (r *orgRepository) Grant(ctx context.Context, unitID, roleID int) error {
+	if err := r.ownGrant(ctx, unitID, roleID); err != nil {
+		return err
+	}
+	return r.org.Grant(ctx, unitID, roleID)
+}
+
+//Revoke only check the unit, a grant of a unit is owned by it, even of a role purged
+func (r *orgRepository) Revoke(ctx context.Context, unitID, roleID int) error {
+	if _, err := r.FindById(ctx, unitID); err != nil {
+		return err
+	}
+	return r.org.Revoke(ctx, unitID, roleID)
+}
+
+func (r *orgRepository) Grants(ctx context.Context, unitID int) ([]int, error) {
+	if _, err := r.FindById(ctx, unitID); err != nil {
+		return nil, err
+	}
+	return r.org.Grants(ctx, unitID)
+}
@@ -0,0 +1,90 @@
+package tenant
+
+import (
+	"context"
+	"time"
+
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+)
+
+type resourceRepository struct {
+	resources repository.IResource
+}
+
+//NewResourceRepository scope resources to the tenant of ctx
+func NewResourceRepository(resources repository.IResource) repository.IResource {
+	return &resourceRepository{resources: resources}
+}
+
+func (r *resourceRepository) FindById(ctx context.Context, id int64) (*models.Resource, error) {
+	resource, err := r.resources.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err = own(ctx, "resource", id, resource.TenantID); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+func (r *resourceRepository) FindByName(ctx context.Context, name string) (*models.Resource, error) {
+	resource, err := r.resources.FindByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if err = own(ctx, "resource", name, resource.TenantID); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+func (r *resourceRepository) Add(ctx context.Context, resource *models.Resource) error {
+	if err := assign(ctx, &resource.TenantID); err != nil {
+		return err
+	}
+	return r.resources.Add(ctx, resource)
+}
+
+func (r *resourceRepository) Update(ctx context.Context, resource *models.Resource) error {
+	if _, err := r.FindById(repository.WithDeleted(ctx), int64(resource.ID)); err != nil {
+		return err
+	}
+	if err := assign(ctx, &resource.TenantID); err != nil {
+		return err
+	}
+	return r.resources.Update(ctx, resource)
+}
+
+func (r *resourceRepository) Delete(ctx context.Context, id, version int64) error {
+	if _, err := r.FindById(repository.WithDeleted(ctx), id); err != nil {
+		return err
+	}
+	return r.resources.Delete(ctx, id, version)
+}
+
+func (r *resourceRepository) Restore(ctx context.Context, id int64) error {
+	if _, err := r.FindById(repository.WithDeleted(ctx), id); err != nil {
+		return err
+	}
+	return r.resources.Restore(ctx, id)
+}
+
+func (r *resourceRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	if err := mustBeSuper(ctx, "purge resources"); err != nil {
+		return 0, err
+	}
+	return r.resources.Purge(ctx, before)
+}
+
+func (r *resourceRepository) List(ctx context.Context, opts repository.ListOptions) ([]*models.Resource, int64, error) {
+	scope(ctx, &opts)
+	return r.resources.List(ctx, opts)
+}
+
+func (r *resourceRepository) Search(ctx context.Context, tenantID int, types ...models.ResourceCatalog) ([]*models.Resource, error) {
+	if tenant, ok := repository.ScopedTenant(ctx); ok {
+		tenantID = tenant
+	}
+	return r.resources.Search(ctx, tenantID, types...)
+}
@@ -0,0 +1,83 @@
+package tenant
+
+import (
+	"context"
+	"time"
+
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+)
+
+type roleRepository struct {
+	roles repository.IRole
+}
+
+//NewRoleRepository scope roles to the tenant of ctx
+func NewRoleRepository(roles repository.IRole) repository.IRole {
+	return &roleRepository{roles: roles}
+}
+
+func (r *roleRepository) FindById(ctx context.Context, id int64) (*models.Role, error) {
+	role, err := r.roles.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err = own(ctx, "role", id, role.TenantID); err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+func (r *roleRepository) FindByName(ctx context.Context, name string) (*models.Role, error) {
+	role, err := r.roles.FindByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if err = own(ctx, "role", name, role.TenantID); err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+func (r *roleRepository) Add(ctx context.Context, role *models.Role) error {
+	if err := assign(ctx, &role.TenantID); err != nil {
+		return err
+	}
+	return r.roles.Add(ctx, role)
+}
+
+func (r *roleRepository) Update(ctx context.Context, role *models.Role) error {
+	if _, err := r.FindById(repository.WithDeleted(ctx), int64(role.ID)); err != nil {
+		return err
+	}
+	if err := assign(ctx, &role.TenantID); err != nil {
+		return err
+	}
+	return r.roles.Update(ctx, role)
+}
+
+func (r *roleRepository) Delete(ctx context.Context, id, version int64) error {
+	if _, err := r.FindById(repository.WithDeleted(ctx), id); err != nil {
+		return err
+	}
+	return r.roles.Delete(ctx, id, version)
+}
+
+func (r *roleRepository) Restore(ctx context.Context, id int64) error {
+	if _, err := r.FindById(repository.WithDeleted(ctx), id); err != nil {
+		return err
+	}
+	return r.roles.Restore(ctx, id)
+}
+
+func (r *roleRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	if err := mustBeSuper(ctx, "purge roles"); err != nil {
+		return 0, err
+	}
+	return r.roles.Purge(ctx, before)
+}
+
+func (r *roleRepository) List(ctx context.Context, opts repository.ListOptions) ([]*models.Role, int64, error) {
+	scope(ctx, &opts)
+	return r.roles.List(ctx, opts)
+}
@@ -0,0 +1,47 @@
+//Package tenant scope repositories of any backend to the tenant of ctx, see repository.WithTenant.
+//A ctx of no tenant or of the super tenant is not scoped.
+//Entities of other tenants are reported missing, so a tenant never learns about them.
+package tenant
+
+import (
+	"context"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/repository"
+)
+
+//own return an errs.NotFound error when the entity of id does not belong to the tenant of ctx
+func own(ctx context.Context, kind string, id interface{}, tenantID int) error {
+	if tenant, ok := repository.ScopedTenant(ctx); ok && tenantID != tenant {
+		return errs.NewNotFound("%s %v not found", kind, id)
+	}
+	return nil
+}
+
+//assign the tenant of ctx to an entity written, an entity of another tenant is rejected
+func assign(ctx context.Context, tenantID *int) error {
+	tenant, ok := repository.ScopedTenant(ctx)
+	if !ok {
+		return nil
+	}
+	if *tenantID != 0 && *tenantID != tenant {
+		return errs.NewPermissionDenied("tenant %d can not write entities of tenant %d", tenant, *tenantID)
+	}
+	*tenantID = tenant
+	return nil
+}
+
+//scope the list options to the tenant of ctx
+func scope(ctx context.Context, opts *repository.ListOptions) {
+	if tenant, ok := repository.ScopedTenant(ctx); ok {
+		opts.TenantID = tenant
+	}
+}
+
+//mustBeSuper return an errs.PermissionDenied error for a ctx scoped to a tenant
+func mustBeSuper(ctx context.Context, op string) error {
+	if tenant, ok := repository.ScopedTenant(ctx); ok {
+		return errs.NewPermissionDenied("tenant %d can not %s, only the super tenant can", tenant, op)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package tenant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	"github.com/micro-community/auth/repository/memory"
+)
+
+func TestTenantIsolation(t *testing.T) {
+	backendUsers, backendRoles, backendResources := memory.NewUserRepository(), memory.NewRoleRepository(), memory.NewResourceRepository()
+	users, roles := NewUserRepository(backendUsers), NewRoleRepository(backendRoles)
+	links := NewLinkRepository(memory.NewLinkRepository(backendUsers, backendRoles, backendResources), backendUsers, backendRoles, backendResources)
+	logs := NewLogRepository(memory.NewLogRepository())
+
+	acme := repository.WithTenant(context.Background(), 1)
+	umbrella := repository.WithTenant(context.Background(), 2)
+	super := repository.WithTenant(context.Background(), models.SuperTenant)
+
+	alice := &models.User{Name: "alice"}
+	if err := users.Add(acme, alice); err != nil || alice.TenantID != 1 {
+		t.Fatalf("add should assign the tenant, got %d %v", alice.TenantID, err)
+	}
+	if err := users.Add(acme, &models.User{Name: "mallory", TenantID: 2}); errs.CodeOf(err) != errs.PermissionDenied {
+		t.Fatalf("add to another tenant should be denied, got %v", err)
+	}
+	bob := &models.User{Name: "bob"}
+	if err := users.Add(umbrella, bob); err != nil {
+		t.Fatal(err)
+	}
+	editor := &models.Role{Name: "editor"}
+	if err := roles.Add(umbrella, editor); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := users.FindById(umbrella, alice.ID); errs.CodeOf(err) != errs.NotFound {
+		t.Errorf("find a user of another tenant should not be found, got %v", err)
+	}
+	if _, err := users.FindByName(umbrella, "alice"); errs.CodeOf(err) != errs.NotFound {
+		t.Errorf("find a user of another tenant by name should not be found, got %v", err)
+	}
+	if err := users.Delete(umbrella, alice.ID, alice.Version); errs.CodeOf(err) != errs.NotFound {
+		t.Errorf("delete a user of another tenant should not be found, got %v", err)
+	}
+	if err := links.LinkUserRole(acme, alice.ID, editor.ID); errs.CodeOf(err) != errs.NotFound {
+		t.Errorf("link to a role of another tenant should not be found, got %v", err)
+	}
+	if _, err := users.Purge(acme, alice.CreatedAt); errs.CodeOf(err) != errs.PermissionDenied {
+		t.Errorf("purge by a tenant should be denied, got %v", err)
+	}
+
+	listed, total, err := users.List(acme, repository.ListOptions{TenantID: 2})
+	if err != nil || total != 1 || listed[0].ID != alice.ID {
+		t.Errorf("list should only see users of the tenant, got %d %v", total, err)
+	}
+	//admin is seeded in the super tenant
+	if _, total, _ = users.List(super, repository.ListOptions{}); total != 3 {
+		t.Errorf("super tenant should see users of every tenant, got %d", total)
+	}
+	if err = links.LinkUserRole(super, alice.ID, editor.ID); err != nil {
+		t.Errorf("super tenant should link across tenants: %v", err)
+	}
+	if linked, _ := links.UserRoles(acme, alice.ID); len(linked) != 0 {
+		t.Errorf("roles of another tenant linked by the super tenant should be hidden, got %d", len(linked))
+	}
+
+	if err = logs.Append(acme, &models.Log{Actor: "alice", TenantID: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if found, _ := logs.Query(umbrella, repository.LogQuery{}); len(found) != 0 {
+		t.Errorf("logs of another tenant should be hidden, got %d", len(found))
+	}
+	if found, _ := logs.Query(super, repository.LogQuery{}); len(found) != 1 || found[0].TenantID != 1 {
+		t.Errorf("log should belong to the tenant it was appended in, got %+v", found)
+	}
+}
@@ -0,0 +1,83 @@
+package tenant
+
+import (
+	"context"
+	"time"
+
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+)
+
+type userRepository struct {
+	users repository.IUser
+}
+
+//NewUserRepository scope users to the tenant of ctx
+func NewUserRepository(users repository.IUser) repository.IUser {
+	return &userRepository{users: users}
+}
+
+func (r *userRepository) FindById(ctx context.Context, id int64) (*models.User, error) {
+	user, err := r.users.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err = own(ctx, "user", id, user.TenantID); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (r *userRepository) FindByName(ctx context.Context, name string) (*models.User, error) {
+	user, err := r.users.FindByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if err = own(ctx, "user", name, user.TenantID); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (r *userRepository) Add(ctx context.Context, user *models.User) error {
+	if err := assign(ctx, &user.TenantID); err != nil {
+		return err
+	}
+	return r.users.Add(ctx, user)
+}
+
+func (r *userRepository) Update(ctx context.Context, user *models.User) error {
+	if _, err := r.FindById(repository.WithDeleted(ctx), user.ID); err != nil {
+		return err
+	}
+	if err := assign(ctx, &user.TenantID); err != nil {
+		return err
+	}
+	return r.users.Update(ctx, user)
+}
+
+func (r *userRepository) Delete(ctx context.Context, id, version int64) error {
+	if _, err := r.FindById(repository.WithDeleted(ctx), id); err != nil {
+		return err
+	}
+	return r.users.Delete(ctx, id, version)
+}
+
+func (r *userRepository) Restore(ctx context.Context, id int64) error {
+	if _, err := r.FindById(repository.WithDeleted(ctx), id); err != nil {
+		return err
+	}
+	return r.users.Restore(ctx, id)
+}
+
+func (r *userRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	if err := mustBeSuper(ctx, "purge users"); err != nil {
+		return 0, err
+	}
+	return r.users.Purge(ctx, before)
+}
+
+func (r *userRepository) List(ctx context.Context, opts repository.ListOptions) ([]*models.User, int64, error) {
+	scope(ctx, &opts)
+	return r.users.List(ctx, opts)
+}
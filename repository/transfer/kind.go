@@ -0,0 +1,254 @@
+package transfer
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+)
+
+//entity of any kind as the transfer sees it, tenant is the tenant in src its name is unique in
+type entity struct {
+	id      int64
+	name    string
+	tenant  int
+	deleted bool
+	item    interface{}
+}
+
+//kind of entities to transfer, ids map source ids to target ids
+type kind struct {
+	name       string
+	ids        map[int64]int64
+	list       func(ctx context.Context, offset, limit int) ([]entity, error)
+	add        func(ctx context.Context, e entity) (int64, error)
+	findByName func(ctx context.Context, name string) (int64, error)
+	delete     func(ctx context.Context, id int64) error
+	count      func(ctx context.Context, r Repositories) (int64, error)
+}
+
+//page of a list in order of id
+func page(offset, limit int) repository.ListOptions {
+	return repository.ListOptions{SortBy: repository.SortByID, Offset: offset, Limit: limit}
+}
+
+//live reset the fields of ext kept by the target, the entity is deleted after its links are copied
+func live(ext *models.ModelExtension) {
+	ext.IsSoftDel, ext.DeletedAt, ext.Version = false, time.Time{}, 0
+}
+
+//mapTenant return the id of the tenant in dst of the tenant id in src,
+//the super tenant and deleted tenants keep their ids
+func mapTenant(state *State, id int) int {
+	if mapped, ok := state.Tenants[int64(id)]; ok {
+		return int(mapped)
+	}
+	return id
+}
+
+//kindsOf tenants, users, roles, resources and role templates in the order of transfer
+func kindsOf(src, dst Repositories, state *State) []kind {
+	return []kind{
+		{
+			name: "tenants",
+			ids:  state.Tenants,
+			list: func(ctx context.Context, offset, limit int) ([]entity, error) {
+				//tenants are few, they are listed at once
+				tenants, err := src.Tenants.List(ctx)
+				if offset >= len(tenants) {
+					return nil, err
+				}
+				if tenants = tenants[offset:]; len(tenants) > limit {
+					tenants = tenants[:limit]
+				}
+				batch := make([]entity, 0, len(tenants))
+				for _, tenant := range tenants {
+					batch = append(batch, entity{int64(tenant.ID), tenant.Name, models.SuperTenant, false, tenant})
+				}
+				return batch, err
+			},
+			add: func(ctx context.Context, e entity) (int64, error) {
+				tenant := *e.item.(*models.Tenant)
+				live(&tenant.ModelExtension)
+				err := dst.Tenants.Add(ctx, &tenant)
+				return int64(tenant.ID), err
+			},
+			findByName: func(ctx context.Context, name string) (int64, error) {
+				tenant, err := dst.Tenants.FindByName(ctx, name)
+				if err != nil {
+					return 0, err
+				}
+				return int64(tenant.ID), nil
+			},
+			//a deleted tenant is removed for good, it is never listed
+			delete: func(ctx context.Context, id int64) error { return nil },
+			count: func(ctx context.Context, r Repositories) (int64, error) {
+				tenants, err := r.Tenants.List(ctx)
+				return int64(len(tenants)), err
+			},
+		},
+		{
+			name: "users",
+			ids:  state.Users,
+			list: func(ctx context.Context, offset, limit int) ([]entity, error) {
+				users, _, err := src.Users.List(ctx, page(offset, limit))
+				batch := make([]entity, 0, len(users))
+				for _, user := range users {
+					batch = append(batch, entity{user.ID, user.Name, user.TenantID, user.IsSoftDel, user})
+				}
+				return batch, err
+			},
+			add: func(ctx context.Context, e entity) (int64, error) {
+				user := *e.item.(*models.User)
+				user.Uid, user.Type, user.Roles = "", "", nil
+				user.TenantID = mapTenant(state, user.TenantID)
+				live(&user.ModelExtension)
+				err := dst.Users.Add(ctx, &user)
+				return user.ID, err
+			},
+			findByName: func(ctx context.Context, name string) (int64, error) {
+				user, err := dst.Users.FindByName(ctx, name)
+				if err != nil {
+					return 0, err
+				}
+				return user.ID, nil
+			},
+			delete: func(ctx context.Context, id int64) error {
+				user, err := dst.Users.FindById(ctx, id)
+				if err != nil || user.IsSoftDel {
+					return err
+				}
+				return dst.Users.Delete(ctx, id, user.Version)
+			},
+			count: func(ctx context.Context, r Repositories) (int64, error) {
+				_, total, err := r.Users.List(ctx, page(0, 1))
+				return total, err
+			},
+		},
+		{
+			name: "roles",
+			ids:  state.Roles,
+			list: func(ctx context.Context, offset, limit int) ([]entity, error) {
+				roles, _, err := src.Roles.List(ctx, page(offset, limit))
+				batch := make([]entity, 0, len(roles))
+				for _, role := range roles {
+					batch = append(batch, entity{int64(role.ID), role.Name, role.TenantID, role.IsSoftDel, role})
+				}
+				return batch, err
+			},
+			add: func(ctx context.Context, e entity) (int64, error) {
+				role := *e.item.(*models.Role)
+				role.Uid, role.Type, role.Resources = "", "", nil
+				role.TenantID = mapTenant(state, role.TenantID)
+				live(&role.ModelExtension)
+				err := dst.Roles.Add(ctx, &role)
+				return int64(role.ID), err
+			},
+			findByName: func(ctx context.Context, name string) (int64, error) {
+				role, err := dst.Roles.FindByName(ctx, name)
+				if err != nil {
+					return 0, err
+				}
+				return int64(role.ID), nil
+			},
+			delete: func(ctx context.Context, id int64) error {
+				role, err := dst.Roles.FindById(ctx, id)
+				if err != nil || role.IsSoftDel {
+					return err
+				}
+				return dst.Roles.Delete(ctx, id, role.Version)
+			},
+			count: func(ctx context.Context, r Repositories) (int64, error) {
+				_, total, err := r.Roles.List(ctx, page(0, 1))
+				return total, err
+			},
+		},
+		{
+			name: "resources",
+			ids:  state.Resources,
+			list: func(ctx context.Context, offset, limit int) ([]entity, error) {
+				resources, _, err := src.Resources.List(ctx, page(offset, limit))
+				batch := make([]entity, 0, len(resources))
+				for _, resource := range resources {
+					batch = append(batch, entity{int64(resource.ID), resource.Name, resource.TenantID, resource.IsSoftDel, resource})
+				}
+				return batch, err
+			},
+			add: func(ctx context.Context, e entity) (int64, error) {
+				resource := *e.item.(*models.Resource)
+				resource.Uid = ""
+				resource.TenantID = mapTenant(state, resource.TenantID)
+				live(&resource.ModelExtension)
+				err := dst.Resources.Add(ctx, &resource)
+				return int64(resource.ID), err
+			},
+			findByName: func(ctx context.Context, name string) (int64, error) {
+				resource, err := dst.Resources.FindByName(ctx, name)
+				if err != nil {
+					return 0, err
+				}
+				return int64(resource.ID), nil
+			},
+			delete: func(ctx context.Context, id int64) error {
+				resource, err := dst.Resources.FindById(ctx, id)
+				if err != nil || resource.IsSoftDel {
+					return err
+				}
+				return dst.Resources.Delete(ctx, id, resource.Version)
+			},
+			count: func(ctx context.Context, r Repositories) (int64, error) {
+				_, total, err := r.Resources.List(ctx, page(0, 1))
+				return total, err
+			},
+		},
+		{
+			name: "role templates",
+			ids:  state.Templates,
+			list: func(ctx context.Context, offset, limit int) ([]entity, error) {
+				//templates are few, they are listed at once
+				templates, err := src.Templates.List(ctx)
+				if offset >= len(templates) {
+					return nil, err
+				}
+				if templates = templates[offset:]; len(templates) > limit {
+					templates = templates[:limit]
+				}
+				batch := make([]entity, 0, len(templates))
+				for _, template := range templates {
+					batch = append(batch, entity{int64(template.ID), template.Name, models.SuperTenant, false, template})
+				}
+				return batch, err
+			},
+			add: func(ctx context.Context, e entity) (int64, error) {
+				template := *e.item.(*models.RoleTemplate)
+				live(&template.ModelExtension)
+				err := dst.Templates.Add(ctx, &template)
+				return int64(template.ID), err
+			},
+			findByName: func(ctx context.Context, name string) (int64, error) {
+				template, err := dst.Templates.FindByName(ctx, name)
+				if err != nil {
+					return 0, err
+				}
+				return int64(template.ID), nil
+			},
+			//a deleted template is removed for good, it is never listed
+			delete: func(ctx context.Context, id int64) error { return nil },
+			count: func(ctx context.Context, r Repositories) (int64, error) {
+				templates, err := r.Templates.List(ctx)
+				return int64(len(templates)), err
+			},
+		},
+	}
+}
+
+func sortedKeys(ids map[int64]int64) []int64 {
+	keys := make([]int64, 0, len(ids))
+	for id := range ids {
+		keys = append(keys, id)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
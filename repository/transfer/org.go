@@ -0,0 +1,131 @@
+package transfer
+
+import (
+	"context"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+)
+
+//ParentsFirst order units so every unit follows its parent, in order of id otherwise.
+//Units whose parents are missing go last.
+func ParentsFirst(units []*models.OrgUnit) []*models.OrgUnit {
+	ordered := make([]*models.OrgUnit, 0, len(units))
+	placed := map[int]bool{0: true}
+	for len(units) > 0 {
+		var rest []*models.OrgUnit
+		for _, unit := range units {
+			if placed[unit.ParentID] {
+				ordered = append(ordered, unit)
+				placed[unit.ID] = true
+			} else {
+				rest = append(rest, unit)
+			}
+		}
+		if len(rest) == len(units) {
+			return append(ordered, rest...)
+		}
+		units = rest
+	}
+	return ordered
+}
+
+//copyUnits add the units of src not mapped yet to dst, parents first, so the parents of units are mapped
+func (t *transfer) copyUnits(ctx context.Context) error {
+	units, err := t.src.Org.List(ctx)
+	if err != nil {
+		return err
+	}
+	for i, unit := range ParentsFirst(units) {
+		if _, ok := t.state.Units[int64(unit.ID)]; !ok {
+			if err = t.addUnit(ctx, unit); err != nil {
+				return err
+			}
+		}
+		if (i+1)%t.opts.BatchSize == 0 || i == len(units)-1 {
+			if err = t.opts.Save(t.state); err != nil {
+				return err
+			}
+			t.opts.Progress("org units", i+1)
+		}
+	}
+	return nil
+}
+
+//addUnit add the unit to dst and map its id, or map the unit of its name under the same parent in dst
+func (t *transfer) addUnit(ctx context.Context, unit *models.OrgUnit) error {
+	copied := *unit
+	live(&copied.ModelExtension)
+	copied.TenantID = mapTenant(t.state, unit.TenantID)
+	if unit.ParentID != 0 {
+		parentID, ok := t.state.Units[int64(unit.ParentID)]
+		if !ok {
+			return errs.NewInvalidArgument("transfer org unit %d %s: parent %d is missing", unit.ID, unit.Name, unit.ParentID)
+		}
+		copied.ParentID = int(parentID)
+	}
+	err := t.dst.Org.Add(ctx, &copied)
+	if errs.CodeOf(err) == errs.AlreadyExists {
+		copied.ID, err = findUnit(ctx, t.dst.Org, &copied)
+	}
+	if err != nil {
+		return errs.Wrap(err, errs.CodeOf(err), "transfer org unit %d %s", unit.ID, unit.Name)
+	}
+	t.state.Units[int64(unit.ID)] = int64(copied.ID)
+	return nil
+}
+
+//findUnit return the id of the unit of the name under the parent of unit in its tenant
+func findUnit(ctx context.Context, org repository.IOrg, unit *models.OrgUnit) (int, error) {
+	units, err := org.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, other := range units {
+		if other.Name == unit.Name && other.ParentID == unit.ParentID && other.TenantID == unit.TenantID {
+			return other.ID, nil
+		}
+	}
+	return 0, errs.NewNotFound("org unit %s not found", unit.Name)
+}
+
+//copyUnitLinks copy the members of the source department from and the roles granted to the source unit from
+//to the target unit to, users and roles not transferred are skipped
+func (t *transfer) copyUnitLinks(ctx context.Context, from, to int64) error {
+	members, err := t.src.Org.Members(ctx, int(from))
+	if err != nil {
+		return err
+	}
+	for _, member := range members {
+		userID, ok := t.state.Users[member.UserID]
+		//holders of a position are copied with their departments
+		if !ok || int64(member.DeptID) != from {
+			continue
+		}
+		copied := *member
+		copied.UserID, copied.DeptID = userID, int(to)
+		copied.TenantID = mapTenant(t.state, member.TenantID)
+		if positionID, ok := t.state.Units[int64(member.PositionID)]; ok {
+			copied.PositionID = int(positionID)
+		}
+		if err = t.dst.Org.SetMember(ctx, &copied); err != nil {
+			return errs.Wrap(err, errs.CodeOf(err), "copy member %d of org unit %d", member.UserID, from)
+		}
+	}
+
+	roleIDs, err := t.src.Org.Grants(ctx, int(from))
+	if err != nil {
+		return err
+	}
+	for _, id := range roleIDs {
+		roleID, ok := t.state.Roles[int64(id)]
+		if !ok {
+			continue
+		}
+		if err = t.dst.Org.Grant(ctx, int(to), int(roleID)); err != nil && errs.CodeOf(err) != errs.AlreadyExists {
+			return errs.Wrap(err, errs.CodeOf(err), "grant role %d to org unit %d", id, from)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,307 @@
+//Package transfer copy tenants, users, roles, resources, their links, role templates and their instances,
+//org units, their members and grants from the repositories of a backend to another.
+//Every backend assigns its own ids, so the ids of the source are mapped to the ids assigned by the target
+//and the map is kept in a State, which is saved after every batch to resume an interrupted transfer.
+package transfer
+
+import (
+	"context"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/repository"
+)
+
+//Repositories of a backend to transfer from or to
+type Repositories struct {
+	Tenants   repository.ITenant
+	Users     repository.IUser
+	Roles     repository.IRole
+	Resources repository.IResource
+	Links     repository.ILink
+	Templates repository.IRoleTemplate
+	Org       repository.IOrg
+}
+
+//Phase of a transfer, phases run in order
+type Phase int
+
+const (
+	CopyEntities Phase = iota
+	CopyLinks
+	DeleteEntities
+	Done
+)
+
+//State of a transfer, the maps are keyed by source ids
+type State struct {
+	From      string
+	To        string
+	Phase     Phase
+	Tenants   map[int64]int64
+	Users     map[int64]int64
+	Roles     map[int64]int64
+	Resources map[int64]int64
+	Templates map[int64]int64
+	Units     map[int64]int64
+	//last source user whose roles are linked, last source role whose resources are linked,
+	//last source template whose instances are copied, last source unit whose members and grants are copied
+	UserLinks int64
+	RoleLinks int64
+	Instances int64
+	UnitLinks int64
+}
+
+//NewState of a transfer from a backend to another
+func NewState(from, to string) *State {
+	return &State{
+		From:      from,
+		To:        to,
+		Tenants:   map[int64]int64{},
+		Users:     map[int64]int64{},
+		Roles:     map[int64]int64{},
+		Resources: map[int64]int64{},
+		Templates: map[int64]int64{},
+		Units:     map[int64]int64{},
+	}
+}
+
+//Options of a transfer
+type Options struct {
+	BatchSize int
+	//Save the state after every batch, a transfer resumes from the last saved state
+	Save func(state *State) error
+	//Progress of the phase, done of the items of the kind
+	Progress func(kind string, done int)
+}
+
+//Transfer copy the entities and links of src into dst from the state.
+//Entities are added live, then linked, the roles instantiated from templates are bound to them
+//and users and roles are assigned to org units, then the deleted ones of src are deleted in dst, so they can be restored.
+//An entity whose name is taken in dst is taken as transferred before the state was saved.
+//Tenants go first, so entities are added to the tenants of the ids mapped, org units go last, parents first.
+func Transfer(ctx context.Context, src, dst Repositories, state *State, opts Options) error {
+	//a state saved before tenants, templates and org units were transferred
+	if state.Tenants == nil {
+		state.Tenants = map[int64]int64{}
+	}
+	if state.Templates == nil {
+		state.Templates = map[int64]int64{}
+	}
+	if state.Units == nil {
+		state.Units = map[int64]int64{}
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 100
+	}
+	if opts.Save == nil {
+		opts.Save = func(*State) error { return nil }
+	}
+	if opts.Progress == nil {
+		opts.Progress = func(string, int) {}
+	}
+	ctx = repository.WithDeleted(ctx)
+	t := &transfer{src: src, dst: dst, state: state, opts: opts}
+
+	steps := []func(ctx context.Context) error{t.copyEntities, t.copyLinks, t.deleteEntities}
+	for state.Phase < Done {
+		if err := steps[state.Phase](ctx); err != nil {
+			return err
+		}
+		state.Phase++
+		if err := opts.Save(state); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type transfer struct {
+	src   Repositories
+	dst   Repositories
+	state *State
+	opts  Options
+}
+
+func (t *transfer) copyEntities(ctx context.Context) error {
+	for _, k := range kindsOf(t.src, t.dst, t.state) {
+		if err := t.copyKind(ctx, k); err != nil {
+			return err
+		}
+	}
+	return t.copyUnits(ctx)
+}
+
+//copyKind add the entities of src not mapped yet to dst batch by batch
+func (t *transfer) copyKind(ctx context.Context, k kind) error {
+	done := 0
+	for offset := 0; ; offset += t.opts.BatchSize {
+		batch, err := k.list(ctx, offset, t.opts.BatchSize)
+		if err != nil {
+			return err
+		}
+		for _, e := range batch {
+			if _, ok := k.ids[e.id]; !ok {
+				if err = t.add(ctx, k, e); err != nil {
+					return err
+				}
+			}
+		}
+		done += len(batch)
+		if err = t.opts.Save(t.state); err != nil {
+			return err
+		}
+		t.opts.Progress(k.name, done)
+		if len(batch) < t.opts.BatchSize {
+			return nil
+		}
+	}
+}
+
+//add the entity live to dst and map its id, or map the entity of its name in its tenant in dst
+func (t *transfer) add(ctx context.Context, k kind, e entity) error {
+	id, err := k.add(ctx, e)
+	if errs.CodeOf(err) == errs.AlreadyExists {
+		id, err = k.findByName(repository.WithTenant(ctx, mapTenant(t.state, e.tenant)), e.name)
+	}
+	if err != nil {
+		return errs.Wrap(err, errs.CodeOf(err), "transfer %s %d %s", k.name, e.id, e.name)
+	}
+	k.ids[e.id] = id
+	return nil
+}
+
+//copyLinks link roles of users and resources of roles in dst by the mapped ids,
+//and copy the instances of templates, the members of units and the roles granted to them
+func (t *transfer) copyLinks(ctx context.Context) error {
+	err := t.eachMapped(ctx, "user roles", t.state.Users, &t.state.UserLinks, func(from, to int64) error {
+		roles, err := t.src.Links.UserRoles(ctx, from)
+		if err != nil {
+			return err
+		}
+		for _, role := range roles {
+			roleID, ok := t.state.Roles[int64(role.ID)]
+			if !ok {
+				continue
+			}
+			if err = t.dst.Links.LinkUserRole(ctx, to, int(roleID)); err != nil && errs.CodeOf(err) != errs.AlreadyExists {
+				return errs.Wrap(err, errs.CodeOf(err), "link user %d to role %d", from, role.ID)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	err = t.eachMapped(ctx, "role resources", t.state.Roles, &t.state.RoleLinks, func(from, to int64) error {
+		resources, err := t.src.Links.RoleResources(ctx, int(from))
+		if err != nil {
+			return err
+		}
+		for _, resource := range resources {
+			resourceID, ok := t.state.Resources[int64(resource.ID)]
+			if !ok {
+				continue
+			}
+			if err = t.dst.Links.LinkRoleResource(ctx, int(to), int(resourceID)); err != nil && errs.CodeOf(err) != errs.AlreadyExists {
+				return errs.Wrap(err, errs.CodeOf(err), "link role %d to resource %d", from, resource.ID)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	err = t.eachMapped(ctx, "role instances", t.state.Templates, &t.state.Instances, func(from, to int64) error {
+		return t.copyInstances(ctx, from, to)
+	})
+	if err != nil {
+		return err
+	}
+	return t.eachMapped(ctx, "org members and grants", t.state.Units, &t.state.UnitLinks, func(from, to int64) error {
+		return t.copyUnitLinks(ctx, from, to)
+	})
+}
+
+//copyInstances of the source template from to the target template to, roles not transferred are skipped
+func (t *transfer) copyInstances(ctx context.Context, from, to int64) error {
+	srcTemplate, err := t.src.Templates.FindById(ctx, int(from))
+	if err != nil {
+		return err
+	}
+	dstTemplate, err := t.dst.Templates.FindById(ctx, int(to))
+	if err != nil {
+		return err
+	}
+	instances, err := t.src.Templates.Instances(ctx, int(from))
+	if err != nil {
+		return err
+	}
+	for _, instance := range instances {
+		roleID, ok := t.state.Roles[int64(instance.RoleID)]
+		if !ok {
+			continue
+		}
+		copied := *instance
+		copied.RoleID, copied.TemplateID = int(roleID), int(to)
+		copied.TenantID = mapTenant(t.state, instance.TenantID)
+		if scopeID, ok := t.state.Resources[int64(instance.ScopeID)]; ok {
+			copied.ScopeID = int(scopeID)
+		}
+		//the target numbers the versions of the template anew, an instance behind its template stays behind
+		copied.TemplateVersion = dstTemplate.Version
+		if instance.TemplateVersion < srcTemplate.Version {
+			copied.TemplateVersion--
+		}
+		if err = t.dst.Templates.SaveInstance(ctx, &copied); err != nil {
+			return errs.Wrap(err, errs.CodeOf(err), "copy instance of template %d in role %d", from, instance.RoleID)
+		}
+	}
+	return nil
+}
+
+//eachMapped run fn on the ids mapped after the last one done in order of source id, last is saved after every batch
+func (t *transfer) eachMapped(ctx context.Context, name string, ids map[int64]int64, last *int64, fn func(from, to int64) error) error {
+	done := 0
+	for i, from := range sortedKeys(ids) {
+		if from > *last {
+			if err := fn(from, ids[from]); err != nil {
+				return err
+			}
+			*last = from
+		}
+		done++
+		if (i+1)%t.opts.BatchSize == 0 || i == len(ids)-1 {
+			if err := t.opts.Save(t.state); err != nil {
+				return err
+			}
+			t.opts.Progress(name, done)
+		}
+	}
+	return nil
+}
+
+//deleteEntities delete the entities in dst which are deleted in src
+func (t *transfer) deleteEntities(ctx context.Context) error {
+	for _, k := range kindsOf(t.src, t.dst, t.state) {
+		done := 0
+		for offset := 0; ; offset += t.opts.BatchSize {
+			batch, err := k.list(ctx, offset, t.opts.BatchSize)
+			if err != nil {
+				return err
+			}
+			for _, e := range batch {
+				if id, ok := k.ids[e.id]; ok && e.deleted {
+					if err = k.delete(ctx, id); err != nil {
+						return errs.Wrap(err, errs.CodeOf(err), "delete %s %d", k.name, id)
+					}
+					done++
+				}
+			}
+			if len(batch) < t.opts.BatchSize {
+				break
+			}
+		}
+		t.opts.Progress("deleted "+k.name, done)
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package transfer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	"github.com/micro-community/auth/repository/memory"
+)
+
+func newMemory() Repositories {
+	users, roles, resources := memory.NewUserRepository(), memory.NewRoleRepository(), memory.NewResourceRepository()
+	return Repositories{Tenants: memory.NewTenantRepository(), Users: users, Roles: roles, Resources: resources,
+		Links: memory.NewLinkRepository(users, roles, resources), Templates: memory.NewRoleTemplateRepository(),
+		Org: memory.NewOrgRepository()}
+}
+
+//seed src with users linked to roles linked to resources of a tenant, the last user is deleted.
+//The first role is instantiated from a template, scoped to the first resource and behind the template.
+//The first user holds a position in a department granted the second role, which is moved under an organization
+//added after it
+func seed(t *testing.T, src Repositories) {
+	ctx := context.Background()
+	gone, tenant := &models.Tenant{Name: "gone"}, &models.Tenant{Name: "acme"}
+	for _, err := range []error{src.Tenants.Add(ctx, gone), src.Tenants.Delete(ctx, gone.ID, 1), src.Tenants.Add(ctx, tenant)} {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i < 5; i++ {
+		resource := &models.Resource{Name: fmt.Sprint("resource", i), Type: int(models.Device), TenantID: tenant.ID}
+		role := &models.Role{Name: fmt.Sprint("role", i), TenantID: tenant.ID}
+		user := &models.User{Name: fmt.Sprint("user", i), TenantID: tenant.ID}
+		if err := src.Resources.Add(ctx, resource); err != nil {
+			t.Fatal(err)
+		}
+		if err := src.Roles.Add(ctx, role); err != nil {
+			t.Fatal(err)
+		}
+		if err := src.Users.Add(ctx, user); err != nil {
+			t.Fatal(err)
+		}
+		if err := src.Links.LinkRoleResource(ctx, role.ID, resource.ID); err != nil {
+			t.Fatal(err)
+		}
+		if err := src.Links.LinkUserRole(ctx, user.ID, role.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	acme := repository.WithTenant(ctx, tenant.ID)
+	user, _ := src.Users.FindByName(acme, "user4")
+	if err := src.Users.Delete(ctx, user.ID, user.Version); err != nil {
+		t.Fatal(err)
+	}
+
+	template := &models.RoleTemplate{Name: "admin"}
+	if err := src.Templates.Add(ctx, template); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.Templates.Update(ctx, template); err != nil {
+		t.Fatal(err)
+	}
+	role, _ := src.Roles.FindByName(acme, "role0")
+	resource, _ := src.Resources.FindByName(acme, "resource0")
+	instance := &models.RoleInstance{RoleID: role.ID, TemplateID: template.ID, TemplateVersion: template.Version - 1,
+		TenantID: tenant.ID, ScopeID: resource.ID}
+	if err := src.Templates.SaveInstance(ctx, instance); err != nil {
+		t.Fatal(err)
+	}
+
+	hq := &models.OrgUnit{Kind: models.OrgOrganization, Name: "hq", TenantID: tenant.ID}
+	if err := src.Org.Add(ctx, hq); err != nil {
+		t.Fatal(err)
+	}
+	dev := &models.OrgUnit{Kind: models.OrgDepartment, Name: "dev", ParentID: hq.ID, TenantID: tenant.ID}
+	if err := src.Org.Add(ctx, dev); err != nil {
+		t.Fatal(err)
+	}
+	branch := &models.OrgUnit{Kind: models.OrgOrganization, Name: "branch", TenantID: tenant.ID}
+	if err := src.Org.Add(ctx, branch); err != nil {
+		t.Fatal(err)
+	}
+	dev.ParentID = branch.ID
+	if err := src.Org.Update(ctx, dev); err != nil {
+		t.Fatal(err)
+	}
+	lead := &models.OrgUnit{Kind: models.OrgPosition, Name: "lead", ParentID: dev.ID, TenantID: tenant.ID}
+	if err := src.Org.Add(ctx, lead); err != nil {
+		t.Fatal(err)
+	}
+	user, _ = src.Users.FindByName(acme, "user0")
+	role, _ = src.Roles.FindByName(acme, "role1")
+	if err := src.Org.SetMember(ctx, &models.OrgMember{UserID: user.ID, DeptID: dev.ID, PositionID: lead.ID, TenantID: tenant.ID}); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.Org.Grant(ctx, dev.ID, role.ID); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTransferResume(t *testing.T) {
+	ctx := context.Background()
+	src, dst := newMemory(), newMemory()
+	seed(t, src)
+	//ids of the target differ from the source
+	if err := dst.Users.Add(ctx, &models.User{Name: "existing"}); err != nil {
+		t.Fatal(err)
+	}
+
+	//the transfer is interrupted after roles of a batch are added but not saved
+	var saved []byte
+	errInterrupted := errors.New("interrupted")
+	saves := 0
+	interrupt := Options{BatchSize: 2, Save: func(state *State) (err error) {
+		if saves++; saves == 4 {
+			return errInterrupted
+		}
+		saved, err = json.Marshal(state)
+		return err
+	}}
+	if err := Transfer(ctx, src, dst, NewState("memory", "memory"), interrupt); err != errInterrupted {
+		t.Fatalf("transfer should be interrupted, got %v", err)
+	}
+	state := &State{}
+	if err := json.Unmarshal(saved, state); err != nil {
+		t.Fatal(err)
+	}
+	if err := Transfer(ctx, src, dst, state, Options{BatchSize: 2}); err != nil {
+		t.Fatalf("resume transfer: %v", err)
+	}
+
+	mismatches, err := Verify(ctx, src, dst, state)
+	if err != nil || len(mismatches) > 0 {
+		t.Fatalf("verify: %v %v", mismatches, err)
+	}
+	srcTenant, _ := src.Tenants.FindByName(ctx, "acme")
+	user, _ := src.Users.FindByName(repository.WithTenant(ctx, srcTenant.ID), "user0")
+	if state.Users[user.ID] == user.ID {
+		t.Errorf("user id %d should be remapped", user.ID)
+	}
+	tenant, err := dst.Tenants.FindByName(ctx, "acme")
+	if err != nil {
+		t.Fatalf("tenant should be transferred: %v", err)
+	}
+	acme := repository.WithTenant(ctx, tenant.ID)
+	if _, err = dst.Users.FindByName(acme, "user4"); err == nil {
+		t.Errorf("user4 should be deleted in the target")
+	}
+	if _, err = dst.Users.FindByName(repository.WithDeleted(acme), "user4"); err != nil {
+		t.Errorf("user4 should be kept deleted in the target: %v", err)
+	}
+	if user, err = dst.Users.FindByName(acme, "user0"); err != nil || user.TenantID != tenant.ID {
+		t.Errorf("user0 should be in tenant %d of the target, got %+v %v", tenant.ID, user, err)
+	}
+
+	template, err := dst.Templates.FindByName(ctx, "admin")
+	if err != nil {
+		t.Fatalf("template should be transferred: %v", err)
+	}
+	instances, err := dst.Templates.Instances(ctx, template.ID)
+	if err != nil || len(instances) != 1 {
+		t.Fatalf("instance should be transferred, got %d %v", len(instances), err)
+	}
+	role, _ := dst.Roles.FindByName(acme, "role0")
+	resource, _ := dst.Resources.FindByName(acme, "resource0")
+	if got := instances[0]; got.RoleID != role.ID || got.TenantID != tenant.ID || got.ScopeID != resource.ID ||
+		got.TemplateVersion != template.Version-1 {
+		t.Errorf("instance transferred as %+v, want role %d of tenant %d scoped to %d behind version %d",
+			got, role.ID, tenant.ID, resource.ID, template.Version)
+	}
+
+	member, err := dst.Org.MemberOf(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("member should be transferred: %v", err)
+	}
+	dev, _ := dst.Org.FindById(ctx, member.DeptID)
+	branch, _ := dst.Org.FindById(ctx, dev.ParentID)
+	lead, _ := dst.Org.FindById(ctx, member.PositionID)
+	if dev.Name != "dev" || branch.Name != "branch" || lead.Name != "lead" || lead.ParentID != dev.ID || dev.TenantID != tenant.ID {
+		t.Errorf("org units transferred as %+v %+v %+v", branch, dev, lead)
+	}
+	role, _ = dst.Roles.FindByName(acme, "role1")
+	if roleIDs, err := dst.Org.Grants(ctx, dev.ID); err != nil || len(roleIDs) != 1 || roleIDs[0] != role.ID {
+		t.Errorf("role1 should be granted to dev, got %v %v", roleIDs, err)
+	}
+}
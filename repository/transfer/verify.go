@@ -0,0 +1,109 @@
+package transfer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/micro-community/auth/repository"
+)
+
+//Verify compare the counts of entities and of the links, instances, members and grants of every mapped entity in src and dst,
+//return the mismatches found. dst may keep more entities than src, which were there before the transfer.
+func Verify(ctx context.Context, src, dst Repositories, state *State) ([]string, error) {
+	ctx = repository.WithDeleted(ctx)
+	var mismatches []string
+	for _, k := range kindsOf(src, dst, state) {
+		srcTotal, err := k.count(ctx, src)
+		if err != nil {
+			return nil, err
+		}
+		dstTotal, err := k.count(ctx, dst)
+		if err != nil {
+			return nil, err
+		}
+		if int64(len(k.ids)) != srcTotal {
+			mismatches = append(mismatches, fmt.Sprintf("%s: %d in source, %d transferred", k.name, srcTotal, len(k.ids)))
+		}
+		if dstTotal < srcTotal {
+			mismatches = append(mismatches, fmt.Sprintf("%s: %d in source, %d in target", k.name, srcTotal, dstTotal))
+		}
+	}
+
+	for from, to := range state.Users {
+		srcRoles, err := src.Links.UserRoles(ctx, from)
+		if err != nil {
+			return nil, err
+		}
+		dstRoles, err := dst.Links.UserRoles(ctx, to)
+		if err != nil {
+			return nil, err
+		}
+		if len(srcRoles) != len(dstRoles) {
+			mismatches = append(mismatches, fmt.Sprintf("user %d: %d roles in source, %d in target user %d", from, len(srcRoles), len(dstRoles), to))
+		}
+	}
+	for from, to := range state.Roles {
+		srcResources, err := src.Links.RoleResources(ctx, int(from))
+		if err != nil {
+			return nil, err
+		}
+		dstResources, err := dst.Links.RoleResources(ctx, int(to))
+		if err != nil {
+			return nil, err
+		}
+		if len(srcResources) != len(dstResources) {
+			mismatches = append(mismatches, fmt.Sprintf("role %d: %d resources in source, %d in target role %d", from, len(srcResources), len(dstResources), to))
+		}
+	}
+	srcUnits, err := src.Org.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dstUnits, err := dst.Org.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(state.Units) != len(srcUnits) {
+		mismatches = append(mismatches, fmt.Sprintf("org units: %d in source, %d transferred", len(srcUnits), len(state.Units)))
+	}
+	if len(dstUnits) < len(srcUnits) {
+		mismatches = append(mismatches, fmt.Sprintf("org units: %d in source, %d in target", len(srcUnits), len(dstUnits)))
+	}
+	for from, to := range state.Units {
+		srcMembers, err := src.Org.Members(ctx, int(from))
+		if err != nil {
+			return nil, err
+		}
+		dstMembers, err := dst.Org.Members(ctx, int(to))
+		if err != nil {
+			return nil, err
+		}
+		srcGrants, err := src.Org.Grants(ctx, int(from))
+		if err != nil {
+			return nil, err
+		}
+		dstGrants, err := dst.Org.Grants(ctx, int(to))
+		if err != nil {
+			return nil, err
+		}
+		if len(srcMembers) != len(dstMembers) || len(srcGrants) != len(dstGrants) {
+			mismatches = append(mismatches, fmt.Sprintf("org unit %d: %d members and %d grants in source, %d and %d in target unit %d",
+				from, len(srcMembers), len(srcGrants), len(dstMembers), len(dstGrants), to))
+		}
+	}
+	for from, to := range state.Templates {
+		srcInstances, err := src.Templates.Instances(ctx, int(from))
+		if err != nil {
+			return nil, err
+		}
+		dstInstances, err := dst.Templates.Instances(ctx, int(to))
+		if err != nil {
+			return nil, err
+		}
+		if len(srcInstances) != len(dstInstances) {
+			mismatches = append(mismatches, fmt.Sprintf("role template %d: %d instances in source, %d in target template %d",
+				from, len(srcInstances), len(dstInstances), to))
+		}
+	}
+	return mismatches, nil
+}
@@ -0,0 +1,8 @@
+package repository
+
+import "github.com/micro-community/auth/errs"
+
+//StaleVersion return the errs.Conflict error of a write to the entity of kind and id at a stale version
+func StaleVersion(kind string, id, version int64) error {
+	return errs.NewConflict("%s %d is modified, version %d is stale", kind, id, version)
+}
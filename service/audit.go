@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	"github.com/micro/micro/v3/service/auth"
+	"github.com/micro/micro/v3/service/context/metadata"
+)
+
+//fields of entities left out of diffs, secrets are masked
+var (
+	ignoredFields = map[string]bool{"uid": true, "dgraph.type": true}
+	secretFields  = map[string]bool{"password": true}
+)
+
+//Auditor keep logs of mutations of users, roles, resources and their links for auditors
+type Auditor struct {
+	logs repository.ILog
+}
+
+//NewAuditor return an Auditor appending to logs
+func NewAuditor(logs repository.ILog) *Auditor {
+	return &Auditor{logs: logs}
+}
+
+//Record a mutation by the caller of ctx of an entity of the tenant, before and after are the entity around it, nil when it is missing.
+//Record in the unit of work of the mutation, so on backends with transactions it is not kept without its log.
+func (a *Auditor) Record(ctx context.Context, tenant int, kind models.ChangeKind, action models.ChangeAction, id, targetID string, before, after interface{}) error {
+	log := &models.Log{
+		TenantID:  tenant,
+		Actor:     operator(ctx),
+		Kind:      kind,
+		Action:    action,
+		EntityID:  id,
+		TargetID:  targetID,
+		Diff:      diff(before, after),
+		RequestID: requestID(ctx),
+		Time:      time.Now(),
+	}
+	return a.logs.Append(ctx, log)
+}
+
+//Query logs matched q from the cursor, newest first, at most size logs in a page
+func (a *Auditor) Query(ctx context.Context, q repository.LogQuery, cursor string, size int) ([]*models.Log, string, error) {
+	switch {
+	case size <= 0:
+		size = defaultPageSize
+	case size > maxPageSize:
+		size = maxPageSize
+	}
+	q.Limit = size
+	if cursor != "" {
+		before, err := strconv.ParseInt(cursor, 10, 64)
+		if err != nil || before <= 0 {
+			return nil, "", ErrInvalidCursor
+		}
+		q.BeforeID = before
+	}
+
+	logs, err := a.logs.Query(ctx, q)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(logs) < size {
+		return logs, "", nil
+	}
+	return logs, strconv.FormatInt(logs[len(logs)-1].ID, 10), nil
+}
+
+//operator return the account id of the caller
+func operator(ctx context.Context) string {
+	if acc, ok := auth.AccountFromContext(ctx); ok {
+		return acc.ID
+	}
+	return ""
+}
+
+//requestID of the call, the one given by the client or the gateway goes first
+func requestID(ctx context.Context) string {
+	if id, ok := metadata.Get(ctx, "X-Request-Id"); ok {
+		return id
+	}
+	id, _ := metadata.Get(ctx, "Micro-Id")
+	return id
+}
+
+//stamp the time of an entity written now, a created one gets its creation time as well
+func stamp(ext *models.ModelExtension, created bool) {
+	ext.UpdatedAt = time.Now()
+	if created {
+		ext.CreatedAt = ext.UpdatedAt
+	}
+}
+
+//diff the json fields of before and after in order of name
+func diff(before, after interface{}) []models.FieldDiff {
+	b, a := fieldsOf(before), fieldsOf(after)
+	names := make([]string, 0, len(b)+len(a))
+	for name := range b {
+		names = append(names, name)
+	}
+	for name := range a {
+		if _, ok := b[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var diffs []models.FieldDiff
+	for _, name := range names {
+		if ignoredFields[name] || string(b[name]) == string(a[name]) {
+			continue
+		}
+		d := models.FieldDiff{Field: name, Before: string(b[name]), After: string(a[name])}
+		if secretFields[name] {
+			d.Before, d.After = mask(d.Before), mask(d.After)
+		}
+		diffs = append(diffs, d)
+	}
+	return diffs
+}
+
+//fieldsOf an entity by their json names, nil for a nil entity
+func fieldsOf(entity interface{}) map[string]json.RawMessage {
+	if entity == nil {
+		return nil
+	}
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return nil
+	}
+	var fields map[string]json.RawMessage
+	json.Unmarshal(data, &fields)
+	return fields
+}
+
+//mask a secret value, only whether it is set is kept
+func mask(value string) string {
+	if value == "" {
+		return ""
+	}
+	return `"******"`
+}
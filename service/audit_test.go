@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/micro-community/auth/db/migration"
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	"github.com/micro-community/auth/repository/sql"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+//failingLog refuse every append
+type failingLog struct {
+	repository.ILog
+}
+
+func (failingLog) Append(ctx context.Context, log *models.Log) error {
+	return errs.NewUnavailable(nil, "logs are down")
+}
+
+//newSQLUserService of a migrated sqlite database appending logs to logs, or to the database when logs is nil
+func newSQLUserService(t *testing.T, logs repository.ILog) (*UserService, repository.IUser, repository.ILog) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "auth.db")), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = migration.Up(context.Background(), migration.NewSQL(db), 0); err != nil {
+		t.Fatal(err)
+	}
+	if logs == nil {
+		logs = sql.NewLogRepository(db)
+	}
+	users := sql.NewUserRepository(db)
+	s := NewUser(users, sql.NewLinkRepository(db), sql.NewTenantRepository(db), sql.NewUnitOfWork(db), NewChangeFeed(), NewAuditor(logs))
+	return s, users, logs
+}
+
+func TestAuditInUnitOfWork(t *testing.T) {
+	ctx := context.Background()
+	s, users, logs := newSQLUserService(t, nil)
+	user := &models.User{Name: "logged", Password: "secret"}
+	if err := s.Create(ctx, user, nil); err != nil {
+		t.Fatal(err)
+	}
+	got, err := logs.Query(ctx, repository.LogQuery{Kinds: []models.ChangeKind{models.UserChange}})
+	if err != nil || len(got) != 1 || got[0].Action != models.Created {
+		t.Fatalf("logs of create: %v %v", got, err)
+	}
+
+	s, users, _ = newSQLUserService(t, failingLog{})
+	if err = s.Create(ctx, &models.User{Name: "unlogged", Password: "secret"}, nil); errs.CodeOf(err) != errs.Unavailable {
+		t.Fatalf("create should fail with its log, got %v", err)
+	}
+	if _, err = users.FindByName(ctx, "unlogged"); errs.CodeOf(err) != errs.NotFound {
+		t.Fatalf("user should roll back with its log, got %v", err)
+	}
+}
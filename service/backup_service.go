@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"io"
+
+	"github.com/micro-community/auth/config"
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/repository"
+	"github.com/micro-community/auth/repository/backup"
+	"github.com/micro-community/auth/repository/transfer"
+	"github.com/micro/micro/v3/service/logger"
+)
+
+//BackupService back up and restore all tenants, users, roles, resources, their links, role templates, their instances,
+//org units, their members and grants, and logs of the database
+type BackupService struct {
+	repos  backup.Repositories
+	source string
+}
+
+func NewBackup(tenants repository.ITenant, users repository.IUser, roles repository.IRole, resources repository.IResource,
+	links repository.ILink, templates repository.IRoleTemplate, org repository.IOrg, logs repository.ILog,
+	work repository.UnitOfWork, conf *config.Options) *BackupService {
+	return &BackupService{
+		repos: backup.Repositories{
+			Repositories: transfer.Repositories{Tenants: tenants, Users: users, Roles: roles, Resources: resources, Links: links,
+				Templates: templates, Org: org},
+			Logs: logs,
+			Work: work,
+		},
+		source: conf.DBType,
+	}
+}
+
+//Backup write a consistent backup to w
+func (s *BackupService) Backup(ctx context.Context, w io.Writer) (backup.Counts, error) {
+	if err := mustBeSuper(ctx, "back up"); err != nil {
+		return backup.Counts{}, err
+	}
+	counts, err := backup.Backup(ctx, s.repos, s.source, w)
+	if err == nil {
+		logger.Infof("backed up %+v", counts)
+	}
+	return counts, err
+}
+
+//Restore the backup of r to the empty database
+func (s *BackupService) Restore(ctx context.Context, r io.Reader) (backup.Counts, error) {
+	if err := mustBeSuper(ctx, "restore"); err != nil {
+		return backup.Counts{}, err
+	}
+	br, err := backup.NewReader(r)
+	if err != nil {
+		return backup.Counts{}, err
+	}
+	counts, err := backup.Restore(ctx, br, s.repos)
+	if err == nil {
+		logger.Infof("restored %+v from a backup of %s at %s", counts, br.Header.Source, br.Header.CreatedAt)
+	}
+	return counts, err
+}
+
+//Verify restore the backup of r to memory, return the differences of the restored data from the backup
+func (s *BackupService) Verify(ctx context.Context, r io.Reader) ([]string, backup.Counts, error) {
+	if err := mustBeSuper(ctx, "verify backups"); err != nil {
+		return nil, backup.Counts{}, err
+	}
+	br, err := backup.NewReader(r)
+	if err != nil {
+		return nil, backup.Counts{}, err
+	}
+	return backup.Verify(ctx, br)
+}
+
+//mustBeSuper return an errs.PermissionDenied error for a ctx scoped to a tenant,
+//backups hold the data of every tenant
+func mustBeSuper(ctx context.Context, op string) error {
+	if tenant, ok := repository.ScopedTenant(ctx); ok {
+		return errs.NewPermissionDenied("tenant %d can not %s, only the super tenant can", tenant, op)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+)
+
+const (
+	changeHistorySize = 1024
+	watcherBufferSize = 64
+)
+
+var (
+	//ErrRevisionCompacted is returned when the changes after a revision are not kept any more
+	ErrRevisionCompacted = errs.NewConflict("revision compacted")
+	//ErrFutureRevision is returned when a revision is not reached yet
+	ErrFutureRevision = errs.NewConflict("future revision")
+	//ErrUnknownEpoch is returned when a revision comes from another feed, e.g. before the service restarted
+	ErrUnknownEpoch = errs.NewConflict("unknown epoch")
+)
+
+//ChangeFeed broadcast changes of rbac data to watchers, recent changes are kept to resume watching.
+//Changes are kept in memory of the process, revisions only mean something in the epoch of the feed
+type ChangeFeed struct {
+	mu       *sync.Mutex
+	epoch    string
+	revision int64
+	history  []models.Change
+	watchers map[chan models.Change]watcher
+}
+
+//watcher of the changes of a tenant, or of all tenants when it is not scoped
+type watcher struct {
+	tenant int
+	scoped bool
+}
+
+func (w watcher) sees(change models.Change) bool {
+	return !w.scoped || change.TenantID == w.tenant
+}
+
+//NewChangeFeed return a ChangeFeed
+func NewChangeFeed() *ChangeFeed {
+	return &ChangeFeed{
+		mu:       &sync.Mutex{},
+		epoch:    strconv.FormatInt(time.Now().UnixNano(), 36),
+		history:  make([]models.Change, 0, changeHistorySize),
+		watchers: map[chan models.Change]watcher{},
+	}
+}
+
+//Epoch of the feed, a new feed starts a new epoch whose revisions restart from 1
+func (f *ChangeFeed) Epoch() string {
+	return f.epoch
+}
+
+//Revision return the revision of the last change
+func (f *ChangeFeed) Revision() int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.revision
+}
+
+//Publish a change of an entity of the tenant to watchers, a watcher too slow to receive it is closed and should resume by revision
+func (f *ChangeFeed) Publish(ctx context.Context, tenant int, kind models.ChangeKind, action models.ChangeAction, id, targetID string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.revision++
+	change := models.Change{
+		Epoch:    f.epoch,
+		Revision: f.revision,
+		Kind:     kind,
+		Action:   action,
+		ID:       id,
+		TargetID: targetID,
+		TenantID: tenant,
+		Time:     time.Now(),
+	}
+	if len(f.history) == changeHistorySize {
+		f.history = append(f.history[:0], f.history[1:]...)
+	}
+	f.history = append(f.history, change)
+
+	for ch, w := range f.watchers {
+		if !w.sees(change) {
+			continue
+		}
+		select {
+		case ch <- change:
+		default:
+			delete(f.watchers, ch)
+			close(ch)
+		}
+	}
+}
+
+//Watch changes after the revision of the epoch, 0 for changes from now on, a ctx scoped to a tenant only sees changes of it.
+//The channel is closed when ctx is done or the watcher falls behind.
+func (f *ChangeFeed) Watch(ctx context.Context, epoch string, revision int64) (<-chan models.Change, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if revision > 0 && epoch != f.epoch {
+		return nil, ErrUnknownEpoch
+	}
+	if revision > f.revision {
+		return nil, ErrFutureRevision
+	}
+	var backlog []models.Change
+	if revision > 0 && revision < f.revision {
+		if revision < f.history[0].Revision-1 {
+			return nil, ErrRevisionCompacted
+		}
+		backlog = f.history[revision-f.history[0].Revision+1:]
+	}
+
+	w := watcher{}
+	w.tenant, w.scoped = repository.ScopedTenant(ctx)
+	ch := make(chan models.Change, watcherBufferSize+len(backlog))
+	for _, change := range backlog {
+		if w.sees(change) {
+			ch <- change
+		}
+	}
+	f.watchers[ch] = w
+
+	go func() {
+		<-ctx.Done()
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if _, ok := f.watchers[ch]; ok {
+			delete(f.watchers, ch)
+			close(ch)
+		}
+	}()
+	return ch, nil
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+)
+
+//received drain the changes already sent to ch
+func received(t *testing.T, ch <-chan models.Change) []string {
+	var got []string
+	for {
+		select {
+		case change, ok := <-ch:
+			if !ok {
+				return got
+			}
+			got = append(got, fmt.Sprintf("%d:%s", change.Revision, change.ID))
+		case <-time.After(10 * time.Millisecond):
+			return got
+		}
+	}
+}
+
+func TestChangeFeedResume(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	f := NewChangeFeed()
+	for _, id := range []string{"a", "b", "c"} {
+		f.Publish(ctx, 0, models.UserChange, models.Created, id, "")
+	}
+
+	ch, err := f.Watch(ctx, f.Epoch(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Publish(ctx, 0, models.UserChange, models.Created, "d", "")
+	if got := fmt.Sprint(received(t, ch)); got != "[2:b 3:c 4:d]" {
+		t.Errorf("resume after 1: %s, want the changes after it", got)
+	}
+
+	if _, err = f.Watch(ctx, f.Epoch(), 5); err != ErrFutureRevision {
+		t.Errorf("watch a future revision: %v, want ErrFutureRevision", err)
+	}
+	if _, err = f.Watch(ctx, "", 1); err != ErrUnknownEpoch {
+		t.Errorf("watch without the epoch: %v, want ErrUnknownEpoch", err)
+	}
+	//a new feed, e.g. of a restarted service, does not resume revisions of the old one
+	if _, err = NewChangeFeed().Watch(ctx, f.Epoch(), 1); err != ErrUnknownEpoch {
+		t.Errorf("watch a revision of another feed: %v, want ErrUnknownEpoch", err)
+	}
+}
+
+func TestChangeFeedCompacted(t *testing.T) {
+	ctx := context.Background()
+	f := NewChangeFeed()
+	for i := 0; i < changeHistorySize+2; i++ {
+		f.Publish(ctx, 0, models.UserChange, models.Created, fmt.Sprint(i), "")
+	}
+	if _, err := f.Watch(ctx, f.Epoch(), 1); err != ErrRevisionCompacted {
+		t.Errorf("watch a compacted revision: %v, want ErrRevisionCompacted", err)
+	}
+}
+
+func TestChangeFeedFiltersTenant(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	f := NewChangeFeed()
+	f.Publish(ctx, 1, models.RoleChange, models.Created, "a", "")
+	f.Publish(ctx, 2, models.RoleChange, models.Created, "b", "")
+
+	tenant, err := f.Watch(repository.WithTenant(ctx, 1), f.Epoch(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	super, err := f.Watch(repository.WithTenant(ctx, models.SuperTenant), f.Epoch(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := f.Watch(repository.WithTenant(ctx, 2), f.Epoch(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Publish(ctx, 1, models.RoleChange, models.Updated, "c", "")
+	f.Publish(ctx, 2, models.RoleChange, models.Updated, "d", "")
+
+	if got := fmt.Sprint(received(t, tenant)); got != "[3:c]" {
+		t.Errorf("watcher of tenant 1: %s, want only its changes", got)
+	}
+	if got := fmt.Sprint(received(t, super)); got != "[3:c 4:d]" {
+		t.Errorf("watcher of the super tenant: %s, want all changes", got)
+	}
+	if got := fmt.Sprint(received(t, other)); got != "[4:d]" {
+		t.Errorf("watcher of tenant 2: %s, want only its changes", got)
+	}
+
+	backlog, err := f.Watch(repository.WithTenant(ctx, 2), f.Epoch(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(received(t, backlog)); got != "[2:b 4:d]" {
+		t.Errorf("other watcher of tenant 2: %s, want only its changes after 1", got)
+	}
+}
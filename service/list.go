@@ -0,0 +1,110 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+//ErrInvalidCursor is returned when a cursor is malformed or used with other filters
+var ErrInvalidCursor = errs.NewInvalidArgument("invalid cursor")
+
+//Page of a list result, NextCursor is empty on the last page
+type Page struct {
+	NextCursor string
+	Total      int64
+}
+
+//cursor is the key of the last item of a page, bound to the query it comes from,
+//the next page starts after the key so inserts and deletes do not shift it
+type cursor struct {
+	ID      int64     `json:"i"`
+	Name    string    `json:"n,omitempty"`
+	Created time.Time `json:"c"`
+	Seen    int64     `json:"s"`
+	Query   uint32    `json:"q"`
+}
+
+//queryHash identify the filters and sort order of opts
+func queryHash(opts repository.ListOptions) uint32 {
+	opts.After, opts.Offset, opts.Limit = nil, 0, 0
+	h := fnv.New32a()
+	fmt.Fprintf(h, "%+v", opts)
+	return h.Sum32()
+}
+
+//paginate set the key after and limit of opts by an opaque cursor and a page size,
+//return the count of items seen in the pages before
+func paginate(opts *repository.ListOptions, token string, size int) (int64, error) {
+	switch {
+	case size <= 0:
+		size = defaultPageSize
+	case size > maxPageSize:
+		size = maxPageSize
+	}
+	opts.After, opts.Offset, opts.Limit = nil, 0, size
+
+	if token == "" {
+		return 0, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+	var c cursor
+	if err = json.Unmarshal(data, &c); err != nil || c.Seen < 0 || c.Query != queryHash(*opts) {
+		return 0, ErrInvalidCursor
+	}
+	opts.After = &repository.ListKey{ID: c.ID, Name: c.Name, Created: c.Created}
+	return c.Seen, nil
+}
+
+//nextPage return the page of a list result with count items after seen ones, last is the key of the last item
+func nextPage(opts repository.ListOptions, seen int64, count int, last *repository.ListKey, total int64) Page {
+	page := Page{Total: total}
+	seen += int64(count)
+	if last == nil || count < opts.Limit || seen >= total {
+		return page
+	}
+	data, _ := json.Marshal(cursor{ID: last.ID, Name: last.Name, Created: last.Created, Seen: seen, Query: queryHash(opts)})
+	page.NextCursor = base64.RawURLEncoding.EncodeToString(data)
+	return page
+}
+
+//lastUser return the key of the last user of a page, nil for an empty page
+func lastUser(users []*models.User) *repository.ListKey {
+	if len(users) == 0 {
+		return nil
+	}
+	u := users[len(users)-1]
+	return &repository.ListKey{ID: u.ID, Name: u.Name, Created: u.CreatedAt}
+}
+
+//lastRole return the key of the last role of a page, nil for an empty page
+func lastRole(roles []*models.Role) *repository.ListKey {
+	if len(roles) == 0 {
+		return nil
+	}
+	r := roles[len(roles)-1]
+	return &repository.ListKey{ID: int64(r.ID), Name: r.Name, Created: r.CreatedAt}
+}
+
+//lastResource return the key of the last resource of a page, nil for an empty page
+func lastResource(resources []*models.Resource) *repository.ListKey {
+	if len(resources) == 0 {
+		return nil
+	}
+	r := resources[len(resources)-1]
+	return &repository.ListKey{ID: int64(r.ID), Name: r.Name, Created: r.CreatedAt}
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	"github.com/micro-community/auth/repository/memory"
+)
+
+func newUserService() *UserService {
+	users, roles, resources := memory.NewUserRepository(), memory.NewRoleRepository(), memory.NewResourceRepository()
+	links := memory.NewLinkRepository(users, roles, resources)
+	return NewUser(users, links, memory.NewTenantRepository(), memory.NewUnitOfWork(), NewChangeFeed(), NewAuditor(memory.NewLogRepository()))
+}
+
+func createUsers(t *testing.T, s *UserService, names ...string) []*models.User {
+	created := make([]*models.User, 0, len(names))
+	for _, name := range names {
+		user := &models.User{Name: name, Password: "secret"}
+		if err := s.Create(context.Background(), user, nil); err != nil {
+			t.Fatal(err)
+		}
+		created = append(created, user)
+	}
+	return created
+}
+
+func namesOf(users []*models.User) []string {
+	names := make([]string, 0, len(users))
+	for _, user := range users {
+		names = append(names, user.Name)
+	}
+	return names
+}
+
+func TestListPagesAfterCursor(t *testing.T) {
+	ctx := context.Background()
+	s := newUserService()
+	created := createUsers(t, s, "u-bob", "u-dan", "u-eve", "u-fay")
+	opts := repository.ListOptions{NamePrefix: "u-", SortBy: repository.SortByName}
+
+	first, page, err := s.List(ctx, opts, "", 2)
+	if err != nil || fmt.Sprint(namesOf(first)) != "[u-bob u-dan]" || page.Total != 4 || page.NextCursor == "" {
+		t.Fatalf("first page: %v %+v %v", namesOf(first), page, err)
+	}
+
+	//a deleted item of the first page and an item inserted before the cursor do not shift the next page
+	if err = s.Delete(ctx, created[0].ID, created[0].Version); err != nil {
+		t.Fatal(err)
+	}
+	createUsers(t, s, "u-amy")
+
+	second, page, err := s.List(ctx, opts, page.NextCursor, 2)
+	if err != nil || fmt.Sprint(namesOf(second)) != "[u-eve u-fay]" {
+		t.Fatalf("second page: %v %+v %v", namesOf(second), page, err)
+	}
+	if page.NextCursor != "" {
+		t.Errorf("last page should not have a cursor, got %q", page.NextCursor)
+	}
+}
+
+func TestListRejectsCursorOfOtherQuery(t *testing.T) {
+	ctx := context.Background()
+	s := newUserService()
+	createUsers(t, s, "u-bob", "u-dan", "u-eve")
+
+	_, page, err := s.List(ctx, repository.ListOptions{NamePrefix: "u-", SortBy: repository.SortByName}, "", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = s.List(ctx, repository.ListOptions{NamePrefix: "u-", SortBy: repository.SortByName, Desc: true}, page.NextCursor, 2); err != ErrInvalidCursor {
+		t.Errorf("cursor of another sort order: %v, want ErrInvalidCursor", err)
+	}
+	if _, _, err = s.List(ctx, repository.ListOptions{NamePrefix: "u-", SortBy: repository.SortByName}, "not a cursor", 2); err != ErrInvalidCursor {
+		t.Errorf("malformed cursor: %v, want ErrInvalidCursor", err)
+	}
+}
+
+func TestExportAfterLastUser(t *testing.T) {
+	ctx := context.Background()
+	s := newUserService()
+	var names []string
+	for i := 0; i < maxPageSize+2; i++ {
+		names = append(names, fmt.Sprintf("u-%03d", i))
+	}
+	created := createUsers(t, s, names...)
+
+	var exported []string
+	err := s.Export(ctx, repository.ListOptions{NamePrefix: "u-"}, 0, func(user *models.User) error {
+		//deleting exported users while exporting does not skip the rest
+		if err := s.Delete(ctx, user.ID, user.Version); err != nil {
+			return err
+		}
+		exported = append(exported, user.Name)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(exported) != len(created) || fmt.Sprint(exported) != fmt.Sprint(names) {
+		t.Errorf("exported %d users, want %d", len(exported), len(created))
+	}
+}
@@ -0,0 +1,535 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+)
+
+//OrgService manage the org trees of tenants: organizations, departments and sub departments under them, and positions.
+//Users are assigned to a department with a position, and inherit the roles granted to the position,
+//the department and every unit above it.
+type OrgService struct {
+	org   repository.IOrg
+	users repository.IUser
+	roles repository.IRole
+	uow   repository.UnitOfWork
+	feed  *ChangeFeed
+	audit *Auditor
+}
+
+func NewOrg(org repository.IOrg, users repository.IUser, roles repository.IRole, uow repository.UnitOfWork,
+	feed *ChangeFeed, audit *Auditor) *OrgService {
+	return &OrgService{
+		org:   org,
+		users: users,
+		roles: roles,
+		uow:   uow,
+		feed:  feed,
+		audit: audit,
+	}
+}
+
+//Create an organization, or a department or a position under its parent in the tenant of the parent
+func (s *OrgService) Create(ctx context.Context, unit *models.OrgUnit) error {
+	parent, err := s.parentOf(ctx, unit.Kind, unit.ParentID)
+	if err != nil {
+		return err
+	}
+	if parent != nil {
+		unit.TenantID = parent.TenantID
+	}
+	stamp(&unit.ModelExtension, true)
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.org.Add(ctx, unit); err != nil {
+			return err
+		}
+		return s.recordUnit(ctx, unit.TenantID, models.Created, unit.ID, nil, unit)
+	})
+	if err != nil {
+		return err
+	}
+	s.publishUnit(ctx, unit.TenantID, models.Created, unit.ID)
+	return nil
+}
+
+//parentOf find the parent of a unit of kind, an organization has none.
+//It returns an errs.InvalidArgument error when a unit of kind can not be under the parent.
+func (s *OrgService) parentOf(ctx context.Context, kind models.OrgUnitKind, parentID int) (*models.OrgUnit, error) {
+	switch kind {
+	case models.OrgOrganization:
+		if parentID != 0 {
+			return nil, errs.NewInvalidArgument("organization can not be under org unit %d", parentID)
+		}
+		return nil, nil
+	case models.OrgDepartment, models.OrgPosition:
+		if parentID == 0 {
+			return nil, errs.NewInvalidArgument("department or position must be under an organization or a department")
+		}
+	default:
+		return nil, errs.NewInvalidArgument("unknown org unit kind %d", kind)
+	}
+	parent, err := s.org.FindById(ctx, parentID)
+	if err != nil {
+		return nil, err
+	}
+	if parent.Kind == models.OrgPosition {
+		return nil, errs.NewInvalidArgument("org unit can not be under position %d", parentID)
+	}
+	return parent, nil
+}
+
+func (s *OrgService) Get(ctx context.Context, id int) (*models.OrgUnit, error) {
+	return s.org.FindById(ctx, id)
+}
+
+//List all units of the tenant in order of id, parents are listed before their children unless moved after
+func (s *OrgService) List(ctx context.Context) ([]*models.OrgUnit, error) {
+	return s.org.List(ctx)
+}
+
+//Rename a unit at the version
+func (s *OrgService) Rename(ctx context.Context, id int, name string, version int64) (*models.OrgUnit, error) {
+	return s.update(ctx, id, version, func(unit *models.OrgUnit) error {
+		unit.Name = name
+		return nil
+	})
+}
+
+//Move a department or a position at the version under another parent with its sub units, members keep their units
+func (s *OrgService) Move(ctx context.Context, id, parentID int, version int64) (*models.OrgUnit, error) {
+	return s.update(ctx, id, version, func(unit *models.OrgUnit) error {
+		if unit.Kind == models.OrgOrganization {
+			return errs.NewInvalidArgument("organization %d can not be moved", id)
+		}
+		parent, err := s.parentOf(ctx, unit.Kind, parentID)
+		if err != nil {
+			return err
+		}
+		if err = s.checkUnder(ctx, unit, parent); err != nil {
+			return err
+		}
+		unit.ParentID = parentID
+		return nil
+	})
+}
+
+//update the unit of id at the version by modify
+func (s *OrgService) update(ctx context.Context, id int, version int64, modify func(*models.OrgUnit) error) (*models.OrgUnit, error) {
+	unit, err := s.org.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if unit.Version != version {
+		return nil, repository.StaleVersion("org unit", int64(id), version)
+	}
+	before := *unit
+	if err = modify(unit); err != nil {
+		return nil, err
+	}
+	stamp(&unit.ModelExtension, false)
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.org.Update(ctx, unit); err != nil {
+			return err
+		}
+		return s.recordUnit(ctx, unit.TenantID, models.Updated, id, &before, unit)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.publishUnit(ctx, unit.TenantID, models.Updated, id)
+	return unit, nil
+}
+
+//checkUnder return an errs.InvalidArgument error when the units below unit can not be under target:
+//target is of another tenant, unit itself or below it
+func (s *OrgService) checkUnder(ctx context.Context, unit, target *models.OrgUnit) error {
+	if target.TenantID != unit.TenantID {
+		return errs.NewInvalidArgument("org unit %d is of another tenant", target.ID)
+	}
+	units, err := s.org.List(ctx)
+	if err != nil {
+		return err
+	}
+	byID := make(map[int]*models.OrgUnit, len(units))
+	for _, u := range units {
+		byID[u.ID] = u
+	}
+	for above := target; above != nil; above = byID[above.ParentID] {
+		if above.ID == unit.ID {
+			return errs.NewInvalidArgument("org unit %d can not be under itself", unit.ID)
+		}
+	}
+	return nil
+}
+
+//OrgMerge counts what is moved to the unit merged into
+type OrgMerge struct {
+	Into    *models.OrgUnit `json:"into"`
+	Units   int             `json:"units"`   // sub units moved
+	Members int             `json:"members"` // members moved
+	Grants  int             `json:"grants"`  // roles granted, those granted to both already are not counted
+}
+
+//Merge a unit at the version into another of its kind: its sub units and members are moved to the other,
+//the roles granted to it are granted to the other, then it is deleted. Nothing is kept on backends with transactions
+//when any fails, e.g. a sub unit of a name taken under the other.
+func (s *OrgService) Merge(ctx context.Context, id, intoID int, version int64) (*OrgMerge, error) {
+	unit, err := s.org.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if unit.Version != version {
+		return nil, repository.StaleVersion("org unit", int64(id), version)
+	}
+	into, err := s.org.FindById(ctx, intoID)
+	if err != nil {
+		return nil, err
+	}
+	if into.Kind != unit.Kind {
+		return nil, errs.NewInvalidArgument("org unit %d can only be merged into one of its kind", id)
+	}
+	if err = s.checkUnder(ctx, unit, into); err != nil {
+		return nil, err
+	}
+
+	var children []*models.OrgUnit
+	var moved [][2]*models.OrgMember
+	var granted []int
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		units, err := s.org.List(ctx)
+		if err != nil {
+			return err
+		}
+		for _, child := range units {
+			if child.ParentID != id {
+				continue
+			}
+			child.ParentID = intoID
+			stamp(&child.ModelExtension, false)
+			if err = s.org.Update(ctx, child); err != nil {
+				return err
+			}
+			if err = s.recordUnit(ctx, unit.TenantID, models.Updated, child.ID, nil, child); err != nil {
+				return err
+			}
+			children = append(children, child)
+		}
+
+		members, err := s.org.Members(ctx, id)
+		if err != nil {
+			return err
+		}
+		for _, member := range members {
+			before := *member
+			if member.DeptID == id {
+				member.DeptID = intoID
+			}
+			if member.PositionID == id {
+				member.PositionID = intoID
+			}
+			if err = s.org.SetMember(ctx, member); err != nil {
+				return err
+			}
+			if err = s.recordMember(ctx, unit.TenantID, member.UserID, &before, member); err != nil {
+				return err
+			}
+			moved = append(moved, [2]*models.OrgMember{&before, member})
+		}
+
+		roleIDs, err := s.org.Grants(ctx, id)
+		if err != nil {
+			return err
+		}
+		for _, roleID := range roleIDs {
+			if err = s.org.Grant(ctx, intoID, roleID); errs.CodeOf(err) == errs.AlreadyExists {
+				continue
+			} else if err != nil {
+				return err
+			}
+			if err = s.recordGrant(ctx, unit.TenantID, models.Linked, intoID, roleID); err != nil {
+				return err
+			}
+			granted = append(granted, roleID)
+		}
+		if err = s.org.Delete(ctx, id, version); err != nil {
+			return err
+		}
+		return s.recordUnit(ctx, unit.TenantID, models.Deleted, id, unit, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, child := range children {
+		s.publishUnit(ctx, unit.TenantID, models.Updated, child.ID)
+	}
+	for _, member := range moved {
+		s.publishMember(ctx, unit.TenantID, member[1].UserID)
+	}
+	for _, roleID := range granted {
+		s.publishGrant(ctx, unit.TenantID, models.Linked, intoID, roleID)
+	}
+	s.publishUnit(ctx, unit.TenantID, models.Deleted, id)
+	if into, err = s.org.FindById(ctx, intoID); err != nil {
+		return nil, err
+	}
+	return &OrgMerge{Into: into, Units: len(children), Members: len(moved), Grants: len(granted)}, nil
+}
+
+//Delete a unit at the version with the roles granted to it, a unit with sub units or members can not be deleted
+func (s *OrgService) Delete(ctx context.Context, id int, version int64) error {
+	before, err := s.org.FindById(ctx, id)
+	if err != nil {
+		return err
+	}
+	units, err := s.org.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, unit := range units {
+		if unit.ParentID == id {
+			return errs.NewConflict("org unit %d has sub units, move or merge them first", id)
+		}
+	}
+	members, err := s.org.Members(ctx, id)
+	if err != nil {
+		return err
+	}
+	if len(members) > 0 {
+		return errs.NewConflict("org unit %d has members, assign them to other units first", id)
+	}
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.org.Delete(ctx, id, version); err != nil {
+			return err
+		}
+		return s.recordUnit(ctx, before.TenantID, models.Deleted, id, before, nil)
+	})
+	if err != nil {
+		return err
+	}
+	s.publishUnit(ctx, before.TenantID, models.Deleted, id)
+	return nil
+}
+
+//Assign a user to a department with a position, or none when positionID is 0, in place of the units assigned before
+func (s *OrgService) Assign(ctx context.Context, userID int64, deptID, positionID int) (*models.OrgMember, error) {
+	user, err := s.users.FindById(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.mustBeOf(ctx, deptID, models.OrgDepartment, "department", user.TenantID); err != nil {
+		return nil, err
+	}
+	if positionID != 0 {
+		if err = s.mustBeOf(ctx, positionID, models.OrgPosition, "position", user.TenantID); err != nil {
+			return nil, err
+		}
+	}
+
+	before, err := s.org.MemberOf(ctx, userID)
+	if err != nil && errs.CodeOf(err) != errs.NotFound {
+		return nil, err
+	}
+	member := &models.OrgMember{UserID: userID, DeptID: deptID, PositionID: positionID, TenantID: user.TenantID}
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.org.SetMember(ctx, member); err != nil {
+			return err
+		}
+		return s.recordMember(ctx, user.TenantID, userID, before, member)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.publishMember(ctx, user.TenantID, userID)
+	return member, nil
+}
+
+//mustBeOf return an errs.InvalidArgument error when the unit of id is not of kind in the tenant
+func (s *OrgService) mustBeOf(ctx context.Context, id int, kind models.OrgUnitKind, name string, tenantID int) error {
+	unit, err := s.org.FindById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if unit.Kind != kind {
+		return errs.NewInvalidArgument("org unit %d is not a %s", id, name)
+	}
+	if unit.TenantID != tenantID {
+		return errs.NewInvalidArgument("%s %d is of another tenant", name, id)
+	}
+	return nil
+}
+
+//Unassign a user from its department and position
+func (s *OrgService) Unassign(ctx context.Context, userID int64) error {
+	before, err := s.org.MemberOf(ctx, userID)
+	if err != nil {
+		return err
+	}
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.org.RemoveMember(ctx, userID); err != nil {
+			return err
+		}
+		return s.recordMember(ctx, before.TenantID, userID, before, nil)
+	})
+	if err != nil {
+		return err
+	}
+	s.publishMember(ctx, before.TenantID, userID)
+	return nil
+}
+
+//MemberOf return the department and position of a user, an errs.NotFound error when it is assigned none
+func (s *OrgService) MemberOf(ctx context.Context, userID int64) (*models.OrgMember, error) {
+	return s.org.MemberOf(ctx, userID)
+}
+
+//Members in a department or of a position in order of user id, members of sub departments are not
+func (s *OrgService) Members(ctx context.Context, unitID int) ([]*models.OrgMember, error) {
+	return s.org.Members(ctx, unitID)
+}
+
+//GrantRole grant a role to the members of a unit and of the units below it
+func (s *OrgService) GrantRole(ctx context.Context, unitID, roleID int) error {
+	unit, err := s.org.FindById(ctx, unitID)
+	if err != nil {
+		return err
+	}
+	role, err := s.roles.FindById(ctx, int64(roleID))
+	if err != nil {
+		return err
+	}
+	if role.TenantID != unit.TenantID {
+		return errs.NewInvalidArgument("role %d is of another tenant", roleID)
+	}
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.org.Grant(ctx, unitID, roleID); err != nil {
+			return err
+		}
+		return s.recordGrant(ctx, unit.TenantID, models.Linked, unitID, roleID)
+	})
+	if err != nil {
+		return err
+	}
+	s.publishGrant(ctx, unit.TenantID, models.Linked, unitID, roleID)
+	return nil
+}
+
+//RevokeRole revoke a role granted to a unit
+func (s *OrgService) RevokeRole(ctx context.Context, unitID, roleID int) error {
+	unit, err := s.org.FindById(ctx, unitID)
+	if err != nil {
+		return err
+	}
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.org.Revoke(ctx, unitID, roleID); err != nil {
+			return err
+		}
+		return s.recordGrant(ctx, unit.TenantID, models.Unlinked, unitID, roleID)
+	})
+	if err != nil {
+		return err
+	}
+	s.publishGrant(ctx, unit.TenantID, models.Unlinked, unitID, roleID)
+	return nil
+}
+
+//Grants return the roles granted to a unit in order of id, deleted ones are left out
+func (s *OrgService) Grants(ctx context.Context, unitID int) ([]*models.Role, error) {
+	roleIDs, err := s.org.Grants(ctx, unitID)
+	if err != nil {
+		return nil, err
+	}
+	return s.findRoles(ctx, roleIDs, map[int]bool{})
+}
+
+//InheritedRoles return the roles a user inherits from its position, its department and the units above it,
+//in order of id without duplicates, none when the user is assigned no department
+func (s *OrgService) InheritedRoles(ctx context.Context, userID int64) ([]*models.Role, error) {
+	member, err := s.org.MemberOf(ctx, userID)
+	if errs.CodeOf(err) == errs.NotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	unitIDs := []int{}
+	if member.PositionID != 0 {
+		unitIDs = append(unitIDs, member.PositionID)
+	}
+	for id := member.DeptID; id != 0; {
+		unit, err := s.org.FindById(ctx, id)
+		if errs.CodeOf(err) == errs.NotFound {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		unitIDs = append(unitIDs, id)
+		id = unit.ParentID
+	}
+
+	seen := map[int]bool{}
+	var roles []*models.Role
+	for _, id := range unitIDs {
+		roleIDs, err := s.org.Grants(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		granted, err := s.findRoles(ctx, roleIDs, seen)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, granted...)
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i].ID < roles[j].ID })
+	return roles, nil
+}
+
+//findRoles find the roles of ids not seen, deleted ones are left out
+func (s *OrgService) findRoles(ctx context.Context, ids []int, seen map[int]bool) ([]*models.Role, error) {
+	roles := make([]*models.Role, 0, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		role, err := s.roles.FindById(ctx, int64(id))
+		if errs.CodeOf(err) == errs.NotFound {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+	return roles, nil
+}
+
+//recordUnit record the change of a unit of the tenant in the unit of work of ctx, publishUnit publish it once it is committed
+func (s *OrgService) recordUnit(ctx context.Context, tenant int, action models.ChangeAction, id int, before, after *models.OrgUnit) error {
+	return s.audit.Record(ctx, tenant, models.OrgChange, action, strconv.Itoa(id), "", before, after)
+}
+
+func (s *OrgService) publishUnit(ctx context.Context, tenant int, action models.ChangeAction, id int) {
+	s.feed.Publish(ctx, tenant, models.OrgChange, action, strconv.Itoa(id), "")
+}
+
+//recordMember record the change of the units of a user as an update of the user
+func (s *OrgService) recordMember(ctx context.Context, tenant int, userID int64, before, after *models.OrgMember) error {
+	return s.audit.Record(ctx, tenant, models.UserChange, models.Updated, strconv.FormatInt(userID, 10), "", before, after)
+}
+
+func (s *OrgService) publishMember(ctx context.Context, tenant int, userID int64) {
+	s.feed.Publish(ctx, tenant, models.UserChange, models.Updated, strconv.FormatInt(userID, 10), "")
+}
+
+func (s *OrgService) recordGrant(ctx context.Context, tenant int, action models.ChangeAction, unitID, roleID int) error {
+	return s.audit.Record(ctx, tenant, models.OrgChange, action, strconv.Itoa(unitID), strconv.Itoa(roleID), nil, nil)
+}
+
+func (s *OrgService) publishGrant(ctx context.Context, tenant int, action models.ChangeAction, unitID, roleID int) {
+	s.feed.Publish(ctx, tenant, models.OrgChange, action, strconv.Itoa(unitID), strconv.Itoa(roleID))
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/micro-community/auth/config"
+	"github.com/micro-community/auth/repository"
+	"github.com/micro/micro/v3/service/logger"
+)
+
+//Purger purge users, roles and resources deleted longer than the retention ago
+type Purger struct {
+	users     repository.IUser
+	roles     repository.IRole
+	resources repository.IResource
+	retention time.Duration
+	interval  time.Duration
+}
+
+//NewPurger return a Purger of the retention in conf
+func NewPurger(users repository.IUser, roles repository.IRole, resources repository.IResource, conf *config.Options) *Purger {
+	return &Purger{
+		users:     users,
+		roles:     roles,
+		resources: resources,
+		retention: conf.SoftDeleteRetention,
+		interval:  conf.PurgeInterval,
+	}
+}
+
+//Purge once the entities deleted before the retention, return the number purged
+func (p *Purger) Purge(ctx context.Context) (int64, error) {
+	before := time.Now().Add(-p.retention)
+	var total int64
+	for _, purge := range []func(context.Context, time.Time) (int64, error){p.users.Purge, p.roles.Purge, p.resources.Purge} {
+		n, err := purge(ctx, before)
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+//Run purge every interval until ctx is done, a zero retention or interval disables it
+func (p *Purger) Run(ctx context.Context) {
+	if p.retention <= 0 || p.interval <= 0 {
+		logger.Infof("purge of deleted entities disabled")
+		return
+	}
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := p.Purge(ctx)
+			if err != nil {
+				logger.Errorf("purge deleted entities error: %v", err)
+			}
+			if n > 0 {
+				logger.Infof("purged %d entities deleted before %s ago", n, p.retention)
+			}
+		}
+	}
+}
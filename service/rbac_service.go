@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+)
+
+//RbacService for links of users, roles and resources
+type RbacService struct {
+	links repository.ILink
+	users repository.IUser
+	roles repository.IRole
+	org   *OrgService
+	uow   repository.UnitOfWork
+	feed  *ChangeFeed
+	audit *Auditor
+}
+
+func NewRbac(links repository.ILink, users repository.IUser, roles repository.IRole, org *OrgService, uow repository.UnitOfWork,
+	feed *ChangeFeed, audit *Auditor) *RbacService {
+	return &RbacService{
+		links: links,
+		users: users,
+		roles: roles,
+		org:   org,
+		uow:   uow,
+		feed:  feed,
+		audit: audit,
+	}
+}
+
+//LinkUserRole grant a role to a user
+func (s *RbacService) LinkUserRole(ctx context.Context, userID int64, roleID int) error {
+	tenant, err := s.tenantOfUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.links.LinkUserRole(ctx, userID, roleID); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, tenant, models.UserChange, models.Linked, strconv.FormatInt(userID, 10), strconv.Itoa(roleID), nil, nil)
+	})
+	if err != nil {
+		return err
+	}
+	s.feed.Publish(ctx, tenant, models.UserChange, models.Linked, strconv.FormatInt(userID, 10), strconv.Itoa(roleID))
+	return nil
+}
+
+//UnlinkUserRole revoke a role from a user
+func (s *RbacService) UnlinkUserRole(ctx context.Context, userID int64, roleID int) error {
+	tenant, err := s.tenantOfUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.links.UnlinkUserRole(ctx, userID, roleID); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, tenant, models.UserChange, models.Unlinked, strconv.FormatInt(userID, 10), strconv.Itoa(roleID), nil, nil)
+	})
+	if err != nil {
+		return err
+	}
+	s.feed.Publish(ctx, tenant, models.UserChange, models.Unlinked, strconv.FormatInt(userID, 10), strconv.Itoa(roleID))
+	return nil
+}
+
+//LinkRoleResource grant a resource to a role
+func (s *RbacService) LinkRoleResource(ctx context.Context, roleID, resourceID int) error {
+	tenant, err := s.tenantOfRole(ctx, roleID)
+	if err != nil {
+		return err
+	}
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.links.LinkRoleResource(ctx, roleID, resourceID); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, tenant, models.RoleChange, models.Linked, strconv.Itoa(roleID), strconv.Itoa(resourceID), nil, nil)
+	})
+	if err != nil {
+		return err
+	}
+	s.feed.Publish(ctx, tenant, models.RoleChange, models.Linked, strconv.Itoa(roleID), strconv.Itoa(resourceID))
+	return nil
+}
+
+//UnlinkRoleResource revoke a resource from a role
+func (s *RbacService) UnlinkRoleResource(ctx context.Context, roleID, resourceID int) error {
+	tenant, err := s.tenantOfRole(ctx, roleID)
+	if err != nil {
+		return err
+	}
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.links.UnlinkRoleResource(ctx, roleID, resourceID); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, tenant, models.RoleChange, models.Unlinked, strconv.Itoa(roleID), strconv.Itoa(resourceID), nil, nil)
+	})
+	if err != nil {
+		return err
+	}
+	s.feed.Publish(ctx, tenant, models.RoleChange, models.Unlinked, strconv.Itoa(roleID), strconv.Itoa(resourceID))
+	return nil
+}
+
+//tenantOfUser return the tenant of a user, deleted or not, whose links are of it
+func (s *RbacService) tenantOfUser(ctx context.Context, userID int64) (int, error) {
+	user, err := s.users.FindById(repository.WithDeleted(ctx), userID)
+	if err != nil {
+		return 0, err
+	}
+	return user.TenantID, nil
+}
+
+//tenantOfRole return the tenant of a role, deleted or not, whose links are of it
+func (s *RbacService) tenantOfRole(ctx context.Context, roleID int) (int, error) {
+	role, err := s.roles.FindById(repository.WithDeleted(ctx), int64(roleID))
+	if err != nil {
+		return 0, err
+	}
+	return role.TenantID, nil
+}
+
+//UserRoles return roles of a user, granted to it or inherited from its org units, in order of id
+func (s *RbacService) UserRoles(ctx context.Context, userID int64) ([]*models.Role, error) {
+	roles, err := s.links.UserRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	inherited, err := s.org.InheritedRoles(ctx, userID)
+	if err != nil || len(inherited) == 0 {
+		return roles, err
+	}
+	seen := make(map[int]bool, len(roles))
+	for _, role := range roles {
+		seen[role.ID] = true
+	}
+	for _, role := range inherited {
+		if !seen[role.ID] {
+			roles = append(roles, role)
+		}
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i].ID < roles[j].ID })
+	return roles, nil
+}
+
+//RoleResources return resources of a role
+func (s *RbacService) RoleResources(ctx context.Context, roleID int) ([]*models.Resource, error) {
+	return s.links.RoleResources(ctx, roleID)
+}
+
+//UserResources return resources of all roles of a user, those inherited from its org units included, in order of id
+func (s *RbacService) UserResources(ctx context.Context, userID int64) ([]*models.Resource, error) {
+	resources, err := s.links.UserResources(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	inherited, err := s.org.InheritedRoles(ctx, userID)
+	if err != nil || len(inherited) == 0 {
+		return resources, err
+	}
+	seen := make(map[int]bool, len(resources))
+	for _, resource := range resources {
+		seen[resource.ID] = true
+	}
+	for _, role := range inherited {
+		granted, err := s.links.RoleResources(ctx, role.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, resource := range granted {
+			if !seen[resource.ID] {
+				seen[resource.ID] = true
+				resources = append(resources, resource)
+			}
+		}
+	}
+	sort.Slice(resources, func(i, j int) bool { return resources[i].ID < resources[j].ID })
+	return resources, nil
+}
@@ -1,17 +1,143 @@
 package service
 
 import (
+	"context"
+	"strconv"
+
+	"github.com/micro-community/auth/models"
 	"github.com/micro-community/auth/repository"
 )
 
 //ResourceService for sdb
 type ResourceService struct {
-	repo repository.IResource
+	repo  repository.IResource
+	uow   repository.UnitOfWork
+	feed  *ChangeFeed
+	audit *Auditor
 }
 
 // NewResource return ResourceService
-func NewResource(repo repository.IResource) *ResourceService {
+func NewResource(repo repository.IResource, uow repository.UnitOfWork, feed *ChangeFeed, audit *Auditor) *ResourceService {
 	return &ResourceService{
-		repo: repo,
+		repo:  repo,
+		uow:   uow,
+		feed:  feed,
+		audit: audit,
+	}
+}
+
+//Create register a new resource, the caller is recorded as its owner
+func (s *ResourceService) Create(ctx context.Context, resource *models.Resource) error {
+	resource.AddedBy = operator(ctx)
+	resource.UpdateBy = resource.AddedBy
+	stamp(&resource.ModelExtension, true)
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.repo.Add(ctx, resource); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, resource.TenantID, models.ResourceChange, models.Created, strconv.Itoa(resource.ID), "", nil, resource)
+	})
+	if err != nil {
+		return err
+	}
+	s.feed.Publish(ctx, resource.TenantID, models.ResourceChange, models.Created, strconv.Itoa(resource.ID), "")
+	return nil
+}
+
+//Get return the resource of id
+func (s *ResourceService) Get(ctx context.Context, id int64) (*models.Resource, error) {
+	return s.repo.FindById(ctx, id)
+}
+
+//Update modify name and type of a resource at the version of update, the caller is recorded as its last updater.
+//An empty name is kept, the type is only modified with withType, as its zero value is a type as well.
+func (s *ResourceService) Update(ctx context.Context, update *models.Resource, withType bool) (*models.Resource, error) {
+	resource, err := s.Get(ctx, int64(update.ID))
+	if err != nil {
+		return nil, err
+	}
+	before := *resource
+
+	if update.Name != "" {
+		resource.Name = update.Name
+	}
+	if withType {
+		resource.Type = update.Type
+	}
+	resource.UpdateBy = operator(ctx)
+	resource.Version = update.Version
+	stamp(&resource.ModelExtension, false)
+
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, resource); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, resource.TenantID, models.ResourceChange, models.Updated, strconv.Itoa(resource.ID), "", &before, resource)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.feed.Publish(ctx, resource.TenantID, models.ResourceChange, models.Updated, strconv.Itoa(resource.ID), "")
+	return resource, nil
+}
+
+//Delete remove the resource of id at the version
+func (s *ResourceService) Delete(ctx context.Context, id, version int64) error {
+	before, err := s.repo.FindById(ctx, id)
+	if err != nil {
+		return err
+	}
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.repo.Delete(ctx, id, version); err != nil {
+			return err
+		}
+		after, _ := s.repo.FindById(repository.WithDeleted(ctx), id)
+		return s.audit.Record(ctx, before.TenantID, models.ResourceChange, models.Deleted, strconv.FormatInt(id, 10), "", before, after)
+	})
+	if err != nil {
+		return err
+	}
+	s.feed.Publish(ctx, before.TenantID, models.ResourceChange, models.Deleted, strconv.FormatInt(id, 10), "")
+	return nil
+}
+
+//Restore a deleted resource with the links of roles to it
+func (s *ResourceService) Restore(ctx context.Context, id int64) (*models.Resource, error) {
+	before, err := s.repo.FindById(repository.WithDeleted(ctx), id)
+	if err != nil {
+		return nil, err
+	}
+	var after *models.Resource
+	err = s.uow.Do(ctx, func(ctx context.Context) (err error) {
+		if err = s.repo.Restore(ctx, id); err != nil {
+			return err
+		}
+		if after, err = s.repo.FindById(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, before.TenantID, models.ResourceChange, models.Restored, strconv.FormatInt(id, 10), "", before, after)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.feed.Publish(ctx, before.TenantID, models.ResourceChange, models.Restored, strconv.FormatInt(id, 10), "")
+	return after, nil
+}
+
+//Search resources of a tenant by catalog types
+func (s *ResourceService) Search(ctx context.Context, tenantID int, types ...models.ResourceCatalog) ([]*models.Resource, error) {
+	return s.repo.Search(ctx, tenantID, types...)
+}
+
+//List resources matched opts from the cursor, at most size resources in a page
+func (s *ResourceService) List(ctx context.Context, opts repository.ListOptions, cursor string, size int) ([]*models.Resource, Page, error) {
+	seen, err := paginate(&opts, cursor, size)
+	if err != nil {
+		return nil, Page{}, err
+	}
+	resources, total, err := s.repo.List(ctx, opts)
+	if err != nil {
+		return nil, Page{}, err
 	}
+	return resources, nextPage(opts, seen, len(resources), lastResource(resources), total), nil
 }
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	"github.com/micro-community/auth/repository/memory"
+	"github.com/micro-community/auth/repository/tenant"
+)
+
+func TestResourceUpdateKeepsType(t *testing.T) {
+	ctx := context.Background()
+	s := NewResource(memory.NewResourceRepository(), memory.NewUnitOfWork(), NewChangeFeed(), NewAuditor(memory.NewLogRepository()))
+
+	resource := &models.Resource{Name: "printer", Type: int(models.Organization)}
+	if err := s.Create(ctx, resource); err != nil {
+		t.Fatal(err)
+	}
+	updated, err := s.Update(ctx, &models.Resource{ID: resource.ID, Name: "scanner", ModelExtension: models.ModelExtension{Version: 1}}, false)
+	if err != nil || updated.Name != "scanner" || updated.Type != int(models.Organization) {
+		t.Fatalf("name only update should keep the type, got %+v %v", updated, err)
+	}
+	updated, err = s.Update(ctx, &models.Resource{ID: resource.ID, Type: 0, ModelExtension: models.ModelExtension{Version: 2}}, true)
+	if err != nil || updated.Name != "scanner" || updated.Type != 0 {
+		t.Fatalf("update with the type should set it even to its zero value, got %+v %v", updated, err)
+	}
+}
+
+func TestResourceOfTenantCreatedBySuperTenant(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	feed, logs := NewChangeFeed(), tenant.NewLogRepository(memory.NewLogRepository())
+	s := NewResource(tenant.NewResourceRepository(memory.NewResourceRepository()), memory.NewUnitOfWork(), feed, NewAuditor(logs))
+	watched, err := feed.Watch(repository.WithTenant(ctx, 2), feed.Epoch(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource := &models.Resource{Name: "printer", TenantID: 2}
+	if err = s.Create(repository.WithTenant(ctx, models.SuperTenant), resource); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case change := <-watched:
+		if change.TenantID != 2 || change.Action != models.Created {
+			t.Errorf("change should be of the tenant of the resource, got %+v", change)
+		}
+	default:
+		t.Error("watcher of the tenant of the resource should see its creation")
+	}
+	found, err := logs.Query(repository.WithTenant(ctx, 2), repository.LogQuery{Kinds: []models.ChangeKind{models.ResourceChange}})
+	if err != nil || len(found) != 1 || found[0].TenantID != 2 {
+		t.Errorf("log should be of the tenant of the resource, got %+v %v", found, err)
+	}
+}
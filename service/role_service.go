@@ -1,16 +1,234 @@
 package service
 
 import (
+	"context"
+	"strconv"
+
+	"github.com/micro-community/auth/models"
 	"github.com/micro-community/auth/repository"
 )
 
 //RoleService for sdb
 type RoleService struct {
-	repo repository.IRole
+	repo  repository.IRole
+	links repository.ILink
+	uow   repository.UnitOfWork
+	feed  *ChangeFeed
+	audit *Auditor
 }
 
-func NewRole(repo repository.IRole) *RoleService {
+func NewRole(repo repository.IRole, links repository.ILink, uow repository.UnitOfWork, feed *ChangeFeed, audit *Auditor) *RoleService {
 	return &RoleService{
-		repo: repo,
+		repo:  repo,
+		links: links,
+		uow:   uow,
+		feed:  feed,
+		audit: audit,
+	}
+}
+
+//Create add a role linked to the resources, the role is not kept when any resource fails to link
+func (s *RoleService) Create(ctx context.Context, role *models.Role, resourceIDs []int) error {
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		return s.add(ctx, role, resourceIDs)
+	})
+	if err != nil {
+		return err
+	}
+	s.created(ctx, role, resourceIDs)
+	return nil
+}
+
+//add the role linked to the resources and record them in the unit of work of ctx,
+//events are left to created once it is done
+func (s *RoleService) add(ctx context.Context, role *models.Role, resourceIDs []int) error {
+	stamp(&role.ModelExtension, true)
+	if err := s.repo.Add(ctx, role); err != nil {
+		return err
+	}
+	if err := s.audit.Record(ctx, role.TenantID, models.RoleChange, models.Created, strconv.Itoa(role.ID), "", nil, role); err != nil {
+		return err
+	}
+	for _, resourceID := range resourceIDs {
+		if err := s.links.LinkRoleResource(ctx, role.ID, resourceID); err != nil {
+			return err
+		}
+	}
+	return s.record(ctx, role.TenantID, role.ID, models.Linked, resourceIDs)
+}
+
+func (s *RoleService) created(ctx context.Context, role *models.Role, resourceIDs []int) {
+	s.feed.Publish(ctx, role.TenantID, models.RoleChange, models.Created, strconv.Itoa(role.ID), "")
+	s.linked(ctx, role.TenantID, role.ID, models.Linked, resourceIDs)
+}
+
+//record the resources linked to or unlinked from a role of the tenant in the unit of work of ctx
+func (s *RoleService) record(ctx context.Context, tenant, roleID int, action models.ChangeAction, resourceIDs []int) error {
+	id := strconv.Itoa(roleID)
+	for _, resourceID := range resourceIDs {
+		if err := s.audit.Record(ctx, tenant, models.RoleChange, action, id, strconv.Itoa(resourceID), nil, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *RoleService) linked(ctx context.Context, tenant, roleID int, action models.ChangeAction, resourceIDs []int) {
+	id := strconv.Itoa(roleID)
+	for _, resourceID := range resourceIDs {
+		s.feed.Publish(ctx, tenant, models.RoleChange, action, id, strconv.Itoa(resourceID))
+	}
+}
+
+//SetResources link the role to exactly the resources, return the resources linked and unlinked
+func (s *RoleService) SetResources(ctx context.Context, roleID int, resourceIDs []int) (linked, unlinked []int, err error) {
+	role, err := s.Get(ctx, int64(roleID))
+	if err != nil {
+		return nil, nil, err
+	}
+	err = s.uow.Do(ctx, func(ctx context.Context) (err error) {
+		linked, unlinked, err = s.setResources(ctx, role.TenantID, roleID, resourceIDs)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	s.linked(ctx, role.TenantID, roleID, models.Linked, linked)
+	s.linked(ctx, role.TenantID, roleID, models.Unlinked, unlinked)
+	return linked, unlinked, nil
+}
+
+func (s *RoleService) setResources(ctx context.Context, tenant, roleID int, resourceIDs []int) (linked, unlinked []int, err error) {
+	current, err := s.links.RoleResources(ctx, roleID)
+	if err != nil {
+		return nil, nil, err
+	}
+	want := map[int]bool{}
+	for _, id := range resourceIDs {
+		want[id] = true
+	}
+	for _, resource := range current {
+		if want[resource.ID] {
+			delete(want, resource.ID)
+			continue
+		}
+		if err = s.links.UnlinkRoleResource(ctx, roleID, resource.ID); err != nil {
+			return nil, nil, err
+		}
+		unlinked = append(unlinked, resource.ID)
+	}
+	for _, id := range resourceIDs {
+		if !want[id] {
+			continue
+		}
+		if err = s.links.LinkRoleResource(ctx, roleID, id); err != nil {
+			return nil, nil, err
+		}
+		delete(want, id)
+		linked = append(linked, id)
+	}
+	if err = s.record(ctx, tenant, roleID, models.Linked, linked); err != nil {
+		return nil, nil, err
+	}
+	if err = s.record(ctx, tenant, roleID, models.Unlinked, unlinked); err != nil {
+		return nil, nil, err
+	}
+	return linked, unlinked, nil
+}
+
+//Get return the role of id
+func (s *RoleService) Get(ctx context.Context, id int64) (*models.Role, error) {
+	return s.repo.FindById(ctx, id)
+}
+
+//Update modify the name of a role at the version of update, an empty one is kept, the key is never modified
+func (s *RoleService) Update(ctx context.Context, update *models.Role) (*models.Role, error) {
+	role, err := s.Get(ctx, int64(update.ID))
+	if err != nil {
+		return nil, err
+	}
+	before := *role
+
+	if update.Name != "" {
+		role.Name = update.Name
+	}
+	role.Version = update.Version
+
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		return s.update(ctx, &before, role)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.updated(ctx, role.TenantID, role.ID)
+	return role, nil
+}
+
+//update the role changed from before and record it in the unit of work of ctx, the event is left to updated
+func (s *RoleService) update(ctx context.Context, before, role *models.Role) error {
+	stamp(&role.ModelExtension, false)
+	if err := s.repo.Update(ctx, role); err != nil {
+		return err
+	}
+	return s.audit.Record(ctx, role.TenantID, models.RoleChange, models.Updated, strconv.Itoa(role.ID), "", before, role)
+}
+
+func (s *RoleService) updated(ctx context.Context, tenant, roleID int) {
+	s.feed.Publish(ctx, tenant, models.RoleChange, models.Updated, strconv.Itoa(roleID), "")
+}
+
+//Delete a role at the version with its links
+func (s *RoleService) Delete(ctx context.Context, id, version int64) error {
+	before, err := s.repo.FindById(ctx, id)
+	if err != nil {
+		return err
+	}
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.repo.Delete(ctx, id, version); err != nil {
+			return err
+		}
+		after, _ := s.repo.FindById(repository.WithDeleted(ctx), id)
+		return s.audit.Record(ctx, before.TenantID, models.RoleChange, models.Deleted, strconv.FormatInt(id, 10), "", before, after)
+	})
+	if err != nil {
+		return err
+	}
+	s.feed.Publish(ctx, before.TenantID, models.RoleChange, models.Deleted, strconv.FormatInt(id, 10), "")
+	return nil
+}
+
+//Restore a deleted role with its links
+func (s *RoleService) Restore(ctx context.Context, id int64) (*models.Role, error) {
+	before, err := s.repo.FindById(repository.WithDeleted(ctx), id)
+	if err != nil {
+		return nil, err
+	}
+	var after *models.Role
+	err = s.uow.Do(ctx, func(ctx context.Context) (err error) {
+		if err = s.repo.Restore(ctx, id); err != nil {
+			return err
+		}
+		if after, err = s.repo.FindById(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, before.TenantID, models.RoleChange, models.Restored, strconv.FormatInt(id, 10), "", before, after)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.feed.Publish(ctx, before.TenantID, models.RoleChange, models.Restored, strconv.FormatInt(id, 10), "")
+	return after, nil
+}
+
+//List roles matched opts from the cursor, at most size roles in a page
+func (s *RoleService) List(ctx context.Context, opts repository.ListOptions, cursor string, size int) ([]*models.Role, Page, error) {
+	seen, err := paginate(&opts, cursor, size)
+	if err != nil {
+		return nil, Page{}, err
+	}
+	roles, total, err := s.repo.List(ctx, opts)
+	if err != nil {
+		return nil, Page{}, err
 	}
+	return roles, nextPage(opts, seen, len(roles), lastRole(roles), total), nil
 }
@@ -0,0 +1,293 @@
+package service
+
+import (
+	"context"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+)
+
+//RoleTemplateService define role templates and instantiate them into roles of tenants or resource scopes.
+//Templates are shared by all tenants, only the super tenant may change them.
+type RoleTemplateService struct {
+	templates repository.IRoleTemplate
+	tenants   repository.ITenant
+	resources repository.IResource
+	roles     *RoleService
+	uow       repository.UnitOfWork
+}
+
+func NewRoleTemplate(templates repository.IRoleTemplate, tenants repository.ITenant, resources repository.IResource,
+	roles *RoleService, uow repository.UnitOfWork) *RoleTemplateService {
+	return &RoleTemplateService{
+		templates: templates,
+		tenants:   tenants,
+		resources: resources,
+		roles:     roles,
+		uow:       uow,
+	}
+}
+
+//Create a template at version 1
+func (s *RoleTemplateService) Create(ctx context.Context, template *models.RoleTemplate) error {
+	if err := mustBeSuper(ctx, "create role templates"); err != nil {
+		return err
+	}
+	stamp(&template.ModelExtension, true)
+	return s.templates.Add(ctx, template)
+}
+
+func (s *RoleTemplateService) Get(ctx context.Context, id int) (*models.RoleTemplate, error) {
+	return s.templates.FindById(ctx, id)
+}
+
+//List all templates in order of id
+func (s *RoleTemplateService) List(ctx context.Context) ([]*models.RoleTemplate, error) {
+	return s.templates.List(ctx)
+}
+
+//Update the name, grants and default of a template at its version to a new version, roles of it are changed by Sync.
+//The key of a template can not be modified, as the key of a role can not.
+func (s *RoleTemplateService) Update(ctx context.Context, update *models.RoleTemplate) (*models.RoleTemplate, error) {
+	if err := mustBeSuper(ctx, "update role templates"); err != nil {
+		return nil, err
+	}
+	template, err := s.templates.FindById(ctx, update.ID)
+	if err != nil {
+		return nil, err
+	}
+	if update.Key != "" && update.Key != template.Key {
+		return nil, errs.NewConflict("role template key modify forbidden")
+	}
+	if update.Name != "" {
+		template.Name = update.Name
+	}
+	template.Grants = update.Grants
+	template.Default = update.Default
+	template.Version = update.Version
+	stamp(&template.ModelExtension, false)
+	if err = s.templates.Update(ctx, template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+//Delete a template at the version, the roles of it are kept but no longer synced
+func (s *RoleTemplateService) Delete(ctx context.Context, id int, version int64) error {
+	if err := mustBeSuper(ctx, "delete role templates"); err != nil {
+		return err
+	}
+	return s.templates.Delete(ctx, id, version)
+}
+
+//Provision a role of every template in the tenant of ctx, or in the scope of a resource of it when scopeID is not 0.
+//A role is named by its template after the tenant or resource, and linked to the resources of the tenant with the keys
+//granted by its template, and to the resource of its scope. No role is kept when any fails on backends with transactions.
+func (s *RoleTemplateService) Provision(ctx context.Context, templateIDs []int, scopeID int) ([]*models.Role, error) {
+	var roles []*models.Role
+	var grants [][]int
+	err := s.uow.Do(ctx, func(ctx context.Context) (err error) {
+		roles, grants, err = s.provision(ctx, templateIDs, scopeID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i, role := range roles {
+		s.roles.created(ctx, role, grants[i])
+	}
+	return roles, nil
+}
+
+//provision roles of the templates in the unit of work of ctx, return them with the resources granted to them
+func (s *RoleTemplateService) provision(ctx context.Context, templateIDs []int, scopeID int) ([]*models.Role, [][]int, error) {
+	prefix, err := s.scopeName(ctx, scopeID)
+	if err != nil {
+		return nil, nil, err
+	}
+	roles := make([]*models.Role, 0, len(templateIDs))
+	grants := make([][]int, 0, len(templateIDs))
+	for _, id := range templateIDs {
+		template, err := s.templates.FindById(ctx, id)
+		if err != nil {
+			return nil, nil, err
+		}
+		resourceIDs, err := s.granted(ctx, template.Grants, scopeID)
+		if err != nil {
+			return nil, nil, err
+		}
+		role := &models.Role{Key: template.Key, Name: roleName(prefix, template.Name)}
+		if err = s.roles.add(ctx, role, resourceIDs); err != nil {
+			return nil, nil, err
+		}
+		instance := &models.RoleInstance{
+			RoleID:          role.ID,
+			TemplateID:      template.ID,
+			TemplateVersion: template.Version,
+			TenantID:        role.TenantID,
+			ScopeID:         scopeID,
+		}
+		if err = s.templates.SaveInstance(ctx, instance); err != nil {
+			return nil, nil, err
+		}
+		roles = append(roles, role)
+		grants = append(grants, resourceIDs)
+	}
+	return roles, grants, nil
+}
+
+//provisionDefaults provision a role of every default template in the tenant of ctx, in the unit of work of ctx
+func (s *RoleTemplateService) provisionDefaults(ctx context.Context) ([]*models.Role, [][]int, error) {
+	templates, err := s.templates.List(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	var ids []int
+	for _, template := range templates {
+		if template.Default {
+			ids = append(ids, template.ID)
+		}
+	}
+	return s.provision(ctx, ids, 0)
+}
+
+//roleName of a role of the template name in the scope named prefix
+func roleName(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+//scopeName return the name of the resource of scopeID, or of the tenant of ctx when it is 0,
+//it is empty for the super tenant
+func (s *RoleTemplateService) scopeName(ctx context.Context, scopeID int) (string, error) {
+	if scopeID != 0 {
+		resource, err := s.resources.FindById(ctx, int64(scopeID))
+		if err != nil {
+			return "", err
+		}
+		return resource.Name, nil
+	}
+	id, ok := repository.ScopedTenant(ctx)
+	if !ok {
+		return "", nil
+	}
+	tenant, err := s.tenants.FindById(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	return tenant.Name, nil
+}
+
+//granted return the ids of the resources of the tenant of ctx with the keys, with the resource of the scope
+func (s *RoleTemplateService) granted(ctx context.Context, keys models.Keys, scopeID int) ([]int, error) {
+	var ids []int
+	if scopeID != 0 {
+		ids = append(ids, scopeID)
+	}
+	if len(keys) == 0 {
+		return ids, nil
+	}
+	wanted := map[string]bool{}
+	for _, key := range keys {
+		wanted[key] = true
+	}
+	tenant, _ := repository.TenantOf(ctx)
+	opts := repository.ListOptions{SortBy: repository.SortByID, Limit: maxPageSize}
+	for {
+		resources, _, err := s.resources.List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, resource := range resources {
+			if wanted[resource.Key] && resource.TenantID == tenant && resource.ID != scopeID {
+				ids = append(ids, resource.ID)
+			}
+		}
+		if len(resources) < opts.Limit {
+			return ids, nil
+		}
+		opts.Offset += len(resources)
+	}
+}
+
+//TemplateSync counts the roles of a template synced
+type TemplateSync struct {
+	Synced   int // roles linked to the resources of the current version
+	Renamed  int // synced roles renamed after the name of the current version
+	Missing  int // roles deleted, they are synced once restored
+	Linked   int // links of the synced roles to resources added
+	Unlinked int // links of the synced roles to resources removed
+}
+
+//Sync the roles of a template behind its version in all tenants: a role is named by the template and linked to exactly
+//the resources the template grants, like a role provisioned at the version. Links added to the role by hand are removed.
+func (s *RoleTemplateService) Sync(ctx context.Context, id int) (*TemplateSync, error) {
+	if err := mustBeSuper(ctx, "sync role templates"); err != nil {
+		return nil, err
+	}
+	template, err := s.templates.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	instances, err := s.templates.Instances(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TemplateSync{}
+	for _, instance := range instances {
+		if instance.TemplateVersion >= template.Version {
+			continue
+		}
+		tctx := repository.WithTenant(ctx, instance.TenantID)
+		role, err := s.roles.repo.FindById(tctx, int64(instance.RoleID))
+		if errs.CodeOf(err) == errs.NotFound {
+			result.Missing++
+			continue
+		} else if err != nil {
+			return result, err
+		}
+
+		var linked, unlinked []int
+		var renamed bool
+		err = s.uow.Do(tctx, func(ctx context.Context) error {
+			prefix, err := s.scopeName(ctx, instance.ScopeID)
+			if err != nil {
+				return err
+			}
+			if name := roleName(prefix, template.Name); name != role.Name {
+				before := *role
+				role.Name = name
+				if err = s.roles.update(ctx, &before, role); err != nil {
+					return err
+				}
+				renamed = true
+			}
+			resourceIDs, err := s.granted(ctx, template.Grants, instance.ScopeID)
+			if err != nil {
+				return err
+			}
+			if linked, unlinked, err = s.roles.setResources(ctx, role.TenantID, instance.RoleID, resourceIDs); err != nil {
+				return err
+			}
+			instance.TemplateVersion = template.Version
+			return s.templates.SaveInstance(ctx, instance)
+		})
+		if err != nil {
+			return result, err
+		}
+		if renamed {
+			s.roles.updated(ctx, role.TenantID, role.ID)
+			result.Renamed++
+		}
+		s.roles.linked(ctx, role.TenantID, instance.RoleID, models.Linked, linked)
+		s.roles.linked(ctx, role.TenantID, instance.RoleID, models.Unlinked, unlinked)
+		result.Synced++
+		result.Linked += len(linked)
+		result.Unlinked += len(unlinked)
+	}
+	return result, nil
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	"github.com/micro-community/auth/repository/memory"
+	"github.com/micro-community/auth/repository/tenant"
+)
+
+func TestTemplateSyncRenamesRoles(t *testing.T) {
+	ctx := context.Background()
+	users, roles, resources := memory.NewUserRepository(), memory.NewRoleRepository(), memory.NewResourceRepository()
+	links := memory.NewLinkRepository(users, roles, resources)
+	tenants, uow := memory.NewTenantRepository(), memory.NewUnitOfWork()
+	logs := memory.NewLogRepository()
+	roleService := NewRole(tenant.NewRoleRepository(roles), links, uow, NewChangeFeed(), NewAuditor(logs))
+	s := NewRoleTemplate(memory.NewRoleTemplateRepository(), tenants, resources, roleService, uow)
+
+	acme := &models.Tenant{Name: "acme", State: models.TenantActive}
+	if err := tenants.Add(ctx, acme); err != nil {
+		t.Fatal(err)
+	}
+	template := &models.RoleTemplate{Name: "admin", Key: "admin"}
+	if err := s.Create(ctx, template); err != nil {
+		t.Fatal(err)
+	}
+	provisioned, err := s.Provision(repository.WithTenant(ctx, acme.ID), []int{template.ID}, 0)
+	if err != nil || len(provisioned) != 1 || provisioned[0].Name != "acme.admin" {
+		t.Fatalf("provision should name the role after the tenant, got %+v %v", provisioned, err)
+	}
+	if _, err = s.Provision(ctx, []int{template.ID}, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = s.Update(ctx, &models.RoleTemplate{ID: template.ID, Name: "owner", ModelExtension: models.ModelExtension{Version: 1}}); err != nil {
+		t.Fatal(err)
+	}
+	result, err := s.Sync(ctx, template.ID)
+	if err != nil || result.Synced != 2 || result.Renamed != 2 {
+		t.Fatalf("sync should rename both roles, got %+v %v", result, err)
+	}
+	for name, tenant := range map[string]int{"acme.owner": acme.ID, "owner": models.SuperTenant} {
+		role, err := roles.FindByName(repository.WithTenant(ctx, tenant), name)
+		if err != nil || role.Key != "admin" || role.Version != 2 {
+			t.Errorf("role %s should be renamed at version 2 keeping its key, got %+v %v", name, role, err)
+		}
+	}
+	changes, err := logs.Query(ctx, repository.LogQuery{Kinds: []models.ChangeKind{models.RoleChange}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	renames := 0
+	for _, change := range changes {
+		if change.Action == models.Updated {
+			renames++
+		}
+	}
+	if renames != 2 {
+		t.Errorf("renames should be logged, got %d", renames)
+	}
+
+	if result, err = s.Sync(ctx, template.ID); err != nil || result.Synced != 0 || result.Renamed != 0 {
+		t.Errorf("roles at the version of the template should not be synced again, got %+v %v", result, err)
+	}
+}
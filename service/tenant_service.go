@@ -0,0 +1,380 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"unicode"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/pubsub"
+	"github.com/micro-community/auth/repository"
+)
+
+//event types of the lifecycle of tenants published by TenantService
+const (
+	EventTenantCreated   = "tenant.created"
+	EventTenantUpdated   = "tenant.updated"
+	EventTenantSuspended = "tenant.suspended"
+	EventTenantResumed   = "tenant.resumed"
+	EventTenantDeleted   = "tenant.deleted"
+)
+
+//AdminRoleKey of the role seeded for the admin of a new tenant
+const AdminRoleKey = "admin"
+
+//TenantService provision tenants and manage their lifecycle, only the super tenant may
+type TenantService struct {
+	tenants   repository.ITenant
+	users     repository.IUser
+	roles     repository.IRole
+	resources repository.IResource
+	links     repository.ILink
+	org       repository.IOrg
+	uow       repository.UnitOfWork
+	feed      *ChangeFeed
+	audit     *Auditor
+	events    *pubsub.Publisher
+	templates *RoleTemplateService
+}
+
+func NewTenant(tenants repository.ITenant, users repository.IUser, roles repository.IRole, resources repository.IResource,
+	links repository.ILink, org repository.IOrg, uow repository.UnitOfWork, feed *ChangeFeed, audit *Auditor,
+	events *pubsub.Publisher, templates *RoleTemplateService) *TenantService {
+	return &TenantService{
+		tenants:   tenants,
+		users:     users,
+		roles:     roles,
+		resources: resources,
+		links:     links,
+		org:       org,
+		uow:       uow,
+		feed:      feed,
+		audit:     audit,
+		events:    events,
+		templates: templates,
+	}
+}
+
+//Create provision an active tenant with its admin user, the admin role linked to it and a role of every default template,
+//nothing is kept on backends with transactions when any of them fails
+func (s *TenantService) Create(ctx context.Context, tenant *models.Tenant, admin *models.User) error {
+	if err := mustBeSuper(ctx, "create tenants"); err != nil {
+		return err
+	}
+	if err := checkPassword(tenant.Settings.PasswordPolicy, admin.Password); err != nil {
+		return err
+	}
+	tenant.State = models.TenantActive
+	stamp(&tenant.ModelExtension, true)
+	stamp(&admin.ModelExtension, true)
+	var role *models.Role
+	var provisioned []*models.Role
+	var grants [][]int
+	err := s.uow.Do(ctx, func(ctx context.Context) (err error) {
+		if err = s.tenants.Add(ctx, tenant); err != nil {
+			return err
+		}
+		ctx = repository.WithTenant(ctx, tenant.ID)
+		if err = s.users.Add(ctx, admin); err != nil {
+			return err
+		}
+		role = &models.Role{Key: AdminRoleKey, Name: tenant.Name + "." + AdminRoleKey}
+		stamp(&role.ModelExtension, true)
+		if err = s.roles.Add(ctx, role); err != nil {
+			return err
+		}
+		if err = s.links.LinkUserRole(ctx, admin.ID, role.ID); err != nil {
+			return err
+		}
+		userID, roleID := strconv.FormatInt(admin.ID, 10), strconv.Itoa(role.ID)
+		if err = s.audit.Record(ctx, tenant.ID, models.UserChange, models.Created, userID, "", nil, admin); err != nil {
+			return err
+		}
+		if err = s.audit.Record(ctx, tenant.ID, models.RoleChange, models.Created, roleID, "", nil, role); err != nil {
+			return err
+		}
+		if err = s.audit.Record(ctx, tenant.ID, models.UserChange, models.Linked, userID, roleID, nil, nil); err != nil {
+			return err
+		}
+		provisioned, grants, err = s.templates.provisionDefaults(ctx)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	userID, roleID := strconv.FormatInt(admin.ID, 10), strconv.Itoa(role.ID)
+	s.feed.Publish(ctx, tenant.ID, models.UserChange, models.Created, userID, "")
+	s.feed.Publish(ctx, tenant.ID, models.RoleChange, models.Created, roleID, "")
+	s.feed.Publish(ctx, tenant.ID, models.UserChange, models.Linked, userID, roleID)
+	for i, provisionedRole := range provisioned {
+		s.templates.roles.created(ctx, provisionedRole, grants[i])
+	}
+	s.events.Publish(ctx, tenant.ID, EventTenantCreated, tenant)
+	return nil
+}
+
+//Get a tenant, a tenant may get itself
+func (s *TenantService) Get(ctx context.Context, id int) (*models.Tenant, error) {
+	if scoped, ok := repository.ScopedTenant(ctx); ok && scoped != id {
+		return nil, errs.NewNotFound("tenant %d not found", id)
+	}
+	return s.tenants.FindById(ctx, id)
+}
+
+//List all tenants in order of id
+func (s *TenantService) List(ctx context.Context) ([]*models.Tenant, error) {
+	if err := mustBeSuper(ctx, "list tenants"); err != nil {
+		return nil, err
+	}
+	return s.tenants.List(ctx)
+}
+
+//UpdateSettings of a tenant at the version, they apply to users created or registered after
+func (s *TenantService) UpdateSettings(ctx context.Context, id int, settings models.TenantSettings, version int64) (*models.Tenant, error) {
+	return s.update(ctx, id, version, "update tenants", EventTenantUpdated, func(tenant *models.Tenant) error {
+		tenant.Settings = settings
+		return nil
+	})
+}
+
+//Suspend an active tenant at the version, requests in it are rejected until it is resumed
+func (s *TenantService) Suspend(ctx context.Context, id int, version int64) (*models.Tenant, error) {
+	return s.update(ctx, id, version, "suspend tenants", EventTenantSuspended, func(tenant *models.Tenant) error {
+		if tenant.State == models.TenantSuspended {
+			return errs.NewConflict("tenant %d is suspended already", id)
+		}
+		tenant.State = models.TenantSuspended
+		return nil
+	})
+}
+
+//Resume a suspended tenant at the version
+func (s *TenantService) Resume(ctx context.Context, id int, version int64) (*models.Tenant, error) {
+	return s.update(ctx, id, version, "resume tenants", EventTenantResumed, func(tenant *models.Tenant) error {
+		if tenant.State != models.TenantSuspended {
+			return errs.NewConflict("tenant %d is not suspended", id)
+		}
+		tenant.State = models.TenantActive
+		return nil
+	})
+}
+
+//update the tenant of id at the version by modify, then publish the event.
+//The write is at the version too, so a tenant changed since it is read is not overwritten
+func (s *TenantService) update(ctx context.Context, id int, version int64, op, eventType string, modify func(*models.Tenant) error) (*models.Tenant, error) {
+	if err := mustBeSuper(ctx, op); err != nil {
+		return nil, err
+	}
+	tenant, err := s.tenants.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if tenant.Version != version {
+		return nil, repository.StaleVersion("tenant", int64(id), version)
+	}
+	if err = modify(tenant); err != nil {
+		return nil, err
+	}
+	if err = s.tenants.Update(ctx, tenant); err != nil {
+		return nil, err
+	}
+	s.events.Publish(ctx, id, eventType, tenant)
+	return tenant, nil
+}
+
+//TenantDeletion counts the entities deleted with a tenant
+type TenantDeletion struct {
+	Tenant    *models.Tenant `json:"tenant"`
+	Users     int            `json:"users"`
+	Roles     int            `json:"roles"`
+	Resources int            `json:"resources"`
+	OrgUnits  int            `json:"orgUnits"`
+}
+
+//Delete a tenant at the version with its users, roles and resources, the tenant is kept hidden so its id is never reused.
+//They are deleted like any other, so they are purged with their links after the retention, logs are kept.
+//Its org units are removed for good with their members and grants.
+func (s *TenantService) Delete(ctx context.Context, id int, version int64) (*TenantDeletion, error) {
+	if err := mustBeSuper(ctx, "delete tenants"); err != nil {
+		return nil, err
+	}
+	tenant, err := s.tenants.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if tenant.Version != version {
+		return nil, repository.StaleVersion("tenant", int64(id), version)
+	}
+
+	tctx := repository.WithTenant(ctx, id)
+	var users, roles, resources, units []entityVersion
+	err = s.uow.Do(tctx, func(ctx context.Context) error {
+		var err error
+		if units, err = s.deleteOrg(ctx); err != nil {
+			return err
+		}
+		if users, err = deleteAll(ctx, s.listUsers, s.users.Delete); err != nil {
+			return err
+		}
+		if roles, err = deleteAll(ctx, s.listRoles, s.roles.Delete); err != nil {
+			return err
+		}
+		if resources, err = deleteAll(ctx, s.listResources, s.resources.Delete); err != nil {
+			return err
+		}
+		for kind, deleted := range map[models.ChangeKind][]entityVersion{models.UserChange: users, models.RoleChange: roles,
+			models.ResourceChange: resources, models.OrgChange: units} {
+			for _, entity := range deleted {
+				if err = s.audit.Record(ctx, id, kind, models.Deleted, strconv.FormatInt(entity.id, 10), "", nil, nil); err != nil {
+					return err
+				}
+			}
+		}
+		//the tenant goes last at the version, so a tenant changed meanwhile rolls back the deletion
+		return s.tenants.Delete(ctx, id, version)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for kind, deleted := range map[models.ChangeKind][]entityVersion{models.UserChange: users, models.RoleChange: roles,
+		models.ResourceChange: resources, models.OrgChange: units} {
+		for _, entity := range deleted {
+			s.feed.Publish(ctx, id, kind, models.Deleted, strconv.FormatInt(entity.id, 10), "")
+		}
+	}
+	deletion := &TenantDeletion{Tenant: tenant, Users: len(users), Roles: len(roles), Resources: len(resources), OrgUnits: len(units)}
+	s.events.Publish(ctx, id, EventTenantDeleted, deletion)
+	return deletion, nil
+}
+
+//entityVersion identify an entity at its version
+type entityVersion struct {
+	id, version int64
+}
+
+//deleteAll delete every entity listed, all pages are listed before any is deleted
+func deleteAll(ctx context.Context, list func(context.Context, repository.ListOptions) ([]entityVersion, error),
+	del func(ctx context.Context, id, version int64) error) ([]entityVersion, error) {
+	opts := repository.ListOptions{SortBy: repository.SortByID, Limit: maxPageSize}
+	var all []entityVersion
+	for {
+		page, err := list(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if len(page) < opts.Limit {
+			break
+		}
+		opts.Offset += len(page)
+	}
+	for _, entity := range all {
+		if err := del(ctx, entity.id, entity.version); err != nil {
+			return nil, err
+		}
+	}
+	return all, nil
+}
+
+//deleteOrg remove the org units of the tenant of ctx with their members
+func (s *TenantService) deleteOrg(ctx context.Context) ([]entityVersion, error) {
+	units, err := s.org.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	removed := map[int64]bool{}
+	deleted := make([]entityVersion, 0, len(units))
+	for _, unit := range units {
+		members, err := s.org.Members(ctx, unit.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range members {
+			if removed[member.UserID] {
+				continue
+			}
+			if err = s.org.RemoveMember(ctx, member.UserID); err != nil {
+				return nil, err
+			}
+			removed[member.UserID] = true
+		}
+		if err = s.org.Delete(ctx, unit.ID, unit.Version); err != nil {
+			return nil, err
+		}
+		deleted = append(deleted, entityVersion{int64(unit.ID), unit.Version})
+	}
+	return deleted, nil
+}
+
+func (s *TenantService) listUsers(ctx context.Context, opts repository.ListOptions) ([]entityVersion, error) {
+	users, _, err := s.users.List(ctx, opts)
+	page := make([]entityVersion, 0, len(users))
+	for _, user := range users {
+		page = append(page, entityVersion{user.ID, user.Version})
+	}
+	return page, err
+}
+
+func (s *TenantService) listRoles(ctx context.Context, opts repository.ListOptions) ([]entityVersion, error) {
+	roles, _, err := s.roles.List(ctx, opts)
+	page := make([]entityVersion, 0, len(roles))
+	for _, role := range roles {
+		page = append(page, entityVersion{int64(role.ID), role.Version})
+	}
+	return page, err
+}
+
+func (s *TenantService) listResources(ctx context.Context, opts repository.ListOptions) ([]entityVersion, error) {
+	resources, _, err := s.resources.List(ctx, opts)
+	page := make([]entityVersion, 0, len(resources))
+	for _, resource := range resources {
+		page = append(page, entityVersion{int64(resource.ID), resource.Version})
+	}
+	return page, err
+}
+
+//passwordPolicy of the tenant of ctx, the super tenant has none
+func passwordPolicy(ctx context.Context, tenants repository.ITenant) (models.PasswordPolicy, error) {
+	id, ok := repository.ScopedTenant(ctx)
+	if !ok {
+		return models.PasswordPolicy{}, nil
+	}
+	tenant, err := tenants.FindById(ctx, id)
+	if err != nil {
+		return models.PasswordPolicy{}, err
+	}
+	return tenant.Settings.PasswordPolicy, nil
+}
+
+//checkPassword return an errs.InvalidArgument error when the password breaks the policy
+func checkPassword(policy models.PasswordPolicy, password string) error {
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			symbol = true
+		}
+	}
+	switch {
+	case len([]rune(password)) < policy.MinLength:
+		return errs.NewInvalidArgument("password is shorter than %d", policy.MinLength)
+	case policy.RequireUpper && !upper:
+		return errs.NewInvalidArgument("password requires an upper case letter")
+	case policy.RequireLower && !lower:
+		return errs.NewInvalidArgument("password requires a lower case letter")
+	case policy.RequireDigit && !digit:
+		return errs.NewInvalidArgument("password requires a digit")
+	case policy.RequireSymbol && !symbol:
+		return errs.NewInvalidArgument("password requires a symbol")
+	}
+	return nil
+}
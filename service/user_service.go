@@ -1,65 +1,255 @@
 package service
 
 import (
-	"fmt"
+	"context"
+	"strconv"
 
+	"github.com/micro-community/auth/errs"
 	"github.com/micro-community/auth/models"
 	"github.com/micro-community/auth/repository"
 )
 
 //UserService for sdb
 type UserService struct {
-	repo repository.IUser
+	repo    repository.IUser
+	links   repository.ILink
+	tenants repository.ITenant
+	uow     repository.UnitOfWork
+	feed    *ChangeFeed
+	audit   *Auditor
 }
 
-func NewUser(repo repository.IUser) *UserService {
+func NewUser(repo repository.IUser, links repository.ILink, tenants repository.ITenant, uow repository.UnitOfWork, feed *ChangeFeed, audit *Auditor) *UserService {
 	return &UserService{
-		repo: repo,
+		repo:    repo,
+		links:   links,
+		tenants: tenants,
+		uow:     uow,
+		feed:    feed,
+		audit:   audit,
 	}
 }
 
-func (s *UserService) Login(name, pwd string) (*models.User, error) {
-	user, err := s.repo.FindByName(name)
+func (s *UserService) Login(ctx context.Context, name, pwd string) (*models.User, error) {
+	user, err := s.repo.FindByName(ctx, name)
 
-	if err != nil {
+	if err != nil && errs.CodeOf(err) != errs.NotFound {
 		return nil, err
-	} else if user == nil {
-		return nil, nil
-	}
-
-	if user.Password != pwd {
-		return nil, nil
+	} else if user == nil || user.Password != pwd {
+		return nil, errs.NewInvalidCredentials("invalid name or password")
 	}
 
 	return user, nil
 
 }
 
-func (s *UserService) Register(name, pwd string) (*models.User, error) {
-	err := s.Duplicated(name)
+func (s *UserService) Register(ctx context.Context, name, pwd string) (*models.User, error) {
+	err := s.Duplicated(ctx, name)
 	if err != nil {
 		return nil, err
 	}
+	if err = s.checkPassword(ctx, pwd); err != nil {
+		return nil, err
+	}
 
 	u := models.User{
 		Name:     name,
 		Password: pwd,
 	}
-	err = s.repo.Add(&u)
+	stamp(&u.ModelExtension, true)
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.repo.Add(ctx, &u); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, u.TenantID, models.UserChange, models.Created, strconv.FormatInt(u.ID, 10), "", nil, &u)
+	})
 	if err != nil {
 		return nil, err
 	}
+	s.feed.Publish(ctx, u.TenantID, models.UserChange, models.Created, strconv.FormatInt(u.ID, 10), "")
 
 	return &u, nil
 }
 
-func (s *UserService) Duplicated(name string) error {
-	user, err := s.repo.FindByName(name)
-	if user != nil {
-		return fmt.Errorf("%s already exists", name)
+//Create add a user linked to the roles, the user is not kept when any role fails to link
+func (s *UserService) Create(ctx context.Context, user *models.User, roleIDs []int) error {
+	if err := s.checkPassword(ctx, user.Password); err != nil {
+		return err
+	}
+	stamp(&user.ModelExtension, true)
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.repo.Add(ctx, user); err != nil {
+			return err
+		}
+		id := strconv.FormatInt(user.ID, 10)
+		if err := s.audit.Record(ctx, user.TenantID, models.UserChange, models.Created, id, "", nil, user); err != nil {
+			return err
+		}
+		for _, roleID := range roleIDs {
+			if err := s.links.LinkUserRole(ctx, user.ID, roleID); err != nil {
+				return err
+			}
+			if err := s.audit.Record(ctx, user.TenantID, models.UserChange, models.Linked, id, strconv.Itoa(roleID), nil, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	id := strconv.FormatInt(user.ID, 10)
+	s.feed.Publish(ctx, user.TenantID, models.UserChange, models.Created, id, "")
+	for _, roleID := range roleIDs {
+		s.feed.Publish(ctx, user.TenantID, models.UserChange, models.Linked, id, strconv.Itoa(roleID))
+	}
+	return nil
+}
+
+//Get return the user of id
+func (s *UserService) Get(ctx context.Context, id int64) (*models.User, error) {
+	return s.repo.FindById(ctx, id)
+}
+
+//Update modify the profile of a user at the version of update: nick name, state, email and phone, empty ones are kept
+func (s *UserService) Update(ctx context.Context, update *models.User) (*models.User, error) {
+	user, err := s.Get(ctx, update.ID)
+	if err != nil {
+		return nil, err
+	}
+	before := *user
+
+	if update.NickName != "" {
+		user.NickName = update.NickName
+	}
+	if update.Stated != 0 {
+		user.Stated = update.Stated
+	}
+	if update.Email != "" {
+		user.Email = update.Email
+	}
+	if update.Phone != "" {
+		user.Phone = update.Phone
+	}
+	user.Version = update.Version
+	stamp(&user.ModelExtension, false)
+
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, user); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, user.TenantID, models.UserChange, models.Updated, strconv.FormatInt(user.ID, 10), "", &before, user)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.feed.Publish(ctx, user.TenantID, models.UserChange, models.Updated, strconv.FormatInt(user.ID, 10), "")
+	return user, nil
+}
+
+//Delete a user at the version with the links to its roles
+func (s *UserService) Delete(ctx context.Context, id, version int64) error {
+	before, err := s.repo.FindById(ctx, id)
+	if err != nil {
+		return err
 	}
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.repo.Delete(ctx, id, version); err != nil {
+			return err
+		}
+		after, _ := s.repo.FindById(repository.WithDeleted(ctx), id)
+		return s.audit.Record(ctx, before.TenantID, models.UserChange, models.Deleted, strconv.FormatInt(id, 10), "", before, after)
+	})
 	if err != nil {
 		return err
 	}
+	s.feed.Publish(ctx, before.TenantID, models.UserChange, models.Deleted, strconv.FormatInt(id, 10), "")
 	return nil
 }
+
+//Restore a deleted user with the links to its roles
+func (s *UserService) Restore(ctx context.Context, id int64) (*models.User, error) {
+	before, err := s.repo.FindById(repository.WithDeleted(ctx), id)
+	if err != nil {
+		return nil, err
+	}
+	var after *models.User
+	err = s.uow.Do(ctx, func(ctx context.Context) (err error) {
+		if err = s.repo.Restore(ctx, id); err != nil {
+			return err
+		}
+		if after, err = s.repo.FindById(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, before.TenantID, models.UserChange, models.Restored, strconv.FormatInt(id, 10), "", before, after)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.feed.Publish(ctx, before.TenantID, models.UserChange, models.Restored, strconv.FormatInt(id, 10), "")
+	return after, nil
+}
+
+//checkPassword against the password policy of the tenant of ctx
+func (s *UserService) checkPassword(ctx context.Context, password string) error {
+	policy, err := passwordPolicy(ctx, s.tenants)
+	if err != nil {
+		return err
+	}
+	return checkPassword(policy, password)
+}
+
+func (s *UserService) Duplicated(ctx context.Context, name string) error {
+	_, err := s.repo.FindByName(ctx, name)
+	switch {
+	case err == nil:
+		return errs.NewAlreadyExists("%s already exists", name)
+	case errs.CodeOf(err) == errs.NotFound:
+		return nil
+	}
+	return err
+}
+
+//List users matched opts from the cursor, at most size users in a page
+func (s *UserService) List(ctx context.Context, opts repository.ListOptions, cursor string, size int) ([]*models.User, Page, error) {
+	seen, err := paginate(&opts, cursor, size)
+	if err != nil {
+		return nil, Page{}, err
+	}
+	users, total, err := s.repo.List(ctx, opts)
+	if err != nil {
+		return nil, Page{}, err
+	}
+	return users, nextPage(opts, seen, len(users), lastUser(users), total), nil
+}
+
+//Export walk through users matched opts in order of id, at most limit users (0 for all)
+func (s *UserService) Export(ctx context.Context, opts repository.ListOptions, limit int64, fn func(*models.User) error) error {
+	opts.SortBy, opts.Desc = repository.SortByID, false
+	opts.After, opts.Offset, opts.Limit = nil, 0, maxPageSize
+
+	var exported int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		users, _, err := s.repo.List(ctx, opts)
+		if err != nil {
+			return err
+		}
+		for _, user := range users {
+			if limit > 0 && exported >= limit {
+				return nil
+			}
+			if err = fn(user); err != nil {
+				return err
+			}
+			exported++
+		}
+		if len(users) < opts.Limit {
+			return nil
+		}
+		opts.After = &repository.ListKey{ID: users[len(users)-1].ID}
+	}
+}
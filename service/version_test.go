@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/pubsub"
+	"github.com/micro-community/auth/repository"
+	"github.com/micro-community/auth/repository/memory"
+)
+
+func TestRoleVersion(t *testing.T) {
+	ctx := context.Background()
+	users, roles, resources := memory.NewUserRepository(), memory.NewRoleRepository(), memory.NewResourceRepository()
+	links := memory.NewLinkRepository(users, roles, resources)
+	s := NewRole(roles, links, memory.NewUnitOfWork(), NewChangeFeed(), NewAuditor(memory.NewLogRepository()))
+
+	role := &models.Role{Key: "editor", Name: "editor"}
+	if err := s.Create(ctx, role, nil); err != nil {
+		t.Fatal(err)
+	}
+	updated, err := s.Update(ctx, &models.Role{ID: role.ID, Name: "writer", ModelExtension: models.ModelExtension{Version: 1}})
+	if err != nil || updated.Version != 2 || updated.Name != "writer" || updated.Key != "editor" {
+		t.Fatalf("update should step the version and keep empty fields, got %+v %v", updated, err)
+	}
+	if _, err = s.Update(ctx, &models.Role{ID: role.ID, Name: "reader", ModelExtension: models.ModelExtension{Version: 1}}); errs.CodeOf(err) != errs.Conflict {
+		t.Errorf("update at a stale version should conflict, got %v", err)
+	}
+	if err = s.Delete(ctx, int64(role.ID), 1); errs.CodeOf(err) != errs.Conflict {
+		t.Errorf("delete at a stale version should conflict, got %v", err)
+	}
+	if err = s.Delete(ctx, int64(role.ID), 2); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.Get(ctx, int64(role.ID)); errs.CodeOf(err) != errs.NotFound {
+		t.Errorf("deleted role should not be found, got %v", err)
+	}
+}
+
+func TestUserVersion(t *testing.T) {
+	ctx := context.Background()
+	users, roles, resources := memory.NewUserRepository(), memory.NewRoleRepository(), memory.NewResourceRepository()
+	links := memory.NewLinkRepository(users, roles, resources)
+	s := NewUser(users, links, memory.NewTenantRepository(), memory.NewUnitOfWork(), NewChangeFeed(), NewAuditor(memory.NewLogRepository()))
+
+	user := &models.User{Name: "alice", NickName: "al", Password: "secret"}
+	if err := s.Create(ctx, user, nil); err != nil {
+		t.Fatal(err)
+	}
+	updated, err := s.Update(ctx, &models.User{ID: user.ID, UserDetails: models.UserDetails{Email: "alice@example.com"}, ModelExtension: models.ModelExtension{Version: 1}})
+	if err != nil || updated.Version != 2 || updated.Email != "alice@example.com" || updated.NickName != "al" {
+		t.Fatalf("update should step the version and keep empty fields, got %+v %v", updated, err)
+	}
+	if _, err = s.Update(ctx, &models.User{ID: user.ID, NickName: "ally", ModelExtension: models.ModelExtension{Version: 1}}); errs.CodeOf(err) != errs.Conflict {
+		t.Errorf("update at a stale version should conflict, got %v", err)
+	}
+	if found, _ := s.Get(ctx, user.ID); found.NickName != "al" {
+		t.Errorf("update at a stale version should not be kept, got %q", found.NickName)
+	}
+	if err = s.Delete(ctx, user.ID, 1); errs.CodeOf(err) != errs.Conflict {
+		t.Errorf("delete at a stale version should conflict, got %v", err)
+	}
+}
+
+//racedTenants find the tenant as read before it is changed by another request
+type racedTenants struct {
+	repository.ITenant
+	read models.Tenant
+}
+
+func (r *racedTenants) FindById(ctx context.Context, id int) (*models.Tenant, error) {
+	tenant := r.read
+	return &tenant, nil
+}
+
+func TestTenantVersion(t *testing.T) {
+	ctx := context.Background()
+	users, roles, resources := memory.NewUserRepository(), memory.NewRoleRepository(), memory.NewResourceRepository()
+	links := memory.NewLinkRepository(users, roles, resources)
+	tenants, uow := memory.NewTenantRepository(), memory.NewUnitOfWork()
+	newService := func(tenants repository.ITenant) *TenantService {
+		roleService := NewRole(roles, links, uow, NewChangeFeed(), NewAuditor(memory.NewLogRepository()))
+		templates := NewRoleTemplate(memory.NewRoleTemplateRepository(), tenants, resources, roleService, uow)
+		return NewTenant(tenants, users, roles, resources, links, memory.NewOrgRepository(), uow, NewChangeFeed(),
+			NewAuditor(memory.NewLogRepository()), pubsub.NewPublisher(nil), templates)
+	}
+
+	tenant := &models.Tenant{Name: "acme", State: models.TenantActive}
+	if err := tenants.Add(ctx, tenant); err != nil {
+		t.Fatal(err)
+	}
+	read := *tenant
+	tenant.Settings.MFARequired = true
+	if err := tenants.Update(ctx, tenant); err != nil {
+		t.Fatal(err)
+	}
+
+	raced := newService(&racedTenants{ITenant: tenants, read: read})
+	if _, err := raced.Suspend(ctx, tenant.ID, 1); errs.CodeOf(err) != errs.Conflict {
+		t.Errorf("suspend of a tenant changed since it is read should conflict, got %v", err)
+	}
+	if _, err := raced.Delete(ctx, tenant.ID, 1); errs.CodeOf(err) != errs.Conflict {
+		t.Errorf("delete of a tenant changed since it is read should conflict, got %v", err)
+	}
+	found, err := tenants.FindById(ctx, tenant.ID)
+	if err != nil || found.State != models.TenantActive || !found.Settings.MFARequired || found.Version != 2 {
+		t.Fatalf("tenant changed since it is read should be kept, got %+v %v", found, err)
+	}
+
+	s := newService(tenants)
+	if _, err = s.Delete(ctx, tenant.ID, 2); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.Get(ctx, tenant.ID); errs.CodeOf(err) != errs.NotFound {
+		t.Errorf("deleted tenant should not be found, got %v", err)
+	}
+	if err = tenants.Add(ctx, &models.Tenant{Name: "acme"}); err != nil {
+		t.Errorf("name of a deleted tenant should be free, got %v", err)
+	}
+}
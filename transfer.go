@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/micro-community/auth/config"
+	"github.com/micro-community/auth/db"
+	dbsql "github.com/micro-community/auth/db/sql"
+	"github.com/micro-community/auth/repository/transfer"
+	"github.com/urfave/cli/v2"
+	"gorm.io/gorm"
+)
+
+//transferCommand copy users, roles, resources and their links from a database to another of a different type,
+//the target is migrated first, an interrupted transfer resumes from its state file:
+//	auth transfer --from sqlite --from-name auth.db --to mongo --to-host 127.0.0.1 [--state file] [--verify]
+func transferCommand() *cli.Command {
+	flags := []cli.Flag{
+		&cli.StringFlag{Name: "from", Usage: "type of the source database: mysql, sqlite, mongo, dgraph or file", Required: true},
+		&cli.StringFlag{Name: "to", Usage: "type of the target database: mysql, sqlite, mongo, dgraph or file", Required: true},
+		&cli.StringFlag{Name: "state", Usage: "file of the state to resume from, transfer-<from>-<to>.json by default"},
+		&cli.IntFlag{Name: "batch", Usage: "entities read from the source at a time", Value: 100},
+		&cli.BoolFlag{Name: "verify", Usage: "only verify the counts of a finished transfer"},
+	}
+	flags = append(flags, dbFlags("from-")...)
+	flags = append(flags, dbFlags("to-")...)
+	return &cli.Command{
+		Name:   "transfer",
+		Usage:  "copy the data of a database to another",
+		Flags:  flags,
+		Action: runTransfer,
+	}
+}
+
+func runTransfer(c *cli.Context) error {
+	from, to := c.String("from"), c.String("to")
+	for _, dbType := range []string{from, to} {
+		switch dbType {
+		case "mysql", "sqlite", "mongo", "dgraph", "file":
+		default:
+			return fmt.Errorf("can not transfer with %q, use mysql, sqlite, mongo, dgraph or file", dbType)
+		}
+	}
+	//connections of a type are shared, so both ends must be of different types
+	if from == to {
+		return fmt.Errorf("source and target are both %s", from)
+	}
+	applyDBFlags(c, "from-", from, config.Default)
+	applyDBFlags(c, "to-", to, config.Default)
+
+	statePath := c.String("state")
+	if statePath == "" {
+		statePath = fmt.Sprintf("transfer-%s-%s.json", from, to)
+	}
+	state, err := loadTransferState(statePath, from, to)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	src, err := openSource(from)
+	if err != nil {
+		return err
+	}
+	db.BuildDBContext(to)
+	if err = db.Migrate(ctx); err != nil {
+		return fmt.Errorf("migrate %s: %v", to, err)
+	}
+	dst := openRepositories(to).Repositories
+
+	if !c.Bool("verify") {
+		opts := transfer.Options{
+			BatchSize: c.Int("batch"),
+			Save:      func(state *transfer.State) error { return saveTransferState(statePath, state) },
+			Progress:  func(kind string, done int) { fmt.Printf("%-20s %d\n", kind, done) },
+		}
+		if err = transfer.Transfer(ctx, src, dst, state, opts); err != nil {
+			return fmt.Errorf("%v, run it again to resume from %s", err, statePath)
+		}
+	}
+
+	mismatches, err := transfer.Verify(ctx, src, dst, state)
+	if err != nil {
+		return err
+	}
+	for _, m := range mismatches {
+		fmt.Println("mismatch:", m)
+	}
+	if len(mismatches) > 0 {
+		return fmt.Errorf("%d mismatches between %s and %s", len(mismatches), from, to)
+	}
+	fmt.Printf("transferred %d tenants, %d users, %d roles, %d resources, %d role templates and %d org units, ids are mapped in %s\n",
+		len(state.Tenants), len(state.Users), len(state.Roles), len(state.Resources), len(state.Templates), len(state.Units), statePath)
+	return nil
+}
+
+//openSource open the repositories of the source, sql opens a connection of its own,
+//as the shared one of the db context is taken by a sql target
+func openSource(dbType string) (transfer.Repositories, error) {
+	switch dbType {
+	case "mysql", "sqlite":
+		var g *gorm.DB
+		var err error
+		if dbType == "mysql" {
+			g, err = dbsql.NewMySQL(config.Default.MySQL)
+		} else {
+			g, err = dbsql.NewSQLite(config.Default.SQLite)
+		}
+		if err != nil {
+			return transfer.Repositories{}, fmt.Errorf("connect to %s: %v", dbType, err)
+		}
+		return sqlRepositories(g).Repositories, nil
+	case "file":
+		//the source must exist, opening a missing file creates it
+		if _, err := os.Stat(config.Default.File.Path); err != nil {
+			return transfer.Repositories{}, err
+		}
+	}
+	return openRepositories(dbType).Repositories, nil
+}
+
+//loadTransferState from path, a new state when it is missing
+func loadTransferState(path, from, to string) (*transfer.State, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return transfer.NewState(from, to), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	state := &transfer.State{}
+	if err = json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("state %s: %v", path, err)
+	}
+	if state.From != from || state.To != to {
+		return nil, fmt.Errorf("state %s is of a transfer from %s to %s", path, state.From, state.To)
+	}
+	return state, nil
+}
+
+//saveTransferState to a temporary file renamed to path, so an interruption never leaves a broken state
+func saveTransferState(path string, state *transfer.State) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	temp := path + ".tmp"
+	if err = ioutil.WriteFile(temp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(temp, path)
+}
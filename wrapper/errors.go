@@ -0,0 +1,50 @@
+package wrapper
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro/micro/v3/service/errors"
+	"github.com/micro/micro/v3/service/server"
+)
+
+//codes of micro errors for domain errors
+var codes = map[errs.Code]int32{
+	errs.Unknown:            http.StatusInternalServerError,
+	errs.NotFound:           http.StatusNotFound,
+	errs.AlreadyExists:      http.StatusConflict,
+	errs.InvalidArgument:    http.StatusBadRequest,
+	errs.InvalidCredentials: http.StatusUnauthorized,
+	errs.PermissionDenied:   http.StatusForbidden,
+	errs.Conflict:           http.StatusConflict,
+	errs.Unavailable:        http.StatusServiceUnavailable,
+	errs.Unimplemented:      http.StatusNotImplemented,
+}
+
+//Errors return a handler wrapper translating errors of handlers to micro errors,
+//the Status of them is the stable reason of the domain error code
+func Errors() server.HandlerWrapper {
+	return func(fn server.HandlerFunc) server.HandlerFunc {
+		return func(ctx context.Context, req server.Request, rsp interface{}) error {
+			return MicroError(req.Service(), fn(ctx, req, rsp))
+		}
+	}
+}
+
+//MicroError translate err to a micro error, micro errors are returned as they are
+func MicroError(id string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(*errors.Error); ok {
+		return err
+	}
+	code := errs.CodeOf(err)
+	return &errors.Error{
+		Id:     id,
+		Code:   codes[code],
+		Detail: err.Error(),
+		Status: code.Reason(),
+	}
+}
@@ -0,0 +1,59 @@
+package wrapper
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro/micro/v3/service/errors"
+	"github.com/micro/micro/v3/service/server"
+)
+
+//failWith a handler returning err through the Errors wrapper
+func failWith(err error) error {
+	handler := Errors()(func(ctx context.Context, req server.Request, rsp interface{}) error {
+		return err
+	})
+	return handler(context.Background(), &request{}, nil)
+}
+
+func TestErrorsMapDomainCodes(t *testing.T) {
+	tests := []struct {
+		err    error
+		code   int32
+		status string
+	}{
+		{errs.NewNotFound("user %d not found", 1), http.StatusNotFound, "NOT_FOUND"},
+		{errs.NewAlreadyExists("alice already exists"), http.StatusConflict, "ALREADY_EXISTS"},
+		{errs.NewInvalidArgument("bad name"), http.StatusBadRequest, "INVALID_ARGUMENT"},
+		{errs.NewInvalidCredentials("wrong password"), http.StatusUnauthorized, "INVALID_CREDENTIALS"},
+		{errs.NewPermissionDenied("missing tenant"), http.StatusForbidden, "PERMISSION_DENIED"},
+		{errs.NewConflict("stale version"), http.StatusConflict, "CONFLICT"},
+		{errs.NewUnavailable(fmt.Errorf("refused"), "query error"), http.StatusServiceUnavailable, "UNAVAILABLE"},
+		{errs.NewUnimplemented("not implemented"), http.StatusNotImplemented, "UNIMPLEMENTED"},
+		//wrapped domain errors keep their code, other errors are unknown
+		{fmt.Errorf("create: %w", errs.NewNotFound("role missing")), http.StatusNotFound, "NOT_FOUND"},
+		{fmt.Errorf("boom"), http.StatusInternalServerError, "UNKNOWN"},
+	}
+	for _, tt := range tests {
+		merr, ok := failWith(tt.err).(*errors.Error)
+		if !ok {
+			t.Fatalf("%v should be a micro error", tt.err)
+		}
+		if merr.Id != "auth" || merr.Code != tt.code || merr.Status != tt.status || merr.Detail != tt.err.Error() {
+			t.Errorf("%v: got %+v, want code %d status %s", tt.err, merr, tt.code, tt.status)
+		}
+	}
+}
+
+func TestErrorsKeepMicroErrors(t *testing.T) {
+	timeout := errors.Timeout("auth", "no ping")
+	if err := failWith(timeout); err != timeout {
+		t.Errorf("micro error should be returned as it is, got %v", err)
+	}
+	if err := failWith(nil); err != nil {
+		t.Errorf("nil should stay nil, got %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+package wrapper
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/micro-community/auth/config"
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	"github.com/micro/micro/v3/service/auth"
+	"github.com/micro/micro/v3/service/context/metadata"
+	"github.com/micro/micro/v3/service/server"
+)
+
+//Tenant return a handler wrapper scoping requests to the tenant in the metadata of conf.TenantKey,
+//tenancy is off while the key is empty.
+//The tenant in the metadata of the caller's account can not be forged, so it wins over the request metadata,
+//except that an account of the super tenant may act in any tenant. A request without an account or with an account
+//of no tenant can not choose its tenant, as the request metadata is the caller's to set.
+func Tenant(conf *config.Options) server.HandlerWrapper {
+	return func(fn server.HandlerFunc) server.HandlerFunc {
+		return func(ctx context.Context, req server.Request, rsp interface{}) error {
+			if conf.TenantKey == "" {
+				return fn(ctx, req, rsp)
+			}
+			tenant, err := resolveTenant(ctx, conf.TenantKey)
+			if err != nil {
+				return err
+			}
+			return fn(repository.WithTenant(ctx, tenant), req, rsp)
+		}
+	}
+}
+
+func resolveTenant(ctx context.Context, key string) (int, error) {
+	acc, hasAccount := auth.AccountFromContext(ctx)
+	var owned string
+	var hasOwned bool
+	if hasAccount {
+		owned, hasOwned = acc.Metadata[key]
+	}
+	ownedID := -1
+	if hasOwned {
+		id, err := parseTenant(owned)
+		if err != nil {
+			return 0, err
+		}
+		ownedID = id
+	}
+
+	requested, hasRequested := metadata.Get(ctx, key)
+	if !hasRequested {
+		if hasOwned {
+			return ownedID, nil
+		}
+		return 0, errs.NewPermissionDenied("missing tenant %s in metadata", key)
+	}
+	if !hasAccount {
+		return 0, errs.NewPermissionDenied("request without an account can not choose its tenant")
+	}
+	if !hasOwned {
+		return 0, errs.NewPermissionDenied("account without a tenant can not choose its tenant")
+	}
+	requestedID, err := parseTenant(requested)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case ownedID == models.SuperTenant || requestedID == ownedID:
+		return requestedID, nil
+	case requestedID == models.SuperTenant:
+		return 0, errs.NewPermissionDenied("only an account of the super tenant can act in it")
+	}
+	return 0, errs.NewPermissionDenied("account of tenant %d can not act in tenant %d", ownedID, requestedID)
+}
+
+func parseTenant(value string) (int, error) {
+	tenant, err := strconv.Atoi(value)
+	if err != nil || tenant < 0 {
+		return 0, errs.NewInvalidArgument("invalid tenant %q", value)
+	}
+	return tenant, nil
+}
+
+//ActiveTenant return a handler wrapper rejecting requests scoped to a tenant unknown to tenants or suspended,
+//it is added after Tenant, which resolves the tenant of requests
+func ActiveTenant(tenants repository.ITenant) server.HandlerWrapper {
+	return func(fn server.HandlerFunc) server.HandlerFunc {
+		return func(ctx context.Context, req server.Request, rsp interface{}) error {
+			id, ok := repository.ScopedTenant(ctx)
+			if !ok {
+				return fn(ctx, req, rsp)
+			}
+			tenant, err := tenants.FindById(ctx, id)
+			if errs.CodeOf(err) == errs.NotFound {
+				return errs.NewPermissionDenied("tenant %d does not exist", id)
+			} else if err != nil {
+				return err
+			}
+			if tenant.State == models.TenantSuspended {
+				return errs.NewPermissionDenied("tenant %d is suspended", id)
+			}
+			return fn(ctx, req, rsp)
+		}
+	}
+}
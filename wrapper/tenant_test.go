@@ -0,0 +1,88 @@
+package wrapper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/micro-community/auth/config"
+	"github.com/micro-community/auth/errs"
+	"github.com/micro-community/auth/models"
+	"github.com/micro-community/auth/repository"
+	"github.com/micro/micro/v3/service/auth"
+	"github.com/micro/micro/v3/service/context/metadata"
+	"github.com/micro/micro/v3/service/server"
+)
+
+const tenantKey = "Tenant"
+
+//scopedBy call the tenant wrapper with ctx and return the tenant the handler was scoped to
+func scopedBy(ctx context.Context) (int, error) {
+	tenant := -1
+	handler := Tenant(&config.Options{TenantKey: tenantKey})(func(ctx context.Context, req server.Request, rsp interface{}) error {
+		tenant, _ = repository.ScopedTenant(ctx)
+		return nil
+	})
+	err := handler(ctx, nil, nil)
+	return tenant, err
+}
+
+func withTenants(requested string, owned string) context.Context {
+	ctx := context.Background()
+	if owned != "" {
+		ctx = auth.ContextWithAccount(ctx, &auth.Account{ID: "acc", Metadata: map[string]string{tenantKey: owned}})
+	}
+	if requested != "" {
+		ctx = metadata.NewContext(ctx, metadata.Metadata{tenantKey: requested})
+	}
+	return ctx
+}
+
+func TestTenantWithoutAccount(t *testing.T) {
+	for _, requested := range []string{"0", "1"} {
+		if _, err := scopedBy(withTenants(requested, "")); errs.CodeOf(err) != errs.PermissionDenied {
+			t.Errorf("request for tenant %s without an account should be denied, got %v", requested, err)
+		}
+	}
+	if _, err := scopedBy(context.Background()); errs.CodeOf(err) != errs.PermissionDenied {
+		t.Errorf("request without a tenant should be denied, got %v", err)
+	}
+}
+
+func TestTenantOfAccount(t *testing.T) {
+	if tenant, err := scopedBy(withTenants("", "1")); err != nil || tenant != 1 {
+		t.Errorf("request should be scoped to the tenant of its account, got %d %v", tenant, err)
+	}
+	if tenant, err := scopedBy(withTenants("1", "1")); err != nil || tenant != 1 {
+		t.Errorf("request for the tenant of its account should be scoped to it, got %d %v", tenant, err)
+	}
+	if _, err := scopedBy(withTenants("2", "1")); errs.CodeOf(err) != errs.PermissionDenied {
+		t.Errorf("request for another tenant should be denied, got %v", err)
+	}
+	if _, err := scopedBy(withTenants("0", "1")); errs.CodeOf(err) != errs.PermissionDenied {
+		t.Errorf("request for the super tenant by a tenant account should be denied, got %v", err)
+	}
+	if _, err := scopedBy(withTenants("x", "1")); errs.CodeOf(err) != errs.InvalidArgument {
+		t.Errorf("request for an invalid tenant should be rejected, got %v", err)
+	}
+}
+
+func TestTenantOfSuperAccount(t *testing.T) {
+	if tenant, err := scopedBy(withTenants("", "0")); err != nil || tenant != models.SuperTenant {
+		t.Errorf("request of a super account should be scoped to the super tenant, got %d %v", tenant, err)
+	}
+	if tenant, err := scopedBy(withTenants("2", "0")); err != nil || tenant != 2 {
+		t.Errorf("super account should act in any tenant, got %d %v", tenant, err)
+	}
+}
+
+func TestTenantOfAccountWithoutTenant(t *testing.T) {
+	ctx := auth.ContextWithAccount(context.Background(), &auth.Account{ID: "acc"})
+	for _, requested := range []string{"0", "1"} {
+		if _, err := scopedBy(metadata.NewContext(ctx, metadata.Metadata{tenantKey: requested})); errs.CodeOf(err) != errs.PermissionDenied {
+			t.Errorf("account without a tenant should not request tenant %s, got %v", requested, err)
+		}
+	}
+	if _, err := scopedBy(ctx); errs.CodeOf(err) != errs.PermissionDenied {
+		t.Errorf("account without a tenant should be denied without a requested one, got %v", err)
+	}
+}
@@ -0,0 +1,124 @@
+package wrapper
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/micro-community/auth/errs"
+	"github.com/micro/micro/v3/service/errors"
+	"github.com/micro/micro/v3/service/server"
+)
+
+//validator is implemented by messages generated by protoc-gen-validate
+type validator interface {
+	Validate() error
+}
+
+//allValidator is implemented by messages generated by newer protoc-gen-validate,
+//it reports all the violations instead of the first one
+type allValidator interface {
+	ValidateAll() error
+}
+
+//multiError is the error of ValidateAll
+type multiError interface {
+	AllErrors() []error
+}
+
+//fieldError is the error of a violated field
+type fieldError interface {
+	Field() string
+	Reason() string
+	Cause() error
+}
+
+//Violation of a request field
+type Violation struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+//BadRequest is the detail of the error returned for invalid requests
+type BadRequest struct {
+	Violations []Violation `json:"violations"`
+}
+
+//Validator return a handler wrapper validating requests and streamed messages
+//by their generated validators before they reach handlers
+func Validator() server.HandlerWrapper {
+	return func(fn server.HandlerFunc) server.HandlerFunc {
+		return func(ctx context.Context, req server.Request, rsp interface{}) error {
+			if stream, ok := rsp.(server.Stream); ok && req.Stream() {
+				return fn(ctx, req, &validatedStream{Stream: stream})
+			}
+			if err := validate(req.Service(), req.Body()); err != nil {
+				return err
+			}
+			return fn(ctx, req, rsp)
+		}
+	}
+}
+
+//validatedStream validate messages received from a stream
+type validatedStream struct {
+	server.Stream
+}
+
+func (s *validatedStream) Recv(msg interface{}) error {
+	if err := s.Stream.Recv(msg); err != nil {
+		return err
+	}
+	return validate(s.Request().Service(), msg)
+}
+
+//validate msg, return a bad request error listing every violation
+func validate(id string, msg interface{}) error {
+	var err error
+	switch v := msg.(type) {
+	case allValidator:
+		err = v.ValidateAll()
+	case validator:
+		err = v.Validate()
+	default:
+		return nil
+	}
+	if err == nil {
+		return nil
+	}
+
+	detail := BadRequest{Violations: violations(err)}
+	body, _ := json.Marshal(detail)
+	return &errors.Error{
+		Id:     id,
+		Code:   http.StatusBadRequest,
+		Detail: string(body),
+		Status: errs.InvalidArgument.Reason(),
+	}
+}
+
+//violations of err, the field path is joined by '.' for embedded messages
+func violations(err error) []Violation {
+	if multi, ok := err.(multiError); ok {
+		var all []Violation
+		for _, e := range multi.AllErrors() {
+			all = append(all, violations(e)...)
+		}
+		return all
+	}
+	fe, ok := err.(fieldError)
+	if !ok {
+		return []Violation{{Reason: err.Error()}}
+	}
+	cause := fe.Cause()
+	if _, ok := cause.(fieldError); !ok {
+		if _, ok = cause.(multiError); !ok {
+			return []Violation{{Field: fe.Field(), Reason: fe.Reason()}}
+		}
+	}
+	inner := violations(cause)
+	for i := range inner {
+		inner[i].Field = fe.Field() + "." + inner[i].Field
+	}
+	return inner
+}
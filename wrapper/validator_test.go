@@ -0,0 +1,111 @@
+package wrapper
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/micro-community/auth/errs"
+	pb "github.com/micro-community/auth/protos/resource"
+	"github.com/micro/micro/v3/service/errors"
+	"github.com/micro/micro/v3/service/server"
+)
+
+//request of a unary call to a handler
+type request struct {
+	server.Request
+	body interface{}
+}
+
+func (r *request) Service() string     { return "auth" }
+func (r *request) Body() interface{}   { return r.body }
+func (r *request) Stream() bool        { return false }
+func (r *request) Endpoint() string    { return "Resource.Update" }
+func (r *request) ContentType() string { return "application/json" }
+
+//violationsOf the bad request err returned by the validator
+func violationsOf(t *testing.T, err error) []Violation {
+	t.Helper()
+	merr, ok := err.(*errors.Error)
+	if !ok || merr.Code != 400 || merr.Status != errs.InvalidArgument.Reason() {
+		t.Fatalf("invalid request should be a bad request, got %v", err)
+	}
+	var detail BadRequest
+	if err := json.Unmarshal([]byte(merr.Detail), &detail); err != nil {
+		t.Fatal(err)
+	}
+	return detail.Violations
+}
+
+func TestValidatorReportsEveryViolation(t *testing.T) {
+	called := false
+	handler := Validator()(func(ctx context.Context, req server.Request, rsp interface{}) error {
+		called = true
+		return nil
+	})
+
+	invalid := &pb.UpdateRequest{Name: strings.Repeat("x", 129), TypeUpdate: &pb.UpdateRequest_Type{Type: pb.Catalog(99)}}
+	found := violationsOf(t, handler(context.Background(), &request{body: invalid}, nil))
+	fields := map[string]bool{}
+	for _, v := range found {
+		fields[v.Field] = true
+		if v.Reason == "" {
+			t.Errorf("violation of %s should have a reason", v.Field)
+		}
+	}
+	for _, field := range []string{"Id", "Name", "Version", "Type"} {
+		if !fields[field] {
+			t.Errorf("violation of %s should be reported, got %+v", field, found)
+		}
+	}
+	if len(found) != 4 || called {
+		t.Errorf("invalid request should not reach the handler, got %d violations, called %v", len(found), called)
+	}
+
+	if err := handler(context.Background(), &request{body: &pb.UpdateRequest{Id: 1, Version: 1}}, nil); err != nil || !called {
+		t.Errorf("valid request should reach the handler, got %v", err)
+	}
+}
+
+//failing message failing validation with the violations of err
+type failing struct {
+	err error
+}
+
+func (f failing) Validate() error { return f.err }
+
+type multi []error
+
+func (m multi) Error() string       { return "multi" }
+func (m multi) AllErrors() []error { return m }
+
+type fieldViolation struct {
+	field, reason string
+	cause         error
+}
+
+func (f fieldViolation) Error() string  { return f.field + ": " + f.reason }
+func (f fieldViolation) Field() string  { return f.field }
+func (f fieldViolation) Reason() string { return f.reason }
+func (f fieldViolation) Cause() error   { return f.cause }
+
+func TestValidatorJoinsEmbeddedFields(t *testing.T) {
+	err := multi{
+		fieldViolation{field: "Owner", reason: "embedded message failed validation", cause: multi{
+			fieldViolation{field: "Name", reason: "too long"},
+			fieldViolation{field: "Age", reason: "too old"},
+		}},
+		fieldViolation{field: "Id", reason: "must be positive"},
+	}
+	found := violationsOf(t, validate("auth", failing{err}))
+	want := []Violation{{"Owner.Name", "too long"}, {"Owner.Age", "too old"}, {"Id", "must be positive"}}
+	if len(found) != len(want) {
+		t.Fatalf("violations should be flattened, got %+v", found)
+	}
+	for i := range want {
+		if found[i] != want[i] {
+			t.Errorf("violation %d should be %+v, got %+v", i, want[i], found[i])
+		}
+	}
+}